@@ -4,9 +4,75 @@
 package v1
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for AccessTokenScope.
+const (
+	Admin    AccessTokenScope = "admin"
+	ReadOnly AccessTokenScope = "read-only"
+	Upload   AccessTokenScope = "upload"
+)
+
+// Defines values for FsckIssueKind.
+const (
+	ExpiredTrash      FsckIssueKind = "expired_trash"
+	MissingBucket     FsckIssueKind = "missing_bucket"
+	MissingThumbnail  FsckIssueKind = "missing_thumbnail"
+	OrphanedBucket    FsckIssueKind = "orphaned_bucket"
+	OrphanedMetadata  FsckIssueKind = "orphaned_metadata"
+	OrphanedThumbnail FsckIssueKind = "orphaned_thumbnail"
+	StaleUpload       FsckIssueKind = "stale_upload"
+)
+
+// AccessToken defines model for AccessToken.
+type AccessToken struct {
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Href      string    `json:"href"`
+	Id        string    `json:"id"`
+	Kind      string    `json:"kind"`
+
+	// last time the token has been used. Missing if never used.
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	// what the token is used for
+	Name string `json:"name"`
+
+	// permissions granted by the token, within the permissions of its owner
+	Scope AccessTokenScope `json:"scope"`
+}
+
+// AccessTokenList defines model for AccessTokenList.
+type AccessTokenList struct {
+	Items []AccessToken `json:"items"`
+	Kind  string        `json:"kind"`
+	Page  int           `json:"page"`
+	Size  int           `json:"size"`
+	Total int           `json:"total"`
+}
+
+// AccessTokenRequestPayload defines model for AccessTokenRequestPayload.
+type AccessTokenRequestPayload struct {
+	// expiration date of the token. The token expires after 90 days if missing.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// what the token is used for
+	Name string `json:"name"`
+
+	// permissions granted by the token, within the permissions of its owner
+	Scope AccessTokenScope `json:"scope"`
+}
+
+// permissions granted by the token, within the permissions of its owner
+type AccessTokenScope string
+
 // Album defines model for Album.
 type Album struct {
 	// path of the bucket where media is stored
@@ -17,9 +83,12 @@ type Album struct {
 
 	// description of the album
 	Description *string `json:"description,omitempty"`
-	Href        string  `json:"href"`
-	Id          string  `json:"id"`
-	Kind        string  `json:"kind"`
+
+	// true if the media of the album are encrypted at rest
+	Encrypted *bool  `json:"encrypted,omitempty"`
+	Href      string `json:"href"`
+	Id        string `json:"id"`
+	Kind      string `json:"kind"`
 
 	// location of the album
 	Location *string `json:"location,omitempty"`
@@ -29,7 +98,10 @@ type Album struct {
 	Owner       ObjectReference `json:"owner"`
 	Permissions ObjectReference `json:"permissions"`
 	Photos      ObjectReference `json:"photos"`
-	Tags        *[]Tag          `json:"tags,omitempty"`
+
+	// place found from the gps coordinates
+	Place *Place `json:"place,omitempty"`
+	Tags  *[]Tag `json:"tags,omitempty"`
 
 	// url of the thumbnail of the album
 	Thumbnail *string `json:"thumbnail,omitempty"`
@@ -44,6 +116,19 @@ type AlbumList struct {
 	Total int     `json:"total"`
 }
 
+// AlbumLocation defines model for AlbumLocation.
+type AlbumLocation struct {
+	Album       ObjectReference `json:"album"`
+	BoundingBox BoundingBox     `json:"bounding_box"`
+	Center      GeoPoint        `json:"center"`
+
+	// number of geolocated photos
+	Count int `json:"count"`
+
+	// location which can be set on the album. It is the place of the album if known, the coordinates of the center otherwise.
+	SuggestedLocation string `json:"suggested_location"`
+}
+
 // AlbumPermissions defines model for AlbumPermissions.
 type AlbumPermissions struct {
 	Album  *ObjectReference `json:"album,omitempty"`
@@ -66,10 +151,53 @@ type AlbumPermissionsRequest = []struct {
 	Permissions []string `json:"permissions"`
 }
 
+// AlbumProposal defines model for AlbumProposal.
+type AlbumProposal struct {
+	Count int `json:"count"`
+
+	// capture date of the last photo
+	End time.Time `json:"end"`
+
+	// suggested location of the album
+	Location string `json:"location"`
+
+	// suggested name of the album
+	Name   string  `json:"name"`
+	Photos []Photo `json:"photos"`
+
+	// place found from the gps coordinates
+	Place *Place `json:"place,omitempty"`
+
+	// capture date of the first photo
+	Start time.Time `json:"start"`
+}
+
+// AlbumProposalList defines model for AlbumProposalList.
+type AlbumProposalList struct {
+	Album ObjectReference `json:"album"`
+	Items []AlbumProposal `json:"items"`
+	Kind  string          `json:"kind"`
+
+	// number of photos in all the proposals
+	Total int `json:"total"`
+}
+
+// AlbumProposalRequestPayload defines model for AlbumProposalRequestPayload.
+type AlbumProposalRequestPayload struct {
+	Location *string `json:"location,omitempty"`
+	Name     string  `json:"name"`
+
+	// ids of the photos moved to the album
+	PhotoIds []string `json:"photo_ids"`
+}
+
 // AlbumRequestPayload defines model for AlbumRequestPayload.
 type AlbumRequestPayload struct {
-	CreatedAt        *int64  `json:"created_at,omitempty"`
-	Description      *string `json:"description,omitempty"`
+	CreatedAt   *int64  `json:"created_at,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	// encrypt the media of the album at rest. It is only read when the album is created.
+	Encrypted        *bool   `json:"encrypted,omitempty"`
 	GroupPermissions *string `json:"group_permissions,omitempty"`
 	Location         *string `json:"location,omitempty"`
 	Name             string  `json:"name"`
@@ -79,6 +207,97 @@ type AlbumRequestPayload struct {
 	UserPermissions *string `json:"user_permissions,omitempty"`
 }
 
+// AlbumThumbnailRequestPayload defines model for AlbumThumbnailRequestPayload.
+type AlbumThumbnailRequestPayload struct {
+	// id of the photo used as thumbnail
+	PhotoId string `json:"photo_id"`
+}
+
+// AlbumUsage defines model for AlbumUsage.
+type AlbumUsage struct {
+	Album ObjectReference `json:"album"`
+	Bytes int64           `json:"bytes"`
+	Name  string          `json:"name"`
+}
+
+// BoundingBox defines model for BoundingBox.
+type BoundingBox struct {
+	East  float64 `json:"east"`
+	North float64 `json:"north"`
+	South float64 `json:"south"`
+	West  float64 `json:"west"`
+}
+
+// Comment defines model for Comment.
+type Comment struct {
+	Author ObjectReference `json:"author"`
+
+	// text of the comment
+	Content   string          `json:"content"`
+	CreatedAt time.Time       `json:"created_at"`
+	Href      string          `json:"href"`
+	Id        string          `json:"id"`
+	Kind      string          `json:"kind"`
+	Photo     ObjectReference `json:"photo"`
+
+	// date of the last edit
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// CommentHistory defines model for CommentHistory.
+type CommentHistory struct {
+	Comment *ObjectReference  `json:"comment,omitempty"`
+	Items   []CommentRevision `json:"items"`
+	Kind    string            `json:"kind"`
+	Page    int               `json:"page"`
+	Size    int               `json:"size"`
+	Total   int               `json:"total"`
+}
+
+// CommentList defines model for CommentList.
+type CommentList struct {
+	Items []Comment `json:"items"`
+	Kind  string    `json:"kind"`
+	Page  int       `json:"page"`
+	Size  int       `json:"size"`
+	Total int       `json:"total"`
+}
+
+// CommentRequestPayload defines model for CommentRequestPayload.
+type CommentRequestPayload struct {
+	Content string `json:"content"`
+}
+
+// CommentRevision defines model for CommentRevision.
+type CommentRevision struct {
+	// content of the comment before the edit
+	Content string `json:"content"`
+
+	// date when this version has been replaced
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreatedAccessToken defines model for CreatedAccessToken.
+type CreatedAccessToken struct {
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Href      string    `json:"href"`
+	Id        string    `json:"id"`
+	Kind      string    `json:"kind"`
+
+	// last time the token has been used. Missing if never used.
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	// what the token is used for
+	Name string `json:"name"`
+
+	// permissions granted by the token, within the permissions of its owner
+	Scope AccessTokenScope `json:"scope"`
+
+	// the token to send as bearer token. It cannot be retrieved later.
+	Token string `json:"token"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Code   int     `json:"code"`
@@ -88,6 +307,49 @@ type Error struct {
 	Reason *string `json:"reason,omitempty"`
 }
 
+// Event defines model for Event.
+type Event struct {
+	Album     ObjectReference `json:"album"`
+	CreatedAt time.Time       `json:"created_at"`
+
+	// kind of the event (photo.added, photo.removed, thumbnail.created, album.created, album.updated, album.deleted)
+	Kind  string           `json:"kind"`
+	Photo *ObjectReference `json:"photo,omitempty"`
+}
+
+// FsckIssue defines model for FsckIssue.
+type FsckIssue struct {
+	// what has been done to repair the issue. Missing if the issue has not been repaired.
+	Action *string          `json:"action,omitempty"`
+	Album  *ObjectReference `json:"album,omitempty"`
+	Bucket string           `json:"bucket"`
+
+	// why the repair failed
+	Error *string       `json:"error,omitempty"`
+	Kind  FsckIssueKind `json:"kind"`
+
+	// name of the object or of the photo. Missing for the issues of the buckets.
+	Name *string `json:"name,omitempty"`
+}
+
+// FsckIssueKind defines model for FsckIssue.Kind.
+type FsckIssueKind string
+
+// FsckReport defines model for FsckReport.
+type FsckReport struct {
+	Issues []FsckIssue `json:"issues"`
+	Kind   string      `json:"kind"`
+
+	// number of issues repaired
+	Repaired int `json:"repaired"`
+}
+
+// GeoPoint defines model for GeoPoint.
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
 // Group defines model for Group.
 type Group struct {
 	Href    string             `json:"href"`
@@ -116,6 +378,26 @@ type List struct {
 	Total int    `json:"total"`
 }
 
+// MapCluster defines model for MapCluster.
+type MapCluster struct {
+	BoundingBox BoundingBox `json:"bounding_box"`
+	Center      GeoPoint    `json:"center"`
+
+	// number of photos in the cluster
+	Count int   `json:"count"`
+	Cover Photo `json:"cover"`
+}
+
+// MapClusterList defines model for MapClusterList.
+type MapClusterList struct {
+	Items []MapCluster `json:"items"`
+	Kind  string       `json:"kind"`
+
+	// number of photos in all the clusters
+	Total int `json:"total"`
+	Zoom  int `json:"zoom"`
+}
+
 // ObjectReference defines model for ObjectReference.
 type ObjectReference struct {
 	Href string `json:"href"`
@@ -123,6 +405,11 @@ type ObjectReference struct {
 	Kind string `json:"kind"`
 }
 
+// OrganizeRequestPayload defines model for OrganizeRequestPayload.
+type OrganizeRequestPayload struct {
+	Albums []AlbumProposalRequestPayload `json:"albums"`
+}
+
 // Permissions defines model for Permissions.
 type Permissions struct {
 	Owner       ObjectReference `json:"owner"`
@@ -132,26 +419,184 @@ type Permissions struct {
 // Photo defines model for Photo.
 type Photo struct {
 	Album ObjectReference `json:"album"`
-	Href  string          `json:"href"`
-	Id    string          `json:"id"`
-	Kind  string          `json:"kind"`
+
+	// caption of the photo
+	Caption *string `json:"caption,omitempty"`
+
+	// date when the photo has been taken
+	CaptureDate *time.Time `json:"capture_date,omitempty"`
+
+	// description of the photo
+	Description *string `json:"description,omitempty"`
+
+	// true if the photo is a favorite of the current user
+	Favorite *bool     `json:"favorite,omitempty"`
+	Href     string    `json:"href"`
+	Id       string    `json:"id"`
+	Kind     string    `json:"kind"`
+	Location *GeoPoint `json:"location,omitempty"`
+
+	// place found from the gps coordinates
+	Place *Place `json:"place,omitempty"`
+
+	// size of the photo in bytes
+	Size *int64 `json:"size,omitempty"`
+	Tags *[]Tag `json:"tags,omitempty"`
 
 	// path to the thumbnail of the photo
 	Thumbnail string `json:"thumbnail"`
+
+	// date when the photo has been uploaded
+	UploadDate *time.Time `json:"upload_date,omitempty"`
 }
 
 // PhotoList defines model for PhotoList.
 type PhotoList struct {
 	Items []Photo `json:"items"`
 	Kind  string  `json:"kind"`
-	Page  int     `json:"page"`
-	Size  int     `json:"size"`
-	Total int     `json:"total"`
+
+	// cursor of the next page. Missing on the last page.
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Page       int     `json:"page"`
+	Size       int     `json:"size"`
+	Total      int     `json:"total"`
+}
+
+// PhotoMetadataRequestPayload defines model for PhotoMetadataRequestPayload.
+type PhotoMetadataRequestPayload struct {
+	Caption     *string `json:"caption,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// PhotoRef defines model for PhotoRef.
+type PhotoRef struct {
+	// id of the album
+	AlbumId string `json:"album_id"`
+
+	// id of the photo
+	PhotoId string `json:"photo_id"`
 }
 
 // PhotoRequestPayload defines model for PhotoRequestPayload.
 type PhotoRequestPayload = string
 
+// PhotoTransferRequestPayload defines model for PhotoTransferRequestPayload.
+type PhotoTransferRequestPayload struct {
+	// ids of the photos
+	PhotoIds []string `json:"photo_ids"`
+
+	// id of the album receiving the photos
+	TargetAlbumId string `json:"target_album_id"`
+}
+
+// PhotoUpload defines model for PhotoUpload.
+type PhotoUpload struct {
+	// date when the url expires
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// form fields to post to the url with the photo in the field "file"
+	Fields PhotoUpload_Fields `json:"fields"`
+
+	// id of the upload used to complete it
+	Id string `json:"id"`
+
+	// size limit of the photo in bytes
+	MaxSize int64 `json:"max_size"`
+
+	// pre-signed url
+	Url string `json:"url"`
+}
+
+// form fields to post to the url with the photo in the field "file"
+type PhotoUpload_Fields struct {
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// PhotoUploadRequestPayload defines model for PhotoUploadRequestPayload.
+type PhotoUploadRequestPayload struct {
+	// name of the photo
+	Filename string `json:"filename"`
+}
+
+// place found from the gps coordinates
+type Place struct {
+	City    *string `json:"city,omitempty"`
+	Country *string `json:"country,omitempty"`
+	Region  *string `json:"region,omitempty"`
+}
+
+// PresignedUrl defines model for PresignedUrl.
+type PresignedUrl struct {
+	// date when the url expires
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// pre-signed url
+	Url string `json:"url"`
+}
+
+// ReactionList defines model for ReactionList.
+type ReactionList struct {
+	Items []ReactionSummary `json:"items"`
+	Kind  string            `json:"kind"`
+	Page  int               `json:"page"`
+	Size  int               `json:"size"`
+	Total int               `json:"total"`
+}
+
+// ReactionRequestPayload defines model for ReactionRequestPayload.
+type ReactionRequestPayload struct {
+	// kind of reaction (like, love, laugh, wow, sad)
+	Kind string `json:"kind"`
+}
+
+// ReactionSummary defines model for ReactionSummary.
+type ReactionSummary struct {
+	Count int `json:"count"`
+
+	// kind of reaction (like, love, laugh, wow, sad)
+	Kind string `json:"kind"`
+
+	// true if the current user reacted with this kind
+	Reacted bool              `json:"reacted"`
+	Users   []ObjectReference `json:"users"`
+}
+
+// SimilarGroup defines model for SimilarGroup.
+type SimilarGroup struct {
+	Best Photo `json:"best"`
+
+	// number of photos in the group
+	Count int     `json:"count"`
+	Items []Photo `json:"items"`
+}
+
+// SimilarGroupList defines model for SimilarGroupList.
+type SimilarGroupList struct {
+	Items     []SimilarGroup `json:"items"`
+	Kind      string         `json:"kind"`
+	Threshold int            `json:"threshold"`
+
+	// number of photos in all the groups
+	Total int `json:"total"`
+}
+
+// SimilarTrashRequestPayload defines model for SimilarTrashRequestPayload.
+type SimilarTrashRequestPayload struct {
+	Keep *PhotoRef `json:"keep,omitempty"`
+
+	// photos of the group
+	Photos []PhotoRef `json:"photos"`
+
+	// maximum number of different bits between the hashes of the kept photo and of the trashed ones, between 0 and 24. Default to 10.
+	Threshold *int `json:"threshold,omitempty"`
+}
+
+// SimilarTrashResult defines model for SimilarTrashResult.
+type SimilarTrashResult struct {
+	Kept    Photo          `json:"kept"`
+	Trashed []TrashedPhoto `json:"trashed"`
+}
+
 // Tag defines model for Tag.
 type Tag struct {
 	Albums []ObjectReference `json:"albums"`
@@ -185,6 +630,58 @@ type TagRequestPayload struct {
 	Name string `json:"name"`
 }
 
+// Timeline defines model for Timeline.
+type Timeline struct {
+	// number of photos per year, month or day for all the pages.
+	Buckets []TimelineBucket `json:"buckets"`
+	Items   []Photo          `json:"items"`
+	Kind    string           `json:"kind"`
+
+	// cursor of the next page. Missing on the last page.
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Page       int     `json:"page"`
+	Size       int     `json:"size"`
+	Total      int     `json:"total"`
+}
+
+// TimelineBucket defines model for TimelineBucket.
+type TimelineBucket struct {
+	// number of photos in the bucket
+	Count int `json:"count"`
+
+	// cursor of the page starting with the first photo of the bucket. Missing for the first bucket.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// missing if the photos are grouped by year or month
+	Day *int `json:"day,omitempty"`
+
+	// missing if the photos are grouped by year
+	Month *int `json:"month,omitempty"`
+	Year  int  `json:"year"`
+}
+
+// TrashedPhoto defines model for TrashedPhoto.
+type TrashedPhoto struct {
+	Album ObjectReference `json:"album"`
+
+	// date from which the photo can be purged
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// name of the photo before it has been trashed
+	Filename string `json:"filename"`
+
+	// id of the photo in the trash
+	Id        string    `json:"id"`
+	TrashedAt time.Time `json:"trashed_at"`
+}
+
+// TrashedPhotoList defines model for TrashedPhotoList.
+type TrashedPhotoList struct {
+	Items []TrashedPhoto `json:"items"`
+	Kind  string         `json:"kind"`
+	Size  int            `json:"size"`
+}
+
 // User defines model for User.
 type User struct {
 	Groups *[]ObjectReference `json:"groups,omitempty"`
@@ -211,6 +708,19 @@ type UserList struct {
 	Total int    `json:"total"`
 }
 
+// UserUsage defines model for UserUsage.
+type UserUsage struct {
+	// usage of each album, the largest first
+	Albums []AlbumUsage `json:"albums"`
+	Kind   string       `json:"kind"`
+
+	// quota of the user in bytes. Missing if the user has no quota.
+	Limit *int64 `json:"limit,omitempty"`
+
+	// bytes used by the albums owned by the user
+	Used int64 `json:"used"`
+}
+
 // VersionMetadata defines model for VersionMetadata.
 type VersionMetadata struct {
 	Collections *[]struct {
@@ -226,6 +736,9 @@ type VersionMetadata struct {
 // AlbumId defines model for album_id.
 type AlbumId = string
 
+// CommentId defines model for comment_id.
+type CommentId = string
+
 // GroupId defines model for group_id.
 type GroupId = string
 
@@ -235,6 +748,9 @@ type Page = int32
 // PhotoId defines model for photo_id.
 type PhotoId = string
 
+// ReactionKind defines model for reaction_kind.
+type ReactionKind = string
+
 // Search defines model for search.
 type Search = string
 
@@ -244,9 +760,36 @@ type Size = int32
 // TagId defines model for tag_id.
 type TagId = string
 
+// TokenId defines model for token_id.
+type TokenId = string
+
+// TrashId defines model for trash_id.
+type TrashId = string
+
+// UploadId defines model for upload_id.
+type UploadId = string
+
 // UserId defines model for user_id.
 type UserId = string
 
+// CheckStorageParams defines parameters for CheckStorage.
+type CheckStorageParams struct {
+	// repair the inconsistencies found
+	Repair *bool `form:"repair,omitempty" json:"repair,omitempty"`
+}
+
+// UpdatePhotoJSONBody defines parameters for UpdatePhoto.
+type UpdatePhotoJSONBody = PhotoMetadataRequestPayload
+
+// CreatePhotoCommentJSONBody defines parameters for CreatePhotoComment.
+type CreatePhotoCommentJSONBody = CommentRequestPayload
+
+// UpdatePhotoCommentJSONBody defines parameters for UpdatePhotoComment.
+type UpdatePhotoCommentJSONBody = CommentRequestPayload
+
+// AddPhotoReactionJSONBody defines parameters for AddPhotoReaction.
+type AddPhotoReactionJSONBody = ReactionRequestPayload
+
 // GetAlbumsParams defines parameters for GetAlbums.
 type GetAlbumsParams struct {
 	// Sort the list of albums.
@@ -292,6 +835,27 @@ type GetAlbumsByUserParams struct {
 // UpdateAlbumJSONBody defines parameters for UpdateAlbum.
 type UpdateAlbumJSONBody = AlbumRequestPayload
 
+// GetAlbumArchiveParams defines parameters for GetAlbumArchive.
+type GetAlbumArchiveParams struct {
+	// ids of the photos to download. All the photos are downloaded if missing.
+	PhotoIds *[]string `form:"photo_ids,omitempty" json:"photo_ids,omitempty"`
+
+	// add a manifest.json file with the metadata of the album and the photos to the archive.
+	Manifest *bool `form:"manifest,omitempty" json:"manifest,omitempty"`
+}
+
+// GetAlbumOrganizeProposalsParams defines parameters for GetAlbumOrganizeProposals.
+type GetAlbumOrganizeProposalsParams struct {
+	// number of hours without photo after which a new album starts. Default to 6.
+	MaxGap *int `form:"max_gap,omitempty" json:"max_gap,omitempty"`
+
+	// distance in kilometers from the previous photo after which a new album starts. Default to 50.
+	MaxDistance *float64 `form:"max_distance,omitempty" json:"max_distance,omitempty"`
+}
+
+// OrganizeAlbumJSONBody defines parameters for OrganizeAlbum.
+type OrganizeAlbumJSONBody = OrganizeRequestPayload
+
 // SetAlbumPermissionsJSONBody defines parameters for SetAlbumPermissions.
 type SetAlbumPermissionsJSONBody = AlbumPermissionsRequest
 
@@ -302,8 +866,86 @@ type GetAlbumPhotosParams struct {
 
 	// total number of items per page
 	Size *Size `form:"size,omitempty" json:"size,omitempty"`
+
+	// return only the favorites of the current user
+	Favorites *bool `form:"favorites,omitempty" json:"favorites,omitempty"`
+
+	// return only the photos tagged with this tag name
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// sort key (capture_date, upload_date, filename, size). Default to capture_date.
+	Sort *GetAlbumPhotosParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// sort order. Default to desc.
+	Order *GetAlbumPhotosParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// return only the photos captured at or after this date
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// return only the photos captured before this date
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// return only the media of this type
+	MediaType *GetAlbumPhotosParamsMediaType `form:"media_type,omitempty" json:"media_type,omitempty"`
+
+	// cursor returned with the previous page. It cannot be used together with page.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetAlbumPhotosParamsSort defines parameters for GetAlbumPhotos.
+type GetAlbumPhotosParamsSort string
+
+// GetAlbumPhotosParamsOrder defines parameters for GetAlbumPhotos.
+type GetAlbumPhotosParamsOrder string
+
+// GetAlbumPhotosParamsMediaType defines parameters for GetAlbumPhotos.
+type GetAlbumPhotosParamsMediaType string
+
+// CopyPhotosJSONBody defines parameters for CopyPhotos.
+type CopyPhotosJSONBody = PhotoTransferRequestPayload
+
+// MovePhotosJSONBody defines parameters for MovePhotos.
+type MovePhotosJSONBody = PhotoTransferRequestPayload
+
+// CreatePhotoUploadJSONBody defines parameters for CreatePhotoUpload.
+type CreatePhotoUploadJSONBody = PhotoUploadRequestPayload
+
+// GetAlbumSimilarPhotosParams defines parameters for GetAlbumSimilarPhotos.
+type GetAlbumSimilarPhotosParams struct {
+	// maximum number of different bits between the hashes of two similar photos, between 0 and 24. Default to 10.
+	Threshold *int `form:"threshold,omitempty" json:"threshold,omitempty"`
+}
+
+// SetAlbumThumbnailJSONBody defines parameters for SetAlbumThumbnail.
+type SetAlbumThumbnailJSONBody = AlbumThumbnailRequestPayload
+
+// GetMapParams defines parameters for GetMap.
+type GetMapParams struct {
+	// southern latitude of the area. Default to -90.
+	South *float64 `form:"south,omitempty" json:"south,omitempty"`
+
+	// western longitude of the area. Default to -180. It is greater than east if the area crosses the antimeridian.
+	West *float64 `form:"west,omitempty" json:"west,omitempty"`
+
+	// northern latitude of the area. Default to 90.
+	North *float64 `form:"north,omitempty" json:"north,omitempty"`
+
+	// eastern longitude of the area. Default to 180.
+	East *float64 `form:"east,omitempty" json:"east,omitempty"`
+
+	// zoom level of the map between 0 and 20. Default to 0.
+	Zoom *int `form:"zoom,omitempty" json:"zoom,omitempty"`
+}
+
+// GetSimilarPhotosParams defines parameters for GetSimilarPhotos.
+type GetSimilarPhotosParams struct {
+	// maximum number of different bits between the hashes of two similar photos, between 0 and 24. Default to 10.
+	Threshold *int `form:"threshold,omitempty" json:"threshold,omitempty"`
 }
 
+// TrashSimilarPhotosJSONBody defines parameters for TrashSimilarPhotos.
+type TrashSimilarPhotosJSONBody = SimilarTrashRequestPayload
+
 // GetTagsParams defines parameters for GetTags.
 type GetTagsParams struct {
 	// page number
@@ -319,17 +961,127 @@ type CreateTagJSONBody = TagRequestPayload
 // UpdateTagJSONBody defines parameters for UpdateTag.
 type UpdateTagJSONBody = TagRequestPayload
 
+// GetTimelineParams defines parameters for GetTimeline.
+type GetTimelineParams struct {
+	// total number of items per page
+	Size *Size `form:"size,omitempty" json:"size,omitempty"`
+
+	// granularity of the buckets (year, month, day). Default to month.
+	GroupBy *GetTimelineParamsGroupBy `form:"group_by,omitempty" json:"group_by,omitempty"`
+
+	// return only the photos captured at or after this date
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// return only the photos captured before this date
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// cursor returned with the previous page or with a bucket.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetTimelineParamsGroupBy defines parameters for GetTimeline.
+type GetTimelineParamsGroupBy string
+
+// CreateAccessTokenJSONBody defines parameters for CreateAccessToken.
+type CreateAccessTokenJSONBody = AccessTokenRequestPayload
+
+// UpdatePhotoJSONRequestBody defines body for UpdatePhoto for application/json ContentType.
+type UpdatePhotoJSONRequestBody = UpdatePhotoJSONBody
+
+// CreatePhotoCommentJSONRequestBody defines body for CreatePhotoComment for application/json ContentType.
+type CreatePhotoCommentJSONRequestBody = CreatePhotoCommentJSONBody
+
+// UpdatePhotoCommentJSONRequestBody defines body for UpdatePhotoComment for application/json ContentType.
+type UpdatePhotoCommentJSONRequestBody = UpdatePhotoCommentJSONBody
+
+// AddPhotoReactionJSONRequestBody defines body for AddPhotoReaction for application/json ContentType.
+type AddPhotoReactionJSONRequestBody = AddPhotoReactionJSONBody
+
 // CreateAlbumJSONRequestBody defines body for CreateAlbum for application/json ContentType.
 type CreateAlbumJSONRequestBody = CreateAlbumJSONBody
 
 // UpdateAlbumJSONRequestBody defines body for UpdateAlbum for application/json ContentType.
 type UpdateAlbumJSONRequestBody = UpdateAlbumJSONBody
 
+// OrganizeAlbumJSONRequestBody defines body for OrganizeAlbum for application/json ContentType.
+type OrganizeAlbumJSONRequestBody = OrganizeAlbumJSONBody
+
 // SetAlbumPermissionsJSONRequestBody defines body for SetAlbumPermissions for application/json ContentType.
 type SetAlbumPermissionsJSONRequestBody = SetAlbumPermissionsJSONBody
 
+// CopyPhotosJSONRequestBody defines body for CopyPhotos for application/json ContentType.
+type CopyPhotosJSONRequestBody = CopyPhotosJSONBody
+
+// MovePhotosJSONRequestBody defines body for MovePhotos for application/json ContentType.
+type MovePhotosJSONRequestBody = MovePhotosJSONBody
+
+// CreatePhotoUploadJSONRequestBody defines body for CreatePhotoUpload for application/json ContentType.
+type CreatePhotoUploadJSONRequestBody = CreatePhotoUploadJSONBody
+
+// SetAlbumThumbnailJSONRequestBody defines body for SetAlbumThumbnail for application/json ContentType.
+type SetAlbumThumbnailJSONRequestBody = SetAlbumThumbnailJSONBody
+
+// TrashSimilarPhotosJSONRequestBody defines body for TrashSimilarPhotos for application/json ContentType.
+type TrashSimilarPhotosJSONRequestBody = TrashSimilarPhotosJSONBody
+
 // CreateTagJSONRequestBody defines body for CreateTag for application/json ContentType.
 type CreateTagJSONRequestBody = CreateTagJSONBody
 
 // UpdateTagJSONRequestBody defines body for UpdateTag for application/json ContentType.
 type UpdateTagJSONRequestBody = UpdateTagJSONBody
+
+// CreateAccessTokenJSONRequestBody defines body for CreateAccessToken for application/json ContentType.
+type CreateAccessTokenJSONRequestBody = CreateAccessTokenJSONBody
+
+// Getter for additional properties for PhotoUpload_Fields. Returns the specified
+// element and whether it was found
+func (a PhotoUpload_Fields) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for PhotoUpload_Fields
+func (a *PhotoUpload_Fields) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for PhotoUpload_Fields to handle AdditionalProperties
+func (a *PhotoUpload_Fields) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for PhotoUpload_Fields to handle AdditionalProperties
+func (a PhotoUpload_Fields) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
@@ -24,12 +24,57 @@ type ServerInterface interface {
 	// (GET /api/gphotos/v1)
 	GetVersionMetadata(c *gin.Context)
 
+	// (POST /api/gphotos/v1/admin/fsck)
+	CheckStorage(c *gin.Context, params CheckStorageParams)
+
 	// (DELETE /api/gphotos/v1/album/{album_id}/photo/{photo_id})
 	DeletePhoto(c *gin.Context, albumId AlbumId, photoId PhotoId)
 
 	// (GET /api/gphotos/v1/album/{album_id}/photo/{photo_id})
 	GetPhoto(c *gin.Context, albumId AlbumId, photoId PhotoId)
 
+	// (PATCH /api/gphotos/v1/album/{album_id}/photo/{photo_id})
+	UpdatePhoto(c *gin.Context, albumId AlbumId, photoId PhotoId)
+
+	// (GET /api/gphotos/v1/album/{album_id}/photo/{photo_id}/comments)
+	GetPhotoComments(c *gin.Context, albumId AlbumId, photoId PhotoId)
+
+	// (POST /api/gphotos/v1/album/{album_id}/photo/{photo_id}/comments)
+	CreatePhotoComment(c *gin.Context, albumId AlbumId, photoId PhotoId)
+
+	// (DELETE /api/gphotos/v1/album/{album_id}/photo/{photo_id}/comments/{comment_id})
+	DeletePhotoComment(c *gin.Context, albumId AlbumId, photoId PhotoId, commentId CommentId)
+
+	// (PUT /api/gphotos/v1/album/{album_id}/photo/{photo_id}/comments/{comment_id})
+	UpdatePhotoComment(c *gin.Context, albumId AlbumId, photoId PhotoId, commentId CommentId)
+
+	// (GET /api/gphotos/v1/album/{album_id}/photo/{photo_id}/comments/{comment_id}/history)
+	GetPhotoCommentHistory(c *gin.Context, albumId AlbumId, photoId PhotoId, commentId CommentId)
+
+	// (DELETE /api/gphotos/v1/album/{album_id}/photo/{photo_id}/favorite)
+	RemovePhotoFavorite(c *gin.Context, albumId AlbumId, photoId PhotoId)
+
+	// (PUT /api/gphotos/v1/album/{album_id}/photo/{photo_id}/favorite)
+	SetPhotoFavorite(c *gin.Context, albumId AlbumId, photoId PhotoId)
+
+	// (GET /api/gphotos/v1/album/{album_id}/photo/{photo_id}/reactions)
+	GetPhotoReactions(c *gin.Context, albumId AlbumId, photoId PhotoId)
+
+	// (POST /api/gphotos/v1/album/{album_id}/photo/{photo_id}/reactions)
+	AddPhotoReaction(c *gin.Context, albumId AlbumId, photoId PhotoId)
+
+	// (DELETE /api/gphotos/v1/album/{album_id}/photo/{photo_id}/reactions/{reaction_kind})
+	RemovePhotoReaction(c *gin.Context, albumId AlbumId, photoId PhotoId, reactionKind ReactionKind)
+
+	// (DELETE /api/gphotos/v1/album/{album_id}/photo/{photo_id}/tags/{tag_id})
+	RemoveTagFromPhoto(c *gin.Context, albumId AlbumId, photoId PhotoId, tagId TagId)
+
+	// (POST /api/gphotos/v1/album/{album_id}/photo/{photo_id}/tags/{tag_id})
+	SetTagToPhoto(c *gin.Context, albumId AlbumId, photoId PhotoId, tagId TagId)
+
+	// (GET /api/gphotos/v1/album/{album_id}/photo/{photo_id}/url)
+	GetPhotoUrl(c *gin.Context, albumId AlbumId, photoId PhotoId)
+
 	// (GET /api/gphotos/v1/albums)
 	GetAlbums(c *gin.Context, params GetAlbumsParams)
 
@@ -51,6 +96,21 @@ type ServerInterface interface {
 	// (PATCH /api/gphotos/v1/albums/{album_id})
 	UpdateAlbum(c *gin.Context, albumId AlbumId)
 
+	// (GET /api/gphotos/v1/albums/{album_id}/archive)
+	GetAlbumArchive(c *gin.Context, albumId AlbumId, params GetAlbumArchiveParams)
+
+	// (GET /api/gphotos/v1/albums/{album_id}/events)
+	GetAlbumEvents(c *gin.Context, albumId AlbumId)
+
+	// (GET /api/gphotos/v1/albums/{album_id}/location)
+	GetAlbumLocation(c *gin.Context, albumId AlbumId)
+
+	// (GET /api/gphotos/v1/albums/{album_id}/organize)
+	GetAlbumOrganizeProposals(c *gin.Context, albumId AlbumId, params GetAlbumOrganizeProposalsParams)
+
+	// (POST /api/gphotos/v1/albums/{album_id}/organize)
+	OrganizeAlbum(c *gin.Context, albumId AlbumId)
+
 	// (DELETE /api/gphotos/v1/albums/{album_id}/permissions)
 	RemoveAlbumPermissions(c *gin.Context, albumId AlbumId)
 
@@ -66,6 +126,21 @@ type ServerInterface interface {
 	// (POST /api/gphotos/v1/albums/{album_id}/photos)
 	UploadPhoto(c *gin.Context, albumId AlbumId)
 
+	// (POST /api/gphotos/v1/albums/{album_id}/photos/copy)
+	CopyPhotos(c *gin.Context, albumId AlbumId)
+
+	// (POST /api/gphotos/v1/albums/{album_id}/photos/move)
+	MovePhotos(c *gin.Context, albumId AlbumId)
+
+	// (POST /api/gphotos/v1/albums/{album_id}/photos/uploads)
+	CreatePhotoUpload(c *gin.Context, albumId AlbumId)
+
+	// (POST /api/gphotos/v1/albums/{album_id}/photos/uploads/{upload_id})
+	CompletePhotoUpload(c *gin.Context, albumId AlbumId, uploadId UploadId)
+
+	// (GET /api/gphotos/v1/albums/{album_id}/similar)
+	GetAlbumSimilarPhotos(c *gin.Context, albumId AlbumId, params GetAlbumSimilarPhotosParams)
+
 	// (DELETE /api/gphotos/v1/albums/{album_id}/tags/{tag_id})
 	RemoveTagFromAlbum(c *gin.Context, albumId AlbumId, tagId TagId)
 
@@ -75,9 +150,30 @@ type ServerInterface interface {
 	// (GET /api/gphotos/v1/albums/{album_id}/thumbnail)
 	GetAlbumThumbnail(c *gin.Context, albumId AlbumId)
 
+	// (PUT /api/gphotos/v1/albums/{album_id}/thumbnail)
+	SetAlbumThumbnail(c *gin.Context, albumId AlbumId)
+
+	// (GET /api/gphotos/v1/albums/{album_id}/trash)
+	GetAlbumTrash(c *gin.Context, albumId AlbumId)
+
+	// (POST /api/gphotos/v1/albums/{album_id}/trash/{trash_id}/restore)
+	RestoreTrashedPhoto(c *gin.Context, albumId AlbumId, trashId TrashId)
+
+	// (GET /api/gphotos/v1/events)
+	GetEvents(c *gin.Context)
+
 	// (GET /api/gphotos/v1/groups)
 	GetGroups(c *gin.Context)
 
+	// (GET /api/gphotos/v1/map)
+	GetMap(c *gin.Context, params GetMapParams)
+
+	// (GET /api/gphotos/v1/similar)
+	GetSimilarPhotos(c *gin.Context, params GetSimilarPhotosParams)
+
+	// (POST /api/gphotos/v1/similar/trash)
+	TrashSimilarPhotos(c *gin.Context)
+
 	// (GET /api/gphotos/v1/tags)
 	GetTags(c *gin.Context, params GetTagsParams)
 
@@ -90,6 +186,18 @@ type ServerInterface interface {
 	// (PATCH /api/gphotos/v1/tags/{tag_id})
 	UpdateTag(c *gin.Context, tagId TagId)
 
+	// (GET /api/gphotos/v1/timeline)
+	GetTimeline(c *gin.Context, params GetTimelineParams)
+
+	// (GET /api/gphotos/v1/tokens)
+	GetAccessTokens(c *gin.Context)
+
+	// (POST /api/gphotos/v1/tokens)
+	CreateAccessToken(c *gin.Context)
+
+	// (DELETE /api/gphotos/v1/tokens/{token_id})
+	DeleteAccessToken(c *gin.Context, tokenId TokenId)
+
 	// (GET /api/gphotos/v1/users)
 	GetUsers(c *gin.Context)
 
@@ -98,6 +206,9 @@ type ServerInterface interface {
 
 	// (GET /api/gphotos/v1/users/{user_id}/related)
 	GetRelatedUsers(c *gin.Context, userId UserId)
+
+	// (GET /api/gphotos/v1/users/{user_id}/usage)
+	GetUserUsage(c *gin.Context, userId UserId)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -111,6 +222,8 @@ type MiddlewareFunc func(c *gin.Context)
 // GetVersionMetadata operation middleware
 func (siw *ServerInterfaceWrapper) GetVersionMetadata(c *gin.Context) {
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -118,6 +231,34 @@ func (siw *ServerInterfaceWrapper) GetVersionMetadata(c *gin.Context) {
 	siw.Handler.GetVersionMetadata(c)
 }
 
+// CheckStorage operation middleware
+func (siw *ServerInterfaceWrapper) CheckStorage(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CheckStorageParams
+
+	// ------------- Optional query parameter "repair" -------------
+	if paramValue := c.Query("repair"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "repair", c.Request.URL.Query(), &params.Repair)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter repair: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CheckStorage(c, params)
+}
+
 // DeletePhoto operation middleware
 func (siw *ServerInterfaceWrapper) DeletePhoto(c *gin.Context) {
 
@@ -141,6 +282,8 @@ func (siw *ServerInterfaceWrapper) DeletePhoto(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -171,6 +314,8 @@ func (siw *ServerInterfaceWrapper) GetPhoto(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -178,191 +323,227 @@ func (siw *ServerInterfaceWrapper) GetPhoto(c *gin.Context) {
 	siw.Handler.GetPhoto(c, albumId, photoId)
 }
 
-// GetAlbums operation middleware
-func (siw *ServerInterfaceWrapper) GetAlbums(c *gin.Context) {
+// UpdatePhoto operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePhoto(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAlbumsParams
-
-	// ------------- Optional query parameter "sort" -------------
-	if paramValue := c.Query("sort"); paramValue != "" {
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
 
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
+
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sort: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
 		return
 	}
 
-	// ------------- Optional query parameter "personal" -------------
-	if paramValue := c.Query("personal"); paramValue != "" {
+	c.Set(BearerAuthScopes, []string{""})
 
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "personal", c.Request.URL.Query(), &params.Personal)
+	siw.Handler.UpdatePhoto(c, albumId, photoId)
+}
+
+// GetPhotoComments operation middleware
+func (siw *ServerInterfaceWrapper) GetPhotoComments(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter personal: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
 		return
 	}
 
-	// ------------- Optional query parameter "shared" -------------
-	if paramValue := c.Query("shared"); paramValue != "" {
-
-	}
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
 
-	err = runtime.BindQueryParameter("form", true, false, "shared", c.Request.URL.Query(), &params.Shared)
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter shared: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
 		return
 	}
 
-	// ------------- Optional query parameter "search" -------------
-	if paramValue := c.Query("search"); paramValue != "" {
+	c.Set(BearerAuthScopes, []string{""})
 
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "search", c.Request.URL.Query(), &params.Search)
+	siw.Handler.GetPhotoComments(c, albumId, photoId)
+}
+
+// CreatePhotoComment operation middleware
+func (siw *ServerInterfaceWrapper) CreatePhotoComment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter search: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
 		return
 	}
 
-	// ------------- Optional query parameter "page" -------------
-	if paramValue := c.Query("page"); paramValue != "" {
-
-	}
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
 
-	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
 		return
 	}
 
-	// ------------- Optional query parameter "size" -------------
-	if paramValue := c.Query("size"); paramValue != "" {
+	c.Set(BearerAuthScopes, []string{""})
 
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
+	siw.Handler.CreatePhotoComment(c, albumId, photoId)
+}
+
+// DeletePhotoComment operation middleware
+func (siw *ServerInterfaceWrapper) DeletePhotoComment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter size: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
+
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
+		return
 	}
 
-	siw.Handler.GetAlbums(c, params)
-}
+	// ------------- Path parameter "comment_id" -------------
+	var commentId CommentId
 
-// CreateAlbum operation middleware
-func (siw *ServerInterfaceWrapper) CreateAlbum(c *gin.Context) {
+	err = runtime.BindStyledParameter("simple", false, "comment_id", c.Param("comment_id"), &commentId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter comment_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.CreateAlbum(c)
+	siw.Handler.DeletePhotoComment(c, albumId, photoId, commentId)
 }
 
-// GetAlbumsByGroup operation middleware
-func (siw *ServerInterfaceWrapper) GetAlbumsByGroup(c *gin.Context) {
+// UpdatePhotoComment operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePhotoComment(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "group_id" -------------
-	var groupId GroupId
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
 
-	err = runtime.BindStyledParameter("simple", false, "group_id", c.Param("group_id"), &groupId)
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter group_id: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAlbumsByGroupParams
-
-	// ------------- Optional query parameter "page" -------------
-	if paramValue := c.Query("page"); paramValue != "" {
-
-	}
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
 
-	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
 		return
 	}
 
-	// ------------- Optional query parameter "size" -------------
-	if paramValue := c.Query("size"); paramValue != "" {
-
-	}
+	// ------------- Path parameter "comment_id" -------------
+	var commentId CommentId
 
-	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
+	err = runtime.BindStyledParameter("simple", false, "comment_id", c.Param("comment_id"), &commentId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter size: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter comment_id: %s", err)})
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.GetAlbumsByGroup(c, groupId, params)
+	siw.Handler.UpdatePhotoComment(c, albumId, photoId, commentId)
 }
 
-// GetAlbumsByUser operation middleware
-func (siw *ServerInterfaceWrapper) GetAlbumsByUser(c *gin.Context) {
+// GetPhotoCommentHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetPhotoCommentHistory(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId UserId
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
 
-	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter user_id: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAlbumsByUserParams
-
-	// ------------- Optional query parameter "page" -------------
-	if paramValue := c.Query("page"); paramValue != "" {
-
-	}
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
 
-	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
 		return
 	}
 
-	// ------------- Optional query parameter "size" -------------
-	if paramValue := c.Query("size"); paramValue != "" {
-
-	}
+	// ------------- Path parameter "comment_id" -------------
+	var commentId CommentId
 
-	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
+	err = runtime.BindStyledParameter("simple", false, "comment_id", c.Param("comment_id"), &commentId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter size: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter comment_id: %s", err)})
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.GetAlbumsByUser(c, userId, params)
+	siw.Handler.GetPhotoCommentHistory(c, albumId, photoId, commentId)
 }
 
-// DeleteAlbum operation middleware
-func (siw *ServerInterfaceWrapper) DeleteAlbum(c *gin.Context) {
+// RemovePhotoFavorite operation middleware
+func (siw *ServerInterfaceWrapper) RemovePhotoFavorite(c *gin.Context) {
 
 	var err error
 
@@ -375,15 +556,26 @@ func (siw *ServerInterfaceWrapper) DeleteAlbum(c *gin.Context) {
 		return
 	}
 
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
+
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.DeleteAlbum(c, albumId)
+	siw.Handler.RemovePhotoFavorite(c, albumId, photoId)
 }
 
-// GetAlbumByID operation middleware
-func (siw *ServerInterfaceWrapper) GetAlbumByID(c *gin.Context) {
+// SetPhotoFavorite operation middleware
+func (siw *ServerInterfaceWrapper) SetPhotoFavorite(c *gin.Context) {
 
 	var err error
 
@@ -396,15 +588,26 @@ func (siw *ServerInterfaceWrapper) GetAlbumByID(c *gin.Context) {
 		return
 	}
 
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
+
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.GetAlbumByID(c, albumId)
+	siw.Handler.SetPhotoFavorite(c, albumId, photoId)
 }
 
-// UpdateAlbum operation middleware
-func (siw *ServerInterfaceWrapper) UpdateAlbum(c *gin.Context) {
+// GetPhotoReactions operation middleware
+func (siw *ServerInterfaceWrapper) GetPhotoReactions(c *gin.Context) {
 
 	var err error
 
@@ -417,15 +620,1068 @@ func (siw *ServerInterfaceWrapper) UpdateAlbum(c *gin.Context) {
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
+
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetPhotoReactions(c, albumId, photoId)
+}
+
+// AddPhotoReaction operation middleware
+func (siw *ServerInterfaceWrapper) AddPhotoReaction(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
+
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.AddPhotoReaction(c, albumId, photoId)
+}
+
+// RemovePhotoReaction operation middleware
+func (siw *ServerInterfaceWrapper) RemovePhotoReaction(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
+
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
+		return
+	}
+
+	// ------------- Path parameter "reaction_kind" -------------
+	var reactionKind ReactionKind
+
+	err = runtime.BindStyledParameter("simple", false, "reaction_kind", c.Param("reaction_kind"), &reactionKind)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter reaction_kind: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.RemovePhotoReaction(c, albumId, photoId, reactionKind)
+}
+
+// RemoveTagFromPhoto operation middleware
+func (siw *ServerInterfaceWrapper) RemoveTagFromPhoto(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
+
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
+		return
+	}
+
+	// ------------- Path parameter "tag_id" -------------
+	var tagId TagId
+
+	err = runtime.BindStyledParameter("simple", false, "tag_id", c.Param("tag_id"), &tagId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter tag_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.RemoveTagFromPhoto(c, albumId, photoId, tagId)
+}
+
+// SetTagToPhoto operation middleware
+func (siw *ServerInterfaceWrapper) SetTagToPhoto(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
+
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
+		return
+	}
+
+	// ------------- Path parameter "tag_id" -------------
+	var tagId TagId
+
+	err = runtime.BindStyledParameter("simple", false, "tag_id", c.Param("tag_id"), &tagId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter tag_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.SetTagToPhoto(c, albumId, photoId, tagId)
+}
+
+// GetPhotoUrl operation middleware
+func (siw *ServerInterfaceWrapper) GetPhotoUrl(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	// ------------- Path parameter "photo_id" -------------
+	var photoId PhotoId
+
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetPhotoUrl(c, albumId, photoId)
+}
+
+// GetAlbums operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbums(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAlbumsParams
+
+	// ------------- Optional query parameter "sort" -------------
+	if paramValue := c.Query("sort"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sort: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "personal" -------------
+	if paramValue := c.Query("personal"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "personal", c.Request.URL.Query(), &params.Personal)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter personal: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "shared" -------------
+	if paramValue := c.Query("shared"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "shared", c.Request.URL.Query(), &params.Shared)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter shared: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "search" -------------
+	if paramValue := c.Query("search"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "search", c.Request.URL.Query(), &params.Search)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter search: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+	if paramValue := c.Query("page"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "size" -------------
+	if paramValue := c.Query("size"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter size: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetAlbums(c, params)
+}
+
+// CreateAlbum operation middleware
+func (siw *ServerInterfaceWrapper) CreateAlbum(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CreateAlbum(c)
+}
+
+// GetAlbumsByGroup operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumsByGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group_id" -------------
+	var groupId GroupId
+
+	err = runtime.BindStyledParameter("simple", false, "group_id", c.Param("group_id"), &groupId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter group_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAlbumsByGroupParams
+
+	// ------------- Optional query parameter "page" -------------
+	if paramValue := c.Query("page"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "size" -------------
+	if paramValue := c.Query("size"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter size: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetAlbumsByGroup(c, groupId, params)
+}
+
+// GetAlbumsByUser operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumsByUser(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter user_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAlbumsByUserParams
+
+	// ------------- Optional query parameter "page" -------------
+	if paramValue := c.Query("page"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "size" -------------
+	if paramValue := c.Query("size"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter size: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetAlbumsByUser(c, userId, params)
+}
+
+// DeleteAlbum operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAlbum(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.DeleteAlbum(c, albumId)
+}
+
+// GetAlbumByID operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumByID(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetAlbumByID(c, albumId)
+}
+
+// UpdateAlbum operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAlbum(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.UpdateAlbum(c, albumId)
+}
+
+// GetAlbumArchive operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumArchive(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAlbumArchiveParams
+
+	// ------------- Optional query parameter "photo_ids" -------------
+	if paramValue := c.Query("photo_ids"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "photo_ids", c.Request.URL.Query(), &params.PhotoIds)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter photo_ids: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "manifest" -------------
+	if paramValue := c.Query("manifest"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "manifest", c.Request.URL.Query(), &params.Manifest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter manifest: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetAlbumArchive(c, albumId, params)
+}
+
+// GetAlbumEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumEvents(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetAlbumEvents(c, albumId)
+}
+
+// GetAlbumLocation operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumLocation(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetAlbumLocation(c, albumId)
+}
+
+// GetAlbumOrganizeProposals operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumOrganizeProposals(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAlbumOrganizeProposalsParams
+
+	// ------------- Optional query parameter "max_gap" -------------
+	if paramValue := c.Query("max_gap"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "max_gap", c.Request.URL.Query(), &params.MaxGap)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter max_gap: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "max_distance" -------------
+	if paramValue := c.Query("max_distance"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "max_distance", c.Request.URL.Query(), &params.MaxDistance)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter max_distance: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetAlbumOrganizeProposals(c, albumId, params)
+}
+
+// OrganizeAlbum operation middleware
+func (siw *ServerInterfaceWrapper) OrganizeAlbum(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.OrganizeAlbum(c, albumId)
+}
+
+// RemoveAlbumPermissions operation middleware
+func (siw *ServerInterfaceWrapper) RemoveAlbumPermissions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.RemoveAlbumPermissions(c, albumId)
+}
+
+// GetAlbumPermissions operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumPermissions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetAlbumPermissions(c, albumId)
+}
+
+// SetAlbumPermissions operation middleware
+func (siw *ServerInterfaceWrapper) SetAlbumPermissions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.SetAlbumPermissions(c, albumId)
+}
+
+// GetAlbumPhotos operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumPhotos(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAlbumPhotosParams
+
+	// ------------- Optional query parameter "page" -------------
+	if paramValue := c.Query("page"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "size" -------------
+	if paramValue := c.Query("size"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter size: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "favorites" -------------
+	if paramValue := c.Query("favorites"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "favorites", c.Request.URL.Query(), &params.Favorites)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter favorites: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+	if paramValue := c.Query("tag"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", c.Request.URL.Query(), &params.Tag)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter tag: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+	if paramValue := c.Query("sort"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sort: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+	if paramValue := c.Query("order"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "order", c.Request.URL.Query(), &params.Order)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter order: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+	if paramValue := c.Query("from"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter from: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+	if paramValue := c.Query("to"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter to: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "media_type" -------------
+	if paramValue := c.Query("media_type"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "media_type", c.Request.URL.Query(), &params.MediaType)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter media_type: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := c.Query("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cursor: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.UpdateAlbum(c, albumId)
+	siw.Handler.GetAlbumPhotos(c, albumId, params)
 }
 
-// RemoveAlbumPermissions operation middleware
-func (siw *ServerInterfaceWrapper) RemoveAlbumPermissions(c *gin.Context) {
+// UploadPhoto operation middleware
+func (siw *ServerInterfaceWrapper) UploadPhoto(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.UploadPhoto(c, albumId)
+}
+
+// CopyPhotos operation middleware
+func (siw *ServerInterfaceWrapper) CopyPhotos(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CopyPhotos(c, albumId)
+}
+
+// MovePhotos operation middleware
+func (siw *ServerInterfaceWrapper) MovePhotos(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.MovePhotos(c, albumId)
+}
+
+// CreatePhotoUpload operation middleware
+func (siw *ServerInterfaceWrapper) CreatePhotoUpload(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CreatePhotoUpload(c, albumId)
+}
+
+// CompletePhotoUpload operation middleware
+func (siw *ServerInterfaceWrapper) CompletePhotoUpload(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	// ------------- Path parameter "upload_id" -------------
+	var uploadId UploadId
+
+	err = runtime.BindStyledParameter("simple", false, "upload_id", c.Param("upload_id"), &uploadId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter upload_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CompletePhotoUpload(c, albumId, uploadId)
+}
+
+// GetAlbumSimilarPhotos operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumSimilarPhotos(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAlbumSimilarPhotosParams
+
+	// ------------- Optional query parameter "threshold" -------------
+	if paramValue := c.Query("threshold"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "threshold", c.Request.URL.Query(), &params.Threshold)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter threshold: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetAlbumSimilarPhotos(c, albumId, params)
+}
+
+// RemoveTagFromAlbum operation middleware
+func (siw *ServerInterfaceWrapper) RemoveTagFromAlbum(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	// ------------- Path parameter "tag_id" -------------
+	var tagId TagId
+
+	err = runtime.BindStyledParameter("simple", false, "tag_id", c.Param("tag_id"), &tagId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter tag_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.RemoveTagFromAlbum(c, albumId, tagId)
+}
+
+// SetTagToAlbum operation middleware
+func (siw *ServerInterfaceWrapper) SetTagToAlbum(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "album_id" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		return
+	}
+
+	// ------------- Path parameter "tag_id" -------------
+	var tagId TagId
+
+	err = runtime.BindStyledParameter("simple", false, "tag_id", c.Param("tag_id"), &tagId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter tag_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.SetTagToAlbum(c, albumId, tagId)
+}
+
+// GetAlbumThumbnail operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumThumbnail(c *gin.Context) {
 
 	var err error
 
@@ -438,15 +1694,17 @@ func (siw *ServerInterfaceWrapper) RemoveAlbumPermissions(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.RemoveAlbumPermissions(c, albumId)
+	siw.Handler.GetAlbumThumbnail(c, albumId)
 }
 
-// GetAlbumPermissions operation middleware
-func (siw *ServerInterfaceWrapper) GetAlbumPermissions(c *gin.Context) {
+// SetAlbumThumbnail operation middleware
+func (siw *ServerInterfaceWrapper) SetAlbumThumbnail(c *gin.Context) {
 
 	var err error
 
@@ -459,15 +1717,17 @@ func (siw *ServerInterfaceWrapper) GetAlbumPermissions(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.GetAlbumPermissions(c, albumId)
+	siw.Handler.SetAlbumThumbnail(c, albumId)
 }
 
-// SetAlbumPermissions operation middleware
-func (siw *ServerInterfaceWrapper) SetAlbumPermissions(c *gin.Context) {
+// GetAlbumTrash operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumTrash(c *gin.Context) {
 
 	var err error
 
@@ -480,15 +1740,17 @@ func (siw *ServerInterfaceWrapper) SetAlbumPermissions(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.SetAlbumPermissions(c, albumId)
+	siw.Handler.GetAlbumTrash(c, albumId)
 }
 
-// GetAlbumPhotos operation middleware
-func (siw *ServerInterfaceWrapper) GetAlbumPhotos(c *gin.Context) {
+// RestoreTrashedPhoto operation middleware
+func (siw *ServerInterfaceWrapper) RestoreTrashedPhoto(c *gin.Context) {
 
 	var err error
 
@@ -501,109 +1763,110 @@ func (siw *ServerInterfaceWrapper) GetAlbumPhotos(c *gin.Context) {
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAlbumPhotosParams
-
-	// ------------- Optional query parameter "page" -------------
-	if paramValue := c.Query("page"); paramValue != "" {
-
-	}
+	// ------------- Path parameter "trash_id" -------------
+	var trashId TrashId
 
-	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	err = runtime.BindStyledParameter("simple", false, "trash_id", c.Param("trash_id"), &trashId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter trash_id: %s", err)})
 		return
 	}
 
-	// ------------- Optional query parameter "size" -------------
-	if paramValue := c.Query("size"); paramValue != "" {
+	c.Set(BearerAuthScopes, []string{""})
 
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter size: %s", err)})
-		return
-	}
+	siw.Handler.RestoreTrashedPhoto(c, albumId, trashId)
+}
+
+// GetEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetEvents(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{""})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.GetAlbumPhotos(c, albumId, params)
+	siw.Handler.GetEvents(c)
 }
 
-// UploadPhoto operation middleware
-func (siw *ServerInterfaceWrapper) UploadPhoto(c *gin.Context) {
-
-	var err error
-
-	// ------------- Path parameter "album_id" -------------
-	var albumId AlbumId
+// GetGroups operation middleware
+func (siw *ServerInterfaceWrapper) GetGroups(c *gin.Context) {
 
-	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
-		return
-	}
+	c.Set(BearerAuthScopes, []string{""})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.UploadPhoto(c, albumId)
+	siw.Handler.GetGroups(c)
 }
 
-// RemoveTagFromAlbum operation middleware
-func (siw *ServerInterfaceWrapper) RemoveTagFromAlbum(c *gin.Context) {
+// GetMap operation middleware
+func (siw *ServerInterfaceWrapper) GetMap(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "album_id" -------------
-	var albumId AlbumId
+	c.Set(BearerAuthScopes, []string{""})
 
-	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMapParams
+
+	// ------------- Optional query parameter "south" -------------
+	if paramValue := c.Query("south"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "south", c.Request.URL.Query(), &params.South)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter south: %s", err)})
 		return
 	}
 
-	// ------------- Path parameter "tag_id" -------------
-	var tagId TagId
+	// ------------- Optional query parameter "west" -------------
+	if paramValue := c.Query("west"); paramValue != "" {
 
-	err = runtime.BindStyledParameter("simple", false, "tag_id", c.Param("tag_id"), &tagId)
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "west", c.Request.URL.Query(), &params.West)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter tag_id: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter west: %s", err)})
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-	}
+	// ------------- Optional query parameter "north" -------------
+	if paramValue := c.Query("north"); paramValue != "" {
 
-	siw.Handler.RemoveTagFromAlbum(c, albumId, tagId)
-}
+	}
 
-// SetTagToAlbum operation middleware
-func (siw *ServerInterfaceWrapper) SetTagToAlbum(c *gin.Context) {
+	err = runtime.BindQueryParameter("form", true, false, "north", c.Request.URL.Query(), &params.North)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter north: %s", err)})
+		return
+	}
 
-	var err error
+	// ------------- Optional query parameter "east" -------------
+	if paramValue := c.Query("east"); paramValue != "" {
 
-	// ------------- Path parameter "album_id" -------------
-	var albumId AlbumId
+	}
 
-	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	err = runtime.BindQueryParameter("form", true, false, "east", c.Request.URL.Query(), &params.East)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter east: %s", err)})
 		return
 	}
 
-	// ------------- Path parameter "tag_id" -------------
-	var tagId TagId
+	// ------------- Optional query parameter "zoom" -------------
+	if paramValue := c.Query("zoom"); paramValue != "" {
 
-	err = runtime.BindStyledParameter("simple", false, "tag_id", c.Param("tag_id"), &tagId)
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "zoom", c.Request.URL.Query(), &params.Zoom)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter tag_id: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter zoom: %s", err)})
 		return
 	}
 
@@ -611,20 +1874,27 @@ func (siw *ServerInterfaceWrapper) SetTagToAlbum(c *gin.Context) {
 		middleware(c)
 	}
 
-	siw.Handler.SetTagToAlbum(c, albumId, tagId)
+	siw.Handler.GetMap(c, params)
 }
 
-// GetAlbumThumbnail operation middleware
-func (siw *ServerInterfaceWrapper) GetAlbumThumbnail(c *gin.Context) {
+// GetSimilarPhotos operation middleware
+func (siw *ServerInterfaceWrapper) GetSimilarPhotos(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "album_id" -------------
-	var albumId AlbumId
+	c.Set(BearerAuthScopes, []string{""})
 
-	err = runtime.BindStyledParameter("simple", false, "album_id", c.Param("album_id"), &albumId)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSimilarPhotosParams
+
+	// ------------- Optional query parameter "threshold" -------------
+	if paramValue := c.Query("threshold"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "threshold", c.Request.URL.Query(), &params.Threshold)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter album_id: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter threshold: %s", err)})
 		return
 	}
 
@@ -632,17 +1902,19 @@ func (siw *ServerInterfaceWrapper) GetAlbumThumbnail(c *gin.Context) {
 		middleware(c)
 	}
 
-	siw.Handler.GetAlbumThumbnail(c, albumId)
+	siw.Handler.GetSimilarPhotos(c, params)
 }
 
-// GetGroups operation middleware
-func (siw *ServerInterfaceWrapper) GetGroups(c *gin.Context) {
+// TrashSimilarPhotos operation middleware
+func (siw *ServerInterfaceWrapper) TrashSimilarPhotos(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{""})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.GetGroups(c)
+	siw.Handler.TrashSimilarPhotos(c)
 }
 
 // GetTags operation middleware
@@ -650,6 +1922,8 @@ func (siw *ServerInterfaceWrapper) GetTags(c *gin.Context) {
 
 	var err error
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params GetTagsParams
 
@@ -685,6 +1959,8 @@ func (siw *ServerInterfaceWrapper) GetTags(c *gin.Context) {
 // CreateTag operation middleware
 func (siw *ServerInterfaceWrapper) CreateTag(c *gin.Context) {
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -706,6 +1982,8 @@ func (siw *ServerInterfaceWrapper) DeleteTag(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -727,6 +2005,8 @@ func (siw *ServerInterfaceWrapper) UpdateTag(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -734,9 +2014,130 @@ func (siw *ServerInterfaceWrapper) UpdateTag(c *gin.Context) {
 	siw.Handler.UpdateTag(c, tagId)
 }
 
+// GetTimeline operation middleware
+func (siw *ServerInterfaceWrapper) GetTimeline(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTimelineParams
+
+	// ------------- Optional query parameter "size" -------------
+	if paramValue := c.Query("size"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter size: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "group_by" -------------
+	if paramValue := c.Query("group_by"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "group_by", c.Request.URL.Query(), &params.GroupBy)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter group_by: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+	if paramValue := c.Query("from"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter from: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+	if paramValue := c.Query("to"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter to: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := c.Query("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cursor: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetTimeline(c, params)
+}
+
+// GetAccessTokens operation middleware
+func (siw *ServerInterfaceWrapper) GetAccessTokens(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetAccessTokens(c)
+}
+
+// CreateAccessToken operation middleware
+func (siw *ServerInterfaceWrapper) CreateAccessToken(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CreateAccessToken(c)
+}
+
+// DeleteAccessToken operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAccessToken(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "token_id" -------------
+	var tokenId TokenId
+
+	err = runtime.BindStyledParameter("simple", false, "token_id", c.Param("token_id"), &tokenId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter token_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.DeleteAccessToken(c, tokenId)
+}
+
 // GetUsers operation middleware
 func (siw *ServerInterfaceWrapper) GetUsers(c *gin.Context) {
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -758,6 +2159,8 @@ func (siw *ServerInterfaceWrapper) GetRelatedGroups(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -779,6 +2182,8 @@ func (siw *ServerInterfaceWrapper) GetRelatedUsers(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -786,6 +2191,29 @@ func (siw *ServerInterfaceWrapper) GetRelatedUsers(c *gin.Context) {
 	siw.Handler.GetRelatedUsers(c, userId)
 }
 
+// GetUserUsage operation middleware
+func (siw *ServerInterfaceWrapper) GetUserUsage(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter user_id: %s", err)})
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetUserUsage(c, userId)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL     string
@@ -806,10 +2234,40 @@ func RegisterHandlersWithOptions(router *gin.Engine, si ServerInterface, options
 
 	router.GET(options.BaseURL+"/api/gphotos/v1", wrapper.GetVersionMetadata)
 
+	router.POST(options.BaseURL+"/api/gphotos/v1/admin/fsck", wrapper.CheckStorage)
+
 	router.DELETE(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id", wrapper.DeletePhoto)
 
 	router.GET(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id", wrapper.GetPhoto)
 
+	router.PATCH(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id", wrapper.UpdatePhoto)
+
+	router.GET(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/comments", wrapper.GetPhotoComments)
+
+	router.POST(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/comments", wrapper.CreatePhotoComment)
+
+	router.DELETE(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/comments/:comment_id", wrapper.DeletePhotoComment)
+
+	router.PUT(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/comments/:comment_id", wrapper.UpdatePhotoComment)
+
+	router.GET(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/comments/:comment_id/history", wrapper.GetPhotoCommentHistory)
+
+	router.DELETE(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/favorite", wrapper.RemovePhotoFavorite)
+
+	router.PUT(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/favorite", wrapper.SetPhotoFavorite)
+
+	router.GET(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/reactions", wrapper.GetPhotoReactions)
+
+	router.POST(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/reactions", wrapper.AddPhotoReaction)
+
+	router.DELETE(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/reactions/:reaction_kind", wrapper.RemovePhotoReaction)
+
+	router.DELETE(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/tags/:tag_id", wrapper.RemoveTagFromPhoto)
+
+	router.POST(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/tags/:tag_id", wrapper.SetTagToPhoto)
+
+	router.GET(options.BaseURL+"/api/gphotos/v1/album/:album_id/photo/:photo_id/url", wrapper.GetPhotoUrl)
+
 	router.GET(options.BaseURL+"/api/gphotos/v1/albums", wrapper.GetAlbums)
 
 	router.POST(options.BaseURL+"/api/gphotos/v1/albums", wrapper.CreateAlbum)
@@ -824,6 +2282,16 @@ func RegisterHandlersWithOptions(router *gin.Engine, si ServerInterface, options
 
 	router.PATCH(options.BaseURL+"/api/gphotos/v1/albums/:album_id", wrapper.UpdateAlbum)
 
+	router.GET(options.BaseURL+"/api/gphotos/v1/albums/:album_id/archive", wrapper.GetAlbumArchive)
+
+	router.GET(options.BaseURL+"/api/gphotos/v1/albums/:album_id/events", wrapper.GetAlbumEvents)
+
+	router.GET(options.BaseURL+"/api/gphotos/v1/albums/:album_id/location", wrapper.GetAlbumLocation)
+
+	router.GET(options.BaseURL+"/api/gphotos/v1/albums/:album_id/organize", wrapper.GetAlbumOrganizeProposals)
+
+	router.POST(options.BaseURL+"/api/gphotos/v1/albums/:album_id/organize", wrapper.OrganizeAlbum)
+
 	router.DELETE(options.BaseURL+"/api/gphotos/v1/albums/:album_id/permissions", wrapper.RemoveAlbumPermissions)
 
 	router.GET(options.BaseURL+"/api/gphotos/v1/albums/:album_id/permissions", wrapper.GetAlbumPermissions)
@@ -834,14 +2302,38 @@ func RegisterHandlersWithOptions(router *gin.Engine, si ServerInterface, options
 
 	router.POST(options.BaseURL+"/api/gphotos/v1/albums/:album_id/photos", wrapper.UploadPhoto)
 
+	router.POST(options.BaseURL+"/api/gphotos/v1/albums/:album_id/photos/copy", wrapper.CopyPhotos)
+
+	router.POST(options.BaseURL+"/api/gphotos/v1/albums/:album_id/photos/move", wrapper.MovePhotos)
+
+	router.POST(options.BaseURL+"/api/gphotos/v1/albums/:album_id/photos/uploads", wrapper.CreatePhotoUpload)
+
+	router.POST(options.BaseURL+"/api/gphotos/v1/albums/:album_id/photos/uploads/:upload_id", wrapper.CompletePhotoUpload)
+
+	router.GET(options.BaseURL+"/api/gphotos/v1/albums/:album_id/similar", wrapper.GetAlbumSimilarPhotos)
+
 	router.DELETE(options.BaseURL+"/api/gphotos/v1/albums/:album_id/tags/:tag_id", wrapper.RemoveTagFromAlbum)
 
 	router.POST(options.BaseURL+"/api/gphotos/v1/albums/:album_id/tags/:tag_id", wrapper.SetTagToAlbum)
 
 	router.GET(options.BaseURL+"/api/gphotos/v1/albums/:album_id/thumbnail", wrapper.GetAlbumThumbnail)
 
+	router.PUT(options.BaseURL+"/api/gphotos/v1/albums/:album_id/thumbnail", wrapper.SetAlbumThumbnail)
+
+	router.GET(options.BaseURL+"/api/gphotos/v1/albums/:album_id/trash", wrapper.GetAlbumTrash)
+
+	router.POST(options.BaseURL+"/api/gphotos/v1/albums/:album_id/trash/:trash_id/restore", wrapper.RestoreTrashedPhoto)
+
+	router.GET(options.BaseURL+"/api/gphotos/v1/events", wrapper.GetEvents)
+
 	router.GET(options.BaseURL+"/api/gphotos/v1/groups", wrapper.GetGroups)
 
+	router.GET(options.BaseURL+"/api/gphotos/v1/map", wrapper.GetMap)
+
+	router.GET(options.BaseURL+"/api/gphotos/v1/similar", wrapper.GetSimilarPhotos)
+
+	router.POST(options.BaseURL+"/api/gphotos/v1/similar/trash", wrapper.TrashSimilarPhotos)
+
 	router.GET(options.BaseURL+"/api/gphotos/v1/tags", wrapper.GetTags)
 
 	router.POST(options.BaseURL+"/api/gphotos/v1/tags", wrapper.CreateTag)
@@ -850,63 +2342,168 @@ func RegisterHandlersWithOptions(router *gin.Engine, si ServerInterface, options
 
 	router.PATCH(options.BaseURL+"/api/gphotos/v1/tags/:tag_id", wrapper.UpdateTag)
 
+	router.GET(options.BaseURL+"/api/gphotos/v1/timeline", wrapper.GetTimeline)
+
+	router.GET(options.BaseURL+"/api/gphotos/v1/tokens", wrapper.GetAccessTokens)
+
+	router.POST(options.BaseURL+"/api/gphotos/v1/tokens", wrapper.CreateAccessToken)
+
+	router.DELETE(options.BaseURL+"/api/gphotos/v1/tokens/:token_id", wrapper.DeleteAccessToken)
+
 	router.GET(options.BaseURL+"/api/gphotos/v1/users", wrapper.GetUsers)
 
 	router.GET(options.BaseURL+"/api/gphotos/v1/users/:user_id/groups/related", wrapper.GetRelatedGroups)
 
 	router.GET(options.BaseURL+"/api/gphotos/v1/users/:user_id/related", wrapper.GetRelatedUsers)
 
+	router.GET(options.BaseURL+"/api/gphotos/v1/users/:user_id/usage", wrapper.GetUserUsage)
+
 	return router
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xdW2/bOBb+K4Rmgb5443Ta3Ye8tdtpEWBmWqTOvBTBgJaOZbYyqZJUGq/h/z7gTVfq",
-	"4lSOk1ZPM7FIHp7D850bL90FIdukjAKVIrjYBSnmeAMSuP4LJ8ts8zeJ1P9HIEJOUkkYDS6CxRrQ5RvE",
-	"VkiuAel2wSwg6lOK5TqYBRRvILgohpgFHL5mhEMUXEiewSwQ4Ro2WI0tt6lqKyQnNA72+1kQc5alAyjr",
-	"dn7K+RCHUU5xDE2q6ldEs80SuKP2NQO+LcjpfuWhV4xvsAwuAkLli1+DmaNFqIQYuCG2ZpINYJODYBkP",
-	"wc9pPsphnArAPFw3SZvfEdylHIRQv/k5tv17iJD/e8QpmcSJlafikUjYCJQCR1aMXnpqqEMlLHE8QL4S",
-	"x37R2u6HCTYTwAcQVc38VN0Ah5Ddu48aua80IjWEk/er4OLTLvgXh1VwEfwyLxA/tz3m75efIZRXsAIO",
-	"NIRgP9sFKWcpcElAD7jMwi8gfcCQa8eQaYO+rYED2kBEMCICCckUA7P6jGdByAFLiP7GnnH1N8IoirAE",
-	"RCjKKLlDkmxASLxRkM9XX7X4t/rio1EZtU6k9FfdlDUGSliI/aO4L71DmNWtd1e/9nZl3yhw1fewVQxS",
-	"4BuiQSzu01sZlvt0lDjW3TSu+/ovsGbR8ow5x1v99zrbLCkmSVNkGU9y5LpWPSLcl7H0yalzRQftAjlh",
-	"V4WXC+MmH5ppvoP9zX5m8PY7EXI45nTrJtBykQ2SncF5Q3r79kl+qGrEWPYBO4NzoKZoLz2c3/LsPTqj",
-	"DOdIg3lEWFUhw7FPrlfwNQOjCvk8qtLK4Vxbe4/PIJHTbKeWDePwhVBPRyULxHgeInUDQo8xUzNoKnjD",
-	"jORcNabSkGGZhg9XPmr1VdXytUL9gLcJw1FTdlVnUg4N/vvSExo0PEODFRM/1jjvdAutBr8ppnbbVnYH",
-	"RTPPqusgoXt6Nfnr6fgE/hvnjI9pDUIWlRkvSZ0DFl5p1eaqR/Db2ndaoUec7QZUHDrcbvgcXk1l+119",
-	"OyzbWD6BezGiHuhe3PyqBJ1xauivS7SaOuJyhuYXnTf4PvmtmcvJTOJgOs8s5z4c1Be2wcxay8rDDPHz",
-	"2MJ8bbZ6VD2G7eGbW81vt/iT7wsP72nXq3GSmYuXBRVBPYago8P86nxGMn9sqUPAIZid7TyhwixYkQRs",
-	"jJkHoMVcbjSKtJBOAHWzOAOhrhs3PXLudZeEYp26NxRJBftja8ColjtkifGFtXxU/VwqF6iUdA13yLJ8",
-	"r2zPVB28jv1gRnyu3g41c1Lyu9MFjk+gbt6kb982wd7gz79oaoE4cEg5CKCykqWbLuOt29BY69qu7lgI",
-	"ODCBGiV2sWrVEJ3IuL+z/dDXv7VwVhTEBoVLSsYn0Gm9tAOV+i/gymH+ARJHWOJxg+8kgVA2nPo9x+/P",
-	"0Hwcqh8JXWl3L4lMdEXellBmwa3hXs3hr9+uPl6+//MXXeVKgeKUBBfBi7Pzs+c6gpNrPfk5TsncDjC/",
-	"fa4131ePfAcSKbrKKiu04yXLJMK3mCR4mQCylMVZoMlx3eoyMj3ri6IgLVJGhRHsr+fnRr5UAjXKlaYJ",
-	"MQng/LPNa4oSbZeY66S0wKqs2KmiTd5mFrw8fz7aFEzW5yH8J5MIZ3INVKqRIVKU/zMi862UMwp3KYQS",
-	"IgSqDWJhmCmLus+Lip8cdERwo36tKcZc+7r5zu077ef603znNkn2RmcSkB479Ub/buI89I3INRIphGRF",
-	"IEIkauqMaf/BhoXlzbMWlBVN5vnOmEJwT9t8h8cUoSo6+bKVjTxk9bFyMmWypF8cn/RbxpckioBaki8f",
-	"glsr8RXLaGTkrpahkP3lGwR3REhx9mCouqQSOMWJwdRZBUx/QESwrmK2mtPBaHgH8rRQqMuSbHAM888p",
-	"xFUp9uYpTRlegeQEbidQnRJUjJvtnSeHrjY/JTrjGJwkhl+ha/8RWm6VCMQacyhxH2acA5UoYXEMkQ6u",
-	"vdh8ZSg2wFkl/JFxqYdNiJAqYDczOGvblGdcdh8BqBN4CzJcoxS4YEpq3cO7Zj4SS8YSwLSdhhVUDwO6",
-	"Ue/4PVbJnoUYYr9wDEPa6aLlABt3f40u9iw9Wv17ZfVb9a+icz+d9Tu5fbGQVu47ZcJjRv6nd8UQpmYh",
-	"m3bBNHhlC5Tc1Fles2g7rprVCjgeNo1hd3lOTemfjzsbH339AdldRKtQD7C6r3GErNQnAJ0QQK0eem4K",
-	"bPOdO0+47/XZpsczYYOVTn/N+/z16+07u1N4WEydH3/8QX2SE+tyi545Xp9NTunhQnIt9CcTiQ8Auj48",
-	"NN/ZWnM/zFXD0UB+bUrih2HclcV/ZIgbhFtOmwCf8H0sfOvzYz8QvIuS8IAKcH6SdHgV2MXQ9y19HR1z",
-	"Ppladg2ry+1UznoYbD2tKlYpy+xwiF4NavN4r7eXb54aVkxRaYLKBJVhBRksfberrtOouyJjGoziTU5W",
-	"zHnVVcw5f6hijsjCEIRYZUmyRZkWawWpByBrQBGjR0lUuxctVN3phO+Iaea1o5wD4puih464aX51pqqP",
-	"V7Bht9C4OzKm8fZsYRuqxRbIM4HS6nWNydxO5raskK3hSWXr1u1q6ewG08iWMUq61R60HEv9RzaIlWtN",
-	"TXnyw8QxQW2Cmg9q/v2mjyArfoV2BDofx4fVkQIez90+j/RKrZBkaAlIk4VIiSEvKJwdPyLqMQBXIDNO",
-	"q/A3wVHVDE5bYJO1eRzWZlgAnF9WHx4FmD6Vq+Md7t+dXj7i4bZHU6UvriH1HYbrlOUE5wnOlVOm/rDh",
-	"Ok0YjhC25/wkK7Hiz0pNj+89btoZNLSdHu2FTbM+cswTLfbinucwvREq+zKhcELhoNOoZXeqOs135v2j",
-	"zh2zaypx3Fc+WuD4LWeb761q9ntG+2DTsCLTAscoIkKwkOjwN19C7J5VmWBzPNgwri/SPhX0LNR/213Y",
-	"K6dGCGu2NEOdye8Cxwt2SkSMp9r6Nm9TnApfeILXBK/h8Brmm8rvN7RuSXufcKjFle3Z3qL09s7xSr0j",
-	"31ACcgv5fknO+5SGTQFg745i8Y5AW/mkXjQsjjp7UfTODHjEGkXxKpJ/m0NNGJvpGm+cJGY5CI3tzKcj",
-	"9w+uhFYvvEro3o3sPHerGtkLSYdcf9MO5lBb/ngKcu6Zlo5DsyXBLLc/9yHZx54tFPeyzGMyvktZC/3l",
-	"GJtazRd1WoL3o1/H6kgcpqtYPyViWrzCoFJU6XBTEYNJHLcd2TYIO8wjtKfT58dGhWPPVRfKJ1CnEP9Y",
-	"If7TrFF1Hj4dABDT8rsB8vh819FRem3PUmixPpnDpu3WN39T+5C8UHdCcBdCKhGONoQSIbFk3J8rXmsa",
-	"R1yr/EW49vMw9UyRQ0yEBG5j6Co3U+L48MbNKEm7jhZXN92dbQ6Jfr6sN6N07zEL9G1NSi+HUP8lMPt+",
-	"YUONrwy9vPJxz9ucNycCAp+A8KMB4RAEmMXtAIA28qWqQjsCnD2fADAB4JgA0P+KFb91+pXxJLgI5sH+",
-	"Zv9PAAAA//+0V+Ky2WwAAA==",
+	"H4sIAAAAAAAC/+x963McN+7gv8Lq31UlWzWW5MS7t+tvivM4160Tly3vh8u6VJxuzAyjnmaHZEuaqPS/",
+	"XxEk+0n2Q5rRw+4viTVNEiAIgCAAgjdRzLc5zyBTMnp9E+VU0C0oEPgXTZfF9pwl+t8JyFiwXDGeRa+j",
+	"sw2Qtz8SviJqAwTbRYuI6U85VZtoEWV0C9HraohFJODPgglIotdKFLCIZLyBLdVjq12u20olWLaObm8X",
+	"GqstZGoEbNvSD702zDT4a8GLfAR0bOeHXQ4xDXJO19CFqn8lWbFdgnDQ/ixA7Cpw2K8+9IqLLVXR64hl",
+	"6vvvooWDxTIFaxAG2IYrPmKaAiQvRAz+mZajTJupABpraOcXLAtgoL9oHFxTP/zmQNOQkEBFvOlCN78T",
+	"uM4FSFkD3SK77T8AhP3lWVPFFU3toupJMgVbSXIQxK6lF54eauoyK7oesciKrv30td2nEVbxC8jG6I44",
+	"BikJNg+AdyNNREBQuRmBAHIvYRn+gZ0CaLjxpqFR5CmnyQg8TEM/7GqQicAliDGgJYgAYDvAFLC37iPu",
+	"Iae4vme4vLilpL+tote/30T/S8Aqeh39z3G1Ax3bfse/Lf+AWH2AFQjIYohuFzdRLngOQjHAYWMBVEFy",
+	"TlVDCBKq4IViW4gWbbwWEVznTICc1CelUp0XsoTUJKP+SnRXwzx6kmRDJVkCZJqqyRF5x6Rk2ZqwFcng",
+	"EoT5OVqMhG/WoQ33akNVDSSTOCpZceEbQ8Y8x0H6SF5bp4/Y/va2vui/G0zcYIv6AjQo+7lEgOMiRref",
+	"bxd1Lvg3k2o8J2Dr7vKjrmz8Y+TcotsSPyoE3UW31Q8BhD/AnwVI9Z7uUEBft3FpslVzofAb1X8Qvcyl",
+	"qtXjHpEz909ixyB0pUCQf52QhO6k5pmtYZ9nzzBdtlhEnRG6Zg8IJADPJFkLmilIyHJXTWRBrpjaWN1d",
+	"b4zbqST8KkPNBlmx1SgJoMkLnqV6Qy31LU22LIs+e+hwinbtHrXWsogvQPnMO7VxvGHakKsNCCBbSBjV",
+	"yyUV12T1INnUhM1x8VvJfCwjRcauUWFJRbf5aKZqjNoGUvurfSDoDARZLHa5As+WpLcVwkx/M+36YIQK",
+	"IGVvQhURIFUFYsl5ChTFO+Ux9WPqvgyi6Rci/etgV8Nyr6dySp3V79JbGzF36pjSeFDU32MjY0iO17pn",
+	"dN3VtotIbYrtMqMs7RK4EGmpIV2rAYK3dI4VsNb2ZDWR0wZ1UpekC+xbGupj7Fg427F7FSJZ4/omZOq0",
+	"2ETWWPIiS1i2Pl/y66HuP9i2P/Br3TWGTA1LwS/A33OW4V4Q8yLzKLDqdLQGjuILCbEr1j3qLCJZrNcg",
+	"9cKP0AJXGxZvSEwzsgQiQRGeVax2RN4qrXj1DygkTXXEVuQi41fZwjohuEhYRhVI18yQgHC1AXHFJBwN",
+	"8q7j8AbdS1o6Ennn6N1d9XDvm1plX/vYXVkKfSLjpaCOvUeT6MPJngbzCJZvcT576GrNwwYeTWqVW0Lz",
+	"Z9+5jCWOf5yy6mwwfm+JpgXhonRI9bOadZSwxMs5ra2onFUHlQ4N6zB82tYHrb2qhr6C51zStEu1UlF0",
+	"hR98hIlprgoBDfMbj22oRUZbQGF1Uoojuad5UQ00ytCotvxx7I/T9dB7mgUgFRVqHJVXTEwks/8AUZLe",
+	"QTcrXSnE4A7eYia3j+9Hl91hQy+52rMMTqy7QqbdhH07o5m+tu5pmpr9ysKRfnegRxOUXIbA3OQGKTp0",
+	"NK5LTVAK/Jx9zhLp04+y4beTZMsvISGKNwMRd1NZluEq+EECDE084J5imfrHK6/h0jphTTk42U/Bg5M5",
+	"Kjl7Rp9+iT4H6xNmzd7R3yzWR95jlYlntPaGXj05fsV7zgR1XVg18+hDdFX2o+db7uASnzlgQ2sdDqJU",
+	"uzm2MT4XKvvm0UKxHDuI5idpA0Z7Mvp3CuRIng0sZ8CstcJlAPimUz9DdOYDVLa8trxYprU9xAbHNFpc",
+	"qM3ItpIXo9tewUgUWgQwMGx/h97CzMhHhzc2orlPe71QG34Xr0TMMwW+g5mCa9UNwQ44p8aZWsZimI5r",
+	"kSdBR1jH+oOEqbtZJc6gsTStaNSYrd+dYJf2/zCpuNjd26cQV5xyUOPFov0BLhmGQkf6JWy3R3CfWMgT",
+	"ER3c1Cth6GcR17BHukta9kFp2djmQ0vqyBJWXJgAlGXqSS5ilAxrCjBJLkFotKoolgA8ICR3E5aRsrGI",
+	"3pjPd4oWNsM7bXoqN1hLgZXBEMWJhAw35iVQAcKFZ94q7RzKuKYxEaAEA21rplSBGHbnGLh+PfCTEFyM",
+	"n+CIcChPwH8oFkCl1xzrrFQCIWwvLT/ux8S4y57gd3q49BDkfY0k+Ra18xFNEkgWxuo6EoBnhEVldh1Z",
+	"FBbW1df6024k7s8EUlCQ/G1/e1X/GWxAUn6W8cVbKQuf0Rf7XRQY/SslOuGZZn0t2ZQJJB7T4zVi1eWv",
+	"2M/IgNEGVKN95CPGnW3OMirWGRKcoLQntLN5STiFFWWpPzjm+MYFALnINzSD5LwMFNgAa/eHupFedvP+",
+	"uAVFE6poGQhPzl0eiVQ0hXMbavx8p0iTWXnCReMsUS3WitfWUDajiPJorCvQTj/EcB8g58KjAwzQ0fty",
+	"xbtTPCCO6fqcIHb2ZdOxjg87gRoQHwnKSEXXw0EVU0UCTVUWPEOkPFuPb99CuYRVH8eLLjqB97i9bEEj",
+	"NH6duyN2VnuY8cOubM8ehVN+BEvTkHqknel3QAb53qWCesJc7K/Al9JbOI79XdaoySoc9v69o/mbtJDK",
+	"F894WvHCyiuKxrJF2ufGiPkliJHu87bR5GJz7Zid9UybofsJ6WeKaVxYjXZo17IlpD/6+hfn2/G8h63H",
+	"MF1bm3SItUHSeCbI/PMOEKSFJo6KY9geXtzEmmbsLxg6O6JtdMdwQWvsIS+2BeXDthUODoQp75e5ckff",
+	"ezMpw+DinYKzuB87lh1TKy++WFgtCuj8Rd1TuYmZnevDRv+53A5SmfCKmmzpw2RvBTFe0UsumIL+5C2D",
+	"K5OEEtfBjRwXQkCmXMJxf+7W2K1gYgDTm4uvf+0khBtX9WKMK/zAiVGYH2hjXJ3MqOBy2azxO/CX6TnF",
+	"6dOW0xtvDGDFUnBxAHfeqqb9+fazE/BHsOSCAfIMrtV5XAjpO4ma391S6KZ4e6M6nvGscjrjl7EWLeLz",
+	"zh4tB12TNBw67A8t3oZ07AdYdeGEb4RVsa7+jIUxgbJxqVLmXkJvhMxOpE27kqeXLKN4u6aDK/Y8EzST",
+	"KxBjI4BjAtYTotNarYg1qPPRVCcCYmCXmu8aEMcFGWXUhRik6qfc0WKcjL4XINk6g+STSD2yumKQGvrR",
+	"JGF6djR932jRz9e4psSMojVlzqVyGlOnleo88e51H2xP/ot66b9R5JlrP9GNnjRxXcW1Sz5PQQHxu+G3",
+	"9Pq8Z/tJ2Zapu29CrUVF4bBUrYH+HNY1ZkWHeL1U4b1n93FiXI7l5TK3rbe2Qv0zWenzFlkJvjWuglzW",
+	"czCjRVs9MrXz8hAe1IT/m4D1BJVZZ+9JV0SaG7HmVdt6tH1XCJ/JIOCFwUiPObgUpk3vPZ5F9MFetHyE",
+	"3dmB/lhst1plj/S4uG5DXN0fX3AXTMm3KbuABUn5pf4vLdabBbniVwsiafK3QRoHD5HtyU1JejwM5vZ2",
+	"7tA9jbo9T2wPp2qZJPag3zXzp2XuDnoT/f4F54QxwKoZ+VbgI9uylIrScdrybNnMj1Hm40TPVMvLWVvZ",
+	"vZivnSifocnS5KKEfS51guzDQ9Ug8CQf1UaA3PA0GfB3jndh2ST0sRGCCoExvio7zzMd/xlUOgD5qGXV",
+	"pngj47el6s0U2yUJRnONHb57Hq1RvglxS6/ZttjWLq8nbIXiqciSKX2KVFdgt7QNlZsqMHUBuU0KJrSK",
+	"3mK8DBLCM5CLsvcJNvnu1RH5EVa0SNGie3lyNLx2PSnBzRWSRepzyUM+XuAt7uOP/6b9OIFFTCoYvglp",
+	"d8K+/WF7DfXEPPUenfXPteIDWkY3cE2s0XOnYKmpYeDNDb1vlN76LazrKuhk/WxW5BFMJK9b6TaE4HCy",
+	"k3fR9AIJEJALkJCpxn0H02V/6zY2XfeMbSFlGUw4jJY+ptAdXTliT8lBkB1QsSBbnqkN4UJf28aAfJmJ",
+	"T9eAQfhxK2gn8gOiMKgbHKYBJmwOFrYpx9kplb+uG0Ab5RvTpCB4gUO7J8rzeO2WSDN3oZvhYJrar15H",
+	"N9159qpmUoudFRV2pzQ3yvUy6vXDhfRO0ny5++jeQfHDcMDM9jcr5pWA+payt0StwVMrnr/NLcpy8u4+",
+	"ZV6I9WgH8mKKW8FlPLJaTpPbHxehGGB/Yn6r+ktnDDv8hJQ1nzfG4wWvDTx4+q4v8j5M8n47pMckD2Uf",
+	"+O1nbN1nMn+ym/O+DJiJl0z3kqvSDGjVSFWI0GU/MaZ/sICP+zAymKBp/AgmCS7tSJtEt+27zCJ9RNB7",
+	"Cl8RoPHGeMAXNtoi1iCV2TGixYSYu8FgijSg27aL258FV7S+vKUzt5NniV9NmiXBbkfjgo7a7dwFjDCM",
+	"S9qWSjHkwxvF5W+W4aZ6la1MI+RFX6bBf0weuYtf7TfXOU0BvVpNRrzj+MN3kn38qmUb4kIwtfuoR3aO",
+	"IipAnBY+S6Fe6MzxBUsgU0ztSC74JUvMFe4chNThj3ZpNJwBOtIQSrVYG6VyU3eLZSvc/xVTqf6yLiNA",
+	"Nq9fU+U/P334+Pa3X/9HT5TnkNGcRa+j749Ojl5iRpja4FyOac6O7QDHly9Rs/pq1/wCirDMMJI+DNAl",
+	"LxShl5SldJmCu1GAVrBeRmz1NjE922yiuU3mPJOGnt+dnLSuRNA8T5nJETj+wya2V6XI+ha+DQoJ1pyK",
+	"RZWUCb23i+jVycu9oWDS/j2Af+WK6PtEmhuwxoWG/PeTk8NDLjK4zgH9tphrTXgcF1rYb8usht+dMMsI",
+	"+b7FGMdYP+l4JeML1NxcepjkzQbiC6JcFSqjOFBXM+FylRc1C3rRTHWQ6AhSGyiXpnULmK4FwBH5TV9u",
+	"RXwk2qGisOl/GnyXARGrj4oLkwVZL376e3sG9Wz5LOaZZFJBFjOQJhgVqNdoujUqNibGlxW9XtFUQtc9",
+	"fvv5gFJQS+b2cMNb78weTQqODOjvDw/6Zy6WLEkgO3owyXubKRBazaPcHTUE7tSUJPNKmxad4xsXoL89",
+	"xk/HNy6If2tYLAVf0s+P+Ls99OARXOYQsxWDhLCkKyCm/XsbzG3Jh2/WVZNjhyHu4ANtHfKRh/dfBaeh",
+	"Nj1T+eqY9tXJq8OD/JVbipsAfOnFqWj/9kcC10wq+TQk6R0kjGLdnqDxMloafgH1uKLQpiXb0jUc/5HD",
+	"uknFwfymLg0/2OuNs1A9olCh41incT0/6cqp8pXR/oRXKYlLidZWXCjhuCtvpvODixzGRX7gyW5vtO1L",
+	"5bxtHrGVKOD2gPafu8fSWeb3pkKJufpqef8BuOsHmhBL8lm9zOrFr17uYgYf2/oIstdxUSuk0MzTJZIL",
+	"W224UUQ3bBW8cfCeinVw98WqF+7wLJn+XRPLEW4W3Flwm4JbygKaBn6PkGlSv9F+5nzhGUAia6VYLaPV",
+	"Knx7fDkCnLHwpqxH9IxtBn9NmlHWwst9I+Fbfrd+Zc242WKYFc9TUjz3MhqOb6qno8Z41Gp2hNFjsRMP",
+	"kw1huqM5wZQkpnaYJq8Nx+F918ZVnl5n3MNquOG2FbFGevCc9rB0mQX5YILsGPF5mg6Fx3L4KWGqKXAY",
+	"9tG/WLnSQgedVmdYGRcuGS8ksRMkTJqMZJsBtDGV+Xp9EU9e+J6UMXLykMbI7L6YddiXaoUcb6qqob3+",
+	"jFLHucyLVsVIowm3XCq8Lpwp11A3AGmSpgb9HK6G6fMwQfauhdz0PZxhP7WpPquHWT3sRz00y7D4zyUf",
+	"sOhlzZ9Z3pV2vaWvKEtX7M1AKPk/O7hPKBUAESO2xKeZZDnBWeRm90AwXuk7WZwmSU1ibPWIifLyEdQT",
+	"FxYsjqtnNwvKLCiHiLy5ogPDobeypRM2Q67a1SmdcB62RT+UkJ5/0K1RXKMn6lbSbJbaWWonh92QyxrS",
+	"ds+422mSNETxeUfdAvViHjjsNqQK3Hezlc/erln5fAGn2nJfO75x/zzXu//tyGOu6zT5WPvAmmu4bWP6",
+	"I037UiXYo/Asn7N87lc+9TjHN4quh0LinzJdzIWWNQB9gndG1z8Lvn3I3N7htmZuIwXujK5JwqTkMaPK",
+	"uZ5Ko2qWvoNLn2ay5yJ7Z3TdZ5SfOjYiFKeFE6KhtPiPoM7o+ow/F+HZnxRghaMu5bUo0koSm6VeZ0mc",
+	"JbEriXfaAW2d1aBHixK54UK9SJmORTQLsOojd8KvMqwXXHm6EiYgVumu2j6kuY4c9nl9wkqtz93b1SwH",
+	"3V3DHx2tNNFnEZ5NWYT7ADzwviG2ppRXxhWBTFezSKa5yfs94LpIXLs2CxdEbqiob2PuIJvy9RqSwHn2",
+	"F1CnBuJAHYOPXBjfe2r9yQaDo0D9AsmFalQv6JQbagP4GVS8qRUx6R3eNfOBqAoiBGBYQg1MABsNjj+g",
+	"FCVQEW9GqU9TP2h4RF0W66AqFvlhKJowwH8NnpvrQDx4HQhbYCl8iwZvXxCahZLGTYNTW3nuEC5zHNvn",
+	"L29ianYYV1XnYEcEM1MPfPww31b5GgTogSyFn7JY7HLldiC3zFqPFsr+BqYN4xlhMmxHlHIeNCSOTVXD",
+	"4xv8v3PB9ZoWpsc30qLSa1aIIbPih90vtq74tJOHQ/dL3TodWZc78o2b6zfz3vlwRxgk+rNxP4wQdM0v",
+	"8vjGFvgcFnPdcG9C/smUhZwm4xbVL1rEjYTbmXYFfJbvQ8m3pu6XJN6Vg3HktVLDg6OLtTlT/64OwoPL",
+	"nNfbZ6ZrprrczVWnHka2nlcAu3YY7tkQvRwU2vF+2L398bnJivF9zaIyi8o4v1FvYbaw48g02Mtu8mg+",
+	"p9M+n9PJQ/mcZIF1vFdFmu5aN5RfdhemV7JG+FpG+Ea+D0B1JbvvYdMcazc5u4TgqeXHTgxUNl+wpZJQ",
+	"8hfLiR2JSCWAbvWhZsNSfPWDSbIsWKrCev3UYnGvOOnAQ771iO4ROU3T+jcqoPyoVfOK2IdigoGQ2hu8",
+	"FdeNf0u/jS5NEkLJlmZspV2PmqGJfnekUl7tStqW/FnSmiN+M/QMIe/A9EdZpu2Wf7H83kVN/1+NjRpr",
+	"91VslKfm+YDVI+6XXDyzcsHTlB1c9hYY/IiaCydsWjomrKZf6jwJ4hLECwmZso3D2u2ny3vWGRyWRAXX",
+	"ykzvhdG/E+h+GSjSYclRulXcNGebdbZZX/8eWa4eKXopN5gNXjGkAqh+XltA0OTY0Euwz4jRC8iOyNuy",
+	"bpd72V0Wa3xQSHdyoAfKdTlp/bfD9AmfM0scfduIJmBzqrPEPrLEEvsiouNffDpqDRxZExLD5c9xR+Vi",
+	"TTP7vJxXrN8LnnMJJIMrl7uypUnLvGuKd5wWUoEN0sU0V4U2zs3pNymlOSzAv1mcDGiayn0eK6rnNje8",
+	"EBJXnRflC8krBcK+sEirOZtnNGXjWeR/hI3z6/M1zX22ee2VrzZeCZOKZjEQlpELlnIzk9otB1dkaDKi",
+	"fz/pw9TBjRY+0z/hxTKtvbZoqHfgJ3OQCdzihyJG5nstG21OMPliTzXP0QvYlz1mqlRlKya2JQOjbmyW",
+	"EGqpVeMa8Bg+Tl8+Ud+hQ2/YffimRZPDJ66FtMupWZOGJ3FOZZv9J8/cf1LVlZAjg+FVD/Qj2OhF4JKp",
+	"2bhrMPZ5AnsVvIxepvV/I0l9hvOpad6VX/8e1RkyGMtuPMflbmpgKozel03OW423woeXQ7H/vs3rupx0",
+	"6SmmkWMWtVnUfKLmt4I/gmrsK1lPVPzj/sXqQNHxGobW0vUeW6tW2qBfAkGwkGgyND1+j6oAPoAqRNYU",
+	"fxNJb6rB2Raetc3T0DbjDGD8FnR2eq0Az1m8Z/s3AA56X3xSSvei+642SjZ3Tzj0llsNOA7LPhOvkbZh",
+	"W9IqinndluMY/kAQlB+8outpV2QlF4pcwI58a93R51qVLUiRa1eE/UNna2gAC6Ip97eGD7XebfTNXciK",
+	"rebTeudoEdWARovIQdU99YJ9XoycDhcJiAaSulEIOWztxY7KODJSNwp2YAntHBNCFV5vR/c0rqWdp5eN",
+	"BN8G/M5UwQvFthDtAaUlrLiAQWwUPwAuW31h3UiW5utdHoKODc9tg+4qudpOlywBPmqh4kJILohBqFGl",
+	"pYwk6LIXNvaacVULv65BbUCYLrktjuFD2sDolcXPh35bNVyzcawun82J2Zxo1ir3Hls+od52ZaIwSaGZ",
+	"VuRJ6dU97ls2qvfQEnqRfFBsut74Qzrbg68gm82Q8ItZCr9iKdRw//fh4X40pZ7InwVvpeF+I+2LgHAd",
+	"AySTy850zxjHMc9RWEOvsea7UJSPZhy3X/dDfTdWG2DCPe++aLztjpnEdC2PyE/M9u+kSMc812vOBcl4",
+	"5ql5pdG69xHmkI+6nwmayRWI4WDieztrpIpYu7tK0e1jGSQWoWZMEdfDvWCZMzDL5Iy22b8yxxpn1bx3",
+	"1ayjhmHV/E7HFO+smt0tj0X1zL1WQdUjEj3a2bypFFTO71wB7Vk5P4hyNnW9zTOm1SKVB2qqT9BCOR6p",
+	"z2PW27PenvX23vW2OSzKsOoeUxS2aB7jy4KwitfLwbqS0O9/+3hWClilCgiTJBdc8yKG7mLjZrJjM6mV",
+	"f25f2vZXiDOlZbH9E9XmBrlhXf4r3TbTwaODexI+5SFkzJeqiO2sgmcvxlww905q9vjG/MOVzgk5Moyi",
+	"IzRz6g/vh7iS+g1kgxo0ZRe1EaoLNV3/hIG2H+05HEMtKXDYOvtj3aMPwNVn5TYGibnAbusoUnJJU1a/",
+	"6jQruoMamXbtZ5U3RuV9UeauZFuWUjEuTcVUHdW4ZEDFi6Qwk4ZpeSsfDch9pK80cd3Sa7YttqS6d5ew",
+	"1Qoww2TJlCRLUFd4FXgD+mLlxiaiXHFiyWAnsihbnqDf4LtXjeSHl8FbbmojQG54mvTfyDtkoNpSFyu6",
+	"hjwQv5Tr2Jz37EuYb7Y9+eeJG+pr6sNl/ZdK7Mtl973cduDXyMolLP2Ys3V0UOvoi3+NrC8lHp8je0yJ",
+	"eLQnxmbxmsVr4hNjzb1pU2yXGWXpYB2bsmWgiFTYlj4rYRzyAlgo8+sOZdv0eQLYZXWSKec+n/NnA7BT",
+	"2qAI3OlyIZV2eUm18XJW6KrX3sTnQBe9SvyGQyNvk6HAyAMURS3x7SuMOh8vv7Qd/EuuY6AElZtx7jFf",
+	"Io/Js7EBXxxrQbZcKiIghgyDwfpHdIALG/fdodMxL8Ta1LVayfiCfH9CErqT5XUX2NVr25kxjshv7jII",
+	"vjRCrjYcq91dCdZ4cUD/hvcUmJKmc4+JoT8/0fvlZ2bevek2ts3XVB12tjL27WbSPHR8g//DHwRIxcVQ",
+	"Zh8thdtoyCWNL5wu8KX1aWkcTOrz+K4Ql7osHPaobqkQHf7CVTB9rmlf2NWY30h4iNN6g6NHSvurk389",
+	"gI/d4lRhQ7eAd3uxiiZulDRJqu3YzEmyLIYnr5PuUo669gaxAJro+KU2JwLvDk+oVF0WqX7UStNPo8b0",
+	"k66tbAK2fdZru/JG9bikd+VN5DA6oN7vDVzaK8/UoGuc12lqNA3L1hbz+S3WB2dCyxdeJtzSfGR6QavI",
+	"8R2U2KJWEXhlCyn/xfmWpHAJqZel39F86F11yfUii4ykVDFVVCWJqQDaSA148a+TcM2GQm0mlr7tpDhc",
+	"gZ5cRlKerQcwefnPE7xxzyRZYxqwpgbNCFB97qt6kVhwKcH6zjLFtiBYwmgWmshV+zWQO8wj42IkRcME",
+	"xTHui4imxjiCvvxnEBM9yH0RqbjUYbCleTsP5aSBURAfPdbjZaC8o/kbI4QhNW4/y6pMwlfpGnzK9uZe",
+	"88IoqpiJqtyrrAdyx+Z8sDkf7CuQysop7Xc+/V+AnPAMqiAZNTLaXeJmLXC8cSob7mq7GzF7EdWk1Tec",
+	"AJJcaHjj7qTiX/YBFue2QUvN+rcXjecGlN8ZbpBYglRVgv8F5GjWZHreTFauiM6tyo3Bna4py8o30Szz",
+	"6Z4C/oBYmZfdaLarQGRcldSzJEKo3hu06I5rq6tDBAotDIQ3+jasNTGQKQ4aKmxiJ4t02pXYBis+ijoq",
+	"41vS8qRLCPdxwtdzYeFRE1qfV7yxR5+bJjd9bx6nWGgFE/6TSaYSpipNjQM8nTf8z+h66AX/GmGWu6/7",
+	"xf6nnndqXyOhGTEFNX3XlM/wyyG2yDO6Ht4ZMc3a/5zwg6Sgzm9+fJUSE9gVRl1qqD2eUe1+iq67+4Fp",
+	"aSRs2o4QTsw+ObRUuOm5PPX6c/hzpPdQxtXzvO3Q+xL+CAExLe8tIE9v7zq4lH6ytfqRrM/m5fse7cu2",
+	"kLIMpub8TQ1U1bIATe7fwhzHu29Z+s17h+ZUfg3Ual8LmhUpFUztnHdgWcQXoCT5dgcUEc7UZqG9NM2C",
+	"5fh7yAOKUzpf7ryVpvW40SLCAaJFlNDdXBf8/riMq8atSYRfqF3nJ1p4u+Rzn9qz35ovxM5e66fm5eAX",
+	"kMnBW1A5CMn16NT4lky39lMRdbeH8UWbZmoDWwnpJVQlG6rSpr7cZgRyZlA75F2JCs6QP6Mx7/lE9vDW",
+	"pCH8sBfDz6plwkk/q5oQg9XNuGFwTED0O0Vq7HMg50gNwrCheVqf78G9JYYESZ0GQzjNXpSvVGaD+87x",
+	"Df5/yJfyAS75RVi2e3ahgLOlKbkTz5QW45EVIhoSIHAicy78AXPhm0r/mbhKegRFs/HkLGHshCWockVo",
+	"smUZk4oqLvyZw58QxgENLQ2g54EeX96wgDWzyaLd2cwW2MMzqWGSMI8e3+j/4Q0sk3N2LCDVFBsOolZp",
+	"aiaMLzdUr7urfdL2MNsX6Tps/MHAK/Pgp+l1i/1hvel9giBmQfjSBGGKBLhbwUEBQCVfC6SHJcDp81kA",
+	"ZgF4XAEoJF3DoHfJFVvHZwetX96668sUEiciZVag/uMbaUpw2sv1hiMwhXFth0YEtFlkHuxA/II20CfE",
+	"9snKjUGvpxxpnX5fZ67NQx0zkBOfy/GilNfbRSQhLnQcCRl7CVSAOC3UJnr9+2fNv+aSqWH7QqTR6+g4",
+	"uv18+/8HAMjXjsSvSAEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
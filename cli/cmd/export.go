@@ -0,0 +1,116 @@
+/*
+Copyright © 2021 Cosmin Tupangiu <cosmin.tupangiu@gmail.com>
+
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU Lesser General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/conf"
+	"github.com/tupyy/gophoto/internal/repos/postgres/tag"
+	"github.com/tupyy/gophoto/internal/services/backup"
+	tagService "github.com/tupyy/gophoto/internal/services/tag"
+	"go.uber.org/zap"
+)
+
+var exportDecrypt bool
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export <archive>",
+	Short: "export all the albums to an archive",
+	Long: `Export the albums, their permissions, tags and media to a tar archive. The archive holds a versioned manifest
+and the checksum of each object so it can be checked by restore. Favorites, comments and reactions are not exported.
+The export fails if there are encrypted albums unless --decrypt is set: their media are written decrypted to the archive
+and encrypted again by restore. Such an archive must be protected like the master keys.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := setupLogger()
+		defer logger.Sync()
+
+		undo := zap.ReplaceGlobals(logger)
+		defer undo()
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		backupService, err := newBackupService()
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+
+		manifest, err := backupService.Export(ctx, f, exportDecrypt)
+		if err != nil {
+			f.Close()
+			os.Remove(args[0])
+			return err
+		}
+
+		if err := f.Close(); err != nil {
+			return err
+		}
+
+		media := 0
+		for _, a := range manifest.Albums {
+			media += len(a.Media)
+		}
+
+		fmt.Fprintf(os.Stderr, "%d albums, %d media and %d tags exported to %s\n", len(manifest.Albums), media, len(manifest.Tags), args[0])
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().BoolVar(&exportDecrypt, "decrypt", false, "export the encrypted albums too. Their media are written decrypted to the archive")
+}
+
+// newBackupService creates the service exporting and restoring the albums.
+func newBackupService() (*backup.Service, error) {
+	client, err := pgclient.New(conf.GetPostgresConf())
+	if err != nil {
+		return nil, err
+	}
+
+	storage, err := newStorage(conf.GetStorageConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	albumService, mediaService, _, err := newAlbumServices(client, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	tagRepo, err := tag.NewPostgresRepo(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return backup.New(albumService, mediaService, tagService.New(tagRepo)), nil
+}
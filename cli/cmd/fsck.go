@@ -0,0 +1,107 @@
+/*
+Copyright © 2021 Cosmin Tupangiu <cosmin.tupangiu@gmail.com>
+
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU Lesser General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/conf"
+	"github.com/tupyy/gophoto/internal/services/fsck"
+	"go.uber.org/zap"
+)
+
+var fsckRepair bool
+
+// fsckCmd represents the fsck command
+var fsckCmd = &cobra.Command{
+	Use:   "fsck",
+	Short: "check the consistency of the albums and their buckets",
+	Long: `Check that the albums, their buckets, the photos, the thumbnails and the metadata of the photos agree.
+The issues found are printed one per line: kind, bucket, album id and object. With --repair, orphaned buckets are
+marked as deleted like the buckets of deleted albums, albums without bucket are linked to the orphaned bucket with
+their name and owner or get an empty bucket, missing thumbnails are created and orphaned thumbnails and metadata
+are deleted as well as the uploads with pre-signed urls not completed for a day. The photos kept in the trash of
+their album for 30 days are purged with their metadata. The command fails if issues are left.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := setupLogger()
+		defer logger.Sync()
+
+		undo := zap.ReplaceGlobals(logger)
+		defer undo()
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		client, err := pgclient.New(conf.GetPostgresConf())
+		if err != nil {
+			return err
+		}
+
+		storage, err := newStorage(conf.GetStorageConfig())
+		if err != nil {
+			return err
+		}
+
+		albumService, mediaService, _, err := newAlbumServices(client, storage)
+		if err != nil {
+			return err
+		}
+
+		issues, err := fsck.New(albumService, mediaService).Check(ctx, fsckRepair)
+		if err != nil {
+			return err
+		}
+
+		left := 0
+		for _, issue := range issues {
+			line := fmt.Sprintf("%s\t%s\t%s\t%s", issue.Kind, issue.Bucket, issue.AlbumID, issue.Name)
+
+			switch {
+			case issue.Repaired():
+				line += "\t" + issue.Action
+			case issue.Err != nil:
+				line += fmt.Sprintf("\tfailed: %s", issue.Err)
+				left++
+			default:
+				left++
+			}
+
+			fmt.Fprintln(os.Stdout, line)
+		}
+
+		fmt.Fprintf(os.Stderr, "%d issues found, %d repaired\n", len(issues), len(issues)-left)
+
+		if left > 0 {
+			return fmt.Errorf("%d issues left", left)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(fsckCmd)
+
+	fsckCmd.Flags().BoolVar(&fsckRepair, "repair", false, "repair the issues found")
+}
@@ -0,0 +1,144 @@
+/*
+Copyright © 2021 Cosmin Tupangiu <cosmin.tupangiu@gmail.com>
+
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU Lesser General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/conf"
+	"github.com/tupyy/gophoto/internal/services/importer"
+	"go.uber.org/zap"
+)
+
+var (
+	importOwner     string
+	importWorkers   int
+	importStateFile string
+	importDryRun    bool
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <directory>",
+	Short: "import a directory tree of photos",
+	Long: `Import the photos of a directory tree. Each folder holding photos is imported into a new album named after its path.
+The albums and the photos already imported are saved in the state file so an interrupted import can be run again to resume it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := setupLogger()
+		defer logger.Sync()
+
+		undo := zap.ReplaceGlobals(logger)
+		defer undo()
+
+		root, err := filepath.Abs(args[0])
+		if err != nil {
+			return err
+		}
+
+		if len(importOwner) == 0 {
+			return fmt.Errorf("the owner of the albums is missing")
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		fsys := os.DirFS(root)
+
+		folders, err := importer.Scan(fsys, filepath.Base(root))
+		if err != nil {
+			return fmt.Errorf("failed to scan '%s': %v", root, err)
+		}
+
+		return importFolders(ctx, fsys, folders, importStateFile)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringVar(&importOwner, "owner", "", "username of the owner of the created albums")
+	importCmd.Flags().IntVar(&importWorkers, "workers", 4, "number of photos uploaded at the same time")
+	importCmd.Flags().StringVar(&importStateFile, "state", "gphotos-import.state", "file keeping the progress of the import")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "only report what would be imported")
+}
+
+// importFolders imports the folders into new albums. The progress is saved to the state file.
+func importFolders(ctx context.Context, fsys fs.FS, folders []importer.Folder, stateFile string) error {
+	// a dry run reports only what is left to import but does not save the state
+	openState := importer.OpenState
+	if importDryRun {
+		openState = importer.ReadState
+	}
+
+	state, err := openState(stateFile)
+	if err != nil {
+		return err
+	}
+	defer state.Close()
+
+	client, err := pgclient.New(conf.GetPostgresConf())
+	if err != nil {
+		return err
+	}
+
+	storage, err := newStorage(conf.GetStorageConfig())
+	if err != nil {
+		return err
+	}
+
+	albumService, mediaService, _, err := newAlbumServices(client, storage)
+	if err != nil {
+		return err
+	}
+
+	imp := importer.New(albumService, mediaService, state, importer.Options{
+		Owner:    importOwner,
+		Workers:  importWorkers,
+		DryRun:   importDryRun,
+		Progress: os.Stderr,
+	})
+
+	report, err := imp.Run(ctx, fsys, folders)
+
+	verb := "imported"
+	if importDryRun {
+		verb = "to import"
+	}
+	fmt.Fprintf(os.Stderr, "%d albums created, %d photos %s, %d already imported, %d failed\n", report.Albums, report.Imported, verb, report.Skipped, len(report.Failed))
+
+	failed := make([]string, 0, len(report.Failed))
+	for f := range report.Failed {
+		failed = append(failed, f)
+	}
+	sort.Strings(failed)
+
+	for _, f := range failed {
+		fmt.Fprintf(os.Stderr, "failed: %s: %v\n", f, report.Failed[f])
+	}
+
+	return err
+}
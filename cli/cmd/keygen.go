@@ -0,0 +1,50 @@
+/*
+Copyright © 2021 Cosmin Tupangiu <cosmin.tupangiu@gmail.com>
+
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU Lesser General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tupyy/gophoto/internal/services/encryption"
+)
+
+// keygenCmd represents the generate-key command
+var keygenCmd = &cobra.Command{
+	Use:   "generate-key",
+	Short: "print a new master key",
+	Long: `Print a new random master key, base64 encoded, for the id keys or the album encryption keys of the configuration.
+To rotate the id keys, add the new key to id_keys with a new id and make it the active key: the new links are issued
+with it while the links issued with the old keys keep working. Remove an old key, or the legacy encryption_key, to retire
+the links issued with it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, err := encryption.GenerateKey()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stdout, key)
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(keygenCmd)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright © 2021 Cosmin Tupangiu <cosmin.tupangiu@gmail.com>
+
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU Lesser General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/conf"
+	"github.com/tupyy/gophoto/internal/services/migration"
+	"go.uber.org/zap"
+)
+
+var (
+	migratePurge  bool
+	migrateDryRun bool
+)
+
+// migrateCmd represents the migrate-layout command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate-layout",
+	Short: "move the albums to the shared bucket",
+	Long: `Move the albums stored in their own bucket to albums/<album_id>/ in the shared bucket set by storage.bucket.
+The objects are copied before the album is updated, so the command can be stopped and run again. The old buckets are
+marked as deleted like the buckets of deleted albums, or removed with --purge. Set storage.layout to shared for the new
+albums to be created in the shared bucket.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := setupLogger()
+		defer logger.Sync()
+
+		undo := zap.ReplaceGlobals(logger)
+		defer undo()
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		storageConf := conf.GetStorageConfig()
+		if storageConf.Backend == conf.StorageMinio && len(storageConf.Bucket) == 0 {
+			return errors.New("storage.bucket is not set")
+		}
+
+		client, err := pgclient.New(conf.GetPostgresConf())
+		if err != nil {
+			return err
+		}
+
+		storage, err := newStorage(storageConf)
+		if err != nil {
+			return err
+		}
+
+		albumService, mediaService, _, err := newAlbumServices(client, storage)
+		if err != nil {
+			return err
+		}
+
+		migrationService := migration.New(albumService, mediaService)
+
+		albums, err := migrationService.Pending(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i, a := range albums {
+			if migrateDryRun {
+				fmt.Fprintf(os.Stderr, "[%d/%d] %s (%s): %s\n", i+1, len(albums), a.Name, a.ID, a.Bucket)
+				continue
+			}
+
+			migrated, err := migrationService.Migrate(ctx, a, migratePurge)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s (%s): %s -> %s\n", i+1, len(albums), a.Name, a.ID, a.Bucket, migrated.Bucket)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().BoolVar(&migratePurge, "purge", false, "remove the old buckets instead of marking them as deleted")
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "list the albums to migrate without moving them")
+}
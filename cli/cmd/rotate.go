@@ -0,0 +1,103 @@
+/*
+Copyright © 2021 Cosmin Tupangiu <cosmin.tupangiu@gmail.com>
+
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU Lesser General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/conf"
+	"go.uber.org/zap"
+)
+
+// rotateCmd represents the rotate-album-keys command
+var rotateCmd = &cobra.Command{
+	Use:   "rotate-album-keys",
+	Short: "wrap the data keys of the encrypted albums with the active master key",
+	Long: `Wrap the data key of each encrypted album with the active master key. The media are not encrypted again.
+To rotate the master key, add the new key to the configuration and make it the active key, run the command,
+then remove the old key once the command succeeds. The buckets of the deleted albums are rotated too so they can
+still be restored. The command fails if a bucket cannot be rotated.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := setupLogger()
+		defer logger.Sync()
+
+		undo := zap.ReplaceGlobals(logger)
+		defer undo()
+
+		if !conf.GetAlbumEncryptionConfig().Enabled() {
+			return errors.New("no master key configured")
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		client, err := pgclient.New(conf.GetPostgresConf())
+		if err != nil {
+			return err
+		}
+
+		storage, err := newStorage(conf.GetStorageConfig())
+		if err != nil {
+			return err
+		}
+
+		_, mediaService, _, err := newAlbumServices(client, storage)
+		if err != nil {
+			return err
+		}
+
+		buckets, err := mediaService.Buckets(ctx)
+		if err != nil {
+			return err
+		}
+
+		rotated, failed := 0, 0
+		for _, bucket := range buckets {
+			ok, err := mediaService.RotateBucketKey(ctx, bucket)
+			if err != nil {
+				zap.S().Errorw("failed to rotate data key", "error", err, "bucket", bucket)
+				failed++
+				continue
+			}
+
+			if ok {
+				fmt.Fprintln(os.Stdout, bucket)
+				rotated++
+			}
+		}
+
+		fmt.Fprintf(os.Stderr, "%d buckets checked, %d data keys rotated\n", len(buckets), rotated)
+
+		if failed > 0 {
+			return fmt.Errorf("%d buckets failed to rotate", failed)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rotateCmd)
+}
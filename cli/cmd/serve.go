@@ -19,12 +19,13 @@ package cmd
 import (
 	"context"
 	"encoding/gob"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/gin-contrib/sessions/memstore"
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
-	"github.com/minio/minio-go/v7"
 	"github.com/spf13/cobra"
 	apiv1 "github.com/tupyy/gophoto/api/v1"
 	"github.com/tupyy/gophoto/internal/auth"
@@ -34,15 +35,31 @@ import (
 	"github.com/tupyy/gophoto/internal/entity"
 	handlersv1 "github.com/tupyy/gophoto/internal/handlers/v1"
 	keycloakRepo "github.com/tupyy/gophoto/internal/repos/keycloak"
+	"github.com/tupyy/gophoto/internal/repos/localfs"
 	miniorepo "github.com/tupyy/gophoto/internal/repos/minio"
+	oidcRepo "github.com/tupyy/gophoto/internal/repos/oidc"
+	accesstokenRepo "github.com/tupyy/gophoto/internal/repos/postgres/accesstoken"
 	"github.com/tupyy/gophoto/internal/repos/postgres/album"
+	"github.com/tupyy/gophoto/internal/repos/postgres/comment"
+	directoryRepo "github.com/tupyy/gophoto/internal/repos/postgres/directory"
+	eventsRepo "github.com/tupyy/gophoto/internal/repos/postgres/events"
+	mediarepo "github.com/tupyy/gophoto/internal/repos/postgres/media"
 	"github.com/tupyy/gophoto/internal/repos/postgres/tag"
+	"github.com/tupyy/gophoto/internal/repos/postgres/usage"
 	"github.com/tupyy/gophoto/internal/repos/postgres/user"
 	"github.com/tupyy/gophoto/internal/router"
+	"github.com/tupyy/gophoto/internal/services/accesstoken"
 	albumService "github.com/tupyy/gophoto/internal/services/album"
+	commentService "github.com/tupyy/gophoto/internal/services/comment"
 	"github.com/tupyy/gophoto/internal/services/encryption"
+	"github.com/tupyy/gophoto/internal/services/events"
+	"github.com/tupyy/gophoto/internal/services/fsck"
+	"github.com/tupyy/gophoto/internal/services/geocoding"
 	"github.com/tupyy/gophoto/internal/services/media"
+	organizeService "github.com/tupyy/gophoto/internal/services/organize"
+	"github.com/tupyy/gophoto/internal/services/quota"
 	tagService "github.com/tupyy/gophoto/internal/services/tag"
+	timelineService "github.com/tupyy/gophoto/internal/services/timeline"
 	usersService "github.com/tupyy/gophoto/internal/services/users"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -74,33 +91,43 @@ var serveCmd = &cobra.Command{
 		}
 		zap.S().Infow("connected to db", "conf", conf.GetPostgresConf())
 
-		// init minio client
-		minioClient, err := minioclient.New(conf.GetMinioConfig())
+		// create the storage of the media
+		storage, err := newStorage(conf.GetStorageConfig())
+		if err != nil {
+			panic(err)
+		}
+
+		// create the directory of the users and the authenticator of the provider
+		directory, resolver, err := newUserDirectory(client, conf.GetAuthConfig())
+		if err != nil {
+			panic(err)
+		}
+
+		authenticator, err := newAuthenticator(conf.GetAuthConfig(), resolver)
 		if err != nil {
 			panic(err)
 		}
-		zap.S().Infow("connected to minio", "conf", conf.GetMinioConfig())
 
-		// create keycloak authenticator
-		keycloakAuthenticator := auth.NewKeyCloakAuthenticator(conf.GetKeycloakConfig(), conf.GetServerAuthCallback())
+		server, err := createServer(client, storage, directory)
+		if err != nil {
+			panic(err)
+		}
 
-		// create new router
+		// create new router. The requests with a personal access token or an access token of the provider are authenticated before the session.
 		engine := gin.New()
 		engine.Use(ginzap.Ginzap(logger, time.RFC3339, true))
 		engine.Use(ginzap.RecoveryWithZap(logger, true))
-		router.InitEngine(engine, store, keycloakAuthenticator)
+		router.InitEngine(engine, store, authenticator,
+			auth.AccessTokenMiddleware(server.AccessTokenService(), server.UserService()),
+			authenticator.BearerMiddleware(),
+		)
 
-		//api.Logout(r.PrivateGroup, keycloakAuthenticator)
+		//api.Logout(r.PrivateGroup, authenticator)
 
 		opt := apiv1.GinServerOptions{
 			Middlewares: make([]apiv1.MiddlewareFunc, 0),
 		}
 
-		server, err := createServer(client, minioClient)
-		if err != nil {
-			panic(err)
-		}
-
 		apiv1.RegisterHandlersWithOptions(engine, server, opt)
 
 		// run server
@@ -112,53 +139,240 @@ func init() {
 	rootCmd.AddCommand(serveCmd)
 }
 
-func createServer(client pgclient.Client, mclient *minio.Client) (*handlersv1.Server, error) {
+func createServer(client pgclient.Client, storage media.Storage, directory usersService.UserDirectory) (*handlersv1.Server, error) {
 	services := make(map[string]interface{})
 
-	// create keycloak repo
-	kr, err := keycloakRepo.New(context.Background(), conf.GetKeycloakConfig())
+	// create tag repo
+	tagRepo, err := tag.NewPostgresRepo(client)
+	if err != nil {
+		return nil, err
+	}
+	// create comment repo
+	commentRepo, err := comment.NewPostgresRepo(client)
 	if err != nil {
 		return nil, err
 	}
 
-	// create album repo
-	albumRepo, err := album.NewPostgresRepo(client)
+	// create user repo
+	userRepo, err := user.NewPostgresRepo(client)
 	if err != nil {
 		return nil, err
 	}
 
-	// create tag repo
-	tagRepo, err := tag.NewPostgresRepo(client)
+	albumService, mediaService, broker, err := newAlbumServices(client, storage)
 	if err != nil {
 		return nil, err
 	}
-	// create user repo
-	userRepo, err := user.NewPostgresRepo(client)
+
+	// receive the events of the other replicas
+	broker.Start(context.Background())
+
+	usageRepo, err := usage.NewPostgresRepo(client)
 	if err != nil {
 		return nil, err
 	}
 
-	// create minio repo
-	minioRepo := miniorepo.New(mclient)
-	mediaService := media.New(minioRepo)
+	// create access token repo
+	tokenRepo, err := accesstokenRepo.NewPostgresRepo(client)
+	if err != nil {
+		return nil, err
+	}
 
-	albumService := albumService.New(albumRepo, mediaService)
-	usersService := usersService.New(kr, userRepo)
+	usersService := usersService.New(directory, userRepo)
+	quotaService := quota.New(albumService, usersService, storage, usageRepo, quota.Limits(conf.GetQuotaConfig()))
 	tagService := tagService.New(tagRepo)
+	commentService := commentService.New(commentRepo)
+	timelineService := timelineService.New(albumService, mediaService)
+	timelineService.Watch(context.Background(), broker)
+	organizeService := organizeService.New(albumService, mediaService)
+	fsckService := fsck.New(albumService, mediaService)
+	tokenService := accesstoken.New(tokenRepo)
 
 	services["album"] = albumService
 	services["user"] = usersService
 	services["tag"] = tagService
+	services["comment"] = commentService
+	services["timeline"] = timelineService
+	services["organize"] = organizeService
+	services["quota"] = quotaService
+	services["fsck"] = fsckService
+	services["accesstoken"] = tokenService
 
 	encryption, err := encryption.New()
 	if err != nil {
 		return nil, err
 	}
 
-	server := handlersv1.NewServer(albumService, usersService, tagService, mediaService, encryption)
+	server := handlersv1.NewServer(albumService, usersService, tagService, mediaService, encryption, broker, commentService, timelineService, organizeService, quotaService, fsckService, tokenService)
 	return server, nil
 }
 
+// newAlbumServices creates the services managing the albums and their media. The usage of the storage by each album is tracked
+// and the media of the encrypted albums are encrypted with their data keys.
+// The broker must be started to receive the events of the other replicas: the commands only forward their events to the
+// running servers and do not start it.
+func newAlbumServices(client pgclient.Client, storage media.Storage) (*albumService.Service, *media.Service, *events.Broker, error) {
+	// create event broker. Events are forwarded between replicas with postgres LISTEN/NOTIFY
+	transport, err := eventsRepo.NewNotifyTransport(client, conf.GetPostgresConf())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	broker := events.NewBroker(transport)
+
+	// create album repo
+	albumRepo, err := album.NewPostgresRepo(client)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// create media repo
+	mediaRepo, err := mediarepo.NewPostgresRepo(client)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// create the geocoder from the GeoNames datasets. The bundled sample is used if no dataset is configured.
+	geocoder, err := newGeocoder(conf.GetGeocodingConfig())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// create usage repo
+	usageRepo, err := usage.NewPostgresRepo(client)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	layout := media.Layout(conf.GetStorageConfig().Layout)
+	if layout != media.BucketLayout && layout != media.SharedLayout {
+		return nil, nil, nil, fmt.Errorf("unknown storage layout '%s'", layout)
+	}
+
+	keys, err := newKeyring(conf.GetAlbumEncryptionConfig())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	mediaService := media.New(quota.NewStorage(storage, usageRepo), mediaRepo, geocoder, broker)
+
+	// without master keys the encrypted albums cannot be read but the other albums can
+	mediaService.EnableEncryption(keys)
+
+	if storageConf := conf.GetStorageConfig(); storageConf.PresignedURLs {
+		if err := mediaService.EnablePresignedURLs(time.Duration(storageConf.PresignExpiry)*time.Second, storageConf.PresignMaxSize); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return albumService.New(albumRepo, mediaService, broker, layout), mediaService, broker, nil
+}
+
+// newKeyring returns the master keys wrapping the data keys of the encrypted albums, or nil if none is configured.
+func newKeyring(c conf.KeyringConfig) (media.KeyWrapper, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+
+	return encryption.KeyringFromConfig(c)
+}
+
+// newUserDirectory creates the directory of the users selected by the configuration and the resolver mapping the users
+// logged in to the users of the directory. The keycloak directory has no resolver.
+func newUserDirectory(client pgclient.Client, c conf.AuthConfig) (usersService.UserDirectory, auth.UserResolver, error) {
+	switch c.Directory {
+	case conf.DirectoryKeycloak:
+		kr, err := keycloakRepo.New(context.Background(), conf.GetKeycloakConfig())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return kr, nil, nil
+	case conf.DirectoryOIDC:
+		store, err := directoryRepo.NewPostgresRepo(client)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		claimsDirectory := oidcRepo.NewClaimsDirectory(store, conf.GetAuthIssuer())
+
+		return claimsDirectory, claimsDirectory, nil
+	case conf.DirectoryPostgres:
+		store, err := directoryRepo.NewPostgresRepo(client)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		localDirectory := oidcRepo.NewLocalDirectory(store, conf.GetAuthIssuer())
+
+		return localDirectory, localDirectory, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown user directory '%s'", c.Directory)
+	}
+}
+
+// newAuthenticator creates the authenticator of the provider selected by the configuration.
+func newAuthenticator(c conf.AuthConfig, resolver auth.UserResolver) (auth.Authenticator, error) {
+	zap.S().Infow("auth configured", "provider", c.Provider, "directory", c.Directory)
+
+	switch c.Provider {
+	case conf.ProviderKeycloak:
+		return auth.NewKeyCloakAuthenticator(conf.GetKeycloakConfig(), conf.GetServerAuthCallback(), resolver), nil
+	case conf.ProviderOIDC:
+		zap.S().Infow("oidc provider", "conf", c.OIDC)
+
+		return auth.NewOIDCAuthenticator(c.OIDC, conf.GetServerAuthCallback(), resolver)
+	default:
+		return nil, fmt.Errorf("unknown auth provider '%s'", c.Provider)
+	}
+}
+
+// newStorage creates the storage backend selected by the configuration.
+func newStorage(c conf.StorageConfig) (media.Storage, error) {
+	switch c.Backend {
+	case conf.StorageMinio:
+		minioClient, err := minioclient.New(conf.GetMinioConfig())
+		if err != nil {
+			return nil, err
+		}
+
+		zap.S().Infow("connected to minio", "conf", conf.GetMinioConfig(), "layout", c.Layout, "shared_bucket", c.Bucket)
+
+		if c.Layout == string(media.SharedLayout) && len(c.Bucket) == 0 {
+			return nil, errors.New("the shared layout needs the name of the shared bucket")
+		}
+
+		return miniorepo.New(minioClient, c.Bucket), nil
+	case conf.StorageLocal:
+		zap.S().Infow("media stored on the local filesystem", "path", c.Path)
+
+		return localfs.New(c.Path)
+	default:
+		return nil, fmt.Errorf("unknown storage backend '%s'", c.Backend)
+	}
+}
+
+func newGeocoder(c conf.GeocodingConfig) (*geocoding.Geocoder, error) {
+	if len(c.Cities) == 0 && len(c.Regions) == 0 && len(c.Countries) == 0 {
+		zap.S().Info("no geocoding dataset set: use the bundled sample of the GeoNames datasets")
+
+		return geocoding.Default()
+	}
+
+	if len(c.Cities) == 0 || len(c.Regions) == 0 || len(c.Countries) == 0 {
+		return nil, errors.New("geocoding.cities, geocoding.regions and geocoding.countries must be set together (e.g. cities15000.txt, admin1CodesASCII.txt and countryInfo.txt from https://download.geonames.org/export/dump/)")
+	}
+
+	zap.S().Infow("load geocoding datasets", "cities", c.Cities, "regions", c.Regions, "countries", c.Countries)
+
+	g, err := geocoding.LoadFiles(c.Cities, c.Regions, c.Countries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the GeoNames datasets: %w", err)
+	}
+
+	return g, nil
+}
+
 func setupLogger() *zap.Logger {
 	loggerCfg := &zap.Config{
 		Level:    zap.NewAtomicLevelAt(zapcore.InfoLevel),
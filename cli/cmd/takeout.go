@@ -0,0 +1,111 @@
+/*
+Copyright © 2021 Cosmin Tupangiu <cosmin.tupangiu@gmail.com>
+
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU Lesser General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/tupyy/gophoto/internal/services/takeout"
+	"go.uber.org/zap"
+)
+
+var takeoutStateFile string
+
+// takeoutCmd represents the takeout command
+var takeoutCmd = &cobra.Command{
+	Use:   "takeout <archive.zip|directory>",
+	Short: "import a Google Photos export made with Google Takeout",
+	Long: `Import a Google Takeout export of Google Photos, either the zip archive or the extracted folder.
+Each folder of the export is imported into a new album. The names and descriptions of the albums, the captions,
+the capture dates and the locations of the photos are read from the json files of the export.
+Photos of the "Photos from <year>" folders already found in an album are not imported twice.
+The albums and the photos already imported are saved in the state file so an interrupted import can be run again to resume it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := setupLogger()
+		defer logger.Sync()
+
+		undo := zap.ReplaceGlobals(logger)
+		defer undo()
+
+		if len(importOwner) == 0 {
+			return fmt.Errorf("the owner of the albums is missing")
+		}
+
+		info, err := os.Stat(args[0])
+		if err != nil {
+			return err
+		}
+
+		var fsys fs.FS
+		if info.IsDir() {
+			fsys = os.DirFS(args[0])
+		} else {
+			zr, err := zip.OpenReader(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open '%s': %v", args[0], err)
+			}
+			defer zr.Close()
+
+			fsys = zr
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		folders, report, err := takeout.Scan(fsys)
+		if err != nil {
+			return err
+		}
+
+		for _, items := range []struct {
+			name  string
+			items []string
+		}{
+			{"skipped", report.Skipped},
+			{"unsupported", report.Unsupported},
+			{"duplicate", report.Duplicates},
+			{"invalid metadata", report.Invalid},
+			{"no metadata", report.NoMetadata},
+		} {
+			for _, item := range items.items {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", items.name, item)
+			}
+		}
+
+		fmt.Fprintf(os.Stderr, "%d skipped folders, %d unsupported files, %d duplicates, %d invalid metadata files, %d photos without metadata\n",
+			len(report.Skipped), len(report.Unsupported), len(report.Duplicates), len(report.Invalid), len(report.NoMetadata))
+
+		return importFolders(ctx, fsys, folders, takeoutStateFile)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(takeoutCmd)
+
+	takeoutCmd.Flags().StringVar(&importOwner, "owner", "", "username of the owner of the created albums")
+	takeoutCmd.Flags().IntVar(&importWorkers, "workers", 4, "number of photos uploaded at the same time")
+	takeoutCmd.Flags().StringVar(&takeoutStateFile, "state", "gphotos-takeout.state", "file keeping the progress of the import")
+	takeoutCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "only report what would be imported")
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2021 Cosmin Tupangiu <cosmin.tupangiu@gmail.com>
+
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU Lesser General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/conf"
+	"github.com/tupyy/gophoto/internal/repos/postgres/usage"
+	"github.com/tupyy/gophoto/internal/services/quota"
+	"go.uber.org/zap"
+)
+
+// usageCmd represents the recompute-usage command
+var usageCmd = &cobra.Command{
+	Use:   "recompute-usage",
+	Short: "compute the storage used by each album",
+	Long: `Compute the storage used by each album from the objects of its bucket. The usage is tracked when the media are
+saved and removed, so the command is needed only once to count the media saved before the quotas, or if the usage
+could not be updated.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := setupLogger()
+		defer logger.Sync()
+
+		undo := zap.ReplaceGlobals(logger)
+		defer undo()
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		client, err := pgclient.New(conf.GetPostgresConf())
+		if err != nil {
+			return err
+		}
+
+		storage, err := newStorage(conf.GetStorageConfig())
+		if err != nil {
+			return err
+		}
+
+		albumService, _, _, err := newAlbumServices(client, storage)
+		if err != nil {
+			return err
+		}
+
+		usageRepo, err := usage.NewPostgresRepo(client)
+		if err != nil {
+			return err
+		}
+
+		// the users are not needed to compute the usage
+		quotaService := quota.New(albumService, nil, storage, usageRepo, quota.Limits(conf.GetQuotaConfig()))
+
+		albums, err := quotaService.Recompute(ctx)
+		if err != nil {
+			return err
+		}
+
+		var total int64
+		for _, bytes := range albums {
+			total += bytes
+		}
+
+		fmt.Fprintf(os.Stderr, "%d albums using %d bytes\n", len(albums), total)
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(usageCmd)
+}
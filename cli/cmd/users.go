@@ -0,0 +1,193 @@
+/*
+Copyright © 2021 Cosmin Tupangiu <cosmin.tupangiu@gmail.com>
+
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU Lesser General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/common"
+	"github.com/tupyy/gophoto/internal/conf"
+	"github.com/tupyy/gophoto/internal/entity"
+	directoryRepo "github.com/tupyy/gophoto/internal/repos/postgres/directory"
+	"go.uber.org/zap"
+)
+
+var (
+	userRole      string
+	userGroups    []string
+	userCanShare  bool
+	userFirstName string
+	userLastName  string
+	userSubject   string
+)
+
+// usersCmd represents the users command
+var usersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "manage the users of the local directory",
+	Long: `Manage the users kept in postgres. They are the users of the postgres directory and the users recorded from
+the claims of the oidc directory.`,
+}
+
+var usersAddCmd = &cobra.Command{
+	Use:   "add <username>",
+	Short: "add or update a user",
+	Long: `Add the account of a user to the local directory. The account is identified by the subject of its tokens at the
+configured provider, which is the id of the user at the provider. If the account exists, its username, role, groups and
+sharing are replaced.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		role := entity.Role(userRole)
+		if role != entity.RoleUser && role != entity.RoleEditor && role != entity.RoleAdmin {
+			return fmt.Errorf("unknown role '%s'", userRole)
+		}
+
+		if userSubject == "" {
+			return errors.New("the subject of the account is needed")
+		}
+
+		return withDirectory(func(ctx context.Context, directory *directoryRepo.DirectoryRepo) error {
+			issuer := conf.GetAuthIssuer()
+
+			user, err := directory.GetUserBySubject(ctx, issuer, userSubject)
+			if err != nil && !isNotFound(err) {
+				return err
+			}
+
+			if err != nil {
+				user = entity.User{ID: uuid.New().String()}
+			}
+
+			owner, err := directory.GetUserByUsername(ctx, args[0])
+			if err == nil && owner.ID != user.ID {
+				return fmt.Errorf("username '%s' belongs to another account", args[0])
+			}
+
+			user.Username = args[0]
+
+			user.Role = role
+			user.CanShare = userCanShare
+			user.Groups = make([]entity.Group, 0, len(userGroups))
+			for _, g := range userGroups {
+				user.Groups = append(user.Groups, entity.Group{Name: strings.TrimLeft(g, "/")})
+			}
+
+			if cmd.Flags().Changed("first-name") {
+				user.FirstName = userFirstName
+			}
+
+			if cmd.Flags().Changed("last-name") {
+				user.LastName = userLastName
+			}
+
+			if err := directory.Save(ctx, user, issuer, userSubject); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(os.Stdout, user.ID)
+
+			return nil
+		})
+	},
+}
+
+var usersDeleteCmd = &cobra.Command{
+	Use:   "delete <username>",
+	Short: "remove a user",
+	Long:  `Remove a user from the local directory. The albums of the user are kept.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withDirectory(func(ctx context.Context, directory *directoryRepo.DirectoryRepo) error {
+			return directory.Delete(ctx, args[0])
+		})
+	},
+}
+
+var usersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the users",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withDirectory(func(ctx context.Context, directory *directoryRepo.DirectoryRepo) error {
+			users, err := directory.GetUsers(ctx, nil)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "USERNAME\tID\tROLE\tCAN SHARE\tGROUPS")
+
+			for _, u := range users {
+				groups := make([]string, 0, len(u.Groups))
+				for _, g := range u.Groups {
+					groups = append(groups, g.Name)
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", u.Username, u.ID, u.Role, u.CanShare, strings.Join(groups, ","))
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+// withDirectory runs f with the local directory.
+func withDirectory(f func(ctx context.Context, directory *directoryRepo.DirectoryRepo) error) error {
+	logger := setupLogger()
+	defer logger.Sync()
+
+	undo := zap.ReplaceGlobals(logger)
+	defer undo()
+
+	client, err := pgclient.New(conf.GetPostgresConf())
+	if err != nil {
+		return err
+	}
+
+	directory, err := directoryRepo.NewPostgresRepo(client)
+	if err != nil {
+		return err
+	}
+
+	return f(context.Background(), directory)
+}
+
+func isNotFound(err error) bool {
+	var serr common.ServiceError
+	return errors.As(err, &serr) && serr.Cause == common.EntityNotFound
+}
+
+func init() {
+	rootCmd.AddCommand(usersCmd)
+
+	usersCmd.AddCommand(usersAddCmd, usersDeleteCmd, usersListCmd)
+
+	usersAddCmd.Flags().StringVar(&userSubject, "subject", "", "subject of the tokens of the user at the provider (required)")
+	usersAddCmd.Flags().StringVar(&userRole, "role", string(entity.RoleUser), "role of the user: user, editor or admin")
+	usersAddCmd.Flags().StringSliceVar(&userGroups, "group", []string{}, "group of the user. It can be repeated")
+	usersAddCmd.Flags().BoolVar(&userCanShare, "can-share", false, "allow the user to share albums")
+	usersAddCmd.Flags().StringVar(&userFirstName, "first-name", "", "first name of the user")
+	usersAddCmd.Flags().StringVar(&userLastName, "last-name", "", "last name of the user")
+}
@@ -11,9 +11,14 @@ import (
 )
 
 // FakeAuthMiddleware reads the cookie and unmarshall it into session.
-// The cookie must be encoded base64.
+// The cookie must be encoded base64. The requests already authenticated by a previous middleware are left untouched.
 func FakeAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if _, authenticated := c.Get("session"); authenticated {
+			c.Next()
+			return
+		}
+
 		session := sessions.Default(c)
 
 		sessionEncoded := c.Request.Header.Get("SESSIONID")
@@ -21,13 +26,13 @@ func FakeAuthMiddleware() gin.HandlerFunc {
 		payload, err := base64.StdEncoding.DecodeString(sessionEncoded)
 		if err != nil {
 			zap.S().Errorw("cannot decode cookie", "error", err)
-			c.Abort()
+			abortUnauthorized(c, "not authenticated")
 			return
 		}
 		var se entity.Session
 		if err := json.Unmarshal(payload, &se); err != nil {
 			zap.S().Errorw("cannot unmarshal cookie to session", "error", err)
-			c.Abort()
+			abortUnauthorized(c, "not authenticated")
 			return
 		}
 		session.Set(sessionEncoded, se)
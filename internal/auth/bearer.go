@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services/accesstoken"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+// clockSkew is the difference tolerated between our clock and the clock of the provider.
+const clockSkew = time.Minute
+
+// audience is the aud claim, which is either a string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+
+	*a = multiple
+
+	return nil
+}
+
+func (a audience) contains(aud string) bool {
+	for _, s := range a {
+		if s == aud {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jwtVerifier verifies the access tokens issued by the provider: the signature with the keys of the provider, the issuer,
+// the audience and the validity dates. The tokens must have the audience if set, else they must be issued to our client,
+// so the tokens the provider issued to its other clients are rejected.
+type jwtVerifier struct {
+	keys     *keySet
+	issuer   string
+	audience string
+	clientID string
+	now      func() time.Time
+}
+
+func newJWTVerifier(keys *keySet, issuer, audience, clientID string) *jwtVerifier {
+	return &jwtVerifier{keys: keys, issuer: issuer, audience: audience, clientID: clientID, now: time.Now}
+}
+
+// verify returns the claims of the token.
+func (v *jwtVerifier) verify(ctx context.Context, raw string) (jwt.MapClaims, error) {
+	parser := jwt.Parser{
+		ValidMethods:         []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"},
+		SkipClaimsValidation: true,
+	}
+
+	claims := jwt.MapClaims{}
+
+	_, err := parser.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		default:
+			return nil, errUnexpectedSigningMethod
+		}
+
+		kid, found := token.Header["kid"]
+		if !found {
+			return nil, errMissingKeyID
+		}
+
+		id, ok := kid.(string)
+		if !ok {
+			return nil, errInvalidKeyID
+		}
+
+		return v.keys.key(ctx, id)
+	})
+	if err != nil {
+		var verr *jwt.ValidationError
+		if errors.As(err, &verr) && verr.Inner != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidToken, verr.Inner)
+		}
+		return nil, fmt.Errorf("%w: %s", errInvalidToken, err)
+	}
+
+	var std standardClaims
+	if err := decodeClaims(claims, &std); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidClaims, err)
+	}
+
+	now := v.now()
+
+	switch {
+	case std.Issuer != v.issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer '%s'", errInvalidClaims, std.Issuer)
+	case v.audience != "" && !std.Audience.contains(v.audience):
+		return nil, fmt.Errorf("%w: audience '%s' not found", errInvalidClaims, v.audience)
+	case v.audience == "" && std.AuthorizedParty != v.clientID && !std.Audience.contains(v.clientID):
+		return nil, fmt.Errorf("%w: token not issued to client '%s'", errInvalidClaims, v.clientID)
+	case std.ExpiresAt == 0:
+		return nil, fmt.Errorf("%w: missing expiration date", errInvalidClaims)
+	case now.Add(-clockSkew).Unix() >= std.ExpiresAt:
+		return nil, fmt.Errorf("%w: token expired", errInvalidToken)
+	case std.NotBefore != 0 && now.Add(clockSkew).Unix() < std.NotBefore:
+		return nil, fmt.Errorf("%w: token not valid yet", errInvalidToken)
+	}
+
+	return claims, nil
+}
+
+// bearerMiddleware authenticates the requests with an access token of the provider in the Authorization header.
+// The requests without bearer token, with a personal access token or already authenticated are left to the next middlewares.
+// The user of the claims is resolved by the resolver if set.
+func bearerMiddleware(v *jwtVerifier, mapper claimsMapper, resolver UserResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, authenticated := c.Get("session"); authenticated {
+			c.Next()
+			return
+		}
+
+		raw, ok := bearerToken(c.Request)
+		if !ok || strings.HasPrefix(raw, accesstoken.Prefix) {
+			c.Next()
+			return
+		}
+
+		claims, err := v.verify(c.Request.Context(), raw)
+		if err != nil {
+			zap.S().Debugw("bearer token rejected", "error", err)
+			abortUnauthorized(c, "invalid bearer token")
+			return
+		}
+
+		user, err := mapper.user(claims)
+		if err == nil {
+			user, err = resolveUser(c.Request.Context(), resolver, user)
+		}
+
+		if err != nil {
+			zap.S().Debugw("bearer token rejected", "error", err)
+			abortUnauthorized(c, "invalid bearer token")
+			return
+		}
+
+		session, err := newSession(user, claims, &oauth2.Token{AccessToken: raw, TokenType: "Bearer"})
+		if err != nil {
+			abortUnauthorized(c, "invalid bearer token")
+			return
+		}
+		session.Token.Expiry = session.ExpireAt
+
+		c.Set("session", session)
+		c.Next()
+	}
+}
+
+// bearerToken returns the bearer token of the Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+		return "", false
+	}
+
+	token := strings.TrimSpace(parts[1])
+
+	return token, token != ""
+}
+
+// abortUnauthorized rejects the request with a 401 json response.
+func abortUnauthorized(c *gin.Context, msg string) {
+	c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, mappersv1.MapFromStatus(http.StatusUnauthorized, msg))
+}
+
+// isAPIRequest returns true if the request is an api call, which is answered with json instead of being redirected.
+func isAPIRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, apiPrefix)
+}
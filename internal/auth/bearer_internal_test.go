@@ -0,0 +1,304 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+const testIssuer = "http://keycloak/auth/realms/gophoto"
+
+// jwksServer serves the public keys of its signing keys.
+type jwksServer struct {
+	*httptest.Server
+
+	lock    sync.Mutex
+	keys    map[string]*rsa.PrivateKey
+	fetches int
+}
+
+func newJWKSServer(t *testing.T) *jwksServer {
+	s := &jwksServer{keys: make(map[string]*rsa.PrivateKey)}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		s.fetches++
+
+		set := struct {
+			Keys []jsonWebKey `json:"keys"`
+		}{}
+
+		for kid, key := range s.keys {
+			set.Keys = append(set.Keys, jsonWebKey{
+				Kid: kid,
+				Kty: "RSA",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+
+		json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// rollover replaces the keys with a new key.
+func (s *jwksServer) rollover(t *testing.T, kid string) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.keys = map[string]*rsa.PrivateKey{kid: key}
+
+	return key
+}
+
+func sign(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	raw, err := token.SignedString(key)
+	require.Nil(t, err)
+
+	return raw
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":                testIssuer,
+		"sub":                "user-id",
+		"aud":                []string{"account", "gophoto"},
+		"azp":                "gophoto",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"iat":                time.Now().Unix(),
+		"preferred_username": "alice",
+		"role":               "[editor]",
+		"groups":             []string{"/family"},
+	}
+}
+
+func TestJWTVerifier(t *testing.T) {
+	server := newJWKSServer(t)
+	key := server.rollover(t, "k1")
+
+	v := newJWTVerifier(newKeySet(server.URL, server.Client()), testIssuer, "gophoto", "gophoto")
+
+	claims, err := v.verify(context.Background(), sign(t, key, "k1", validClaims()))
+	require.Nil(t, err)
+
+	user, err := keycloakClaims.user(claims)
+	require.Nil(t, err)
+	assert.Equal(t, "alice", user.Username)
+	assert.Equal(t, "user-id", user.ID)
+	assert.Equal(t, entity.RoleEditor, user.Role)
+	assert.Equal(t, []entity.Group{{Name: "family"}}, user.Groups)
+
+	single := validClaims()
+	single["aud"] = "gophoto"
+	_, err = v.verify(context.Background(), sign(t, key, "k1", single))
+	assert.Nil(t, err, "the audience can be a string")
+
+	invalid := map[string]func(c jwt.MapClaims){
+		"issuer":   func(c jwt.MapClaims) { c["iss"] = "http://other" },
+		"audience": func(c jwt.MapClaims) { c["aud"] = "account" },
+		"expired":  func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-2 * clockSkew).Unix() },
+		"no exp":   func(c jwt.MapClaims) { delete(c, "exp") },
+		"nbf":      func(c jwt.MapClaims) { c["nbf"] = time.Now().Add(2 * clockSkew).Unix() },
+		"username": func(c jwt.MapClaims) { delete(c, "preferred_username") },
+	}
+
+	for name, change := range invalid {
+		c := validClaims()
+		change(c)
+
+		claims, err := v.verify(context.Background(), sign(t, key, "k1", c))
+		if err == nil {
+			_, err = keycloakClaims.user(claims)
+		}
+		assert.NotNil(t, err, name)
+	}
+
+	_, err = v.verify(context.Background(), sign(t, key, "", validClaims()))
+	assert.True(t, errors.Is(err, errInvalidToken))
+
+	hmac, err := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims()).SignedString([]byte("secret"))
+	require.Nil(t, err)
+
+	_, err = v.verify(context.Background(), hmac)
+	assert.NotNil(t, err, "the tokens signed with a shared secret must be rejected")
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+
+	_, err = v.verify(context.Background(), sign(t, other, "k1", validClaims()))
+	assert.NotNil(t, err, "the signature must be checked")
+}
+
+func TestJWTVerifierClient(t *testing.T) {
+	server := newJWKSServer(t)
+	key := server.rollover(t, "k1")
+
+	// without audience, the tokens must be issued to the client
+	v := newJWTVerifier(newKeySet(server.URL, server.Client()), testIssuer, "", "gophoto")
+
+	tests := []struct {
+		name  string
+		azp   interface{}
+		aud   interface{}
+		valid bool
+	}{
+		{"issued to the client", "gophoto", "account", true},
+		{"client in the audience", "other", []string{"account", "gophoto"}, true},
+		{"issued to another client", "other", "account", false},
+		{"another client without audience", "other", nil, false},
+		{"no authorized party", nil, "account", false},
+	}
+
+	for _, test := range tests {
+		claims := validClaims()
+		delete(claims, "azp")
+		delete(claims, "aud")
+
+		if test.azp != nil {
+			claims["azp"] = test.azp
+		}
+		if test.aud != nil {
+			claims["aud"] = test.aud
+		}
+
+		_, err := v.verify(context.Background(), sign(t, key, "k1", claims))
+		if test.valid {
+			assert.Nil(t, err, test.name)
+		} else {
+			assert.True(t, errors.Is(err, errInvalidClaims), test.name)
+		}
+	}
+
+	// the audience replaces the client check
+	v = newJWTVerifier(newKeySet(server.URL, server.Client()), testIssuer, "photos-api", "gophoto")
+
+	claims := validClaims()
+	_, err := v.verify(context.Background(), sign(t, key, "k1", claims))
+	assert.NotNil(t, err, "the token of the client without the audience is rejected")
+
+	claims["azp"] = "other"
+	claims["aud"] = "photos-api"
+	_, err = v.verify(context.Background(), sign(t, key, "k1", claims))
+	assert.Nil(t, err)
+}
+
+func TestKeySetRollover(t *testing.T) {
+	server := newJWKSServer(t)
+	old := server.rollover(t, "k1")
+
+	now := time.Now()
+
+	keys := newKeySet(server.URL, server.Client())
+	keys.now = func() time.Time { return now }
+
+	v := newJWTVerifier(keys, testIssuer, "", "gophoto")
+
+	_, err := v.verify(context.Background(), sign(t, old, "k1", validClaims()))
+	require.Nil(t, err)
+
+	_, err = v.verify(context.Background(), sign(t, old, "k1", validClaims()))
+	require.Nil(t, err)
+	assert.Equal(t, 1, server.fetches, "the keys must be cached")
+
+	// the provider rolls its keys over
+	now = now.Add(keySetMinRefresh)
+	rolled := server.rollover(t, "k2")
+
+	_, err = v.verify(context.Background(), sign(t, rolled, "k2", validClaims()))
+	require.Nil(t, err)
+	assert.Equal(t, 2, server.fetches)
+
+	// the unknown keys do not refresh the keys more than once per interval
+	for i := 0; i < 3; i++ {
+		_, err = v.verify(context.Background(), sign(t, rolled, "unknown", validClaims()))
+		assert.True(t, errors.Is(err, errInvalidToken))
+	}
+	assert.Equal(t, 2, server.fetches)
+
+	_, err = v.verify(context.Background(), sign(t, old, "k1", validClaims()))
+	assert.NotNil(t, err, "the retired key has been dropped by the refresh")
+
+	now = now.Add(keySetTTL)
+	server.Close()
+
+	_, err = v.verify(context.Background(), sign(t, rolled, "k2", validClaims()))
+	assert.Nil(t, err, "the cached key is used while the provider is not reachable")
+}
+
+func TestBearerMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	server := newJWKSServer(t)
+	key := server.rollover(t, "k1")
+
+	engine := gin.New()
+	engine.Use(bearerMiddleware(newJWTVerifier(newKeySet(server.URL, server.Client()), testIssuer, "", "gophoto"), keycloakClaims, nil))
+	engine.GET("/api/gphotos/v1/albums", func(c *gin.Context) {
+		session, ok := c.Get("session")
+		if !ok {
+			c.Status(http.StatusTeapot)
+			return
+		}
+		c.String(http.StatusOK, session.(entity.Session).User.Username)
+	})
+
+	tests := []struct {
+		authorization string
+		code          int
+	}{
+		{"", http.StatusTeapot},
+		{"Basic YWxpY2U6cGFzc3dvcmQ=", http.StatusTeapot},
+		{"Bearer gpat_token", http.StatusTeapot},
+		{"Bearer not.a.jwt", http.StatusUnauthorized},
+		{"Bearer " + sign(t, key, "k1", validClaims()), http.StatusOK},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/gphotos/v1/albums", nil)
+		if test.authorization != "" {
+			req.Header.Set("Authorization", test.authorization)
+		}
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, test.code, w.Code, test.authorization)
+
+		switch w.Code {
+		case http.StatusOK:
+			assert.Equal(t, "alice", w.Body.String())
+		case http.StatusUnauthorized:
+			assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+		}
+	}
+}
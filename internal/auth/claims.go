@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/conf"
+	"github.com/tupyy/gophoto/internal/entity"
+	"golang.org/x/oauth2"
+)
+
+// keycloakClaims are the claims set by the mappers of the gophoto realm.
+var keycloakClaims = claimsMapper{
+	username: "preferred_username",
+	groups:   "groups",
+	role:     "role",
+	canShare: "can_share",
+}
+
+// roleRanks ranks the roles to keep the highest one when the role claim holds several roles.
+var roleRanks = map[entity.Role]int{
+	entity.RoleUser:   1,
+	entity.RoleEditor: 2,
+	entity.RoleAdmin:  3,
+}
+
+// standardClaims are the registered claims of the tokens. The aud claim is either a string or an array.
+type standardClaims struct {
+	ID       string   `json:"jti"`
+	Subject  string   `json:"sub"`
+	Issuer   string   `json:"iss"`
+	Audience audience `json:"aud"`
+	// AuthorizedParty is the client the token was issued to.
+	AuthorizedParty string `json:"azp"`
+	ExpiresAt       int64  `json:"exp"`
+	NotBefore       int64  `json:"nbf"`
+	IssuedAt        int64  `json:"iat"`
+	// SessionID is the sid claim of the spec. Keycloak sets session_state instead.
+	SessionID    string `json:"sid"`
+	SessionState string `json:"session_state"`
+}
+
+// claimsMapper maps the claims of a token to the user. The claims are looked up by name or by a dotted path
+// like realm_access.roles.
+type claimsMapper struct {
+	username string
+	groups   string
+	role     string
+	canShare string
+}
+
+func newClaimsMapper(c conf.OIDCConfig) claimsMapper {
+	return claimsMapper{
+		username: c.UsernameClaim,
+		groups:   c.GroupsClaim,
+		role:     c.RoleClaim,
+		canShare: c.CanShareClaim,
+	}
+}
+
+// user returns the user of the claims. The role is user if the role claim is missing or unknown.
+func (m claimsMapper) user(claims map[string]interface{}) (entity.User, error) {
+	username, _ := lookupClaim(claims, m.username).(string)
+	if username == "" {
+		return entity.User{}, fmt.Errorf("%w: missing username claim '%s'", errInvalidClaims, m.username)
+	}
+
+	sub, _ := claims["sub"].(string)
+	firstName, _ := claims["given_name"].(string)
+	lastName, _ := claims["family_name"].(string)
+
+	return entity.User{
+		ID:        sub,
+		Username:  username,
+		FirstName: firstName,
+		LastName:  lastName,
+		Role:      roleFromClaim(lookupClaim(claims, m.role)),
+		CanShare:  canShareFromClaim(lookupClaim(claims, m.canShare)),
+		Groups:    groupsFromClaim(lookupClaim(claims, m.groups)),
+	}, nil
+}
+
+// lookupClaim returns the claim with the name, else the claim found by following the dotted path.
+func lookupClaim(claims map[string]interface{}, name string) interface{} {
+	if v, found := claims[name]; found {
+		return v
+	}
+
+	var v interface{} = claims
+	for _, key := range strings.Split(name, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		v = m[key]
+	}
+
+	return v
+}
+
+// stringsFromClaim returns the strings of a claim which is either a string or an array.
+func stringsFromClaim(v interface{}) []string {
+	switch value := v.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// roleFromClaim returns the highest role of the claim. Keycloak writes the role like [editor].
+func roleFromClaim(v interface{}) entity.Role {
+	role := entity.RoleUser
+
+	for _, s := range stringsFromClaim(v) {
+		r := entity.Role(strings.ToLower(strings.Trim(s, "[]/ ")))
+		if roleRanks[r] > roleRanks[role] {
+			role = r
+		}
+	}
+
+	return role
+}
+
+func groupsFromClaim(v interface{}) []entity.Group {
+	names := stringsFromClaim(v)
+
+	groups := make([]entity.Group, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimLeft(name, "/")
+		if name != "" {
+			groups = append(groups, entity.Group{Name: name})
+		}
+	}
+
+	return groups
+}
+
+func canShareFromClaim(v interface{}) bool {
+	switch value := v.(type) {
+	case bool:
+		return value
+	case string:
+		return strings.EqualFold(value, "true")
+	default:
+		return false
+	}
+}
+
+// decodeClaims decodes the claims into v.
+func decodeClaims(claims map[string]interface{}, v interface{}) error {
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// newSession returns the session of the user authenticated with the token.
+func newSession(user entity.User, claims map[string]interface{}, token *oauth2.Token) (entity.Session, error) {
+	var std standardClaims
+	if err := decodeClaims(claims, &std); err != nil {
+		return entity.Session{}, fmt.Errorf("%w: %s", errInvalidClaims, err)
+	}
+
+	session := entity.NewSession()
+
+	session.User = user
+	session.TokenID = std.ID
+	session.SessionID = std.SessionID
+	if session.SessionID == "" {
+		session.SessionID = std.SessionState
+	}
+	session.Token = token
+	session.ExpireAt = time.Unix(std.ExpiresAt, 0)
+	session.IssueAt = time.Unix(std.IssuedAt, 0)
+
+	return *session, nil
+}
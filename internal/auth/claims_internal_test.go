@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+func TestClaimsMapper(t *testing.T) {
+	mapper := claimsMapper{
+		username: "email",
+		groups:   "realm_access.groups",
+		role:     "realm_access.roles",
+		canShare: "https://gophoto/can_share",
+	}
+
+	user, err := mapper.user(map[string]interface{}{
+		"sub":         "id",
+		"email":       "alice@example.com",
+		"given_name":  "Alice",
+		"family_name": "Smith",
+		"realm_access": map[string]interface{}{
+			"groups": []interface{}{"/family", "friends", ""},
+			"roles":  []interface{}{"offline_access", "Editor", "user"},
+		},
+		"https://gophoto/can_share": "true",
+	})
+	require.Nil(t, err)
+
+	assert.Equal(t, entity.User{
+		ID:        "id",
+		Username:  "alice@example.com",
+		FirstName: "Alice",
+		LastName:  "Smith",
+		Role:      entity.RoleEditor,
+		CanShare:  true,
+		Groups:    []entity.Group{{Name: "family"}, {Name: "friends"}},
+	}, user)
+
+	user, err = mapper.user(map[string]interface{}{"sub": "id", "email": "bob@example.com"})
+	require.Nil(t, err)
+	assert.Equal(t, entity.RoleUser, user.Role, "the role defaults to user")
+	assert.False(t, user.CanShare)
+	assert.Empty(t, user.Groups)
+
+	_, err = mapper.user(map[string]interface{}{"sub": "id", "preferred_username": "alice"})
+	assert.True(t, errors.Is(err, errInvalidClaims), "the username is needed")
+}
+
+func TestKeycloakClaims(t *testing.T) {
+	tests := []struct {
+		role     interface{}
+		expected entity.Role
+	}{
+		{"[admin]", entity.RoleAdmin},
+		{"[editor]", entity.RoleEditor},
+		{"[user]", entity.RoleUser},
+		{"[unknown]", entity.RoleUser},
+		{nil, entity.RoleUser},
+	}
+
+	for _, test := range tests {
+		claims := map[string]interface{}{"preferred_username": "alice", "can_share": true}
+		if test.role != nil {
+			claims["role"] = test.role
+		}
+
+		user, err := keycloakClaims.user(claims)
+		require.Nil(t, err)
+		assert.Equal(t, test.expected, user.Role, test.role)
+		assert.True(t, user.CanShare)
+	}
+}
@@ -15,5 +15,6 @@ var (
 	errInvalidKeyID            = errors.New("key id is not a string")
 	errUnknownKeyID            = errors.New("unknown key id")
 
-	errInternalError = errors.New("internal error")
+	errInternalError  = errors.New("internal error")
+	errSessionExpired = errors.New("session expired")
 )
@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// keySetTTL - the keys are fetched again after this delay to drop the retired keys.
+	keySetTTL = time.Hour
+	// keySetMinRefresh - an unknown key id triggers a fetch of the keys at most once per interval, so tokens with random
+	// key ids cannot make us hammer the provider.
+	keySetMinRefresh = 30 * time.Second
+)
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// keySet caches the public keys of the provider published at the jwks url. The keys are fetched again when they are
+// older than keySetTTL or when a token is signed with an unknown key, which happens when the provider rolls its keys over.
+type keySet struct {
+	url    string
+	client *http.Client
+	now    func() time.Time
+
+	lock        sync.RWMutex
+	keys        map[string]interface{}
+	fetchedAt   time.Time
+	lastAttempt time.Time
+}
+
+func newKeySet(url string, client *http.Client) *keySet {
+	return &keySet{
+		url:    url,
+		client: client,
+		now:    time.Now,
+		keys:   make(map[string]interface{}),
+	}
+}
+
+// key returns the public key with the id.
+func (k *keySet) key(ctx context.Context, kid string) (interface{}, error) {
+	k.lock.RLock()
+	key, found := k.keys[kid]
+	fresh := k.now().Sub(k.fetchedAt) < keySetTTL
+	k.lock.RUnlock()
+
+	if found && fresh {
+		return key, nil
+	}
+
+	if err := k.refresh(ctx, found); err != nil {
+		// keep on using the cached key while the provider is not reachable
+		if found {
+			zap.S().Warnw("failed to refresh jwks. cached key used", "error", err, "kid", kid)
+			return key, nil
+		}
+		return nil, err
+	}
+
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	if key, found := k.keys[kid]; found {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: '%s'", errUnknownKeyID, kid)
+}
+
+// refresh fetches the keys, at most once per keySetMinRefresh.
+func (k *keySet) refresh(ctx context.Context, expired bool) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	now := k.now()
+
+	switch {
+	case expired && now.Sub(k.fetchedAt) < keySetTTL:
+		// refreshed by another request meanwhile
+		return nil
+	case now.Sub(k.lastAttempt) < keySetMinRefresh:
+		return nil
+	}
+
+	k.lastAttempt = now
+
+	keys, err := k.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	k.keys = keys
+	k.fetchedAt = now
+
+	zap.S().Debugw("jwks refreshed", "url", k.url, "keys", len(keys))
+
+	return nil
+}
+
+func (k *keySet) fetch(ctx context.Context) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch jwks: %s", resp.Status)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			// the other keys are still usable
+			zap.S().Warnw("jwk ignored", "error", err, "kid", jwk.Kid)
+			continue
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (j jsonWebKey) publicKey() (interface{}, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, err
+		}
+
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid rsa exponent")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve '%s'", j.Crv)
+		}
+
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("invalid ec point")
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type '%s'", j.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
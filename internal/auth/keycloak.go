@@ -2,24 +2,17 @@ package auth
 
 import (
 	"context"
-	"crypto/rand"
 	"encoding/base64"
-	"errors"
 	"fmt"
-	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/Nerzal/gocloak/v8"
-	"github.com/coreos/go-oidc/v3/oidc"
-	"github.com/dgrijalva/jwt-go"
-	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/tupyy/gophoto/internal/conf"
-	"github.com/tupyy/gophoto/internal/entity"
 	"go.uber.org/zap"
 	"golang.org/x/oauth2"
 )
@@ -28,157 +21,56 @@ const (
 	sessionID = "sessionID"
 )
 
-var (
-	errSessionExpired = errors.New("session expired")
-)
-
 // Authenticator is the high level interface that authenticates a request based on a header given as parameter.
 type Authenticator interface {
 	// AuthMiddleware is the authentication middleware.
 	AuthMiddleware() gin.HandlerFunc
-	// Callback return an endpoint which will be called by keycloak after a successful authentication.
+	// BearerMiddleware authenticates the api calls with an access token issued by the provider.
+	BearerMiddleware() gin.HandlerFunc
+	// Callback return an endpoint which will be called by the provider after a successful authentication.
 	Callback() gin.HandlerFunc
-	// Logout logs out the user from keycloak only. It is up to the controller to clean up any remaining sessions.
+	// Logout logs out the user from the provider only. It is up to the controller to clean up any remaining sessions.
 	Logout(c *gin.Context, username, refreshToken string) error
 }
 
+// keyCloakAuthenticator is the oidc authenticator of a keycloak realm. The tokens are refreshed and the users logged out
+// with the keycloak api.
 type keyCloakAuthenticator struct {
-	oidcProvider *oidcProvider
-	client       gocloak.GoCloak
-	conf         conf.KeycloakConfig
+	*oidcAuthenticator
+	client gocloak.GoCloak
+	conf   conf.KeycloakConfig
 }
 
-func NewKeyCloakAuthenticator(c conf.KeycloakConfig, authCallback string) Authenticator {
+// NewKeyCloakAuthenticator returns the authenticator of the keycloak realm. The user is read from the claims set by the
+// mappers of the realm and resolved by the resolver if set.
+func NewKeyCloakAuthenticator(c conf.KeycloakConfig, authCallback string, resolver UserResolver) Authenticator {
 	// initialize oidc provier
-	oidcProvider := newOidcProvider(c, authCallback)
-	keycloakClient := gocloak.NewClient(c.BaseURL)
+	oidcProvider, err := newOidcProvider(c.Issuer(), c.ClientID, c.ClientSecret, authCallback, []string{"profile", "email", "roles"})
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	zap.S().Debugw("auth callback set", "callback url", authCallback)
 
-	return &keyCloakAuthenticator{oidcProvider: oidcProvider, client: keycloakClient, conf: c}
-}
-
-// Middleware returns the authentication middleware used for private routes.
-func (k *keyCloakAuthenticator) AuthMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		session := sessions.Default(c)
-
-		cookie, err := c.Request.Cookie(sessionID)
-		if err != nil {
-			c.Abort()
-			redirectToLogin(c, k.oidcProvider.Config)
-			return
-		}
-
-		s := session.Get(cookie.Value)
-		if s == nil {
-			zap.S().Warnw("no session found. redirect to login", "session id", cookie.Value)
-			c.Abort()
-			redirectToLogin(c, k.oidcProvider.Config)
-			return
-		}
-
-		sessionData, _ := s.(entity.Session)
-		if err := k.authenticate(c, sessionData); err != nil {
-			zap.S().Errorw("failed to authenticate", "session data", sessionData)
-			c.Abort()
-			redirectToLogin(c, k.oidcProvider.Config)
-			return
-		}
-
-		session.Set(cookie.Value, sessionData)
-		session.Save()
-
-		zap.S().Infow("user authenticated", "session data", sessionData)
-
-		c.Next()
+	authenticator, err := newOIDCAuthenticator(oidcProvider, keycloakClaims, resolver, c.Audience)
+	if err != nil {
+		log.Fatal(err)
 	}
-}
-
-// Callback returns a handler called after a successful authentication.
-func (k *keyCloakAuthenticator) Callback() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		session := sessions.Default(c)
-
-		// generate a session ID
-		uuid := uuid.New()
-
-		state := session.Get("state")
-		if state == nil {
-			http.Error(c.Writer, "state not found", http.StatusBadRequest)
-			return
-		}
 
-		if c.Request.URL.Query().Get("state") != state.(string) {
-			http.Error(c.Writer, "state did not match", http.StatusBadRequest)
-			return
-		}
-
-		oauth2Token, err := k.oidcProvider.Config.Exchange(context.Background(), c.Request.URL.Query().Get("code"))
-		if err != nil {
-			http.Error(c.Writer, "Failed to exchange token: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		rawIDToken, ok := oauth2Token.Extra("id_token").(string)
-		if !ok {
-			http.Error(c.Writer, "No id_token field in oauth2 token.", http.StatusInternalServerError)
-			return
-		}
-
-		idToken, err := k.oidcProvider.Verifier().Verify(context.Background(), rawIDToken)
-		if err != nil {
-			http.Error(c.Writer, "Failed to verify ID Token: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		nonce := session.Get("nonce")
-		if nonce == nil {
-			http.Error(c.Writer, "nonce not found", http.StatusBadRequest)
-			return
-		}
-		if idToken.Nonce != nonce.(string) {
-			http.Error(c.Writer, "nonce did not match", http.StatusBadRequest)
-			return
-		}
-
-		var claims gophotoClaims
-		if err := idToken.Claims(&claims); err != nil {
-			http.Error(c.Writer, "nonce did not match", http.StatusBadRequest)
-		}
-
-		username := getUsernameFromClaims(claims)
-
-		loggedUser := entityFromClaims(*username, claims)
-		loggedUser.Groups = getGroupsFromClaims(claims)
-
-		sessionData := entity.NewSession()
-
-		sessionData.User = loggedUser
-		sessionData.TokenID = claims.StandardClaims.Id
-		sessionData.SessionID = claims.SessionState
-		sessionData.Token = oauth2Token
-		sessionData.ExpireAt = time.Unix(claims.StandardClaims.ExpiresAt, 0)
-		sessionData.IssueAt = time.Unix(claims.StandardClaims.IssuedAt, 0)
-
-		session.Set(uuid.String(), sessionData)
-		session.Save()
-
-		// save uuid to cookie
-		c.SetCookie(sessionID, uuid.String(), 3600, "/", c.Request.Host, true, true)
-
-		next := session.Get("next")
-		if next != nil {
-			session.Delete("next")
-			http.Redirect(c.Writer, c.Request, next.(string), http.StatusFound)
-		}
+	k := &keyCloakAuthenticator{
+		oidcAuthenticator: authenticator,
+		client:            gocloak.NewClient(c.BaseURL),
+		conf:              c,
 	}
+	authenticator.refresh = k.refreshToken
+
+	return k
 }
 
 func (k *keyCloakAuthenticator) Logout(c *gin.Context, username, refreshToken string) error {
 	client := http.DefaultClient
 
-	logoutUrl, err := k.oidcProvider.GetLogoutURL()
+	logoutUrl, err := k.provider.GetLogoutURL()
 	if err != nil {
 		return err
 	}
@@ -209,119 +101,17 @@ func (k *keyCloakAuthenticator) Logout(c *gin.Context, username, refreshToken st
 	return nil
 }
 
-func (k *keyCloakAuthenticator) authenticate(ctx *gin.Context, sessionData entity.Session) error {
-	// check if session not expired
-	if time.Now().After(sessionData.ExpireAt) {
-		// try to refresh the token
-		newJwt, err := k.client.RefreshToken(ctx.Request.Context(), sessionData.Token.RefreshToken, k.conf.ClientID, k.conf.ClientSecret, k.conf.Realm)
-		if err != nil {
-			return errSessionExpired
-		}
-
-		sessionData.Token.AccessToken = newJwt.AccessToken
-		sessionData.Token.RefreshToken = newJwt.RefreshToken
-		sessionData.ExpireAt = time.Now().Add(time.Duration(int64(newJwt.ExpiresIn)) * time.Second)
-
-		zap.S().Infow("session has expired. Token refreshed", "username", sessionData.User.Username, "token expiration", sessionData.ExpireAt)
-	}
-
-	ctx.Set("sessionData", sessionData)
-
-	return nil
-}
-
-func randString(nByte int) (string, error) {
-	b := make([]byte, nByte)
-	if _, err := io.ReadFull(rand.Reader, b); err != nil {
-		return "", err
-	}
-	return base64.RawURLEncoding.EncodeToString(b), nil
-}
-
-func redirectToLogin(c *gin.Context, config oauth2.Config) {
-	session := sessions.Default(c)
-
-	// generate state and nonce
-	state, err := randString(16)
+// refreshToken gets a new token from keycloak.
+func (k *keyCloakAuthenticator) refreshToken(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	newJwt, err := k.client.RefreshToken(ctx, token.RefreshToken, k.conf.ClientID, k.conf.ClientSecret, k.conf.Realm)
 	if err != nil {
-		http.Error(c.Writer, err.Error(), http.StatusInternalServerError)
-		return
+		return nil, err
 	}
 
-	session.Set("state", state)
-
-	// save the current url to redirect back to it if auth is ok.
-	session.Set("next", c.Request.URL.String())
-
-	nonce, err := randString(16)
-	if err != nil {
-		http.Error(c.Writer, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	session.Set("nonce", nonce)
-	session.Save()
-
-	http.Redirect(c.Writer, c.Request, config.AuthCodeURL(state, oidc.Nonce(nonce)), http.StatusFound)
-}
-
-type gophotoClaims struct {
-	UserName     *string  `json:"preferred_username"`
-	Role         *string  `json:"role"`
-	CanShare     *bool    `json:"can_share"`
-	Groups       []string `json:"groups"`
-	SessionState string   `json:"session_state"`
-	FirstName    string   `json:"given_name"`
-	LastName     string   `json:"family_name"`
-	jwt.StandardClaims
-}
-
-func getUsernameFromClaims(claims gophotoClaims) *string {
-	if claims.UserName != nil && *claims.UserName != "" {
-		return claims.UserName
-	}
-
-	return nil
-}
-
-func getGroupsFromClaims(claims gophotoClaims) []entity.Group {
-	grps := make([]entity.Group, 0, len(claims.Groups))
-	for _, name := range claims.Groups {
-		name = strings.TrimLeft(name, "/")
-		grps = append(grps, entity.Group{Name: name})
-	}
-
-	return grps
-}
-
-func entityFromClaims(username string, claims gophotoClaims) entity.User {
-	var r entity.Role
-
-	if claims.Role != nil {
-		switch *claims.Role {
-		case "[admin]":
-			r = entity.RoleAdmin
-		case "[editor]":
-			r = entity.RoleEditor
-		case "[user]":
-			r = entity.RoleUser
-		default:
-			r = entity.RoleUser
-		}
-	} else {
-		r = entity.RoleUser
-	}
-
-	canShare := false
-	if claims.CanShare != nil {
-		canShare = *claims.CanShare
-	}
-
-	return entity.User{
-		Username:  username,
-		ID:        claims.StandardClaims.Subject,
-		Role:      r,
-		CanShare:  canShare,
-		FirstName: claims.FirstName,
-		LastName:  claims.LastName,
-	}
+	return &oauth2.Token{
+		AccessToken:  newJwt.AccessToken,
+		TokenType:    newJwt.TokenType,
+		RefreshToken: newJwt.RefreshToken,
+		Expiry:       time.Now().Add(time.Duration(int64(newJwt.ExpiresIn)) * time.Second),
+	}, nil
 }
@@ -0,0 +1,347 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tupyy/gophoto/internal/conf"
+	"github.com/tupyy/gophoto/internal/entity"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+// UserResolver maps the user found in the claims of a token to the user of the directory.
+type UserResolver interface {
+	// Resolve returns the user of the directory. An error rejects the user.
+	Resolve(ctx context.Context, user entity.User) (entity.User, error)
+}
+
+// resolveUser returns the user of the directory, or the user of the claims if there is no resolver.
+func resolveUser(ctx context.Context, resolver UserResolver, user entity.User) (entity.User, error) {
+	if resolver == nil {
+		return user, nil
+	}
+
+	return resolver.Resolve(ctx, user)
+}
+
+// oidcAuthenticator logs the users in with the authorization code flow of an OpenID Connect provider and authenticates
+// the api calls with the access tokens of the provider.
+type oidcAuthenticator struct {
+	provider *oidcProvider
+	mapper   claimsMapper
+	resolver UserResolver
+	verifier *jwtVerifier
+	client   *http.Client
+	// refresh returns a new token when the session has expired.
+	refresh func(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error)
+}
+
+// NewOIDCAuthenticator returns the authenticator of a generic OpenID Connect provider. The user is read from the claims
+// of the tokens and resolved by the resolver if set.
+func NewOIDCAuthenticator(c conf.OIDCConfig, authCallback string, resolver UserResolver) (Authenticator, error) {
+	provider, err := newOidcProvider(c.Issuer, c.ClientID, c.ClientSecret, authCallback, c.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	zap.S().Debugw("auth callback set", "callback url", authCallback, "issuer", c.Issuer)
+
+	return newOIDCAuthenticator(provider, newClaimsMapper(c), resolver, c.Audience)
+}
+
+func newOIDCAuthenticator(provider *oidcProvider, mapper claimsMapper, resolver UserResolver, audience string) (*oidcAuthenticator, error) {
+	jwksURL, err := provider.JWKSURL()
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: conf.GetHttpRequestTimeout()}
+
+	a := &oidcAuthenticator{
+		provider: provider,
+		mapper:   mapper,
+		resolver: resolver,
+		verifier: newJWTVerifier(newKeySet(jwksURL, client), provider.Issuer, audience, provider.Config.ClientID),
+		client:   client,
+	}
+	a.refresh = a.refreshToken
+
+	return a, nil
+}
+
+// AuthMiddleware returns the authentication middleware used for private routes.
+// The api calls without session get a 401 instead of the redirection to the login page.
+func (a *oidcAuthenticator) AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, authenticated := c.Get("session"); authenticated {
+			c.Next()
+			return
+		}
+
+		session := sessions.Default(c)
+
+		cookie, err := c.Request.Cookie(sessionID)
+		if err != nil {
+			a.unauthenticated(c)
+			return
+		}
+
+		s := session.Get(cookie.Value)
+		if s == nil {
+			zap.S().Warnw("no session found. redirect to login", "session id", cookie.Value)
+			a.unauthenticated(c)
+			return
+		}
+
+		sessionData, ok := s.(entity.Session)
+		if !ok {
+			a.unauthenticated(c)
+			return
+		}
+
+		if err := a.authenticate(c.Request.Context(), &sessionData); err != nil {
+			zap.S().Errorw("failed to authenticate", "session data", sessionData, "error", err)
+			session.Delete(cookie.Value)
+			session.Save()
+			a.unauthenticated(c)
+			return
+		}
+
+		session.Set(cookie.Value, sessionData)
+		session.Save()
+
+		zap.S().Debugw("user authenticated", "session data", sessionData)
+
+		c.Set("session", sessionData)
+		c.Next()
+	}
+}
+
+// BearerMiddleware returns the middleware authenticating the requests with an access token of the provider.
+func (a *oidcAuthenticator) BearerMiddleware() gin.HandlerFunc {
+	return bearerMiddleware(a.verifier, a.mapper, a.resolver)
+}
+
+// unauthenticated rejects the api calls and redirects the other requests to the login page.
+func (a *oidcAuthenticator) unauthenticated(c *gin.Context) {
+	if isAPIRequest(c.Request) {
+		abortUnauthorized(c, "not authenticated")
+		return
+	}
+
+	c.Abort()
+	redirectToLogin(c, a.provider.Config)
+}
+
+// Callback returns a handler called by the provider after a successful authentication.
+func (a *oidcAuthenticator) Callback() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		session := sessions.Default(c)
+
+		state := session.Get("state")
+		if state == nil {
+			http.Error(c.Writer, "state not found", http.StatusBadRequest)
+			return
+		}
+
+		if c.Request.URL.Query().Get("state") != state.(string) {
+			http.Error(c.Writer, "state did not match", http.StatusBadRequest)
+			return
+		}
+
+		ctx := oidc.ClientContext(c.Request.Context(), a.client)
+
+		oauth2Token, err := a.provider.Config.Exchange(ctx, c.Request.URL.Query().Get("code"))
+		if err != nil {
+			http.Error(c.Writer, "Failed to exchange token: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		rawIDToken, ok := oauth2Token.Extra("id_token").(string)
+		if !ok {
+			http.Error(c.Writer, "No id_token field in oauth2 token.", http.StatusInternalServerError)
+			return
+		}
+
+		idToken, err := a.provider.Verifier().Verify(ctx, rawIDToken)
+		if err != nil {
+			http.Error(c.Writer, "Failed to verify ID Token: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		nonce := session.Get("nonce")
+		if nonce == nil {
+			http.Error(c.Writer, "nonce not found", http.StatusBadRequest)
+			return
+		}
+		if idToken.Nonce != nonce.(string) {
+			http.Error(c.Writer, "nonce did not match", http.StatusBadRequest)
+			return
+		}
+
+		claims := make(map[string]interface{})
+		if err := idToken.Claims(&claims); err != nil {
+			http.Error(c.Writer, "Failed to read claims: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		user, err := a.mapper.user(claims)
+		if err != nil {
+			http.Error(c.Writer, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		user, err = resolveUser(c.Request.Context(), a.resolver, user)
+		if err != nil {
+			zap.S().Warnw("user rejected by the directory", "username", user.Username, "error", err)
+			http.Error(c.Writer, "user not allowed", http.StatusForbidden)
+			return
+		}
+
+		sessionData, err := newSession(user, claims, oauth2Token)
+		if err != nil {
+			http.Error(c.Writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// the session lasts as long as the access token, which is refreshed when it expires.
+		if !oauth2Token.Expiry.IsZero() {
+			sessionData.ExpireAt = oauth2Token.Expiry
+		}
+
+		// generate a session ID
+		id := uuid.New().String()
+
+		session.Delete("state")
+		session.Delete("nonce")
+		session.Set(id, sessionData)
+
+		next := session.Get("next")
+		session.Delete("next")
+		session.Save()
+
+		// save uuid to cookie
+		c.SetCookie(sessionID, id, 3600, "/", c.Request.Host, true, true)
+
+		if next != nil {
+			http.Redirect(c.Writer, c.Request, next.(string), http.StatusFound)
+		}
+	}
+}
+
+// Logout revokes the refresh token if the provider has a revocation endpoint.
+func (a *oidcAuthenticator) Logout(c *gin.Context, username, refreshToken string) error {
+	var discovery struct {
+		RevocationURL string `json:"revocation_endpoint"`
+	}
+
+	if err := a.provider.discovery(&discovery); err != nil {
+		return err
+	}
+
+	if discovery.RevocationURL == "" {
+		zap.S().Debugw("provider without revocation endpoint. token not revoked", "username", username)
+		return nil
+	}
+
+	formData := make(url.Values)
+	formData.Add("token", refreshToken)
+	formData.Add("token_type_hint", "refresh_token")
+
+	req, err := http.NewRequestWithContext(c.Request.Context(), http.MethodPost, discovery.RevocationURL, strings.NewReader(formData.Encode()))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(a.provider.Config.ClientID), url.QueryEscape(a.provider.Config.ClientSecret))
+
+	res, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("error logging out user %s: %s", username, res.Status)
+	}
+
+	return nil
+}
+
+// authenticate refreshes the token of the session if it has expired.
+func (a *oidcAuthenticator) authenticate(ctx context.Context, sessionData *entity.Session) error {
+	if !sessionData.ExpireAt.Before(time.Now()) {
+		return nil
+	}
+
+	if sessionData.Token == nil || sessionData.Token.RefreshToken == "" {
+		return errSessionExpired
+	}
+
+	token, err := a.refresh(ctx, sessionData.Token)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errSessionExpired, err)
+	}
+
+	sessionData.Token = token
+	sessionData.ExpireAt = token.Expiry
+
+	zap.S().Infow("session has expired. Token refreshed", "username", sessionData.User.Username, "token expiration", sessionData.ExpireAt)
+
+	return nil
+}
+
+// refreshToken gets a new token with the refresh token.
+func (a *oidcAuthenticator) refreshToken(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	ctx = oidc.ClientContext(ctx, a.client)
+
+	// the expired access token is dropped to force the refresh.
+	return a.provider.Config.TokenSource(ctx, &oauth2.Token{RefreshToken: token.RefreshToken}).Token()
+}
+
+func randString(nByte int) (string, error) {
+	b := make([]byte, nByte)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func redirectToLogin(c *gin.Context, config oauth2.Config) {
+	session := sessions.Default(c)
+
+	// generate state and nonce
+	state, err := randString(16)
+	if err != nil {
+		http.Error(c.Writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	session.Set("state", state)
+
+	// save the current url to redirect back to it if auth is ok.
+	session.Set("next", c.Request.URL.String())
+
+	nonce, err := randString(16)
+	if err != nil {
+		http.Error(c.Writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	session.Set("nonce", nonce)
+	session.Save()
+
+	http.Redirect(c.Writer, c.Request, config.AuthCodeURL(state, oidc.Nonce(nonce)), http.StatusFound)
+}
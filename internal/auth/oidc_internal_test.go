@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/memstore"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/conf"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/utils/oidctest"
+)
+
+const testCallback = "http://gophoto/auth/callback"
+
+// fakeResolver rejects the users unknown to it and returns the users with their local id.
+type fakeResolver map[string]entity.User
+
+func (f fakeResolver) Resolve(ctx context.Context, user entity.User) (entity.User, error) {
+	u, found := f[user.Username]
+	if !found {
+		return entity.User{}, errors.New("unknown user")
+	}
+
+	return u, nil
+}
+
+func newTestProvider(t *testing.T) *oidctest.Server {
+	provider, err := oidctest.New("gophoto", "secret")
+	require.Nil(t, err)
+	t.Cleanup(provider.Close)
+
+	provider.SetClaims(map[string]interface{}{
+		"sub":                "provider-id",
+		"preferred_username": "alice",
+		"groups":             []string{"/family"},
+		"roles":              []string{"editor"},
+	})
+
+	return provider
+}
+
+func newTestAuthenticator(t *testing.T, provider *oidctest.Server, resolver UserResolver) *oidcAuthenticator {
+	a, err := NewOIDCAuthenticator(conf.OIDCConfig{
+		Issuer:        provider.Issuer(),
+		ClientID:      provider.ClientID,
+		ClientSecret:  provider.ClientSecret,
+		Audience:      provider.ClientID,
+		Scopes:        []string{"profile"},
+		UsernameClaim: "preferred_username",
+		GroupsClaim:   "groups",
+		RoleClaim:     "roles",
+		CanShareClaim: "can_share",
+	}, testCallback, resolver)
+	require.Nil(t, err)
+
+	return a.(*oidcAuthenticator)
+}
+
+func newTestEngine(a Authenticator) *gin.Engine {
+	// the sessions of the memstore are copied with gob
+	gob.Register(entity.Session{})
+
+	engine := gin.New()
+	engine.Use(sessions.Sessions("gophoto", memstore.NewStore([]byte("secret"))))
+	engine.Use(a.BearerMiddleware())
+
+	engine.GET("/auth/callback", a.Callback())
+
+	whoami := func(c *gin.Context) {
+		session := c.MustGet("session").(entity.Session)
+		c.String(http.StatusOK, "%s %s %s", session.User.ID, session.User.Username, session.User.Role)
+	}
+
+	engine.GET("/albums", a.AuthMiddleware(), whoami)
+	engine.GET("/api/gphotos/v1/albums", a.AuthMiddleware(), whoami)
+
+	return engine
+}
+
+// browser keeps the cookies of the engine.
+type browser struct {
+	engine  *gin.Engine
+	cookies map[string]*http.Cookie
+}
+
+func (b *browser) get(t *testing.T, target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for _, c := range b.cookies {
+		req.AddCookie(c)
+	}
+
+	w := httptest.NewRecorder()
+	b.engine.ServeHTTP(w, req)
+
+	for _, c := range w.Result().Cookies() {
+		b.cookies[c.Name] = c
+	}
+
+	return w
+}
+
+// login follows the redirection to the provider, which approves the login, and the redirection back to the callback.
+func (b *browser) login(t *testing.T, target string) *httptest.ResponseRecorder {
+	w := b.get(t, target)
+	require.Equal(t, http.StatusFound, w.Code)
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error { return http.ErrUseLastResponse },
+	}
+
+	resp, err := client.Get(w.Header().Get("Location"))
+	require.Nil(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.Nil(t, err)
+
+	return b.get(t, callback.RequestURI())
+}
+
+func TestOIDCLogin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	provider := newTestProvider(t)
+	a := newTestAuthenticator(t, provider, nil)
+
+	b := &browser{engine: newTestEngine(a), cookies: make(map[string]*http.Cookie)}
+
+	w := b.get(t, "/api/gphotos/v1/albums")
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "the api calls are not redirected")
+
+	w = b.login(t, "/albums")
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	assert.Equal(t, "/albums", w.Header().Get("Location"))
+
+	w = b.get(t, "/albums")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "provider-id alice editor", w.Body.String())
+
+	provider.SetClaims(map[string]interface{}{"sub": "provider-id"})
+
+	b = &browser{engine: b.engine, cookies: make(map[string]*http.Cookie)}
+
+	w = b.login(t, "/albums")
+	assert.Equal(t, http.StatusForbidden, w.Code, "the username is needed")
+}
+
+func TestOIDCLoginResolver(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	provider := newTestProvider(t)
+	a := newTestAuthenticator(t, provider, fakeResolver{"alice": {ID: "local-id", Username: "alice", Role: entity.RoleAdmin}})
+
+	b := &browser{engine: newTestEngine(a), cookies: make(map[string]*http.Cookie)}
+
+	w := b.login(t, "/albums")
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+
+	w = b.get(t, "/albums")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "local-id alice admin", w.Body.String(), "the user of the directory is used")
+
+	provider.SetClaims(map[string]interface{}{"sub": "other", "preferred_username": "bob"})
+
+	b = &browser{engine: b.engine, cookies: make(map[string]*http.Cookie)}
+
+	w = b.login(t, "/albums")
+	assert.Equal(t, http.StatusForbidden, w.Code, "the users unknown to the directory are rejected")
+}
+
+func TestOIDCBearer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	provider := newTestProvider(t)
+	engine := newTestEngine(newTestAuthenticator(t, provider, nil))
+
+	token, err := provider.AccessToken()
+	require.Nil(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/gphotos/v1/albums", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "provider-id alice editor", w.Body.String())
+
+	other, err := oidctest.New("gophoto", "secret")
+	require.Nil(t, err)
+	defer other.Close()
+
+	other.SetClaims(map[string]interface{}{"sub": "provider-id", "preferred_username": "alice"})
+
+	forged, err := other.AccessToken()
+	require.Nil(t, err)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/gphotos/v1/albums", nil)
+	req.Header.Set("Authorization", "Bearer "+forged)
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "the tokens of another issuer are rejected")
+}
+
+func TestOIDCRefreshAndLogout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	provider := newTestProvider(t)
+	a := newTestAuthenticator(t, provider, nil)
+
+	// get a token with the authorization code flow
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error { return http.ErrUseLastResponse },
+	}
+
+	resp, err := client.Get(a.provider.Config.AuthCodeURL("state"))
+	require.Nil(t, err)
+	resp.Body.Close()
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.Nil(t, err)
+
+	token, err := a.provider.Config.Exchange(context.Background(), callback.Query().Get("code"))
+	require.Nil(t, err)
+
+	session := entity.Session{Token: token, ExpireAt: time.Now().Add(time.Minute)}
+	require.Nil(t, a.authenticate(context.Background(), &session))
+	assert.Equal(t, token, session.Token, "the token is refreshed only when the session has expired")
+
+	session.ExpireAt = time.Now().Add(-time.Minute)
+	require.Nil(t, a.authenticate(context.Background(), &session))
+	assert.NotEqual(t, token.RefreshToken, session.Token.RefreshToken)
+	assert.True(t, session.ExpireAt.After(time.Now()))
+
+	expired := entity.Session{Token: token, ExpireAt: time.Now().Add(-time.Minute)}
+	assert.True(t, errors.Is(a.authenticate(context.Background(), &expired), errSessionExpired), "the refresh token has been used")
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/logout", nil)
+
+	require.Nil(t, a.Logout(c, "alice", session.Token.RefreshToken))
+	assert.Equal(t, []string{session.Token.RefreshToken}, provider.Revoked())
+}
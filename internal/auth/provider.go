@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"strings"
 
@@ -20,28 +19,29 @@ type oidcProvider struct {
 	Issuer   string
 }
 
-func newOidcProvider(conf conf.KeycloakConfig, authCallBack string) *oidcProvider {
-	issuer := fmt.Sprintf("%s/auth/realms/%s", conf.BaseURL, conf.Realm)
+// newOidcProvider discovers the endpoints of the provider. The openid scope is always requested.
+func newOidcProvider(issuer, clientID, clientSecret, authCallBack string, scopes []string) (*oidcProvider, error) {
+	ctx := oidc.ClientContext(context.Background(), &http.Client{Timeout: conf.GetHttpRequestTimeout()})
 
-	provider, err := oidc.NewProvider(context.Background(), issuer)
+	provider, err := oidc.NewProvider(ctx, issuer)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	// Configure an OpenID Connect aware OAuth2 client.
 	oauth2Config := oauth2.Config{
-		ClientID:     conf.ClientID,
-		ClientSecret: conf.ClientSecret,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
 		RedirectURL:  authCallBack,
 
 		// Discovery returns the OAuth2 endpoints.
 		Endpoint: provider.Endpoint(),
 
 		// "openid" is a required scope for OpenID Connect flows.
-		Scopes: []string{oidc.ScopeOpenID, "profile", "email", "roles"},
+		Scopes: append([]string{oidc.ScopeOpenID}, scopes...),
 	}
 
-	return &oidcProvider{provider, oauth2Config, issuer}
+	return &oidcProvider{provider, oauth2Config, issuer}, nil
 }
 
 func (p *oidcProvider) Verifier() *oidc.IDTokenVerifier {
@@ -53,6 +53,28 @@ func (p *oidcProvider) Verifier() *oidc.IDTokenVerifier {
 	return p.provider.Verifier(oidcConfig)
 }
 
+// JWKSURL returns the url of the keys signing the tokens of the provider.
+func (p *oidcProvider) JWKSURL() (string, error) {
+	var claims struct {
+		JWKSURL string `json:"jwks_uri"`
+	}
+
+	if err := p.discovery(&claims); err != nil {
+		return "", err
+	}
+
+	if claims.JWKSURL == "" {
+		return "", fmt.Errorf("no jwks_uri in the discovery document of '%s'", p.Issuer)
+	}
+
+	return claims.JWKSURL, nil
+}
+
+// discovery decodes the discovery document of the provider into v.
+func (p *oidcProvider) discovery(v interface{}) error {
+	return p.provider.Claims(v)
+}
+
 func (p *oidcProvider) GetLogoutURL() (string, error) {
 	var emptyString string
 
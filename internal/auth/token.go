@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services"
+	"github.com/tupyy/gophoto/internal/services/accesstoken"
+	"github.com/tupyy/gophoto/internal/services/users"
+	"go.uber.org/zap"
+)
+
+const (
+	// userCacheTTL - the owners of the tokens are looked up again after this delay, to follow the changes of their roles and groups.
+	userCacheTTL = time.Minute
+
+	apiPrefix = "/api/gphotos/v1"
+)
+
+// TokenAuthenticator returns the token of a secret.
+type TokenAuthenticator interface {
+	Authenticate(ctx context.Context, secret string) (entity.AccessToken, error)
+}
+
+// scopeRoutes are the routes which do not require the edit permission, by method and path.
+var scopeRoutes = map[string]entity.Permission{
+	http.MethodPost + " " + apiPrefix + "/albums/:album_id/photos":                                    entity.PermissionWriteAlbum,
+	http.MethodPost + " " + apiPrefix + "/albums/:album_id/photos/uploads":                            entity.PermissionWriteAlbum,
+	http.MethodPost + " " + apiPrefix + "/albums/:album_id/photos/uploads/:upload_id":                 entity.PermissionWriteAlbum,
+	http.MethodDelete + " " + apiPrefix + "/albums/:album_id":                                         entity.PermissionDeleteAlbum,
+	http.MethodPost + " " + apiPrefix + "/album/:album_id/photo/:photo_id/comments":                   entity.PermissionCommentAlbum,
+	http.MethodPut + " " + apiPrefix + "/album/:album_id/photo/:photo_id/comments/:comment_id":        entity.PermissionCommentAlbum,
+	http.MethodDelete + " " + apiPrefix + "/album/:album_id/photo/:photo_id/comments/:comment_id":     entity.PermissionCommentAlbum,
+	http.MethodPost + " " + apiPrefix + "/album/:album_id/photo/:photo_id/reactions":                  entity.PermissionCommentAlbum,
+	http.MethodDelete + " " + apiPrefix + "/album/:album_id/photo/:photo_id/reactions/:reaction_kind": entity.PermissionCommentAlbum,
+}
+
+// AccessTokenMiddleware authenticates the requests with a personal access token in the Authorization header.
+// The session of the owner of the token is set and the request is rejected if the scope of the token does not grant the
+// permission required by the route. The tokens cannot be used to manage the tokens.
+// The requests without access token are left to the next middlewares.
+func AccessTokenMiddleware(tokens TokenAuthenticator, usersService *users.Service) gin.HandlerFunc {
+	cache := newUserCache(usersService, userCacheTTL)
+
+	return func(c *gin.Context) {
+		secret, ok := bearerAccessToken(c.Request)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		token, err := tokens.Authenticate(c.Request.Context(), secret)
+		if err != nil {
+			if !errors.Is(err, services.ErrUnknownAccessToken) {
+				zap.S().Errorw("failed to authenticate access token", "error", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, mappersv1.MapFromStatus(http.StatusInternalServerError, "failed to authenticate access token"))
+				return
+			}
+
+			zap.S().Debugw("access token rejected", "error", err)
+			abortUnauthorized(c, "invalid access token")
+			return
+		}
+
+		if !scopeGrants(token.Scope, c.Request.Method, c.FullPath()) {
+			c.AbortWithStatusJSON(http.StatusForbidden, mappersv1.MapFromStatusf(http.StatusForbidden, "access token scope '%s' does not allow this request", token.Scope))
+			return
+		}
+
+		user, err := cache.get(c.Request.Context(), token.Owner)
+		if err != nil {
+			zap.S().Warnw("failed to find owner of access token", "error", err, "token_id", token.ID, "owner", token.Owner)
+			abortUnauthorized(c, "invalid access token")
+			return
+		}
+
+		c.Set("session", entity.Session{
+			User:      user,
+			SessionID: "token:" + token.ID,
+			IssueAt:   token.CreatedAt,
+			ExpireAt:  token.ExpiresAt,
+		})
+		c.Next()
+	}
+}
+
+// bearerAccessToken returns the access token of the Authorization header. The other bearer tokens are ignored.
+func bearerAccessToken(r *http.Request) (string, bool) {
+	secret, ok := bearerToken(r)
+
+	return secret, ok && strings.HasPrefix(secret, accesstoken.Prefix)
+}
+
+// scopeGrants returns true if the scope allows the request. The reads require the read permission and
+// the other requests the permission of their route, the edit permission by default.
+func scopeGrants(scope entity.TokenScope, method, path string) bool {
+	if path == "" || strings.HasPrefix(path, apiPrefix+"/tokens") {
+		return false
+	}
+
+	if method == http.MethodGet || method == http.MethodHead {
+		return scope.Grants(entity.PermissionReadAlbum)
+	}
+
+	if p, ok := scopeRoutes[method+" "+path]; ok {
+		return scope.Grants(p)
+	}
+
+	return scope.Grants(entity.PermissionEditAlbum)
+}
+
+type cachedUser struct {
+	user     entity.User
+	expireAt time.Time
+}
+
+// userCache keeps the owners of the tokens to not query the user directory on each request.
+type userCache struct {
+	lock    sync.Mutex
+	users   map[string]cachedUser
+	service *users.Service
+	ttl     time.Duration
+}
+
+func newUserCache(service *users.Service, ttl time.Duration) *userCache {
+	return &userCache{users: make(map[string]cachedUser), service: service, ttl: ttl}
+}
+
+func (u *userCache) get(ctx context.Context, username string) (entity.User, error) {
+	u.lock.Lock()
+	cached, ok := u.users[username]
+	u.lock.Unlock()
+
+	if ok && time.Now().Before(cached.expireAt) {
+		return cached.user, nil
+	}
+
+	found, err := u.service.Query().Where(users.Username(username)).All(ctx)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	if len(found) != 1 {
+		return entity.User{}, errors.New("user not found")
+	}
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.users[username] = cachedUser{user: found[0], expireAt: time.Now().Add(u.ttl)}
+
+	return found[0], nil
+}
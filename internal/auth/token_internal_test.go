@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/entity"
+	userFilters "github.com/tupyy/gophoto/internal/repos/filters/user"
+	"github.com/tupyy/gophoto/internal/services"
+	"github.com/tupyy/gophoto/internal/services/users"
+)
+
+type fakeTokens map[string]entity.AccessToken
+
+func (f fakeTokens) Authenticate(ctx context.Context, secret string) (entity.AccessToken, error) {
+	t, ok := f[secret]
+	if !ok {
+		return entity.AccessToken{}, services.ErrUnknownAccessToken
+	}
+
+	return t, nil
+}
+
+type fakeDirectory []entity.User
+
+func (f fakeDirectory) GetUsers(ctx context.Context, filters userFilters.Filters) ([]entity.User, error) {
+	found := []entity.User{}
+	for _, u := range f {
+		match := true
+		for _, filter := range filters {
+			match = match && filter(u)
+		}
+
+		if match {
+			found = append(found, u)
+		}
+	}
+
+	return found, nil
+}
+
+func (f fakeDirectory) GetUserByID(ctx context.Context, id string) (entity.User, error) {
+	return entity.User{}, nil
+}
+
+func (f fakeDirectory) GetGroups(ctx context.Context) ([]entity.Group, error) {
+	return []entity.Group{}, nil
+}
+
+func TestAccessTokenMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tokens := fakeTokens{
+		"gpat_read":   {ID: "1", Owner: "alice", Scope: entity.ScopeReadOnly, ExpiresAt: time.Now().Add(time.Hour)},
+		"gpat_upload": {ID: "2", Owner: "alice", Scope: entity.ScopeUpload, ExpiresAt: time.Now().Add(time.Hour)},
+		"gpat_admin":  {ID: "3", Owner: "alice", Scope: entity.ScopeAdmin, ExpiresAt: time.Now().Add(time.Hour)},
+		"gpat_orphan": {ID: "4", Owner: "bob", Scope: entity.ScopeAdmin, ExpiresAt: time.Now().Add(time.Hour)},
+	}
+
+	usersService := users.New(fakeDirectory{{ID: "a", Username: "alice"}}, nil)
+
+	engine := gin.New()
+	engine.Use(AccessTokenMiddleware(tokens, usersService))
+	engine.Use(func(c *gin.Context) {
+		if _, ok := c.Get("session"); !ok {
+			c.AbortWithStatus(http.StatusTeapot)
+		}
+	})
+
+	handler := func(c *gin.Context) {
+		session := c.MustGet("session").(entity.Session)
+		c.String(http.StatusOK, session.User.ID)
+	}
+
+	engine.GET("/api/gphotos/v1/albums", handler)
+	engine.POST("/api/gphotos/v1/albums/:album_id/photos", handler)
+	engine.DELETE("/api/gphotos/v1/albums/:album_id", handler)
+	engine.PATCH("/api/gphotos/v1/albums/:album_id", handler)
+	engine.GET("/api/gphotos/v1/tokens", handler)
+
+	tests := []struct {
+		method        string
+		path          string
+		authorization string
+		code          int
+	}{
+		{http.MethodGet, "/api/gphotos/v1/albums", "", http.StatusTeapot},
+		{http.MethodGet, "/api/gphotos/v1/albums", "Bearer eyJhbGciOiJSUzI1NiJ9", http.StatusTeapot},
+		{http.MethodGet, "/api/gphotos/v1/albums", "Bearer gpat_unknown", http.StatusUnauthorized},
+		{http.MethodGet, "/api/gphotos/v1/albums", "Bearer gpat_orphan", http.StatusUnauthorized},
+		{http.MethodGet, "/api/gphotos/v1/albums", "Bearer gpat_read", http.StatusOK},
+		{http.MethodGet, "/api/gphotos/v1/albums", "bearer gpat_read", http.StatusOK},
+		{http.MethodPost, "/api/gphotos/v1/albums/x/photos", "Bearer gpat_read", http.StatusForbidden},
+		{http.MethodPost, "/api/gphotos/v1/albums/x/photos", "Bearer gpat_upload", http.StatusOK},
+		{http.MethodPatch, "/api/gphotos/v1/albums/x", "Bearer gpat_upload", http.StatusForbidden},
+		{http.MethodDelete, "/api/gphotos/v1/albums/x", "Bearer gpat_upload", http.StatusForbidden},
+		{http.MethodPatch, "/api/gphotos/v1/albums/x", "Bearer gpat_admin", http.StatusOK},
+		{http.MethodDelete, "/api/gphotos/v1/albums/x", "Bearer gpat_admin", http.StatusOK},
+		{http.MethodGet, "/api/gphotos/v1/tokens", "Bearer gpat_admin", http.StatusForbidden},
+		{http.MethodGet, "/api/gphotos/v1/unknown", "Bearer gpat_admin", http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, nil)
+		if test.authorization != "" {
+			req.Header.Set("Authorization", test.authorization)
+		}
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, test.code, w.Code, "%s %s %s", test.method, test.path, test.authorization)
+		if w.Code == http.StatusOK {
+			assert.Equal(t, "a", w.Body.String())
+		}
+	}
+}
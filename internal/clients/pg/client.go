@@ -50,6 +50,12 @@ func (cp ClientParams) String() string {
 	return fmt.Sprintf("Host:%s, Port:%d, User:%s, Has Password:%v, DBName:%s", cp.Host, cp.Port, cp.User, len(cp.Password) > 0, cp.DBName)
 }
 
+// DSN returns the connection string built from params.
+func (cp ClientParams) DSN() string {
+	return fmt.Sprintf("host=%v port=%v user=%v password=%v dbname=%v",
+		cp.Host, cp.Port, cp.User, cp.Password, cp.DBName)
+}
+
 type CircuitBreaker interface {
 	IsAvailable() bool
 	Break()
@@ -57,10 +63,7 @@ type CircuitBreaker interface {
 }
 
 func New(params ClientParams) (Client, error) {
-	dsn := fmt.Sprintf("host=%v port=%v user=%v password=%v dbname=%v",
-		params.Host, params.Port, params.User, params.Password, params.DBName)
-
-	db, err := sql.Open("pgx", dsn)
+	db, err := sql.Open("pgx", params.DSN())
 	if err != nil {
 		return Client{}, errors.Wrapf(err, "could not create postgres sql driver: %v:%v ", params.Host, params.Port)
 	}
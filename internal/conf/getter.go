@@ -34,6 +34,8 @@ type KeycloakConfig struct {
 	Realm         string `json:"realm" yaml:"realm"`
 	AdminUsername string `json:"admin_username" yaml:"admin_username"`
 	AdminPwd      string `json:"admin_password" yaml:"admin_password"`
+	// Audience - if set, the bearer tokens must have this audience. Else they must be issued to the client (azp or aud).
+	Audience string `json:"audience" yaml:"audience"`
 }
 
 func (k KeycloakConfig) String() string {
@@ -45,11 +47,17 @@ func (k KeycloakConfig) String() string {
 		AdminUsername: k.AdminUsername,
 		ClientSecret:  shadePassword(k.ClientSecret),
 		AdminPwd:      shadePassword(k.AdminPwd),
+		Audience:      k.Audience,
 	}
 	j, _ := json.Marshal(kk)
 	return string(j)
 }
 
+// Issuer returns the issuer of the tokens of the realm.
+func (k KeycloakConfig) Issuer() string {
+	return fmt.Sprintf("%s/auth/realms/%s", k.BaseURL, k.Realm)
+}
+
 type MinioConfig struct {
 	Url             string `json:"url" yaml:"url"`
 	AccessID        string `json:"access_id" yaml:"access_id"`
@@ -87,6 +95,137 @@ func (p PostgresConfig) String() string {
 	return string(j)
 }
 
+// GeocodingConfig holds the paths to the GeoNames datasets used for reverse geocoding: the cities (e.g. cities15000.txt),
+// the first level administrative divisions (admin1CodesASCII.txt) and the countries (countryInfo.txt).
+// The sample bundled with the binary is used if none is set.
+type GeocodingConfig struct {
+	Cities    string `json:"cities" yaml:"cities"`
+	Regions   string `json:"regions" yaml:"regions"`
+	Countries string `json:"countries" yaml:"countries"`
+}
+
+const (
+	// StorageMinio stores the media in MinIO. It is the default backend.
+	StorageMinio = "minio"
+	// StorageLocal stores the media on the local filesystem.
+	StorageLocal = "local"
+)
+
+// StorageConfig selects where the media are stored.
+type StorageConfig struct {
+	// Backend - minio or local. MinIO is used if not set.
+	Backend string `json:"backend" yaml:"backend"`
+	// Path - root directory of the local backend
+	Path string `json:"path" yaml:"path"`
+	// Layout - bucket or shared. With the shared layout, new albums are stored under albums/<album_id>/ in one bucket.
+	// Albums already stored in their own bucket are still read until they are migrated. bucket is used if not set.
+	Layout string `json:"layout" yaml:"layout"`
+	// Bucket - name of the shared bucket. It is needed by the shared layout and by the migration.
+	Bucket string `json:"bucket" yaml:"bucket"`
+	// PresignedURLs - let the clients upload and download the photos directly from MinIO with pre-signed urls.
+	// The url of MinIO must be reachable by the clients.
+	PresignedURLs bool `json:"presigned_urls" yaml:"presigned_urls"`
+	// PresignExpiry - validity of the pre-signed urls in seconds. Default to 15 minutes.
+	PresignExpiry int `json:"presign_expiry" yaml:"presign_expiry"`
+	// PresignMaxSize - size limit in bytes of the photos uploaded with pre-signed urls. Default to 100 MiB.
+	PresignMaxSize int64 `json:"presign_max_size" yaml:"presign_max_size"`
+}
+
+// QuotaConfig holds the storage quotas in bytes. The quota of a user is the one set for the username, else the largest
+// quota of the user's groups, else the quota of the user's role. Negative quotas are unlimited and so are the users without quota.
+type QuotaConfig struct {
+	Users  map[string]int64 `json:"users" yaml:"users"`
+	Groups map[string]int64 `json:"groups" yaml:"groups"`
+	Roles  map[string]int64 `json:"roles" yaml:"roles"`
+}
+
+// KeyringConfig holds master keys, base64 encoded and mapped by id, or the key file holding them. The active key encrypts,
+// all the keys decrypt. A key is rotated by adding a new key and making it active, then removing the old key once the data
+// it encrypted is not needed anymore.
+type KeyringConfig struct {
+	// ActiveKey - id of the key used to encrypt
+	ActiveKey string `json:"active_key" yaml:"active_key"`
+	// MasterKeys - base64 encoded 32 bytes keys mapped by id
+	MasterKeys map[string]string `json:"master_keys" yaml:"master_keys"`
+	// KeyFile - json file like {"active_key": "id", "keys": {"id": "<base64 key>"}}. It is used instead of the keys above if set.
+	KeyFile string `json:"key_file" yaml:"key_file"`
+}
+
+// Enabled returns true if a master key is configured.
+func (k KeyringConfig) Enabled() bool {
+	return len(k.KeyFile) > 0 || len(k.MasterKeys) > 0
+}
+
+// String hides the keys.
+func (k KeyringConfig) String() string {
+	j, _ := json.Marshal(k.shaded())
+	return string(j)
+}
+
+func (k KeyringConfig) shaded() KeyringConfig {
+	kk := KeyringConfig{ActiveKey: k.ActiveKey, KeyFile: k.KeyFile}
+	if len(k.MasterKeys) > 0 {
+		kk.MasterKeys = make(map[string]string, len(k.MasterKeys))
+		for id := range k.MasterKeys {
+			kk.MasterKeys[id] = shadePassword("")
+		}
+	}
+	return kk
+}
+
+const (
+	// ProviderKeycloak - users log in with keycloak. It is the default provider.
+	ProviderKeycloak = "keycloak"
+	// ProviderOIDC - users log in with any OpenID Connect provider.
+	ProviderOIDC = "oidc"
+
+	// DirectoryKeycloak - users and groups are read from the keycloak admin api.
+	DirectoryKeycloak = "keycloak"
+	// DirectoryOIDC - users are recorded in postgres from the claims of their tokens when they log in.
+	DirectoryOIDC = "oidc"
+	// DirectoryPostgres - users are managed in postgres with the users command. The role and groups of the tokens are ignored.
+	DirectoryPostgres = "postgres"
+)
+
+// AuthConfig selects the identity provider and the directory of the users.
+type AuthConfig struct {
+	// Provider - keycloak or oidc. keycloak is used if not set.
+	Provider string `json:"provider" yaml:"provider"`
+	// Directory - keycloak, oidc or postgres. It defaults to the provider.
+	Directory string `json:"directory" yaml:"directory"`
+	// OIDC - the generic provider. It is needed by the oidc provider.
+	OIDC OIDCConfig `json:"oidc" yaml:"oidc"`
+}
+
+// OIDCConfig configures a generic OpenID Connect provider. The claims are looked up by name or by a dotted path
+// like realm_access.roles.
+type OIDCConfig struct {
+	// Issuer - url of the provider. The endpoints are discovered from it.
+	Issuer       string `json:"issuer" yaml:"issuer"`
+	ClientID     string `json:"client_id" yaml:"client_id"`
+	ClientSecret string `json:"client_secret" yaml:"client_secret"`
+	// Audience - if set, the bearer tokens must have this audience. Else they must be issued to the client (azp or aud).
+	Audience string `json:"audience" yaml:"audience"`
+	// Scopes - requested in addition to openid. Default to profile and email.
+	Scopes []string `json:"scopes" yaml:"scopes"`
+	// UsernameClaim - default to preferred_username.
+	UsernameClaim string `json:"username_claim" yaml:"username_claim"`
+	// GroupsClaim - default to groups.
+	GroupsClaim string `json:"groups_claim" yaml:"groups_claim"`
+	// RoleClaim - string or array holding admin, editor or user. The highest role is kept. Default to role.
+	RoleClaim string `json:"role_claim" yaml:"role_claim"`
+	// CanShareClaim - default to can_share.
+	CanShareClaim string `json:"can_share_claim" yaml:"can_share_claim"`
+}
+
+// String hides the client secret.
+func (o OIDCConfig) String() string {
+	oo := o
+	oo.ClientSecret = shadePassword(o.ClientSecret)
+	j, _ := json.Marshal(oo)
+	return string(j)
+}
+
 type Configuration struct {
 	LogLevel        string `json:"log_level" yaml:"log_level"`
 	AuthCallbackURL string `json:"auth_callback_url" yaml:"auth_callback_url"`
@@ -94,9 +233,21 @@ type Configuration struct {
 	EncryptionKey   string `json:"encryption_key" yaml:"encryption_key"`
 	NoAuth          bool   `json:"no_auth" yaml:"no_auth"`
 
+	Auth     AuthConfig     `json:"auth" yaml:"auth"`
 	Keycloak KeycloakConfig `json:"keycloak" yaml:"keycloak"`
 	Minio    MinioConfig    `json:"minio" yaml:"minio"`
 	Postgres PostgresConfig `json:"postgres" yaml:"postgres"`
+
+	Geocoding GeocodingConfig `json:"geocoding" yaml:"geocoding"`
+	Storage   StorageConfig   `json:"storage" yaml:"storage"`
+	Quotas    QuotaConfig     `json:"quotas" yaml:"quotas"`
+
+	// AlbumEncryption - keys wrapping the data keys of the encrypted albums. The encrypted albums cannot be created nor
+	// read without them.
+	AlbumEncryption KeyringConfig `json:"album_encryption" yaml:"album_encryption"`
+	// IDKeys - keys of the opaque ids of the resources in the urls. The ids issued with a key are resolved until the key
+	// is removed. A key is derived from the encryption key if none is set.
+	IDKeys KeyringConfig `json:"id_keys" yaml:"id_keys"`
 }
 
 func (c Configuration) String() string {
@@ -126,8 +277,16 @@ func (c Configuration) String() string {
 			AdminUsername: c.Keycloak.AdminUsername,
 			ClientSecret:  shadePassword(c.Keycloak.ClientSecret),
 			AdminPwd:      shadePassword(c.Keycloak.AdminPwd),
+			Audience:      c.Keycloak.Audience,
 		},
+		Geocoding: c.Geocoding,
+		Storage:   c.Storage,
+		Quotas:    c.Quotas,
 	}
+	cc.AlbumEncryption = c.AlbumEncryption.shaded()
+	cc.IDKeys = c.IDKeys.shaded()
+	cc.Auth = c.Auth
+	cc.Auth.OIDC.ClientSecret = shadePassword(c.Auth.OIDC.ClientSecret)
 	j, _ := json.Marshal(cc)
 	return string(j)
 }
@@ -195,10 +354,91 @@ func GetKeycloakConfig() KeycloakConfig {
 	return configuration.Keycloak
 }
 
+// GetAuthIssuer returns the issuer of the tokens of the configured provider.
+func GetAuthIssuer() string {
+	if GetAuthConfig().Provider == ProviderOIDC {
+		return configuration.Auth.OIDC.Issuer
+	}
+
+	return configuration.Keycloak.Issuer()
+}
+
+// GetAuthConfig returns the auth configuration with the defaults set.
+func GetAuthConfig() AuthConfig {
+	c := configuration.Auth
+	if len(c.Provider) == 0 {
+		c.Provider = ProviderKeycloak
+	}
+
+	if len(c.Directory) == 0 {
+		c.Directory = c.Provider
+	}
+
+	o := &c.OIDC
+	if len(o.Scopes) == 0 {
+		o.Scopes = []string{"profile", "email"}
+	}
+
+	if len(o.UsernameClaim) == 0 {
+		o.UsernameClaim = "preferred_username"
+	}
+
+	if len(o.GroupsClaim) == 0 {
+		o.GroupsClaim = "groups"
+	}
+
+	if len(o.RoleClaim) == 0 {
+		o.RoleClaim = "role"
+	}
+
+	if len(o.CanShareClaim) == 0 {
+		o.CanShareClaim = "can_share"
+	}
+
+	return c
+}
+
 func GetMinioConfig() MinioConfig {
 	return configuration.Minio
 }
 
+func GetGeocodingConfig() GeocodingConfig {
+	return configuration.Geocoding
+}
+
+func GetStorageConfig() StorageConfig {
+	c := configuration.Storage
+	if len(c.Backend) == 0 {
+		c.Backend = StorageMinio
+	}
+
+	if len(c.Layout) == 0 {
+		c.Layout = "bucket"
+	}
+
+	if c.PresignExpiry <= 0 {
+		c.PresignExpiry = 15 * 60
+	}
+
+	if c.PresignMaxSize <= 0 {
+		c.PresignMaxSize = 100 << 20
+	}
+
+	return c
+}
+
+func GetQuotaConfig() QuotaConfig {
+	return configuration.Quotas
+}
+
+func GetAlbumEncryptionConfig() KeyringConfig {
+	return configuration.AlbumEncryption
+}
+
+func GetIDKeysConfig() KeyringConfig {
+	return configuration.IDKeys
+}
+
 func GetPostgresConf() postgres.ClientParams {
 	ret := postgres.ClientParams{
 		Host:     configuration.Postgres.Host,
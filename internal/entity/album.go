@@ -19,10 +19,14 @@ type Album struct {
 	Description string
 	// Location - location of the album
 	Location string
+	// Place - names of the place where most of the photos have been taken
+	Place Place
 	// Bucket - name of bucket in the store
 	Bucket string
 	// Thumbnail - name of image set as cover for album on index page.
 	Thumbnail string
+	// Encrypted - the media of the album are encrypted at rest with the album's data key
+	Encrypted bool
 	// UserPermissions - holds the list of permissions of other users for this album.
 	// The key is the user id.
 	UserPermissions []AlbumPermission
@@ -47,6 +51,7 @@ func (a Album) String() string {
 	fmt.Fprintf(&sb, "location = %s ", a.Location)
 	fmt.Fprintf(&sb, "bucket = %s ", a.Bucket)
 	fmt.Fprintf(&sb, "thumbnail = %s ", a.Thumbnail)
+	fmt.Fprintf(&sb, "encrypted = %t ", a.Encrypted)
 	fmt.Fprintf(&sb, "number of photos = %d ", len(a.Photos))
 	fmt.Fprintf(&sb, "number of videos = %d ", len(a.Videos))
 
@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+type Comment struct {
+	// ID - id of the comment
+	ID string
+	// AlbumID - id of the album
+	AlbumID string
+	// Filename - name of the commented media in the album's bucket
+	Filename string
+	// Author - author's username
+	Author string
+	// Content - text of the comment
+	Content string
+	// CreatedAt - creation date
+	CreatedAt time.Time
+	// UpdatedAt - date of the last edit. Nil if the comment has never been edited.
+	UpdatedAt *time.Time
+}
+
+// CommentRevision is a previous version of a comment.
+type CommentRevision struct {
+	// CommentID - id of the comment
+	CommentID string
+	// Content - text of the comment before the edit
+	Content string
+	// CreatedAt - date when this version has been replaced
+	CreatedAt time.Time
+}
+
+type Reaction struct {
+	// AlbumID - id of the album
+	AlbumID string
+	// Filename - name of the media in the album's bucket
+	Filename string
+	// User - username of the user who reacted
+	User string
+	// Kind - kind of reaction (e.g. like)
+	Kind string
+	// CreatedAt - creation date
+	CreatedAt time.Time
+}
@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+type EventKind string
+
+const (
+	// EventPhotoAdded is published when a photo has been uploaded to an album.
+	EventPhotoAdded EventKind = "photo.added"
+	// EventPhotoRemoved is published when a photo has been removed from an album.
+	EventPhotoRemoved EventKind = "photo.removed"
+	// EventThumbnailCreated is published when the thumbnail of a photo is ready.
+	EventThumbnailCreated EventKind = "thumbnail.created"
+	// EventAlbumCreated is published when a new album has been created.
+	EventAlbumCreated EventKind = "album.created"
+	// EventAlbumUpdated is published when the album's metadata or permissions changed.
+	EventAlbumUpdated EventKind = "album.updated"
+	// EventAlbumDeleted is published when an album has been deleted.
+	EventAlbumDeleted EventKind = "album.deleted"
+)
+
+type Event struct {
+	// Kind - kind of the event
+	Kind EventKind
+	// AlbumID - id of the album. It may be empty for media events.
+	AlbumID string
+	// Bucket - bucket of the album
+	Bucket string
+	// Filename - name of the media. Empty for album events.
+	Filename string
+	// CreatedAt - time when the event has been published
+	CreatedAt time.Time
+}
+
+// IsAlbumEvent returns true if the event concerns the album itself and not its media.
+func (e Event) IsAlbumEvent() bool {
+	switch e.Kind {
+	case EventAlbumCreated, EventAlbumUpdated, EventAlbumDeleted:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GeoPoint is a position in decimal degrees.
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func (p GeoPoint) String() string {
+	return fmt.Sprintf("%.6f,%.6f", p.Latitude, p.Longitude)
+}
+
+// Distance returns the great-circle distance in kilometers between the two points.
+func (p GeoPoint) Distance(q GeoPoint) float64 {
+	const earthRadius = 6371.0
+
+	lat1, lat2 := p.Latitude*math.Pi/180, q.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLong := (q.Longitude - p.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLong/2)*math.Sin(dLong/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// BoundingBox is an area delimited by two parallels and two meridians.
+// West is greater than East if the box crosses the antimeridian.
+type BoundingBox struct {
+	South float64
+	West  float64
+	North float64
+	East  float64
+}
+
+// World is the bounding box of the whole world.
+var World = BoundingBox{South: -90, West: -180, North: 90, East: 180}
+
+// Contains returns true if the point is inside the box.
+func (b BoundingBox) Contains(p GeoPoint) bool {
+	if p.Latitude < b.South || p.Latitude > b.North {
+		return false
+	}
+
+	if b.West <= b.East {
+		return p.Longitude >= b.West && p.Longitude <= b.East
+	}
+
+	return p.Longitude >= b.West || p.Longitude <= b.East
+}
+
+// Center returns the center of the box.
+func (b BoundingBox) Center() GeoPoint {
+	east := b.East
+	if b.West > b.East {
+		east += 360
+	}
+
+	lon := (b.West + east) / 2
+	if lon > 180 {
+		lon -= 360
+	}
+
+	return GeoPoint{Latitude: (b.South + b.North) / 2, Longitude: lon}
+}
+
+// Valid returns true if the coordinates of the box are within the world.
+func (b BoundingBox) Valid() bool {
+	return b.South >= -90 && b.North <= 90 && b.South <= b.North &&
+		b.West >= -180 && b.West <= 180 && b.East >= -180 && b.East <= 180
+}
+
+// Place holds the names of the place where a media has been captured.
+type Place struct {
+	City    string
+	Region  string
+	Country string
+}
+
+// IsZero returns true if the place has no name.
+func (p Place) IsZero() bool {
+	return p == Place{}
+}
+
+func (p Place) String() string {
+	names := make([]string, 0, 3)
+	for _, n := range []string{p.City, p.Region, p.Country} {
+		if len(n) > 0 {
+			names = append(names, n)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -17,4 +17,22 @@ type Media struct {
 	Thumbnail  string
 	Metadata   map[string]string
 	CreateDate time.Time
+	// UploadDate - date when the media has been uploaded to the store
+	UploadDate time.Time
+	// Size - size in bytes of the media
+	Size int64
+	// Caption - short caption set by users
+	Caption string
+	// Description - description set by users
+	Description string
+	// Favorite - true if the media is a favorite of the user who requested it
+	Favorite bool
+	// Tags - tags attached to the media
+	Tags []Tag
+	// Location - where the media has been captured. Nil if the media has no GPS data.
+	Location *GeoPoint
+	// Place - names of the place found from the location
+	Place Place
+	// Hash - perceptual hash of the photo. Nil if the photo has been uploaded before hashes were computed.
+	Hash *uint64
 }
@@ -15,6 +15,8 @@ const (
 	PermissionEditAlbum
 	// PermissionDeleteAlbum gives the user the right to delete the album.
 	PermissionDeleteAlbum
+	// PermissionCommentAlbum gives the user the right to comment and react on the album's photos.
+	PermissionCommentAlbum
 	// Permission unknown
 	PermissionUnknown
 )
@@ -31,6 +33,8 @@ func (p Permission) String() string {
 		return "album.edit"
 	case PermissionDeleteAlbum:
 		return "album.delete"
+	case PermissionCommentAlbum:
+		return "album.comment"
 	}
 
 	return "unknown"
@@ -46,6 +50,8 @@ func NewPermission(perm string) (Permission, error) {
 		return PermissionEditAlbum, nil
 	case "album.delete":
 		return PermissionDeleteAlbum, nil
+	case "album.comment":
+		return PermissionCommentAlbum, nil
 	default:
 		return PermissionUnknown, ErrInvalidPermission
 	}
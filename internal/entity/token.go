@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// TokenScope limits what can be done with a personal access token to some of the album permissions of its owner.
+type TokenScope string
+
+const (
+	// ScopeReadOnly grants the read permission.
+	ScopeReadOnly TokenScope = "read-only"
+	// ScopeUpload grants the read and write permissions, to upload photos.
+	ScopeUpload TokenScope = "upload"
+	// ScopeAdmin grants all the permissions.
+	ScopeAdmin TokenScope = "admin"
+)
+
+var ErrInvalidScope = errors.New("invalid scope")
+
+func NewTokenScope(scope string) (TokenScope, error) {
+	switch s := TokenScope(scope); s {
+	case ScopeReadOnly, ScopeUpload, ScopeAdmin:
+		return s, nil
+	default:
+		return "", ErrInvalidScope
+	}
+}
+
+// Grants returns true if the scope grants the album permission. The owner of the token must have the permission too.
+func (s TokenScope) Grants(p Permission) bool {
+	switch s {
+	case ScopeReadOnly:
+		return p == PermissionReadAlbum
+	case ScopeUpload:
+		return p == PermissionReadAlbum || p == PermissionWriteAlbum
+	case ScopeAdmin:
+		return p != PermissionUnknown
+	default:
+		return false
+	}
+}
+
+func (s TokenScope) String() string {
+	return string(s)
+}
+
+// AccessToken is a personal access token to call the api on behalf of its owner, for scripts and devices.
+// The token itself is only known when it is created.
+type AccessToken struct {
+	// ID - id of the token
+	ID string
+	// Owner - owner's username
+	Owner string
+	// Name - what the token is used for
+	Name string
+	// Scope - permissions granted by the token
+	Scope TokenScope
+	// CreatedAt - creation date
+	CreatedAt time.Time
+	// ExpiresAt - the token is rejected from this date
+	ExpiresAt time.Time
+	// LastUsedAt - last time the token has been accepted. Zero if never used.
+	LastUsedAt time.Time
+}
+
+// Expired returns true if the token is expired at the date.
+func (t AccessToken) Expired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -167,6 +167,12 @@ func resolveCommonField(expr *binaryExpr, album entity.Album) (bool, error) {
 		varValue = album.Location
 	case "owner":
 		varValue = album.Owner
+	case "city":
+		varValue = album.Place.City
+	case "region":
+		varValue = album.Place.Region
+	case "country":
+		varValue = album.Place.Country
 	default:
 		return false, fmt.Errorf("%w unknown field %s", FieldNotFoundError, variable.Name)
 	}
@@ -153,6 +153,31 @@ func TestFilterEngine(t *testing.T) {
 			}},
 			expected: false,
 		},
+		{
+			expr:     `country = "France"`,
+			album:    entity.Album{Name: "toto", Place: entity.Place{City: "Paris", Region: "Île-de-France", Country: "France"}},
+			expected: true,
+		},
+		{
+			expr:     "country = 'France' and city != 'Paris'",
+			album:    entity.Album{Name: "toto", Place: entity.Place{City: "Paris", Region: "Île-de-France", Country: "France"}},
+			expected: false,
+		},
+		{
+			expr:     "region in ['Brittany','Normandy']",
+			album:    entity.Album{Name: "toto", Place: entity.Place{City: "Brest", Region: "Brittany", Country: "France"}},
+			expected: true,
+		},
+		{
+			expr:     "city like 'Mon.*'",
+			album:    entity.Album{Name: "toto", Place: entity.Place{City: "Montpellier", Region: "Occitanie", Country: "France"}},
+			expected: true,
+		},
+		{
+			expr:     "country = 'France'",
+			album:    entity.Album{Name: "toto"},
+			expected: false,
+		},
 	}
 
 	for _, d := range data {
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"errors"
 	"fmt"
 	"html"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"github.com/tupyy/gophoto/internal/entity"
 	"github.com/tupyy/gophoto/internal/filter"
 	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services"
 	"github.com/tupyy/gophoto/internal/services/album"
 	"github.com/tupyy/gophoto/internal/services/permissions"
 	"go.uber.org/zap"
@@ -275,8 +277,17 @@ func (server *Server) CreateAlbum(c *gin.Context) {
 		Location:    escapeFieldPtr(payload.Location),
 		Owner:       session.User.Username,
 	}
+	if payload.Encrypted != nil {
+		album.Encrypted = *payload.Encrypted
+	}
+
 	albumID, err := server.AlbumService().Create(c, album)
 	if err != nil {
+		if errors.Is(err, services.ErrEncryptionDisabled) {
+			c.AbortWithStatusJSON(http.StatusNotImplemented, mappersv1.MapFromStatus(http.StatusNotImplemented, err.Error()))
+			return
+		}
+
 		apiErr := mappersv1.MapFromError(err)
 		c.AbortWithStatusJSON(apiErr.Code, apiErr)
 		return
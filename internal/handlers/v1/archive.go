@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services/archive"
+	"github.com/tupyy/gophoto/internal/services/permissions"
+	"go.uber.org/zap"
+)
+
+// (GET /api/gphotos/v1/albums/{album_id}/archive)
+func (server *Server) GetAlbumArchive(c *gin.Context, albumId apiv1.AlbumId, params apiv1.GetAlbumArchiveParams) {
+	session := c.MustGet("session").(entity.Session)
+
+	id, err := server.EncryptionService().Decrypt(albumId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt album id", "error", err, "album_id", albumId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return
+	}
+
+	album, err := server.AlbumService().Query().First(c, id)
+	if err != nil {
+		zap.S().Errorw("failed to get album", "error", err, "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return
+	}
+
+	apr := permissions.NewAlbumPermissionService()
+	hasPermission := apr.Policy(permissions.OwnerPolicy{}).
+		Policy(permissions.RolePolicy{Role: entity.RoleAdmin}).
+		Policy(permissions.AnyUserPermissionPolicty{}).
+		Policy(permissions.AnyGroupPermissionPolicy{}).
+		Strategy(permissions.AtLeastOneStrategy).
+		Resolve(album, session.User)
+
+	if !hasPermission {
+		zap.S().Errorw("user has no read permissions on the album", "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusForbidden, mappersv1.MapFromStatus(http.StatusForbidden, "access denied"))
+		return
+	}
+
+	photos := []entity.Media{}
+	if params.PhotoIds != nil && len(*params.PhotoIds) > 0 {
+		seen := make(map[string]struct{})
+		for _, photoId := range *params.PhotoIds {
+			pID, err := server.EncryptionService().Decrypt(photoId)
+			if err != nil {
+				zap.S().Errorw("failed to decrypt photo id", "error", err, "photo_id", photoId, "user", session.User.Username)
+				c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "photo with id '%s' not found", photoId))
+				return
+			}
+
+			photo, found := findPhoto(album, pID)
+			if !found || photo.MediaType != entity.Photo {
+				zap.S().Errorw("photo not found", "album_id", id, "photo_id", pID, "user", session.User.Username)
+				c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "photo with id '%s' not found", photoId))
+				return
+			}
+
+			if _, found := seen[photo.Filename]; found {
+				continue
+			}
+			seen[photo.Filename] = struct{}{}
+
+			photos = append(photos, photo)
+		}
+	} else {
+		for _, photo := range album.Photos {
+			if photo.MediaType == entity.Photo {
+				photos = append(photos, photo)
+			}
+		}
+	}
+
+	var manifest *archive.Manifest
+	if params.Manifest != nil && *params.Manifest {
+		photos, err = server.MediaService().WithMetadata(c, album.ID, session.User.Username, photos)
+		if err != nil {
+			zap.S().Errorw("failed to get photos metadata", "error", err, "album_id", id, "user", session.User.Username)
+			apiErr := mappersv1.MapFromError(err)
+			c.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		m := archive.NewManifest(album, photos)
+		manifest = &m
+	}
+
+	open := func(ctx context.Context, bucket, filename string) (io.Reader, error) {
+		r, _, err := server.MediaService().GetPhoto(ctx, bucket, filename)
+		return r, err
+	}
+
+	c.Header("Content-Type", "application/zip")
+	// buckets of the shared layout are named albums/<album_id>
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.zip\"", strings.ReplaceAll(album.Bucket, "/", "-")))
+	c.Status(http.StatusOK)
+
+	// the archive is streamed so the status cannot be changed anymore. The client gets a truncated archive.
+	if err := archive.Write(c, c.Writer, photos, open, manifest); err != nil {
+		zap.S().Errorw("failed to write archive", "error", err, "album_id", id, "user", session.User.Username)
+		c.Abort()
+		return
+	}
+
+	zap.S().Infow("album downloaded", "album_id", id, "count", len(photos), "user", session.User.Username)
+}
@@ -0,0 +1,275 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services"
+	"go.uber.org/zap"
+)
+
+// (GET /api/gphotos/v1/album/{album_id}/photo/{photo_id}/comments)
+func (server *Server) GetPhotoComments(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionReadAlbum)
+	if !ok {
+		return
+	}
+
+	comments, err := server.CommentService().Get(c, album.ID, photo.Filename)
+	if err != nil {
+		zap.S().Errorw("failed to get comments", "error", err, "album_id", album.ID, "filename", photo.Filename, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapCommentsToList(album, photo, comments))
+}
+
+// (POST /api/gphotos/v1/album/{album_id}/photo/{photo_id}/comments)
+func (server *Server) CreatePhotoComment(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionCommentAlbum)
+	if !ok {
+		return
+	}
+
+	var form apiv1.CommentRequestPayload
+	if err := c.ShouldBindJSON(&form); err != nil {
+		zap.S().Errorw("failed to bind to payload", "error", err, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "failed to parse payload: %s", err))
+		return
+	}
+
+	comment, err := server.CommentService().Create(c, entity.Comment{
+		AlbumID:  album.ID,
+		Filename: photo.Filename,
+		Author:   session.User.Username,
+		Content:  escapeField(form.Content),
+	})
+	if err != nil {
+		zap.S().Errorw("failed to create comment", "error", err, "album_id", album.ID, "filename", photo.Filename, "user", session.User.Username)
+		apiErr := mapCommentError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappersv1.MapCommentToModel(album, photo, comment))
+}
+
+// (PUT /api/gphotos/v1/album/{album_id}/photo/{photo_id}/comments/{comment_id})
+func (server *Server) UpdatePhotoComment(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId, commentId apiv1.CommentId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionCommentAlbum)
+	if !ok {
+		return
+	}
+
+	comment, ok := server.resolveComment(c, session, album, photo, commentId)
+	if !ok {
+		return
+	}
+
+	var form apiv1.CommentRequestPayload
+	if err := c.ShouldBindJSON(&form); err != nil {
+		zap.S().Errorw("failed to bind to payload", "error", err, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "failed to parse payload: %s", err))
+		return
+	}
+
+	comment.Content = escapeField(form.Content)
+
+	updated, err := server.CommentService().Update(c, comment, session.User)
+	if err != nil {
+		zap.S().Errorw("failed to update comment", "error", err, "comment_id", comment.ID, "user", session.User.Username)
+		apiErr := mapCommentError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapCommentToModel(album, photo, updated))
+}
+
+// (DELETE /api/gphotos/v1/album/{album_id}/photo/{photo_id}/comments/{comment_id})
+func (server *Server) DeletePhotoComment(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId, commentId apiv1.CommentId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionReadAlbum)
+	if !ok {
+		return
+	}
+
+	comment, ok := server.resolveComment(c, session, album, photo, commentId)
+	if !ok {
+		return
+	}
+
+	if err := server.CommentService().Delete(c, album, comment, session.User); err != nil {
+		zap.S().Errorw("failed to delete comment", "error", err, "comment_id", comment.ID, "user", session.User.Username)
+		apiErr := mapCommentError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, gin.H{})
+}
+
+// (GET /api/gphotos/v1/album/{album_id}/photo/{photo_id}/comments/{comment_id}/history)
+func (server *Server) GetPhotoCommentHistory(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId, commentId apiv1.CommentId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionReadAlbum)
+	if !ok {
+		return
+	}
+
+	comment, ok := server.resolveComment(c, session, album, photo, commentId)
+	if !ok {
+		return
+	}
+
+	revisions, err := server.CommentService().History(c, comment)
+	if err != nil {
+		zap.S().Errorw("failed to get comment history", "error", err, "comment_id", comment.ID, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapCommentHistoryToModel(album, photo, comment, revisions))
+}
+
+// (GET /api/gphotos/v1/album/{album_id}/photo/{photo_id}/reactions)
+func (server *Server) GetPhotoReactions(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionReadAlbum)
+	if !ok {
+		return
+	}
+
+	reactions, err := server.CommentService().Reactions(c, album.ID, photo.Filename)
+	if err != nil {
+		zap.S().Errorw("failed to get reactions", "error", err, "album_id", album.ID, "filename", photo.Filename, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapReactionsToList(reactions, session.User))
+}
+
+// (POST /api/gphotos/v1/album/{album_id}/photo/{photo_id}/reactions)
+func (server *Server) AddPhotoReaction(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionCommentAlbum)
+	if !ok {
+		return
+	}
+
+	var form apiv1.ReactionRequestPayload
+	if err := c.ShouldBindJSON(&form); err != nil {
+		zap.S().Errorw("failed to bind to payload", "error", err, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "failed to parse payload: %s", err))
+		return
+	}
+
+	reaction := entity.Reaction{
+		AlbumID:  album.ID,
+		Filename: photo.Filename,
+		User:     session.User.Username,
+		Kind:     form.Kind,
+	}
+
+	if err := server.CommentService().React(c, reaction); err != nil {
+		zap.S().Errorw("failed to add reaction", "error", err, "album_id", album.ID, "filename", photo.Filename, "user", session.User.Username)
+		apiErr := mapCommentError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	reactions, err := server.CommentService().Reactions(c, album.ID, photo.Filename)
+	if err != nil {
+		zap.S().Errorw("failed to get reactions", "error", err, "album_id", album.ID, "filename", photo.Filename, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappersv1.MapReactionsToList(reactions, session.User))
+}
+
+// (DELETE /api/gphotos/v1/album/{album_id}/photo/{photo_id}/reactions/{reaction_kind})
+func (server *Server) RemovePhotoReaction(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId, reactionKind apiv1.ReactionKind) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionReadAlbum)
+	if !ok {
+		return
+	}
+
+	reaction := entity.Reaction{
+		AlbumID:  album.ID,
+		Filename: photo.Filename,
+		User:     session.User.Username,
+		Kind:     reactionKind,
+	}
+
+	if err := server.CommentService().Unreact(c, reaction); err != nil {
+		zap.S().Errorw("failed to remove reaction", "error", err, "album_id", album.ID, "filename", photo.Filename, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, gin.H{})
+}
+
+// resolveComment fetches the comment and checks that it belongs to the photo.
+// The request is aborted if false is returned.
+func (server *Server) resolveComment(c *gin.Context, session entity.Session, album entity.Album, photo entity.Media, commentId apiv1.CommentId) (entity.Comment, bool) {
+	id, err := server.EncryptionService().Decrypt(commentId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt comment id", "error", err, "comment_id", commentId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "comment with id '%s' not found", commentId))
+		return entity.Comment{}, false
+	}
+
+	comment, err := server.CommentService().GetByID(c, id)
+	if err != nil {
+		zap.S().Errorw("failed to get comment", "error", err, "comment_id", id, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return entity.Comment{}, false
+	}
+
+	if comment.AlbumID != album.ID || comment.Filename != photo.Filename {
+		zap.S().Errorw("comment does not belong to photo", "comment_id", id, "album_id", album.ID, "filename", photo.Filename, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "comment with id '%s' not found", commentId))
+		return entity.Comment{}, false
+	}
+
+	return comment, true
+}
+
+// mapCommentError maps validation errors of the comment service to bad requests.
+func mapCommentError(err error) apiv1.Error {
+	if errors.Is(err, services.ErrInvalidComment) || errors.Is(err, services.ErrInvalidReaction) {
+		return mappersv1.MapFromStatus(http.StatusBadRequest, err.Error())
+	}
+
+	if errors.Is(err, services.ErrForbiddenComment) {
+		return mappersv1.MapFromStatus(http.StatusForbidden, err.Error())
+	}
+
+	return mappersv1.MapFromError(err)
+}
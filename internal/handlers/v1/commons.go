@@ -1,6 +1,114 @@
 package v1
 
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services/permissions"
+	"go.uber.org/zap"
+)
+
 type EncryptionService interface {
 	// Decrypt decrypt the data.
 	Decrypt(data string) (string, error)
 }
+
+// findPhoto returns the photo of the album with the decrypted id photoID.
+// The id of a photo is made of the bucket and the filename of the media.
+func findPhoto(album entity.Album, photoID string) (entity.Media, bool) {
+	filename := strings.TrimPrefix(photoID, fmt.Sprintf("%s/", album.Bucket))
+
+	for _, photo := range album.Photos {
+		if photo.Filename == filename {
+			return photo, true
+		}
+	}
+
+	return entity.Media{}, false
+}
+
+// resolvePhoto fetches the album and the photo and checks that the user has the permission on the album.
+// The request is aborted if false is returned.
+func (server *Server) resolvePhoto(c *gin.Context, session entity.Session, albumId apiv1.AlbumId, photoId apiv1.PhotoId, permission entity.Permission) (entity.Album, entity.Media, bool) {
+	id, err := server.EncryptionService().Decrypt(albumId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt album id", "error", err, "album_id", albumId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return entity.Album{}, entity.Media{}, false
+	}
+
+	album, err := server.AlbumService().Query().First(c, id)
+	if err != nil {
+		zap.S().Errorw("failed to get album", "error", err, "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return entity.Album{}, entity.Media{}, false
+	}
+
+	// check permissions to this album
+	ats := permissions.NewAlbumPermissionService()
+	hasPermission := ats.Policy(permissions.OwnerPolicy{}).
+		Policy(permissions.UserPermissionPolicy{Permission: permission}).
+		Policy(permissions.GroupPermissionPolicy{Permission: permission}).
+		Strategy(permissions.AtLeastOneStrategy).
+		Resolve(album, session.User)
+
+	if !hasPermission {
+		zap.S().Errorw("permission denied", "album_id", id, "permission", permission.String(), "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusForbidden, mappersv1.MapFromStatus(http.StatusForbidden, "access denied"))
+		return entity.Album{}, entity.Media{}, false
+	}
+
+	pID, err := server.EncryptionService().Decrypt(photoId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt photo id", "error", err, "photo_id", photoId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "photo with id '%s' not found", photoId))
+		return entity.Album{}, entity.Media{}, false
+	}
+
+	photo, found := findPhoto(album, pID)
+	if !found {
+		zap.S().Errorw("photo not found", "album_id", id, "photo_id", pID, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "photo with id '%s' not found", photoId))
+		return entity.Album{}, entity.Media{}, false
+	}
+
+	return album, photo, true
+}
+
+// writableAlbum fetches the album and checks that the user has the write permission on it.
+// The request is aborted if false is returned.
+func (server *Server) writableAlbum(c *gin.Context, session entity.Session, albumId apiv1.AlbumId) (entity.Album, bool) {
+	id, err := server.EncryptionService().Decrypt(albumId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt album id", "error", err, "album_id", albumId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return entity.Album{}, false
+	}
+
+	album, err := server.AlbumService().Query().First(c, id)
+	if err != nil {
+		zap.S().Errorw("failed to get album", "error", err, "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return entity.Album{}, false
+	}
+
+	ats := permissions.NewAlbumPermissionService()
+	hasPermission := ats.Policy(permissions.OwnerPolicy{}).
+		Policy(permissions.UserPermissionPolicy{Permission: entity.PermissionWriteAlbum}).
+		Policy(permissions.GroupPermissionPolicy{Permission: entity.PermissionWriteAlbum}).
+		Strategy(permissions.AtLeastOneStrategy).
+		Resolve(album, session.User)
+
+	if !hasPermission {
+		zap.S().Errorw("user has no permission to write photo", "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusForbidden, mappersv1.MapFromStatus(http.StatusForbidden, "access denied"))
+		return entity.Album{}, false
+	}
+
+	return album, true
+}
@@ -0,0 +1,184 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/common"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services/permissions"
+	"go.uber.org/zap"
+)
+
+const (
+	keepAliveInterval = 30 * time.Second
+)
+
+// (GET /api/gphotos/v1/albums/{album_id}/events)
+func (server *Server) GetAlbumEvents(c *gin.Context, albumId apiv1.AlbumId) {
+	session := c.MustGet("session").(entity.Session)
+
+	id, err := server.EncryptionService().Decrypt(albumId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt album id", "error", err, "album_id", albumId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return
+	}
+
+	album, err := server.AlbumService().Query().First(c, id)
+	if err != nil {
+		zap.S().Errorw("failed to get album", "error", err, "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return
+	}
+
+	if !canReadEvents(album, session.User) {
+		zap.S().Errorw("permission denied to access album events", "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusForbidden, mappersv1.MapFromStatus(http.StatusForbidden, "access denied"))
+		return
+	}
+
+	events, cancel := server.EventBroker().Subscribe(func(e entity.Event) bool {
+		return e.AlbumID == album.ID || e.Bucket == album.Bucket
+	})
+	defer cancel()
+
+	zap.S().Infow("album events stream opened", "album_id", id, "user", session.User.Username)
+
+	// the permissions are checked again before each event. The stream is closed once the user cannot read the album anymore.
+	revoked := false
+	stream(c, events, func(e entity.Event) (apiv1.Event, bool) {
+		if e.Kind == entity.EventAlbumDeleted {
+			return mappersv1.MapEventToModel(album, e), true
+		}
+
+		current, err := server.AlbumService().Query().First(c, album.ID)
+		if err != nil || !canReadEvents(current, session.User) {
+			zap.S().Infow("album events stream closed", "error", err, "album_id", album.ID, "user", session.User.Username)
+			revoked = true
+			return apiv1.Event{}, false
+		}
+		album = current
+
+		return mappersv1.MapEventToModel(album, e), true
+	}, func(e entity.Event) bool {
+		// nothing left to stream once the album is gone
+		return revoked || e.Kind == entity.EventAlbumDeleted
+	})
+}
+
+// canReadEvents returns true if the user can read the album and its events.
+func canReadEvents(album entity.Album, user entity.User) bool {
+	ats := permissions.NewAlbumPermissionService()
+	return ats.Policy(permissions.OwnerPolicy{}).
+		Policy(permissions.RolePolicy{Role: entity.RoleAdmin}).
+		Policy(permissions.UserPermissionPolicy{Permission: entity.PermissionReadAlbum}).
+		Policy(permissions.GroupPermissionPolicy{Permission: entity.PermissionReadAlbum}).
+		Strategy(permissions.AtLeastOneStrategy).
+		Resolve(album, user)
+}
+
+// (GET /api/gphotos/v1/events)
+func (server *Server) GetEvents(c *gin.Context) {
+	session := c.MustGet("session").(entity.Session)
+
+	// readable albums by bucket.
+	readableAlbums, err := server.readableAlbums(c, session.User)
+	if err != nil {
+		zap.S().Errorw("failed to get albums", "error", err, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	events, cancel := server.EventBroker().Subscribe(nil)
+	defer cancel()
+
+	zap.S().Infow("events stream opened", "user", session.User.Username)
+
+	stream(c, events, func(e entity.Event) (apiv1.Event, bool) {
+		album, found := readableAlbums[e.Bucket]
+
+		// album events may change the list of readable albums. The deleted album is not readable anymore
+		// but its deletion is sent to the users which could read it.
+		if e.IsAlbumEvent() {
+			albums, err := server.readableAlbums(c, session.User)
+			if err != nil {
+				zap.S().Errorw("failed to refresh albums", "error", err, "user", session.User.Username)
+				return apiv1.Event{}, false
+			}
+			readableAlbums = albums
+
+			if e.Kind != entity.EventAlbumDeleted {
+				album, found = readableAlbums[e.Bucket]
+			}
+		}
+
+		if !found {
+			return apiv1.Event{}, false
+		}
+
+		return mappersv1.MapEventToModel(album, e), true
+	}, nil)
+}
+
+// readableAlbums returns the albums owned by or shared with the user mapped by bucket.
+func (server *Server) readableAlbums(c *gin.Context, user entity.User) (map[string]entity.Album, error) {
+	albums, _, err := server.AlbumService().Query().OwnAlbums(true).SharedAlbums(true).All(c, user)
+	if err != nil {
+		var serviceErr common.ServiceError
+		if !errors.As(err, &serviceErr) || serviceErr.Cause != common.EntityNotFound {
+			return nil, err
+		}
+	}
+
+	readable := make(map[string]entity.Album, len(albums))
+	for _, a := range albums {
+		readable[a.Bucket] = a
+	}
+
+	return readable, nil
+}
+
+// stream writes the events to the response as server-sent events until the client goes away.
+// mapFn returns false if the event must be skipped. If last is not nil, the stream is closed after the first event for which it returns true.
+func stream(c *gin.Context, events <-chan entity.Event, mapFn func(e entity.Event) (apiv1.Event, bool), last func(e entity.Event) bool) {
+	w := c.Writer
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	w.Flush()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			w.Flush()
+		case e, more := <-events:
+			if !more {
+				return
+			}
+
+			if model, ok := mapFn(e); ok {
+				c.SSEvent(string(e.Kind), model)
+				w.Flush()
+			}
+
+			if last != nil && last(e) {
+				return
+			}
+		}
+	}
+}
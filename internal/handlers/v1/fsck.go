@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"go.uber.org/zap"
+)
+
+// (POST /api/gphotos/v1/admin/fsck)
+func (server *Server) CheckStorage(c *gin.Context, params apiv1.CheckStorageParams) {
+	session := c.MustGet("session").(entity.Session)
+
+	if session.User.Role != entity.RoleAdmin {
+		zap.S().Errorw("user has no permission to check the storage", "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusForbidden, mappersv1.MapFromStatus(http.StatusForbidden, "access denied"))
+		return
+	}
+
+	repair := params.Repair != nil && *params.Repair
+
+	issues, err := server.FsckService().Check(c, repair)
+	if err != nil {
+		zap.S().Errorw("failed to check storage", "error", err, "repair", repair, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	zap.S().Infow("storage checked", "issues", len(issues), "repair", repair, "user", session.User.Username)
+
+	c.JSON(http.StatusOK, mappersv1.MapFsckIssuesToModel(issues))
+}
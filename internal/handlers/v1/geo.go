@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services/geo"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"github.com/tupyy/gophoto/internal/services/permissions"
+	"go.uber.org/zap"
+)
+
+// (GET /api/gphotos/v1/map)
+func (server *Server) GetMap(c *gin.Context, params apiv1.GetMapParams) {
+	session := c.MustGet("session").(entity.Session)
+
+	bbox := entity.World
+	if params.South != nil {
+		bbox.South = *params.South
+	}
+	if params.West != nil {
+		bbox.West = *params.West
+	}
+	if params.North != nil {
+		bbox.North = *params.North
+	}
+	if params.East != nil {
+		bbox.East = *params.East
+	}
+
+	if !bbox.Valid() {
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatus(http.StatusBadRequest, "invalid area"))
+		return
+	}
+
+	zoom := 0
+	if params.Zoom != nil {
+		zoom = *params.Zoom
+	}
+
+	if zoom < 0 || zoom > geo.MaxZoom {
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "zoom must be between 0 and %d", geo.MaxZoom))
+		return
+	}
+
+	items, err := server.TimelineService().Get(c, session.User, media.Filter{})
+	if err != nil {
+		zap.S().Errorw("failed to get photos", "error", err, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapClustersToModel(geo.Clusters(items, bbox, zoom), zoom))
+}
+
+// (GET /api/gphotos/v1/albums/{album_id}/location)
+func (server *Server) GetAlbumLocation(c *gin.Context, albumId apiv1.AlbumId) {
+	session := c.MustGet("session").(entity.Session)
+
+	id, err := server.EncryptionService().Decrypt(albumId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt album id", "error", err, "album_id", albumId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return
+	}
+
+	album, err := server.AlbumService().Query().First(c, id)
+	if err != nil {
+		zap.S().Errorw("failed to get album", "error", err, "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return
+	}
+
+	apr := permissions.NewAlbumPermissionService()
+	hasPermission := apr.Policy(permissions.OwnerPolicy{}).
+		Policy(permissions.RolePolicy{Role: entity.RoleAdmin}).
+		Policy(permissions.AnyUserPermissionPolicty{}).
+		Policy(permissions.AnyGroupPermissionPolicy{}).
+		Strategy(permissions.AtLeastOneStrategy).
+		Resolve(album, session.User)
+
+	if !hasPermission {
+		zap.S().Errorw("user has no read permissions on the album", "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusForbidden, mappersv1.MapFromStatus(http.StatusForbidden, "access denied"))
+		return
+	}
+
+	bbox, count := geo.Bounds(album.Photos)
+	if count == 0 {
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album '%s' has no geolocated photo", albumId))
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapAlbumLocationToModel(album, bbox, count))
+}
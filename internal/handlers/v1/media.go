@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	apiv1 "github.com/tupyy/gophoto/api/v1"
@@ -66,6 +67,36 @@ func (server *Server) GetAlbumPhotos(c *gin.Context, albumID string, params apiv
 		return
 	}
 
+	photos, err = server.MediaService().WithMetadata(c, album.ID, session.User.Username, photos)
+	if err != nil {
+		zap.S().Errorw("failed to get photos metadata", "error", err, "album id", id, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	photos = filterPhotos(photos, params)
+	photos = mediaFilter(params).Apply(photos)
+
+	// setup sort
+	sortType, order := media.SortByCaptureDate, media.ReverseOrder
+	if params.Sort != nil {
+		switch *params.Sort {
+		case "upload_date":
+			sortType = media.SortByUploadDate
+		case "filename":
+			sortType = media.SortByFilename
+		case "size":
+			sortType = media.SortBySize
+		}
+	}
+
+	if params.Order != nil && *params.Order == "asc" {
+		order = media.NormalOrder
+	}
+
+	media.Sort(photos, sortType, order)
+
 	total := len(photos)
 	page, size := 0, 0
 
@@ -76,11 +107,62 @@ func (server *Server) GetAlbumPhotos(c *gin.Context, albumID string, params apiv
 		size = int(*params.Size)
 	}
 
-	model := mappersv1.MapMediaListToModel(album, paginate(photos, page, size))
+	// use offset pagination only if it is explicitly asked
+	if params.Page != nil {
+		if params.Cursor != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatus(http.StatusBadRequest, "page and cursor cannot be used together"))
+			return
+		}
+
+		model := mappersv1.MapMediaListToModel(album, paginate(photos, page, size))
+		model.Page = page
+		model.Total = total
+		c.JSON(http.StatusOK, model)
+		return
+	}
+
+	cursor := ""
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	photos, next, err := media.Paginate(photos, sortType, order, cursor, size)
+	if err != nil {
+		zap.S().Errorw("failed to paginate photos", "error", err, "cursor", cursor, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "invalid cursor '%s'", cursor))
+		return
+	}
+
+	model := mappersv1.MapMediaListToModel(album, photos)
 	model.Total = total
+	if len(next) > 0 {
+		model.NextCursor = &next
+	}
+
 	c.JSON(http.StatusOK, model)
 }
 
+// mediaFilter returns the filter on capture date and media type set in the query parameters.
+func mediaFilter(params apiv1.GetAlbumPhotosParams) media.Filter {
+	filter := media.Filter{
+		From: params.From,
+		To:   params.To,
+	}
+
+	if params.MediaType != nil {
+		var mediaType entity.MediaType
+		switch *params.MediaType {
+		case "video":
+			mediaType = entity.Video
+		default:
+			mediaType = entity.Photo
+		}
+		filter.MediaType = &mediaType
+	}
+
+	return filter
+}
+
 func (server *Server) GetPhoto(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId) {
 	session := c.MustGet("session").(entity.Session)
 
@@ -192,6 +274,14 @@ func (server *Server) DeletePhoto(c *gin.Context, albumId apiv1.AlbumId, photoId
 		c.AbortWithStatusJSON(apiErr.Code, apiErr)
 		return
 	}
+
+	if err := server.MediaService().DeleteMetadata(c, album.ID, strings.TrimPrefix(pID, fmt.Sprintf("%s/", album.Bucket))); err != nil {
+		zap.S().Warnw("failed to delete photo metadata", "error", err, "photo id", pID, "album id", id, "user", session.User.Username)
+	}
+
+	// if the cover has been removed, pick another one.
+	photo, found := findPhoto(album, pID)
+	server.refreshAlbum(c, session, id, found && media.IsCover(photo, album.Thumbnail))
 	c.JSON(http.StatusNoContent, gin.H{})
 }
 
@@ -240,6 +330,10 @@ func (server *Server) UploadPhoto(c *gin.Context, albumId apiv1.AlbumId) {
 		return
 	}
 
+	if !server.checkQuota(c, session, album, file.Size) {
+		return
+	}
+
 	src, err := file.Open()
 	if err != nil {
 		zap.S().Errorw("failed to open file from request", "error", err, "filename", file.Filename, "user", session.User.Username)
@@ -257,6 +351,9 @@ func (server *Server) UploadPhoto(c *gin.Context, albumId apiv1.AlbumId) {
 		return
 	}
 
+	// new albums get the first uploaded photo as cover.
+	server.refreshAlbum(c, session, id, len(album.Thumbnail) == 0)
+
 	c.JSON(http.StatusCreated, mappersv1.MapMediaToModel(album, entity.Media{
 		MediaType: entity.Photo,
 		Bucket:    album.Bucket,
@@ -264,6 +361,140 @@ func (server *Server) UploadPhoto(c *gin.Context, albumId apiv1.AlbumId) {
 	}))
 }
 
+// refreshAlbum reloads the album after its photos changed, updates its place and picks a new cover if asked.
+// Failures are only logged since the album's thumbnail falls back to the best photo when the album has no cover
+// and the place is updated with the next change.
+func (server *Server) refreshAlbum(c *gin.Context, session entity.Session, albumID string, pickThumbnail bool) {
+	album, err := server.AlbumService().Query().First(c, albumID)
+	if err != nil {
+		zap.S().Warnw("failed to get album", "error", err, "album_id", albumID, "user", session.User.Username)
+		return
+	}
+
+	album, err = server.AlbumService().Locate(c, album)
+	if err != nil {
+		zap.S().Warnw("failed to locate album", "error", err, "album_id", albumID, "user", session.User.Username)
+	}
+
+	if !pickThumbnail {
+		return
+	}
+
+	if _, err := server.AlbumService().PickThumbnail(c, album); err != nil {
+		zap.S().Warnw("failed to pick thumbnail", "error", err, "album_id", albumID, "user", session.User.Username)
+	}
+}
+
+// (PATCH /api/gphotos/v1/album/{album_id}/photo/{photo_id})
+func (server *Server) UpdatePhoto(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionWriteAlbum)
+	if !ok {
+		return
+	}
+
+	var form apiv1.PhotoMetadataRequestPayload
+	if err := c.ShouldBindJSON(&form); err != nil {
+		zap.S().Errorw("failed to bind to payload", "error", err, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "failed to parse payload: %s", err))
+		return
+	}
+
+	photos, err := server.MediaService().WithMetadata(c, album.ID, session.User.Username, []entity.Media{photo})
+	if err != nil {
+		zap.S().Errorw("failed to get photo metadata", "error", err, "album_id", album.ID, "filename", photo.Filename, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	photo = photos[0]
+
+	if form.Caption != nil {
+		photo.Caption = escapeField(*form.Caption)
+	}
+
+	if form.Description != nil {
+		photo.Description = escapeField(*form.Description)
+	}
+
+	if err := server.MediaService().UpdateMetadata(c, album.ID, photo); err != nil {
+		zap.S().Errorw("failed to update photo", "error", err, "album_id", album.ID, "filename", photo.Filename, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapMediaToModel(album, photo))
+}
+
+// (PUT /api/gphotos/v1/album/{album_id}/photo/{photo_id}/favorite)
+func (server *Server) SetPhotoFavorite(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId) {
+	server.setFavorite(c, albumId, photoId, true)
+}
+
+// (DELETE /api/gphotos/v1/album/{album_id}/photo/{photo_id}/favorite)
+func (server *Server) RemovePhotoFavorite(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId) {
+	server.setFavorite(c, albumId, photoId, false)
+}
+
+func (server *Server) setFavorite(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId, favorite bool) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionReadAlbum)
+	if !ok {
+		return
+	}
+
+	if err := server.MediaService().SetFavorite(c, album.ID, photo.Filename, session.User.Username, favorite); err != nil {
+		zap.S().Errorw("failed to set favorite", "error", err, "album_id", album.ID, "filename", photo.Filename, "favorite", favorite, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, gin.H{})
+}
+
+// filterPhotos keeps only the photos matching the query parameters.
+func filterPhotos(photos []entity.Media, params apiv1.GetAlbumPhotosParams) []entity.Media {
+	favoritesOnly := params.Favorites != nil && *params.Favorites
+	tag := ""
+	if params.Tag != nil {
+		tag = strings.ToLower(*params.Tag)
+	}
+
+	if !favoritesOnly && len(tag) == 0 {
+		return photos
+	}
+
+	filtered := make([]entity.Media, 0, len(photos))
+	for _, p := range photos {
+		if favoritesOnly && !p.Favorite {
+			continue
+		}
+
+		if len(tag) > 0 && !hasTag(p, tag) {
+			continue
+		}
+
+		filtered = append(filtered, p)
+	}
+
+	return filtered
+}
+
+func hasTag(photo entity.Media, name string) bool {
+	for _, t := range photo.Tags {
+		if strings.ToLower(t.Name) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 func validate(filename string) error {
 	if len(filename) > FILENAME_MAX_LENGTH {
 		return errors.New("filename length exceeds max length")
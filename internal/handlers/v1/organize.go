@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"html"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services/organize"
+	"github.com/tupyy/gophoto/internal/services/permissions"
+	"go.uber.org/zap"
+)
+
+// (GET /api/gphotos/v1/albums/{album_id}/organize)
+func (server *Server) GetAlbumOrganizeProposals(c *gin.Context, albumId apiv1.AlbumId, params apiv1.GetAlbumOrganizeProposalsParams) {
+	session := c.MustGet("session").(entity.Session)
+
+	opts := organize.DefaultOptions()
+	if params.MaxGap != nil {
+		if *params.MaxGap <= 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatus(http.StatusBadRequest, "max_gap must be positive"))
+			return
+		}
+		opts.MaxGap = time.Duration(*params.MaxGap) * time.Hour
+	}
+
+	if params.MaxDistance != nil {
+		if *params.MaxDistance <= 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatus(http.StatusBadRequest, "max_distance must be positive"))
+			return
+		}
+		opts.MaxDistance = *params.MaxDistance
+	}
+
+	album, ok := server.writableAlbum(c, session, albumId)
+	if !ok {
+		return
+	}
+
+	proposals, err := server.OrganizeService().Propose(c, album, opts)
+	if err != nil {
+		zap.S().Errorw("failed to propose albums", "error", err, "album_id", album.ID, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapProposalsToModel(album, proposals))
+}
+
+// (POST /api/gphotos/v1/albums/{album_id}/organize)
+func (server *Server) OrganizeAlbum(c *gin.Context, albumId apiv1.AlbumId) {
+	session := c.MustGet("session").(entity.Session)
+
+	// only editors and admins have the right to create albums
+	apr := permissions.NewAlbumPermissionService()
+	hasPermission := apr.Policy(permissions.RolePolicy{Role: entity.RoleEditor}).
+		Policy(permissions.RolePolicy{Role: entity.RoleAdmin}).
+		Strategy(permissions.AtLeastOneStrategy).
+		Resolve(entity.Album{}, session.User)
+
+	if !hasPermission {
+		zap.S().Errorw("permission denied to create album", "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusForbidden, mappersv1.MapFromStatus(http.StatusForbidden, "access denied"))
+		return
+	}
+
+	album, ok := server.writableAlbum(c, session, albumId)
+	if !ok {
+		return
+	}
+
+	var payload apiv1.OrganizeRequestPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		zap.S().Errorw("failed to bind to payload", "error", err, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "failed to parse payload: %s", err))
+		return
+	}
+
+	proposals := make([]organize.Proposal, 0, len(payload.Albums))
+	moved := make(map[string]struct{})
+	for _, a := range payload.Albums {
+		if len(a.Name) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatus(http.StatusBadRequest, "album's name is missing"))
+			return
+		}
+
+		p := organize.Proposal{
+			Name:     html.EscapeString(a.Name),
+			Location: escapeFieldPtr(a.Location),
+		}
+
+		for _, photoId := range a.PhotoIds {
+			pID, err := server.EncryptionService().Decrypt(photoId)
+			if err != nil {
+				zap.S().Errorw("failed to decrypt photo id", "error", err, "photo_id", photoId, "user", session.User.Username)
+				c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "photo with id '%s' not found", photoId))
+				return
+			}
+
+			photo, found := findPhoto(album, pID)
+			if !found {
+				zap.S().Errorw("photo not found", "album_id", album.ID, "photo_id", pID, "user", session.User.Username)
+				c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "photo with id '%s' not found", photoId))
+				return
+			}
+
+			if _, found := moved[photo.Filename]; found {
+				c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "photo with id '%s' is in several albums", photoId))
+				return
+			}
+			moved[photo.Filename] = struct{}{}
+
+			p.Photos = append(p.Photos, photo)
+		}
+
+		if len(p.Photos) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "album '%s' has no photo", a.Name))
+			return
+		}
+
+		proposals = append(proposals, p)
+	}
+
+	albums, err := server.OrganizeService().Confirm(c, session.User.Username, album, proposals)
+	if err != nil {
+		zap.S().Errorw("failed to organize album", "error", err, "album_id", album.ID, "created", len(albums), "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	zap.S().Infow("album organized", "album_id", album.ID, "created", len(albums), "user", session.User.Username)
+
+	albumModels := make([]apiv1.Album, 0, len(albums))
+	for _, a := range albums {
+		albumModels = append(albumModels, mappersv1.MapAlbumToModel(a))
+	}
+
+	c.JSON(http.StatusCreated, &apiv1.AlbumList{
+		Kind:  "AlbumList",
+		Page:  1,
+		Size:  len(albumModels),
+		Total: len(albumModels),
+		Items: albumModels,
+	})
+}
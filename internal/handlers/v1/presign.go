@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"errors"
+	"html"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services"
+	"go.uber.org/zap"
+)
+
+// (POST /api/gphotos/v1/albums/{album_id}/photos/uploads)
+func (server *Server) CreatePhotoUpload(c *gin.Context, albumId apiv1.AlbumId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, ok := server.writableAlbum(c, session, albumId)
+	if !ok {
+		return
+	}
+
+	var payload apiv1.PhotoUploadRequestPayload
+	if err := c.BindJSON(&payload); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "failed to parse payload: %s", err))
+		return
+	}
+
+	if err := validate(payload.Filename); err != nil {
+		zap.S().Errorw("failed to valdidate filename", "error", err, "filename", payload.Filename, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "invalid filename '%s'", err))
+		return
+	}
+
+	upload, err := server.MediaService().PresignUpload(c, album.Bucket, html.EscapeString(payload.Filename))
+	if err != nil {
+		zap.S().Errorw("failed to presign upload", "error", err, "album_id", album.ID, "filename", payload.Filename, "user", session.User.Username)
+		abortWithPresignError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappersv1.MapPhotoUploadToModel(upload))
+}
+
+// (POST /api/gphotos/v1/albums/{album_id}/photos/uploads/{upload_id})
+func (server *Server) CompletePhotoUpload(c *gin.Context, albumId apiv1.AlbumId, uploadId apiv1.UploadId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, ok := server.writableAlbum(c, session, albumId)
+	if !ok {
+		return
+	}
+
+	upload, err := server.EncryptionService().Decrypt(uploadId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt upload id", "error", err, "upload_id", uploadId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "upload with id '%s' not found", uploadId))
+		return
+	}
+
+	size, err := server.MediaService().UploadSize(c, album.Bucket, upload)
+	if err != nil {
+		zap.S().Errorw("failed to get upload", "error", err, "album_id", album.ID, "upload", upload, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "upload with id '%s' not found", uploadId))
+		return
+	}
+
+	// the storage enforces the limit of the upload policy but a storage which does not is not trusted either.
+	if size > server.MediaService().UploadMaxSize() {
+		zap.S().Errorw("upload too large", "album_id", album.ID, "upload", upload, "size", size, "user", session.User.Username)
+		if err := server.MediaService().CancelUpload(c, album.Bucket, upload); err != nil {
+			zap.S().Errorw("failed to remove upload", "error", err, "album_id", album.ID, "upload", upload, "user", session.User.Username)
+		}
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "photo larger than %d bytes", server.MediaService().UploadMaxSize()))
+		return
+	}
+
+	if !server.checkQuota(c, session, album, size) {
+		// the upload cannot be completed once the quota is freed since it is not counted in the usage
+		if err := server.MediaService().CancelUpload(c, album.Bucket, upload); err != nil {
+			zap.S().Errorw("failed to remove upload", "error", err, "album_id", album.ID, "upload", upload, "user", session.User.Username)
+		}
+		return
+	}
+
+	photoName, err := server.MediaService().CompleteUpload(c, album.Bucket, upload)
+	if err != nil {
+		zap.S().Errorw("failed to complete upload", "error", err, "album_id", album.ID, "upload", upload, "user", session.User.Username)
+		switch {
+		case errors.Is(err, services.ErrNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "upload with id '%s' not found", uploadId))
+		case errors.Is(err, services.ErrInvalidMedia):
+			c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatus(http.StatusBadRequest, err.Error()))
+		default:
+			abortWithPresignError(c, err)
+		}
+		return
+	}
+
+	// new albums get the first uploaded photo as cover.
+	server.refreshAlbum(c, session, album.ID, len(album.Thumbnail) == 0)
+
+	c.JSON(http.StatusCreated, mappersv1.MapMediaToModel(album, entity.Media{
+		MediaType: entity.Photo,
+		Bucket:    album.Bucket,
+		Filename:  photoName,
+	}))
+}
+
+// (GET /api/gphotos/v1/album/{album_id}/photo/{photo_id}/url)
+func (server *Server) GetPhotoUrl(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionReadAlbum)
+	if !ok {
+		return
+	}
+
+	u, expiresAt, err := server.MediaService().PresignDownload(c, album.Bucket, photo.Filename)
+	if err != nil {
+		zap.S().Errorw("failed to presign download", "error", err, "album_id", album.ID, "filename", photo.Filename, "user", session.User.Username)
+		abortWithPresignError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapPresignedURLToModel(u, expiresAt))
+}
+
+// abortWithPresignError aborts the request with 501 if the pre-signed urls are disabled.
+func abortWithPresignError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrPresignDisabled) {
+		c.AbortWithStatusJSON(http.StatusNotImplemented, mappersv1.MapFromStatus(http.StatusNotImplemented, err.Error()))
+		return
+	}
+
+	apiErr := mappersv1.MapFromError(err)
+	c.AbortWithStatusJSON(apiErr.Code, apiErr)
+}
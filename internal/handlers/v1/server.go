@@ -2,9 +2,16 @@ package v1
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/tupyy/gophoto/internal/services/accesstoken"
 	"github.com/tupyy/gophoto/internal/services/album"
+	"github.com/tupyy/gophoto/internal/services/comment"
+	"github.com/tupyy/gophoto/internal/services/events"
+	"github.com/tupyy/gophoto/internal/services/fsck"
 	"github.com/tupyy/gophoto/internal/services/media"
+	"github.com/tupyy/gophoto/internal/services/organize"
+	"github.com/tupyy/gophoto/internal/services/quota"
 	"github.com/tupyy/gophoto/internal/services/tag"
+	"github.com/tupyy/gophoto/internal/services/timeline"
 	"github.com/tupyy/gophoto/internal/services/users"
 )
 
@@ -14,10 +21,17 @@ type Server struct {
 	tagService       *tag.Service
 	mediaService     *media.Service
 	encryptionServer EncryptionService
+	eventBroker      *events.Broker
+	commentService   *comment.Service
+	timelineService  *timeline.Service
+	organizeService  *organize.Service
+	quotaService     *quota.Service
+	fsckService      *fsck.Service
+	tokenService     *accesstoken.Service
 }
 
-func NewServer(a *album.Service, u *users.Service, tag *tag.Service, m *media.Service, e EncryptionService) *Server {
-	return &Server{a, u, tag, m, e}
+func NewServer(a *album.Service, u *users.Service, tag *tag.Service, m *media.Service, e EncryptionService, b *events.Broker, cs *comment.Service, tl *timeline.Service, o *organize.Service, q *quota.Service, f *fsck.Service, t *accesstoken.Service) *Server {
+	return &Server{a, u, tag, m, e, b, cs, tl, o, q, f, t}
 }
 
 func (server *Server) AlbumService() *album.Service {
@@ -40,6 +54,34 @@ func (server *Server) EncryptionService() EncryptionService {
 	return server.encryptionServer
 }
 
+func (server *Server) CommentService() *comment.Service {
+	return server.commentService
+}
+
+func (server *Server) TimelineService() *timeline.Service {
+	return server.timelineService
+}
+
+func (server *Server) OrganizeService() *organize.Service {
+	return server.organizeService
+}
+
+func (server *Server) QuotaService() *quota.Service {
+	return server.quotaService
+}
+
+func (server *Server) FsckService() *fsck.Service {
+	return server.fsckService
+}
+
+func (server *Server) AccessTokenService() *accesstoken.Service {
+	return server.tokenService
+}
+
+func (server *Server) EventBroker() *events.Broker {
+	return server.eventBroker
+}
+
 // (GET /api/gphotos/v1)
 func (server *Server) GetVersionMetadata(c *gin.Context) {
 
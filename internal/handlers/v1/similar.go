@@ -0,0 +1,266 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"github.com/tupyy/gophoto/internal/services/permissions"
+	"github.com/tupyy/gophoto/internal/services/similar"
+	"github.com/tupyy/gophoto/internal/services/timeline"
+	"go.uber.org/zap"
+)
+
+// (GET /api/gphotos/v1/albums/{album_id}/similar)
+func (server *Server) GetAlbumSimilarPhotos(c *gin.Context, albumId apiv1.AlbumId, params apiv1.GetAlbumSimilarPhotosParams) {
+	session := c.MustGet("session").(entity.Session)
+
+	threshold, ok := similarThreshold(c, params.Threshold)
+	if !ok {
+		return
+	}
+
+	id, err := server.EncryptionService().Decrypt(albumId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt album id", "error", err, "album_id", albumId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return
+	}
+
+	album, err := server.AlbumService().Query().First(c, id)
+	if err != nil {
+		zap.S().Errorw("failed to get album", "error", err, "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return
+	}
+
+	apr := permissions.NewAlbumPermissionService()
+	hasPermission := apr.Policy(permissions.OwnerPolicy{}).
+		Policy(permissions.RolePolicy{Role: entity.RoleAdmin}).
+		Policy(permissions.AnyUserPermissionPolicty{}).
+		Policy(permissions.AnyGroupPermissionPolicy{}).
+		Strategy(permissions.AtLeastOneStrategy).
+		Resolve(album, session.User)
+
+	if !hasPermission {
+		zap.S().Errorw("user has no read permissions on the album", "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusForbidden, mappersv1.MapFromStatus(http.StatusForbidden, "access denied"))
+		return
+	}
+
+	items := []timeline.Item{}
+	for _, photo := range album.Photos {
+		if photo.MediaType == entity.Photo {
+			items = append(items, timeline.Item{Album: album, Photo: photo})
+		}
+	}
+
+	server.similarGroups(c, session, items, threshold)
+}
+
+// (GET /api/gphotos/v1/similar)
+func (server *Server) GetSimilarPhotos(c *gin.Context, params apiv1.GetSimilarPhotosParams) {
+	session := c.MustGet("session").(entity.Session)
+
+	threshold, ok := similarThreshold(c, params.Threshold)
+	if !ok {
+		return
+	}
+
+	items, err := server.TimelineService().Get(c, session.User, media.Filter{})
+	if err != nil {
+		zap.S().Errorw("failed to get photos", "error", err, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	server.similarGroups(c, session, items, threshold)
+}
+
+// (POST /api/gphotos/v1/similar/trash)
+// The photos are moved to the trash of their album and keep their metadata until fsck purges them. The hashes are
+// computed again so a client cannot trash photos which are not similar to the kept one.
+func (server *Server) TrashSimilarPhotos(c *gin.Context) {
+	session := c.MustGet("session").(entity.Session)
+
+	var form apiv1.SimilarTrashRequestPayload
+	if err := c.ShouldBindJSON(&form); err != nil {
+		zap.S().Errorw("failed to bind to payload", "error", err, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "failed to parse payload: %s", err))
+		return
+	}
+
+	threshold, ok := similarThreshold(c, form.Threshold)
+	if !ok {
+		return
+	}
+
+	// trashing photos requires the write permission on every album of the group.
+	items := make([]timeline.Item, 0, len(form.Photos))
+	refs := make(map[string]apiv1.PhotoRef)
+	for _, ref := range form.Photos {
+		album, photo, ok := server.resolvePhoto(c, session, ref.AlbumId, ref.PhotoId, entity.PermissionWriteAlbum)
+		if !ok {
+			return
+		}
+
+		key := album.ID + "/" + photo.Filename
+		if _, found := refs[key]; found {
+			continue
+		}
+		refs[key] = ref
+
+		hash, err := server.MediaService().Hash(c, photo)
+		if err != nil {
+			zap.S().Errorw("failed to hash photo", "error", err, "album_id", album.ID, "filename", photo.Filename, "user", session.User.Username)
+			c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "photo with id '%s' cannot be compared", ref.PhotoId))
+			return
+		}
+		photo.Hash = &hash
+
+		items = append(items, timeline.Item{Album: album, Photo: photo})
+	}
+
+	if len(items) < 2 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatus(http.StatusBadRequest, "a group has at least two photos"))
+		return
+	}
+
+	group := similar.Group{Items: items}
+
+	kept := group.Best(server.ratings(c, session, items))
+	if form.Keep != nil {
+		album, photo, ok := server.resolvePhoto(c, session, form.Keep.AlbumId, form.Keep.PhotoId, entity.PermissionWriteAlbum)
+		if !ok {
+			return
+		}
+
+		kept, ok = findItem(items, album.ID, photo.Filename)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "photo with id '%s' is not part of the group", form.Keep.PhotoId))
+			return
+		}
+	}
+
+	if outliers := group.Outliers(kept, threshold); len(outliers) > 0 {
+		ref := refs[outliers[0].Album.ID+"/"+outliers[0].Photo.Filename]
+		zap.S().Errorw("photo not similar to the kept photo", "album_id", outliers[0].Album.ID, "filename", outliers[0].Photo.Filename, "threshold", threshold, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "photo with id '%s' is not similar to the kept photo", ref.PhotoId))
+		return
+	}
+
+	trashed := []apiv1.TrashedPhoto{}
+	pickThumbnail := make(map[string]bool)
+	for _, item := range items {
+		if item.Album.ID == kept.Album.ID && item.Photo.Filename == kept.Photo.Filename {
+			continue
+		}
+
+		t, err := server.MediaService().Trash(c, item.Album.Bucket, item.Photo)
+		if err != nil {
+			zap.S().Errorw("failed to trash photo", "error", err, "album_id", item.Album.ID, "filename", item.Photo.Filename, "user", session.User.Username)
+			apiErr := mappersv1.MapFromError(err)
+			c.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		trashed = append(trashed, mappersv1.MapTrashedMediaToModel(item.Album, t))
+		pickThumbnail[item.Album.ID] = pickThumbnail[item.Album.ID] || media.IsCover(item.Photo, item.Album.Thumbnail)
+	}
+
+	for albumID, pick := range pickThumbnail {
+		server.refreshAlbum(c, session, albumID, pick)
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapSimilarTrashResultToModel(kept, trashed))
+}
+
+// findItem returns the item of the album's photo.
+func findItem(items []timeline.Item, albumID, filename string) (timeline.Item, bool) {
+	for _, item := range items {
+		if item.Album.ID == albumID && item.Photo.Filename == filename {
+			return item, true
+		}
+	}
+
+	return timeline.Item{}, false
+}
+
+// similarGroups writes the groups of similar photos found among the items.
+func (server *Server) similarGroups(c *gin.Context, session entity.Session, items []timeline.Item, threshold int) {
+	hashed := make([]timeline.Item, 0, len(items))
+	for _, item := range items {
+		hash, err := server.MediaService().Hash(c, item.Photo)
+		if err != nil {
+			zap.S().Warnw("failed to hash photo", "error", err, "album_id", item.Album.ID, "filename", item.Photo.Filename, "user", session.User.Username)
+			continue
+		}
+
+		item.Photo.Hash = &hash
+		hashed = append(hashed, item)
+	}
+
+	groups := similar.Groups(hashed, threshold)
+
+	grouped := []timeline.Item{}
+	for _, g := range groups {
+		grouped = append(grouped, g.Items...)
+	}
+
+	grouped, err := server.TimelineService().WithMetadata(c, session.User, grouped)
+	if err != nil {
+		zap.S().Errorw("failed to get photos metadata", "error", err, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	rating := server.ratings(c, session, grouped)
+
+	bests := make([]timeline.Item, 0, len(groups))
+	for i := range groups {
+		groups[i].Items, grouped = grouped[:len(groups[i].Items)], grouped[len(groups[i].Items):]
+		bests = append(bests, groups[i].Best(rating))
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapSimilarGroupsToModel(groups, bests, threshold))
+}
+
+// ratings returns the rating of the items' photos. Photos of albums whose ratings cannot be read are not rated.
+func (server *Server) ratings(c *gin.Context, session entity.Session, items []timeline.Item) func(item timeline.Item) int {
+	ratings := make(map[string]map[string]int)
+	for _, item := range items {
+		if _, found := ratings[item.Album.ID]; found {
+			continue
+		}
+
+		albumRatings, err := server.MediaService().Ratings(c, item.Album.ID)
+		if err != nil {
+			zap.S().Warnw("failed to get album ratings", "error", err, "album_id", item.Album.ID, "user", session.User.Username)
+		}
+
+		ratings[item.Album.ID] = albumRatings
+	}
+
+	return func(item timeline.Item) int {
+		return ratings[item.Album.ID][item.Photo.Filename]
+	}
+}
+
+// similarThreshold validates the threshold parameter. The request is aborted if false is returned.
+func similarThreshold(c *gin.Context, param *int) (int, bool) {
+	if param == nil {
+		return similar.DefaultThreshold, true
+	}
+
+	if *param < 0 || *param > similar.MaxThreshold {
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "threshold must be between 0 and %d", similar.MaxThreshold))
+		return 0, false
+	}
+
+	return *param, true
+}
@@ -258,3 +258,62 @@ func (server *Server) SetTagToAlbum(c *gin.Context, albumId apiv1.AlbumId, tagId
 	album.Tags = append(album.Tags, tag)
 	c.JSON(http.StatusCreated, mappersv1.MapAlbumToModel(album))
 }
+
+// (POST /api/gphotos/v1/album/{album_id}/photo/{photo_id}/tags/{tag_id})
+func (server *Server) SetTagToPhoto(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId, tagId apiv1.TagId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionWriteAlbum)
+	if !ok {
+		return
+	}
+
+	tagID, err := server.EncryptionService().Decrypt(tagId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt tag id", "error", err, "tag_id", tagId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "tag with '%s' not found", tagId))
+		return
+	}
+
+	tag, err := server.TagService().GetByID(c, session.User.ID, tagID)
+	if err != nil {
+		zap.S().Errorw("failed to get tag", "tag_id", tagID, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "tag with '%s' not found", tagId))
+		return
+	}
+
+	if err := server.MediaService().Tag(c, album.ID, photo.Filename, tag); err != nil {
+		zap.S().Errorw("failed to associate tag to photo", "error", err, "album_id", album.ID, "filename", photo.Filename, "tag_id", tag.ID, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappersv1.MapTagToModel(tag))
+}
+
+// (DELETE /api/gphotos/v1/album/{album_id}/photo/{photo_id}/tags/{tag_id})
+func (server *Server) RemoveTagFromPhoto(c *gin.Context, albumId apiv1.AlbumId, photoId apiv1.PhotoId, tagId apiv1.TagId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, photo, ok := server.resolvePhoto(c, session, albumId, photoId, entity.PermissionWriteAlbum)
+	if !ok {
+		return
+	}
+
+	tagID, err := server.EncryptionService().Decrypt(tagId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt tag id", "error", err, "tag_id", tagId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "tag with '%s' not found", tagId))
+		return
+	}
+
+	if err := server.MediaService().Untag(c, album.ID, photo.Filename, entity.Tag{ID: tagID}); err != nil {
+		zap.S().Errorw("failed to dissociate tag from photo", "error", err, "album_id", album.ID, "filename", photo.Filename, "tag_id", tagID, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, gin.H{})
+}
@@ -8,6 +8,7 @@ import (
 	apiv1 "github.com/tupyy/gophoto/api/v1"
 	"github.com/tupyy/gophoto/internal/entity"
 	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services/media"
 	"github.com/tupyy/gophoto/internal/services/permissions"
 	"go.uber.org/zap"
 )
@@ -45,6 +46,23 @@ func (server *Server) GetAlbumThumbnail(c *gin.Context, albumId apiv1.AlbumId) {
 		return
 	}
 
+	// the cover is only saved when the photos change. Albums without cover get the best photo, which is not saved on read.
+	if len(album.Thumbnail) == 0 {
+		photo, found, err := server.AlbumService().BestThumbnail(c, album)
+		if err != nil {
+			zap.S().Warnw("failed to pick thumbnail", "error", err, "album_id", id, "user", session.User.Username)
+		}
+
+		if found {
+			album.Thumbnail = media.CoverName(photo)
+		}
+	}
+
+	if len(album.Thumbnail) == 0 {
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "thumbnail not found for album '%s'", albumId))
+		return
+	}
+
 	thumbnail, _, err := server.MediaService().GetPhoto(c, album.Bucket, album.Thumbnail)
 	if err != nil {
 		zap.S().Errorw("failed to get album", "error", err, "album_id", id, "thumbnail_filename", album.Thumbnail, "bucket", album.Bucket, "user", session.User.Username)
@@ -61,3 +79,68 @@ func (server *Server) GetAlbumThumbnail(c *gin.Context, albumId apiv1.AlbumId) {
 
 	c.Data(http.StatusOK, "image/jpeg", content)
 }
+
+// (PUT /api/gphotos/v1/albums/{album_id}/thumbnail)
+func (server *Server) SetAlbumThumbnail(c *gin.Context, albumId apiv1.AlbumId) {
+	session := c.MustGet("session").(entity.Session)
+
+	id, err := server.EncryptionService().Decrypt(albumId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt album id", "error", err, "album_id", albumId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return
+	}
+
+	album, err := server.AlbumService().Query().First(c, id)
+	if err != nil {
+		zap.S().Errorw("failed to get album", "error", err, "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "album with id '%s' not found", albumId))
+		return
+	}
+
+	// the thumbnail is part of the album so only users which can edit the album can change it.
+	apr := permissions.NewAlbumPermissionService()
+	hasPermission := apr.Policy(permissions.OwnerPolicy{}).
+		Policy(permissions.RolePolicy{Role: entity.RoleAdmin}).
+		Policy(permissions.UserPermissionPolicy{Permission: entity.PermissionEditAlbum}).
+		Policy(permissions.GroupPermissionPolicy{Permission: entity.PermissionEditAlbum}).
+		Strategy(permissions.AtLeastOneStrategy).
+		Resolve(album, session.User)
+
+	if !hasPermission {
+		zap.S().Errorw("user has no edit permission on the album", "album_id", id, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusForbidden, mappersv1.MapFromStatus(http.StatusForbidden, "access denied"))
+		return
+	}
+
+	var form apiv1.AlbumThumbnailRequestPayload
+	if err := c.ShouldBindJSON(&form); err != nil {
+		zap.S().Errorw("failed to bind to payload", "error", err, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "failed to parse payload: %s", err))
+		return
+	}
+
+	pID, err := server.EncryptionService().Decrypt(form.PhotoId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt photo id", "error", err, "photo_id", form.PhotoId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "photo with id '%s' not found", form.PhotoId))
+		return
+	}
+
+	photo, found := findPhoto(album, pID)
+	if !found {
+		zap.S().Errorw("photo not found", "album_id", id, "photo_id", pID, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "photo with id '%s' not found", form.PhotoId))
+		return
+	}
+
+	updatedAlbum, err := server.AlbumService().SetThumbnail(c, album, photo)
+	if err != nil {
+		zap.S().Errorw("failed to set album thumbnail", "error", err, "album_id", id, "filename", photo.Filename, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapAlbumToModel(updatedAlbum))
+}
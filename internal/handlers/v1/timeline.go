@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"github.com/tupyy/gophoto/internal/services/timeline"
+	"go.uber.org/zap"
+)
+
+const defaultTimelinePageSize = 100
+
+// (GET /api/gphotos/v1/timeline)
+func (server *Server) GetTimeline(c *gin.Context, params apiv1.GetTimelineParams) {
+	session := c.MustGet("session").(entity.Session)
+
+	items, err := server.TimelineService().Get(c, session.User, media.Filter{From: params.From, To: params.To})
+	if err != nil {
+		zap.S().Errorw("failed to get timeline", "error", err, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	granularity := timeline.ByMonth
+	if params.GroupBy != nil {
+		switch *params.GroupBy {
+		case "year":
+			granularity = timeline.ByYear
+		case "day":
+			granularity = timeline.ByDay
+		}
+	}
+
+	buckets := timeline.Group(items, granularity)
+
+	size := defaultTimelinePageSize
+	if params.Size != nil {
+		size = int(*params.Size)
+	}
+
+	cursor := ""
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	page, next, err := timeline.Paginate(items, cursor, size)
+	if err != nil {
+		zap.S().Errorw("failed to paginate timeline", "error", err, "cursor", cursor, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "invalid cursor '%s'", cursor))
+		return
+	}
+
+	page, err = server.TimelineService().WithMetadata(c, session.User, page)
+	if err != nil {
+		zap.S().Errorw("failed to get photos metadata", "error", err, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	model := mappersv1.MapTimelineToModel(page, buckets)
+	if len(next) > 0 {
+		model.NextCursor = &next
+	}
+
+	c.JSON(http.StatusOK, model)
+}
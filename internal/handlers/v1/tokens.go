@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services"
+	"go.uber.org/zap"
+)
+
+// (GET /api/gphotos/v1/tokens)
+func (server *Server) GetAccessTokens(c *gin.Context) {
+	session := c.MustGet("session").(entity.Session)
+
+	tokens, err := server.AccessTokenService().List(c, session.User.Username)
+	if err != nil {
+		zap.S().Errorw("failed to get access tokens", "error", err, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapAccessTokensToList(tokens))
+}
+
+// (POST /api/gphotos/v1/tokens)
+func (server *Server) CreateAccessToken(c *gin.Context) {
+	session := c.MustGet("session").(entity.Session)
+
+	var form apiv1.AccessTokenRequestPayload
+	if err := c.ShouldBindJSON(&form); err != nil {
+		zap.S().Errorw("failed to bind to payload", "error", err, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "failed to parse payload: %s", err))
+		return
+	}
+
+	scope, err := entity.NewTokenScope(string(form.Scope))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "unknown scope '%s'", form.Scope))
+		return
+	}
+
+	var expiresAt time.Time
+	if form.ExpiresAt != nil {
+		expiresAt = *form.ExpiresAt
+	}
+
+	token, secret, err := server.AccessTokenService().Create(c, session.User, escapeField(form.Name), scope, expiresAt)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAccessToken) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatus(http.StatusBadRequest, err.Error()))
+			return
+		}
+
+		zap.S().Errorw("failed to create access token", "error", err, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	zap.S().Infow("access token created", "token_id", token.ID, "scope", token.Scope, "user", session.User.Username)
+
+	c.JSON(http.StatusCreated, mappersv1.MapCreatedAccessTokenToModel(token, secret))
+}
+
+// (DELETE /api/gphotos/v1/tokens/{token_id})
+func (server *Server) DeleteAccessToken(c *gin.Context, tokenId apiv1.TokenId) {
+	session := c.MustGet("session").(entity.Session)
+
+	tokenID, err := server.EncryptionService().Decrypt(tokenId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt token id", "error", err, "token_id", tokenId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "access token '%s' not found", tokenId))
+		return
+	}
+
+	if err := server.AccessTokenService().Revoke(c, session.User.Username, tokenID); err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "access token '%s' not found", tokenId))
+			return
+		}
+
+		zap.S().Errorw("failed to revoke access token", "error", err, "token_id", tokenID, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	zap.S().Infow("access token revoked", "token_id", tokenID, "user", session.User.Username)
+
+	c.JSON(http.StatusNoContent, gin.H{})
+}
@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"go.uber.org/zap"
+)
+
+// (POST /api/gphotos/v1/albums/{album_id}/photos/move)
+func (server *Server) MovePhotos(c *gin.Context, albumId apiv1.AlbumId) {
+	session := c.MustGet("session").(entity.Session)
+
+	src, dst, photos, ok := server.resolveTransfer(c, session, albumId)
+	if !ok {
+		return
+	}
+
+	// moving between the albums of the same owner does not change the owner's usage
+	if src.Owner != dst.Owner && !server.checkQuota(c, session, dst, transferSize(photos)) {
+		return
+	}
+
+	if err := server.MediaService().Move(c, src, dst, photos); err != nil {
+		zap.S().Errorw("failed to move photos", "error", err, "album_id", src.ID, "target_album_id", dst.ID, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	zap.S().Infow("photos moved", "album_id", src.ID, "target_album_id", dst.ID, "count", len(photos), "user", session.User.Username)
+
+	coverMoved := false
+	for _, photo := range photos {
+		coverMoved = coverMoved || media.IsCover(photo, src.Thumbnail)
+	}
+
+	server.refreshAlbum(c, session, src.ID, coverMoved)
+	server.refreshAlbum(c, session, dst.ID, len(dst.Thumbnail) == 0)
+
+	c.JSON(http.StatusOK, mappersv1.MapMediaListToModel(dst, transferred(dst, photos)))
+}
+
+// (POST /api/gphotos/v1/albums/{album_id}/photos/copy)
+func (server *Server) CopyPhotos(c *gin.Context, albumId apiv1.AlbumId) {
+	session := c.MustGet("session").(entity.Session)
+
+	src, dst, photos, ok := server.resolveTransfer(c, session, albumId)
+	if !ok {
+		return
+	}
+
+	if !server.checkQuota(c, session, dst, transferSize(photos)) {
+		return
+	}
+
+	if err := server.MediaService().Copy(c, src, dst, photos); err != nil {
+		zap.S().Errorw("failed to copy photos", "error", err, "album_id", src.ID, "target_album_id", dst.ID, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	zap.S().Infow("photos copied", "album_id", src.ID, "target_album_id", dst.ID, "count", len(photos), "user", session.User.Username)
+
+	server.refreshAlbum(c, session, dst.ID, len(dst.Thumbnail) == 0)
+
+	c.JSON(http.StatusOK, mappersv1.MapMediaListToModel(dst, transferred(dst, photos)))
+}
+
+// resolveTransfer fetches both albums and the photos to transfer. The user must have the write permission on both albums.
+// The request is aborted if false is returned.
+func (server *Server) resolveTransfer(c *gin.Context, session entity.Session, albumId apiv1.AlbumId) (entity.Album, entity.Album, []entity.Media, bool) {
+	var payload apiv1.PhotoTransferRequestPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		zap.S().Errorw("failed to bind to payload", "error", err, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatusf(http.StatusBadRequest, "failed to parse payload: %s", err))
+		return entity.Album{}, entity.Album{}, nil, false
+	}
+
+	if len(payload.PhotoIds) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatus(http.StatusBadRequest, "no photo to transfer"))
+		return entity.Album{}, entity.Album{}, nil, false
+	}
+
+	src, ok := server.writableAlbum(c, session, albumId)
+	if !ok {
+		return entity.Album{}, entity.Album{}, nil, false
+	}
+
+	dst, ok := server.writableAlbum(c, session, payload.TargetAlbumId)
+	if !ok {
+		return entity.Album{}, entity.Album{}, nil, false
+	}
+
+	if src.ID == dst.ID {
+		c.AbortWithStatusJSON(http.StatusBadRequest, mappersv1.MapFromStatus(http.StatusBadRequest, "target album is the album of the photos"))
+		return entity.Album{}, entity.Album{}, nil, false
+	}
+
+	existing := make(map[string]struct{}, len(dst.Photos))
+	for _, photo := range dst.Photos {
+		existing[photo.Filename] = struct{}{}
+	}
+
+	photos := make([]entity.Media, 0, len(payload.PhotoIds))
+	seen := make(map[string]struct{})
+	for _, photoId := range payload.PhotoIds {
+		pID, err := server.EncryptionService().Decrypt(photoId)
+		if err != nil {
+			zap.S().Errorw("failed to decrypt photo id", "error", err, "photo_id", photoId, "user", session.User.Username)
+			c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "photo with id '%s' not found", photoId))
+			return entity.Album{}, entity.Album{}, nil, false
+		}
+
+		photo, found := findPhoto(src, pID)
+		if !found {
+			zap.S().Errorw("photo not found", "album_id", src.ID, "photo_id", pID, "user", session.User.Username)
+			c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "photo with id '%s' not found", photoId))
+			return entity.Album{}, entity.Album{}, nil, false
+		}
+
+		if _, found := existing[photo.Filename]; found {
+			c.AbortWithStatusJSON(http.StatusConflict, mappersv1.MapFromStatusf(http.StatusConflict, "a photo named '%s' already exists in the target album", photo.Filename))
+			return entity.Album{}, entity.Album{}, nil, false
+		}
+
+		if _, found := seen[photo.Filename]; found {
+			continue
+		}
+		seen[photo.Filename] = struct{}{}
+
+		photos = append(photos, photo)
+	}
+
+	return src, dst, photos, true
+}
+
+// transferred returns the photos as stored in the target album.
+func transferred(dst entity.Album, photos []entity.Media) []entity.Media {
+	copies := make([]entity.Media, 0, len(photos))
+	for _, photo := range photos {
+		photo.Bucket = dst.Bucket
+		copies = append(copies, photo)
+	}
+
+	return copies
+}
+
+// transferSize returns the number of bytes of the photos. Their thumbnails are not counted.
+func transferSize(photos []entity.Media) int64 {
+	var size int64
+	for _, photo := range photos {
+		size += photo.Size
+	}
+
+	return size
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services"
+	"go.uber.org/zap"
+)
+
+// (GET /api/gphotos/v1/albums/{album_id}/trash)
+// The trash is listed by the users who can restore its photos.
+func (server *Server) GetAlbumTrash(c *gin.Context, albumId apiv1.AlbumId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, ok := server.writableAlbum(c, session, albumId)
+	if !ok {
+		return
+	}
+
+	trash, err := server.MediaService().Trashed(c, album.Bucket)
+	if err != nil {
+		zap.S().Errorw("failed to list trash", "error", err, "album_id", album.ID, "user", session.User.Username)
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapTrashedMediaListToModel(album, trash))
+}
+
+// (POST /api/gphotos/v1/albums/{album_id}/trash/{trash_id}/restore)
+func (server *Server) RestoreTrashedPhoto(c *gin.Context, albumId apiv1.AlbumId, trashId apiv1.TrashId) {
+	session := c.MustGet("session").(entity.Session)
+
+	album, ok := server.writableAlbum(c, session, albumId)
+	if !ok {
+		return
+	}
+
+	restored, err := server.MediaService().Restore(c, album.Bucket, trashId)
+	if err != nil {
+		zap.S().Errorw("failed to restore photo", "error", err, "album_id", album.ID, "trash_id", trashId, "user", session.User.Username)
+		switch {
+		case errors.Is(err, services.ErrNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "trashed photo with id '%s' not found", trashId))
+		case errors.Is(err, services.ErrMediaExists):
+			c.AbortWithStatusJSON(http.StatusConflict, mappersv1.MapFromStatus(http.StatusConflict, err.Error()))
+		default:
+			apiErr := mappersv1.MapFromError(err)
+			c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		}
+		return
+	}
+
+	// an album emptied by the trash gets the restored photo as cover.
+	server.refreshAlbum(c, session, album.ID, len(album.Thumbnail) == 0)
+
+	c.JSON(http.StatusOK, mappersv1.MapMediaToModel(album, entity.Media{
+		MediaType: entity.Photo,
+		Bucket:    album.Bucket,
+		Filename:  restored.Filename,
+	}))
+}
@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	mappersv1 "github.com/tupyy/gophoto/internal/mappers/v1"
+	"github.com/tupyy/gophoto/internal/services"
+	"github.com/tupyy/gophoto/internal/services/quota"
+	"go.uber.org/zap"
+)
+
+// (GET /api/gphotos/v1/users/{user_id}/usage)
+func (server *Server) GetUserUsage(c *gin.Context, userId apiv1.UserId) {
+	session := c.MustGet("session").(entity.Session)
+
+	username, err := server.EncryptionService().Decrypt(userId)
+	if err != nil {
+		zap.S().Errorw("failed to decrypt user id", "error", err, "user_id", userId, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "user with id '%s' not found", userId))
+		return
+	}
+
+	if username != session.User.Username && session.User.Role != entity.RoleAdmin {
+		zap.S().Errorw("user has no permission to get the usage of another user", "target_user", username, "user", session.User.Username)
+		c.AbortWithStatusJSON(http.StatusForbidden, mappersv1.MapFromStatus(http.StatusForbidden, "access denied"))
+		return
+	}
+
+	var usage quota.Usage
+	if username == session.User.Username {
+		usage, err = server.QuotaService().Usage(c, session.User)
+	} else {
+		usage, err = server.QuotaService().UsageOf(c, username)
+	}
+	if err != nil {
+		zap.S().Errorw("failed to get usage", "error", err, "target_user", username, "user", session.User.Username)
+		if errors.Is(err, services.ErrNotFound) {
+			c.AbortWithStatusJSON(http.StatusNotFound, mappersv1.MapFromStatusf(http.StatusNotFound, "user with id '%s' not found", userId))
+			return
+		}
+
+		apiErr := mappersv1.MapFromError(err)
+		c.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappersv1.MapUsageToModel(usage))
+}
+
+// checkQuota checks that size bytes can be added to the album without exceeding the quota of the album's owner.
+// The request is aborted with 507 if the quota is exceeded and false is returned.
+func (server *Server) checkQuota(c *gin.Context, session entity.Session, album entity.Album, size int64) bool {
+	err := server.QuotaService().Check(c, album, size, session.User)
+	if err == nil {
+		return true
+	}
+
+	zap.S().Errorw("failed to check quota", "error", err, "album_id", album.ID, "size", size, "user", session.User.Username)
+	if errors.Is(err, services.ErrQuotaExceeded) {
+		c.AbortWithStatusJSON(http.StatusInsufficientStorage, mappersv1.MapFromStatus(http.StatusInsufficientStorage, err.Error()))
+		return false
+	}
+
+	apiErr := mappersv1.MapFromError(err)
+	c.AbortWithStatusJSON(apiErr.Code, apiErr)
+
+	return false
+}
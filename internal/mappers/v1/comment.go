@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"fmt"
+	"sort"
+
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/encryption"
+)
+
+func MapCommentToModel(album entity.Album, photo entity.Media, comment entity.Comment) apiv1.Comment {
+	encryption, _ := encryption.New() // must not fail here. todo find a better way
+
+	encryptedID, _ := encryption.Encrypt(comment.ID)
+	photoRef := mapPhotoRef(album, photo)
+
+	model := apiv1.Comment{
+		Id:        encryptedID,
+		Href:      fmt.Sprintf("%s/comments/%s", photoRef.Href, encryptedID),
+		Kind:      CommentKind,
+		Photo:     photoRef,
+		Author:    mapUserRef(comment.Author),
+		Content:   comment.Content,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+
+	return model
+}
+
+func MapCommentsToList(album entity.Album, photo entity.Media, comments []entity.Comment) apiv1.CommentList {
+	list := apiv1.CommentList{
+		Kind:  CommentListKind,
+		Page:  1,
+		Size:  len(comments),
+		Total: len(comments),
+		Items: make([]apiv1.Comment, 0, len(comments)),
+	}
+
+	for _, c := range comments {
+		list.Items = append(list.Items, MapCommentToModel(album, photo, c))
+	}
+
+	return list
+}
+
+func MapCommentHistoryToModel(album entity.Album, photo entity.Media, comment entity.Comment, revisions []entity.CommentRevision) apiv1.CommentHistory {
+	c := MapCommentToModel(album, photo, comment)
+
+	history := apiv1.CommentHistory{
+		Kind:  CommentHistoryKind,
+		Page:  1,
+		Size:  len(revisions),
+		Total: len(revisions),
+		Comment: &apiv1.ObjectReference{
+			Id:   c.Id,
+			Href: c.Href,
+			Kind: c.Kind,
+		},
+		Items: make([]apiv1.CommentRevision, 0, len(revisions)),
+	}
+
+	for _, r := range revisions {
+		history.Items = append(history.Items, apiv1.CommentRevision{
+			Content:   r.Content,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+
+	return history
+}
+
+// MapReactionsToList groups the reactions by kind. Reacted is set if user has reacted with that kind.
+func MapReactionsToList(reactions []entity.Reaction, user entity.User) apiv1.ReactionList {
+	summaries := make(map[string]*apiv1.ReactionSummary)
+	for _, r := range reactions {
+		summary, found := summaries[r.Kind]
+		if !found {
+			summary = &apiv1.ReactionSummary{
+				Kind:  r.Kind,
+				Users: []apiv1.ObjectReference{},
+			}
+			summaries[r.Kind] = summary
+		}
+
+		summary.Count++
+		summary.Users = append(summary.Users, mapUserRef(r.User))
+
+		if r.User == user.Username {
+			summary.Reacted = true
+		}
+	}
+
+	list := apiv1.ReactionList{
+		Kind:  ReactionListKind,
+		Page:  1,
+		Size:  len(summaries),
+		Total: len(summaries),
+		Items: make([]apiv1.ReactionSummary, 0, len(summaries)),
+	}
+
+	for _, s := range summaries {
+		list.Items = append(list.Items, *s)
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].Kind < list.Items[j].Kind
+	})
+
+	return list
+}
+
+func mapPhotoRef(album entity.Album, photo entity.Media) apiv1.ObjectReference {
+	albumRef := mapAlbumRef(album)
+	p := MapMediaToModel(album, photo)
+
+	return apiv1.ObjectReference{
+		Id:   p.Id,
+		Href: fmt.Sprintf("%s/album/%s/photo/%s", baseV1URL, albumRef.Id, p.Id),
+		Kind: PhotoKind,
+	}
+}
+
+func mapUserRef(username string) apiv1.ObjectReference {
+	encryption, _ := encryption.New() // must not fail here. todo find a better way
+	encryptedUsername, _ := encryption.Encrypt(username)
+
+	return apiv1.ObjectReference{
+		Kind: UserKind,
+		Href: fmt.Sprintf("%s/users/%s", baseV1URL, encryptedUsername),
+		Id:   encryptedUsername,
+	}
+}
@@ -8,22 +8,36 @@ import (
 )
 
 type EncryptionService interface {
-	// Encrypt data in a deterministic way.
+	// Encrypt data into an opaque token.
 	Encrypt(data string) (string, error)
 	// Decrypt data.
 	Decrypt(data string) (string, error)
 }
 
 const (
-	AlbumKind            string = "Album"
-	AlbumListKind        string = "AlbumList"
-	AlbumPermissionsKind string = "AlbumPermissionsList"
-	PhotoKind            string = "Photo"
-	PhotoListKind        string = "PhotoList"
-	UserKind             string = "User"
-	GroupKind            string = "Group"
-	TagKind              string = "Tag"
-	TagListKind          string = "TagList"
+	AlbumKind             string = "Album"
+	AlbumListKind         string = "AlbumList"
+	AlbumPermissionsKind  string = "AlbumPermissionsList"
+	PhotoKind             string = "Photo"
+	PhotoListKind         string = "PhotoList"
+	TrashedPhotoListKind  string = "TrashedPhotoList"
+	UserKind              string = "User"
+	GroupKind             string = "Group"
+	TagKind               string = "Tag"
+	TagListKind           string = "TagList"
+	EventKind             string = "Event"
+	CommentKind           string = "Comment"
+	CommentListKind       string = "CommentList"
+	CommentHistoryKind    string = "CommentHistory"
+	ReactionListKind      string = "ReactionList"
+	TimelineKind          string = "Timeline"
+	MapClusterListKind    string = "MapClusterList"
+	SimilarGroupListKind  string = "SimilarGroupList"
+	AlbumProposalListKind string = "AlbumProposalList"
+	UserUsageKind         string = "UserUsage"
+	FsckReportKind        string = "FsckReport"
+	AccessTokenKind       string = "AccessToken"
+	AccessTokenListKind   string = "AccessTokenList"
 )
 
 func MapFromError(err error) apiv1.Error {
@@ -0,0 +1,25 @@
+package v1
+
+import (
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+func MapEventToModel(album entity.Album, event entity.Event) apiv1.Event {
+	model := apiv1.Event{
+		Kind:      string(event.Kind),
+		Album:     mapAlbumRef(album),
+		CreatedAt: event.CreatedAt,
+	}
+
+	if !event.IsAlbumEvent() && len(event.Filename) > 0 {
+		photo := MapMediaToModel(album, entity.Media{Bucket: event.Bucket, Filename: event.Filename})
+		model.Photo = &apiv1.ObjectReference{
+			Id:   photo.Id,
+			Href: photo.Href,
+			Kind: photo.Kind,
+		}
+	}
+
+	return model
+}
@@ -0,0 +1,49 @@
+package v1
+
+import (
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/fsck"
+)
+
+func MapFsckIssuesToModel(issues []fsck.Issue) apiv1.FsckReport {
+	model := apiv1.FsckReport{
+		Kind:   FsckReportKind,
+		Issues: make([]apiv1.FsckIssue, 0, len(issues)),
+	}
+
+	for _, issue := range issues {
+		i := apiv1.FsckIssue{
+			Kind:   apiv1.FsckIssueKind(issue.Kind),
+			Bucket: issue.Bucket,
+		}
+
+		if len(issue.AlbumID) > 0 {
+			ref := mapAlbumRef(entity.Album{ID: issue.AlbumID})
+			i.Album = &ref
+		}
+
+		if len(issue.Name) > 0 {
+			name := issue.Name
+			i.Name = &name
+		}
+
+		if len(issue.Action) > 0 {
+			action := issue.Action
+			i.Action = &action
+		}
+
+		if issue.Err != nil {
+			reason := issue.Err.Error()
+			i.Error = &reason
+		}
+
+		if issue.Repaired() {
+			model.Repaired++
+		}
+
+		model.Issues = append(model.Issues, i)
+	}
+
+	return model
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/geo"
+)
+
+func MapClustersToModel(clusters []geo.Cluster, zoom int) apiv1.MapClusterList {
+	model := apiv1.MapClusterList{
+		Kind:  MapClusterListKind,
+		Zoom:  zoom,
+		Items: make([]apiv1.MapCluster, 0, len(clusters)),
+	}
+
+	for _, c := range clusters {
+		model.Items = append(model.Items, apiv1.MapCluster{
+			Center:      mapGeoPoint(c.Center),
+			BoundingBox: mapBoundingBox(c.Bounds),
+			Count:       c.Count,
+			Cover:       MapMediaToModel(c.Cover.Album, c.Cover.Photo),
+		})
+		model.Total += c.Count
+	}
+
+	return model
+}
+
+func MapAlbumLocationToModel(album entity.Album, bbox entity.BoundingBox, count int) apiv1.AlbumLocation {
+	center := bbox.Center()
+
+	suggestedLocation := center.String()
+	if !album.Place.IsZero() {
+		suggestedLocation = album.Place.String()
+	}
+
+	return apiv1.AlbumLocation{
+		Album:             mapAlbumRef(album),
+		BoundingBox:       mapBoundingBox(bbox),
+		Center:            mapGeoPoint(center),
+		Count:             count,
+		SuggestedLocation: suggestedLocation,
+	}
+}
+
+func mapGeoPoint(p entity.GeoPoint) apiv1.GeoPoint {
+	return apiv1.GeoPoint{
+		Latitude:  p.Latitude,
+		Longitude: p.Longitude,
+	}
+}
+
+func mapBoundingBox(b entity.BoundingBox) apiv1.BoundingBox {
+	return apiv1.BoundingBox{
+		South: b.South,
+		West:  b.West,
+		North: b.North,
+		East:  b.East,
+	}
+}
+
+// mapPlace returns nil if the place is unknown.
+func mapPlace(p entity.Place) *apiv1.Place {
+	if p.IsZero() {
+		return nil
+	}
+
+	return &apiv1.Place{
+		City:    &p.City,
+		Region:  &p.Region,
+		Country: &p.Country,
+	}
+}
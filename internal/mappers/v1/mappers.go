@@ -31,8 +31,10 @@ func MapAlbumToModel(album entity.Album) apiv1.Album {
 		Name:        album.Name,
 		Description: &album.Description,
 		Location:    &album.Location,
+		Place:       mapPlace(album.Place),
 		CreatedAt:   album.CreatedAt,
 		Thumbnail:   &album.Thumbnail,
+		Encrypted:   &album.Encrypted,
 		Owner: apiv1.ObjectReference{
 			Kind: UserKind,
 			Href: fmt.Sprintf("%s/users/%s", baseV1URL, encryptedUsername),
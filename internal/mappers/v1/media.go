@@ -2,10 +2,12 @@ package v1
 
 import (
 	"fmt"
+	"time"
 
 	apiv1 "github.com/tupyy/gophoto/api/v1"
 	"github.com/tupyy/gophoto/internal/entity"
 	"github.com/tupyy/gophoto/internal/services/encryption"
+	"github.com/tupyy/gophoto/internal/services/media"
 )
 
 func MapMediaToModel(album entity.Album, photo entity.Media) apiv1.Photo {
@@ -14,13 +16,38 @@ func MapMediaToModel(album entity.Album, photo entity.Media) apiv1.Photo {
 	encryption, _ := encryption.New() // must not fail here. todo find a better way
 	encryptedID, _ := encryption.Encrypt(id)
 
+	tags := make([]apiv1.Tag, 0, len(photo.Tags))
+	for _, tag := range photo.Tags {
+		tags = append(tags, MapTagToModel(tag))
+	}
+
 	model := apiv1.Photo{
-		Album:     mapAlbumRef(album),
-		Id:        encryptedID,
-		Href:      fmt.Sprintf("%s/photo/%s", baseV1URL, encryptedID),
-		Kind:      PhotoKind,
-		Thumbnail: fmt.Sprintf("%s/photo/%s/thumbnail", baseV1URL, encryptedID),
+		Album:       mapAlbumRef(album),
+		Id:          encryptedID,
+		Href:        fmt.Sprintf("%s/photo/%s", baseV1URL, encryptedID),
+		Kind:        PhotoKind,
+		Thumbnail:   fmt.Sprintf("%s/photo/%s/thumbnail", baseV1URL, encryptedID),
+		Caption:     &photo.Caption,
+		Description: &photo.Description,
+		Favorite:    &photo.Favorite,
+		Tags:        &tags,
+		Size:        &photo.Size,
+		Place:       mapPlace(photo.Place),
+	}
+
+	if !photo.CreateDate.IsZero() {
+		model.CaptureDate = &photo.CreateDate
+	}
+
+	if !photo.UploadDate.IsZero() {
+		model.UploadDate = &photo.UploadDate
+	}
+
+	if photo.Location != nil {
+		location := mapGeoPoint(*photo.Location)
+		model.Location = &location
 	}
+
 	return model
 }
 
@@ -32,5 +59,49 @@ func MapMediaListToModel(album entity.Album, photos []entity.Media) apiv1.PhotoL
 	for _, photo := range photos {
 		model.Items = append(model.Items, MapMediaToModel(album, photo))
 	}
+	model.Size = len(model.Items)
+	return model
+}
+
+func MapTrashedMediaToModel(album entity.Album, trashed media.TrashedMedia) apiv1.TrashedPhoto {
+	return apiv1.TrashedPhoto{
+		Id:        trashed.ID,
+		Album:     mapAlbumRef(album),
+		Filename:  trashed.Filename,
+		TrashedAt: trashed.TrashedAt,
+		ExpiresAt: trashed.ExpiresAt(),
+	}
+}
+
+func MapTrashedMediaListToModel(album entity.Album, trash []media.TrashedMedia) apiv1.TrashedPhotoList {
+	model := apiv1.TrashedPhotoList{
+		Items: make([]apiv1.TrashedPhoto, 0, len(trash)),
+		Kind:  TrashedPhotoListKind,
+	}
+	for _, trashed := range trash {
+		model.Items = append(model.Items, MapTrashedMediaToModel(album, trashed))
+	}
+	model.Size = len(model.Items)
 	return model
 }
+
+func MapPresignedURLToModel(u string, expiresAt time.Time) apiv1.PresignedUrl {
+	return apiv1.PresignedUrl{
+		Url:       u,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// MapPhotoUploadToModel returns the upload url. The id of the upload is the encrypted name of the uploaded object.
+func MapPhotoUploadToModel(upload media.PresignedUpload) apiv1.PhotoUpload {
+	encryption, _ := encryption.New()
+	encryptedID, _ := encryption.Encrypt(upload.Upload)
+
+	return apiv1.PhotoUpload{
+		Id:        encryptedID,
+		Url:       upload.URL,
+		Fields:    apiv1.PhotoUpload_Fields{AdditionalProperties: upload.Fields},
+		MaxSize:   upload.MaxSize,
+		ExpiresAt: upload.ExpiresAt,
+	}
+}
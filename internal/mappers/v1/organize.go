@@ -0,0 +1,35 @@
+package v1
+
+import (
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/organize"
+)
+
+func MapProposalsToModel(album entity.Album, proposals []organize.Proposal) apiv1.AlbumProposalList {
+	model := apiv1.AlbumProposalList{
+		Kind:  AlbumProposalListKind,
+		Album: mapAlbumRef(album),
+		Items: make([]apiv1.AlbumProposal, 0, len(proposals)),
+	}
+
+	for _, p := range proposals {
+		photos := make([]apiv1.Photo, 0, len(p.Photos))
+		for _, photo := range p.Photos {
+			photos = append(photos, MapMediaToModel(album, photo))
+		}
+
+		model.Items = append(model.Items, apiv1.AlbumProposal{
+			Name:     p.Name,
+			Location: p.Location,
+			Place:    mapPlace(p.Place),
+			Start:    p.Start,
+			End:      p.End,
+			Count:    len(p.Photos),
+			Photos:   photos,
+		})
+		model.Total += len(p.Photos)
+	}
+
+	return model
+}
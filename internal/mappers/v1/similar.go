@@ -0,0 +1,43 @@
+package v1
+
+import (
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/services/similar"
+	"github.com/tupyy/gophoto/internal/services/timeline"
+)
+
+// MapSimilarGroupsToModel maps the groups of similar photos. bests holds the best photo of each group.
+func MapSimilarGroupsToModel(groups []similar.Group, bests []timeline.Item, threshold int) apiv1.SimilarGroupList {
+	model := apiv1.SimilarGroupList{
+		Kind:      SimilarGroupListKind,
+		Threshold: threshold,
+		Items:     make([]apiv1.SimilarGroup, 0, len(groups)),
+	}
+
+	for i, g := range groups {
+		model.Items = append(model.Items, apiv1.SimilarGroup{
+			Best:  MapMediaToModel(bests[i].Album, bests[i].Photo),
+			Count: len(g.Items),
+			Items: mapItems(g.Items),
+		})
+		model.Total += len(g.Items)
+	}
+
+	return model
+}
+
+func MapSimilarTrashResultToModel(kept timeline.Item, trashed []apiv1.TrashedPhoto) apiv1.SimilarTrashResult {
+	return apiv1.SimilarTrashResult{
+		Kept:    MapMediaToModel(kept.Album, kept.Photo),
+		Trashed: trashed,
+	}
+}
+
+func mapItems(items []timeline.Item) []apiv1.Photo {
+	photos := make([]apiv1.Photo, 0, len(items))
+	for _, item := range items {
+		photos = append(photos, MapMediaToModel(item.Album, item.Photo))
+	}
+
+	return photos
+}
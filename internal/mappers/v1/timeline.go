@@ -0,0 +1,45 @@
+package v1
+
+import (
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/services/timeline"
+)
+
+func MapTimelineToModel(items []timeline.Item, buckets []timeline.Bucket) apiv1.Timeline {
+	model := apiv1.Timeline{
+		Kind:    TimelineKind,
+		Items:   make([]apiv1.Photo, 0, len(items)),
+		Buckets: make([]apiv1.TimelineBucket, 0, len(buckets)),
+	}
+
+	for _, item := range items {
+		model.Items = append(model.Items, MapMediaToModel(item.Album, item.Photo))
+	}
+
+	for _, b := range buckets {
+		bucket := apiv1.TimelineBucket{
+			Year:  b.Year(),
+			Count: b.Count,
+		}
+
+		if month := b.Month(); month > 0 {
+			bucket.Month = &month
+		}
+
+		if day := b.Day(); day > 0 {
+			bucket.Day = &day
+		}
+
+		if len(b.Cursor) > 0 {
+			cursor := b.Cursor
+			bucket.Cursor = &cursor
+		}
+
+		model.Buckets = append(model.Buckets, bucket)
+		model.Total += b.Count
+	}
+
+	model.Size = len(model.Items)
+
+	return model
+}
@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"fmt"
+
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/encryption"
+)
+
+func MapAccessTokenToModel(token entity.AccessToken) apiv1.AccessToken {
+	encryption, _ := encryption.New() // must not fail here. todo find a better way
+
+	encryptedID, _ := encryption.Encrypt(token.ID)
+
+	model := apiv1.AccessToken{
+		Id:        encryptedID,
+		Href:      fmt.Sprintf("%s/tokens/%s", baseV1URL, encryptedID),
+		Kind:      AccessTokenKind,
+		Name:      token.Name,
+		Scope:     apiv1.AccessTokenScope(token.Scope),
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+	}
+
+	if !token.LastUsedAt.IsZero() {
+		lastUsedAt := token.LastUsedAt
+		model.LastUsedAt = &lastUsedAt
+	}
+
+	return model
+}
+
+// MapCreatedAccessTokenToModel maps a new token with its secret.
+func MapCreatedAccessTokenToModel(token entity.AccessToken, secret string) apiv1.CreatedAccessToken {
+	m := MapAccessTokenToModel(token)
+
+	return apiv1.CreatedAccessToken{
+		Id:         m.Id,
+		Href:       m.Href,
+		Kind:       m.Kind,
+		Name:       m.Name,
+		Scope:      m.Scope,
+		CreatedAt:  m.CreatedAt,
+		ExpiresAt:  m.ExpiresAt,
+		LastUsedAt: m.LastUsedAt,
+		Token:      secret,
+	}
+}
+
+func MapAccessTokensToList(tokens []entity.AccessToken) apiv1.AccessTokenList {
+	list := apiv1.AccessTokenList{
+		Kind:  AccessTokenListKind,
+		Page:  1,
+		Size:  len(tokens),
+		Total: len(tokens),
+		Items: make([]apiv1.AccessToken, 0, len(tokens)),
+	}
+
+	for _, token := range tokens {
+		list.Items = append(list.Items, MapAccessTokenToModel(token))
+	}
+
+	return list
+}
@@ -0,0 +1,29 @@
+package v1
+
+import (
+	apiv1 "github.com/tupyy/gophoto/api/v1"
+	"github.com/tupyy/gophoto/internal/services/quota"
+)
+
+func MapUsageToModel(usage quota.Usage) apiv1.UserUsage {
+	model := apiv1.UserUsage{
+		Kind:   UserUsageKind,
+		Used:   usage.Used,
+		Albums: make([]apiv1.AlbumUsage, 0, len(usage.Albums)),
+	}
+
+	if usage.Limited {
+		limit := usage.Limit
+		model.Limit = &limit
+	}
+
+	for _, a := range usage.Albums {
+		model.Albums = append(model.Albums, apiv1.AlbumUsage{
+			Album: mapAlbumRef(a.Album),
+			Name:  a.Album.Name,
+			Bytes: a.Bytes,
+		})
+	}
+
+	return model
+}
@@ -61,15 +61,15 @@ func (k *KeycloakRepo) GetUsers(ctx context.Context, filters userFilters.Filters
 	}
 
 	// get groups
-	for _, user := range users {
-		groups, err := k.client.GetUserGroups(ctx, k.token.AccessToken, k.realm, user.ID, keycloak.GetGroupsParams{})
+	for i := range users {
+		groups, err := k.client.GetUserGroups(ctx, k.token.AccessToken, k.realm, users[i].ID, keycloak.GetGroupsParams{})
 		if err != nil {
 			return []entity.User{}, err
 		}
 
-		user.Groups = make([]entity.Group, 0, len(groups))
+		users[i].Groups = make([]entity.Group, 0, len(groups))
 		for _, g := range groups {
-			user.Groups = append(user.Groups, entity.Group{Name: *g.Name})
+			users[i].Groups = append(users[i].Groups, entity.Group{Name: *g.Name})
 		}
 	}
 
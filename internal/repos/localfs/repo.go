@@ -0,0 +1,499 @@
+package localfs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/image"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"go.uber.org/zap"
+)
+
+const (
+	// labelsFile holds the labels of a container.
+	labelsFile = ".labels.json"
+	// metadataSuffix is the suffix of the file holding the metadata of an object. The file is hidden next to the object.
+	metadataSuffix = ".metadata.json"
+
+	thumbnailFolder = "thumbnail"
+	photoFolder     = "photos"
+)
+
+// LocalRepo stores the media on the local filesystem. Each container is a directory of root
+// and the metadata of the objects is kept in hidden files next to them.
+type LocalRepo struct {
+	root string
+}
+
+func New(root string) (*LocalRepo, error) {
+	if len(root) == 0 {
+		return nil, errors.New("root directory of the storage is missing")
+	}
+
+	if err := os.MkdirAll(root, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create root directory '%s': %w", root, err)
+	}
+
+	return &LocalRepo{root}, nil
+}
+
+func (l *LocalRepo) CreateContainer(ctx context.Context, container string, labels map[string]string) error {
+	dir, err := l.containerPath(container)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0750); err != nil {
+		return fmt.Errorf("failed to create container '%s': %w", container, err)
+	}
+
+	if err := os.Mkdir(dir, 0750); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("container '%s' already exists", container)
+		}
+		return fmt.Errorf("failed to create container '%s': %w", container, err)
+	}
+
+	return l.SetLabels(ctx, container, labels)
+}
+
+func (l *LocalRepo) DeleteContainer(ctx context.Context, container string) error {
+	dir, err := l.containerPath(container)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to delete container '%s': %w", container, err)
+	}
+
+	return nil
+}
+
+func (l *LocalRepo) Put(ctx context.Context, container, name string, size int64, r io.Reader, metadata map[string]string) error {
+	p, err := l.objectPath(container, name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0750); err != nil {
+		return fmt.Errorf("failed to upload file %s to container %s: %w", name, container, err)
+	}
+
+	// the object is written to a temporary file first so a failed upload does not leave a partial object
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to upload file %s to container %s: %w", name, container, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to upload file %s to container %s: %w", name, container, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to upload file %s to container %s: %w", name, container, err)
+	}
+
+	if size >= 0 && n != size {
+		return fmt.Errorf("failed to upload file %s to container %s: %d bytes read instead of %d", name, container, n, size)
+	}
+
+	canonical := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		canonical[http.CanonicalHeaderKey(k)] = v
+	}
+
+	if err := writeJSON(metadataPath(p), canonical); err != nil {
+		return fmt.Errorf("failed to save metadata of file %s to container %s: %w", name, container, err)
+	}
+
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return fmt.Errorf("failed to upload file %s to container %s: %w", name, container, err)
+	}
+
+	return nil
+}
+
+func (l *LocalRepo) Get(ctx context.Context, container, name string) (io.ReadSeeker, map[string]string, error) {
+	p, err := l.objectPath(container, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := os.ReadFile(p)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w failed to read file '%s/%s'", err, container, name)
+	}
+
+	metadata, err := readMetadata(p)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w failed to read metadata of file '%s/%s'", err, container, name)
+	}
+
+	return bytes.NewReader(content), metadata, nil
+}
+
+func (l *LocalRepo) Size(ctx context.Context, container, name string) (int64, error) {
+	p, err := l.objectPath(container, name)
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := os.Stat(p)
+	if err != nil {
+		return 0, fmt.Errorf("%w failed to stat file '%s/%s'", err, container, name)
+	}
+
+	return info.Size(), nil
+}
+
+func (l *LocalRepo) Copy(ctx context.Context, srcContainer, srcName, dstContainer, dstName string) error {
+	r, metadata, err := l.Get(ctx, srcContainer, srcName)
+	if err != nil {
+		return fmt.Errorf("failed to copy file '%s/%s' to '%s/%s': %w", srcContainer, srcName, dstContainer, dstName, err)
+	}
+
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return l.Put(ctx, dstContainer, dstName, size, r, metadata)
+}
+
+func (l *LocalRepo) Delete(ctx context.Context, container, name string) error {
+	p, err := l.objectPath(container, name)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range []string{p, metadataPath(p)} {
+		if err := os.Remove(f); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w failed to remove file '%s/%s'", err, container, name)
+		}
+	}
+
+	return nil
+}
+
+func (l *LocalRepo) List(ctx context.Context, container string) ([]entity.Media, error) {
+	medias := make([]entity.Media, 0, 100)
+
+	dir, err := l.containerPath(container)
+	if err != nil {
+		return medias, err
+	}
+
+	mediaMap := make(map[string]entity.Media)
+	thumbnailMap := make(map[string]string)
+
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(d.Name(), ".") || d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+
+		// the uploads and the trashed media are not media of the container
+		if strings.HasPrefix(key, media.UploadPrefix) || strings.HasPrefix(key, media.TrashPrefix) {
+			return nil
+		}
+
+		if strings.HasPrefix(key, thumbnailFolder+"/") {
+			thumbnailMap[filename(key)] = key
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		metadata, err := readMetadata(p)
+		if err != nil {
+			return err
+		}
+
+		mediaMap[filename(key)] = toEntity(key, container, info, metadata)
+
+		return nil
+	})
+	if err != nil {
+		return medias, fmt.Errorf("[%w] failed to list container '%s'", err, container)
+	}
+
+	for k, v := range mediaMap {
+		if vv, found := thumbnailMap[k]; found {
+			v.Thumbnail = vv
+		}
+
+		medias = append(medias, v)
+	}
+
+	return medias, nil
+}
+
+func (l *LocalRepo) SetLabels(ctx context.Context, container string, labels map[string]string) error {
+	dir, err := l.containerPath(container)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("%w container '%s' does not exist", err, container)
+	}
+
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	return writeJSON(filepath.Join(dir, labelsFile), labels)
+}
+
+func (l *LocalRepo) GetLabels(ctx context.Context, container string) (map[string]string, error) {
+	dir, err := l.containerPath(container)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]string)
+	if err := readJSON(filepath.Join(dir, labelsFile), &labels); err != nil {
+		return nil, fmt.Errorf("failed to get labels of container '%s': %w", container, err)
+	}
+
+	return labels, nil
+}
+
+func (l *LocalRepo) Containers(ctx context.Context) ([]string, error) {
+	containers := []string{}
+
+	entries, err := os.ReadDir(l.root)
+	if err != nil {
+		return containers, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+
+		// the containers of the shared layout are one level down
+		if e.Name()+"/" == media.AlbumPrefix {
+			shared, err := os.ReadDir(filepath.Join(l.root, e.Name()))
+			if err != nil {
+				return containers, fmt.Errorf("failed to list containers: %w", err)
+			}
+
+			for _, se := range shared {
+				if se.IsDir() && !strings.HasPrefix(se.Name(), ".") {
+					containers = append(containers, media.AlbumContainer(se.Name()))
+				}
+			}
+
+			continue
+		}
+
+		containers = append(containers, e.Name())
+	}
+
+	return containers, nil
+}
+
+func (l *LocalRepo) Names(ctx context.Context, container string) ([]string, error) {
+	names := []string{}
+
+	dir, err := l.containerPath(container)
+	if err != nil {
+		return names, err
+	}
+
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(d.Name(), ".") || d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		if key := filepath.ToSlash(rel); !strings.HasPrefix(key, media.UploadPrefix) {
+			names = append(names, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return names, fmt.Errorf("[%w] failed to list container '%s'", err, container)
+	}
+
+	return names, nil
+}
+
+// containerPath returns the directory of the container. Names which would escape the root directory are rejected.
+// The containers of the shared layout are directories of root/albums.
+func (l *LocalRepo) containerPath(container string) (string, error) {
+	name := container
+	if media.IsAlbumContainer(container) {
+		name = strings.TrimPrefix(container, media.AlbumPrefix)
+	}
+
+	// the parent of the containers of the shared layout is not a container
+	if len(name) == 0 || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) || name+"/" == media.AlbumPrefix {
+		return "", fmt.Errorf("invalid container name '%s'", container)
+	}
+
+	if name != container {
+		return filepath.Join(l.root, filepath.FromSlash(media.AlbumPrefix), name), nil
+	}
+
+	return filepath.Join(l.root, container), nil
+}
+
+// objectPath returns the path of the object. The container must exist.
+func (l *LocalRepo) objectPath(container, name string) (string, error) {
+	dir, err := l.containerPath(container)
+	if err != nil {
+		return "", err
+	}
+
+	if len(name) == 0 || path.IsAbs(name) || strings.Contains(name, `\`) {
+		return "", fmt.Errorf("invalid file name '%s'", name)
+	}
+
+	for _, part := range strings.Split(name, "/") {
+		// hidden files hold the metadata
+		if len(part) == 0 || strings.HasPrefix(part, ".") {
+			return "", fmt.Errorf("invalid file name '%s'", name)
+		}
+	}
+
+	if _, err := os.Stat(dir); err != nil {
+		return "", fmt.Errorf("%w container '%s' does not exist", err, container)
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(name)), nil
+}
+
+func metadataPath(p string) string {
+	dir, base := filepath.Split(p)
+	return filepath.Join(dir, "."+base+metadataSuffix)
+}
+
+func readMetadata(p string) (map[string]string, error) {
+	metadata := make(map[string]string)
+
+	err := readJSON(metadataPath(p), &metadata)
+	if errors.Is(err, fs.ErrNotExist) {
+		return metadata, nil
+	}
+
+	return metadata, err
+}
+
+func readJSON(p string, v interface{}) error {
+	content, err := os.ReadFile(p)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(content, v)
+}
+
+func writeJSON(p string, v interface{}) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(p, content, 0640)
+}
+
+func toEntity(key, container string, info fs.FileInfo, metadata map[string]string) entity.Media {
+	e := entity.Media{
+		Filename:   key,
+		Bucket:     container,
+		Metadata:   metadata,
+		UploadDate: info.ModTime(),
+		Size:       info.Size(),
+	}
+
+	if createTime, found := metadata[http.CanonicalHeaderKey(image.DateKey)]; found {
+		t, err := time.Parse(image.DateFormat, createTime)
+		if err != nil {
+			zap.S().Errorw("failed to parse time from metadata", "error", err, "time", createTime)
+		} else {
+			e.CreateDate = t
+		}
+	}
+
+	lat, latFound := metadata[http.CanonicalHeaderKey(image.LatitudeKey)]
+	long, longFound := metadata[http.CanonicalHeaderKey(image.LongitudeKey)]
+	if latFound && longFound {
+		latitude, latErr := strconv.ParseFloat(lat, 64)
+		longitude, longErr := strconv.ParseFloat(long, 64)
+		if latErr == nil && longErr == nil {
+			e.Location = &entity.GeoPoint{Latitude: latitude, Longitude: longitude}
+		} else {
+			zap.S().Errorw("failed to parse location from metadata", "latitude", lat, "longitude", long)
+		}
+	}
+
+	if h, found := metadata[http.CanonicalHeaderKey(image.HashKey)]; found {
+		hash, err := strconv.ParseUint(h, 16, 64)
+		if err != nil {
+			zap.S().Errorw("failed to parse hash from metadata", "error", err, "hash", h)
+		} else {
+			e.Hash = &hash
+		}
+	}
+
+	if strings.Index(key, "jpg") > 0 {
+		e.MediaType = entity.Photo
+	} else {
+		e.MediaType = entity.Unknown
+	}
+
+	return e
+}
+
+// filename returns the name shared by a photo and its thumbnail.
+func filename(key string) string {
+	if strings.HasPrefix(key, thumbnailFolder+"/") || strings.HasPrefix(key, photoFolder+"/") {
+		return strings.SplitN(key, "/", 2)[1]
+	}
+
+	return key
+}
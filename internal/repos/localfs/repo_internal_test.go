@@ -0,0 +1,36 @@
+package localfs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/repos/storagetest"
+	"github.com/tupyy/gophoto/internal/services/media"
+)
+
+func TestConformance(t *testing.T) {
+	repo, err := New(t.TempDir())
+	assert.Nil(t, err)
+
+	t.Run("bucket layout", func(t *testing.T) { storagetest.Run(t, repo, "") })
+	t.Run("shared layout", func(t *testing.T) { storagetest.Run(t, repo, media.AlbumPrefix) })
+}
+
+func TestInvalidNames(t *testing.T) {
+	ctx := context.Background()
+
+	repo, err := New(t.TempDir())
+	assert.Nil(t, err)
+
+	for _, c := range []string{"", ".", "..", "../escape", "a/b", ".hidden", "albums", "albums/", "albums/..", "albums/a/b"} {
+		assert.NotNil(t, repo.CreateContainer(ctx, c, map[string]string{}), c)
+	}
+
+	assert.Nil(t, repo.CreateContainer(ctx, "album", map[string]string{}))
+
+	for _, name := range []string{"", "/etc/passwd", "../escape.jpg", "photos/../../escape.jpg", "photos/.a.jpg.metadata.json", "photos//a.jpg"} {
+		assert.NotNil(t, repo.Put(ctx, "album", name, 1, strings.NewReader("a"), map[string]string{}), name)
+	}
+}
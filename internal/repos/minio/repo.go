@@ -1,16 +1,21 @@
 package minio
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7"
 	miniotags "github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/media"
 	"go.uber.org/zap"
 )
 
@@ -19,17 +24,36 @@ const (
 	dateFormat       = "2006:01:02 15:04:05"
 	photoContentType = "application/jpg"
 	dateKey          = "X-Amz-Meta-Date"
+	latitudeKey      = "X-Amz-Meta-Latitude"
+	longitudeKey     = "X-Amz-Meta-Longitude"
+	hashKey          = "X-Amz-Meta-Phash"
+
+	// labelsObject holds the labels of a container of the shared layout. Prefixes cannot be tagged like buckets.
+	labelsObject = ".labels.json"
 )
 
+// MinioRepo stores the media in MinIO. Each container is a bucket except the containers of the shared layout
+// which are prefixes of the shared bucket. Both layouts can be used at the same time.
 type MinioRepo struct {
 	client *minio.Client
+	// bucket - shared bucket. The shared layout is not supported if empty.
+	bucket string
 }
 
-func New(client *minio.Client) *MinioRepo {
-	return &MinioRepo{client}
+func New(client *minio.Client, sharedBucket string) *MinioRepo {
+	return &MinioRepo{client, sharedBucket}
 }
 
-func (m *MinioRepo) CreateBucket(ctx context.Context, bucket string, tags map[string]string) error {
+func (m *MinioRepo) CreateContainer(ctx context.Context, container string, tags map[string]string) error {
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return err
+	}
+
+	if len(prefix) > 0 {
+		return m.createPrefix(ctx, bucket, prefix, tags)
+	}
+
 	exists, err := m.client.BucketExists(ctx, bucket)
 	if err != nil {
 		return fmt.Errorf("%w failed to create bucket %s on endpoint %s", err, bucket, m.client.EndpointURL())
@@ -44,10 +68,15 @@ func (m *MinioRepo) CreateBucket(ctx context.Context, bucket string, tags map[st
 		return fmt.Errorf("%w failed to create bucket %s on endpoint %s", err, bucket, m.client.EndpointURL())
 	}
 
-	return m.SetBucketTagging(ctx, bucket, tags)
+	return m.SetLabels(ctx, bucket, tags)
 }
 
-func (m *MinioRepo) DeleteBucket(ctx context.Context, bucket string) error {
+func (m *MinioRepo) DeleteContainer(ctx context.Context, container string) error {
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return err
+	}
+
 	exists, err := m.client.BucketExists(ctx, bucket)
 	if err != nil {
 		return fmt.Errorf("%w failed to delete bucket %s on endpoint %s", err, bucket, m.client.EndpointURL())
@@ -57,6 +86,25 @@ func (m *MinioRepo) DeleteBucket(ctx context.Context, bucket string) error {
 		return nil
 	}
 
+	if err := m.removeObjects(ctx, bucket, prefix); err != nil {
+		return err
+	}
+
+	// the shared bucket is kept
+	if len(prefix) > 0 {
+		return nil
+	}
+
+	err = m.client.RemoveBucket(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to delete bucket %s on endpoint %s: %+v", bucket, m.client.EndpointURL(), err)
+	}
+
+	return nil
+}
+
+// removeObjects removes all the objects of the bucket starting with prefix.
+func (m *MinioRepo) removeObjects(ctx context.Context, bucket, prefix string) error {
 	// remove all objects
 	objectsCh := make(chan minio.ObjectInfo)
 	doneCh := make(chan interface{}, 1)
@@ -66,7 +114,7 @@ func (m *MinioRepo) DeleteBucket(ctx context.Context, bucket string) error {
 	go func() {
 		defer close(objectsCh)
 		// List all objects from a bucket-name with a matching prefix.
-		for object := range m.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Recursive: true}) {
+		for object := range m.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
 			if object.Err != nil {
 				zap.S().Errorw("failed to list bucket", "bucket", bucket, "error", object.Err)
 
@@ -105,20 +153,20 @@ func (m *MinioRepo) DeleteBucket(ctx context.Context, bucket string) error {
 		}
 	}
 
-	err = m.client.RemoveBucket(ctx, bucket)
-	if err != nil {
-		return fmt.Errorf("failed to delete bucket %s on endpoint %s: %+v", bucket, m.client.EndpointURL(), err)
-	}
-
 	return nil
 }
 
-func (m *MinioRepo) PutFile(ctx context.Context, bucket, filename string, size int64, r io.Reader, metadata map[string]string) error {
-	if len(bucket) == 0 || len(filename) == 0 {
+func (m *MinioRepo) Put(ctx context.Context, container, filename string, size int64, r io.Reader, metadata map[string]string) error {
+	if len(container) == 0 || len(filename) == 0 {
 		return errors.New("failed to upload file to minio. bucket or filename missing.")
 	}
 
-	exists, err := m.client.BucketExists(ctx, bucket)
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return err
+	}
+
+	exists, err := m.exists(ctx, bucket, prefix)
 	if err != nil {
 		return fmt.Errorf("failed to upload file %s to bucket %s on endpoint %s: %+v", filename, bucket, m.client.EndpointURL(), err)
 	}
@@ -127,7 +175,7 @@ func (m *MinioRepo) PutFile(ctx context.Context, bucket, filename string, size i
 		return fmt.Errorf("failed to upload file %s to bucket %s on endpoint %s: %+v", filename, bucket, m.client.EndpointURL(), err)
 	}
 
-	_, err = m.client.PutObject(ctx, bucket, filename, r, size, minio.PutObjectOptions{ContentType: photoContentType, UserMetadata: metadata})
+	_, err = m.client.PutObject(ctx, bucket, prefix+filename, r, size, minio.PutObjectOptions{ContentType: photoContentType, UserMetadata: metadata})
 	if err != nil {
 		return fmt.Errorf("failed to upload file %s to bucket %s on endpoint %s: %+v", filename, bucket, m.client.EndpointURL(), err)
 	}
@@ -135,12 +183,17 @@ func (m *MinioRepo) PutFile(ctx context.Context, bucket, filename string, size i
 	return nil
 }
 
-func (m *MinioRepo) GetFile(ctx context.Context, bucket, filename string) (io.ReadSeeker, map[string]string, error) {
-	if len(bucket) == 0 || len(filename) == 0 {
+func (m *MinioRepo) Get(ctx context.Context, container, filename string) (io.ReadSeeker, map[string]string, error) {
+	if len(container) == 0 || len(filename) == 0 {
 		return nil, nil, errors.New("failed to get file. bucket or filename missing.")
 	}
 
-	exists, err := m.client.BucketExists(ctx, bucket)
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	exists, err := m.exists(ctx, bucket, prefix)
 	if err != nil {
 		return nil, nil, fmt.Errorf("%w internal error on endpoint %s", err, m.client.EndpointURL())
 	}
@@ -149,25 +202,75 @@ func (m *MinioRepo) GetFile(ctx context.Context, bucket, filename string) (io.Re
 		return nil, nil, fmt.Errorf("%w bucket %s does not exists on endpoint %s", err, bucket, m.client.EndpointURL())
 	}
 
-	r, err := m.client.GetObject(ctx, bucket, filename, minio.GetObjectOptions{})
+	r, err := m.client.GetObject(ctx, bucket, prefix+filename, minio.GetObjectOptions{})
 	if err != nil {
-		return nil, nil, fmt.Errorf("%w failed to read file '%s/%s'", err, bucket, filename)
+		return nil, nil, fmt.Errorf("%w failed to read file '%s/%s'", err, container, filename)
 	}
 
 	objectInfo, err := r.Stat()
 	if err != nil {
-		return nil, nil, fmt.Errorf("%w failed to stat file '%s/%s'", err, bucket, filename)
+		return nil, nil, fmt.Errorf("%w failed to stat file '%s/%s'", err, container, filename)
 	}
 
 	return r, objectInfo.UserMetadata, nil
 }
 
-func (m *MinioRepo) DeleteFile(ctx context.Context, bucket, filename string) error {
-	if len(bucket) == 0 || len(filename) == 0 {
+// Copy copies a file on the server side. The user metadata of the file is copied too.
+// The containers may belong to different layouts.
+func (m *MinioRepo) Copy(ctx context.Context, srcContainer, srcFilename, dstContainer, dstFilename string) error {
+	if len(srcContainer) == 0 || len(srcFilename) == 0 || len(dstContainer) == 0 || len(dstFilename) == 0 {
+		return errors.New("failed to copy file. bucket or filename missing.")
+	}
+
+	srcBucket, srcPrefix, err := m.locate(srcContainer)
+	if err != nil {
+		return err
+	}
+
+	dstBucket, dstPrefix, err := m.locate(dstContainer)
+	if err != nil {
+		return err
+	}
+
+	src := minio.CopySrcOptions{Bucket: srcBucket, Object: srcPrefix + srcFilename}
+	dst := minio.CopyDestOptions{Bucket: dstBucket, Object: dstPrefix + dstFilename}
+
+	if _, err := m.client.CopyObject(ctx, dst, src); err != nil {
+		return fmt.Errorf("%w failed to copy file '%s/%s' to '%s/%s'", err, srcContainer, srcFilename, dstContainer, dstFilename)
+	}
+
+	return nil
+}
+
+func (m *MinioRepo) Size(ctx context.Context, container, filename string) (int64, error) {
+	if len(container) == 0 || len(filename) == 0 {
+		return 0, errors.New("failed to stat file. bucket or filename missing.")
+	}
+
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return 0, err
+	}
+
+	objectInfo, err := m.client.StatObject(ctx, bucket, prefix+filename, minio.StatObjectOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("%w failed to stat file '%s/%s'", err, container, filename)
+	}
+
+	return objectInfo.Size, nil
+}
+
+func (m *MinioRepo) Delete(ctx context.Context, container, filename string) error {
+	if len(container) == 0 || len(filename) == 0 {
 		return errors.New("failed to get file. bucket or filename missing.")
 	}
 
-	exists, err := m.client.BucketExists(ctx, bucket)
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return err
+	}
+
+	exists, err := m.exists(ctx, bucket, prefix)
 	if err != nil {
 		return fmt.Errorf("%w internal error on endpoint %s", err, m.client.EndpointURL())
 	}
@@ -176,22 +279,27 @@ func (m *MinioRepo) DeleteFile(ctx context.Context, bucket, filename string) err
 		return fmt.Errorf("%w bucket %s does not exists on endpoint %s", err, bucket, m.client.EndpointURL())
 	}
 
-	err = m.client.RemoveObject(ctx, bucket, filename, minio.RemoveObjectOptions{})
+	err = m.client.RemoveObject(ctx, bucket, prefix+filename, minio.RemoveObjectOptions{})
 	if err != nil {
-		return fmt.Errorf("%w failed to remove file '%s/%s'", err, bucket, filename)
+		return fmt.Errorf("%w failed to remove file '%s/%s'", err, container, filename)
 	}
 
 	return nil
 }
 
-func (m *MinioRepo) ListBucket(ctx context.Context, bucket string) ([]entity.Media, error) {
+func (m *MinioRepo) List(ctx context.Context, container string) ([]entity.Media, error) {
 	medias := make([]entity.Media, 0, 100)
 
-	if len(bucket) == 0 {
+	if len(container) == 0 {
 		return medias, errors.New("bucket missing")
 	}
 
-	exists, err := m.client.BucketExists(ctx, bucket)
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return medias, err
+	}
+
+	exists, err := m.exists(ctx, bucket, prefix)
 	if err != nil {
 		return medias, fmt.Errorf("%w internal error on endpoint %s", err, m.client.EndpointURL())
 	}
@@ -201,6 +309,7 @@ func (m *MinioRepo) ListBucket(ctx context.Context, bucket string) ([]entity.Med
 	}
 
 	objectCh := m.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{
+		Prefix:       prefix,
 		Recursive:    true,
 		WithMetadata: true,
 	})
@@ -210,13 +319,19 @@ func (m *MinioRepo) ListBucket(ctx context.Context, bucket string) ([]entity.Med
 
 	for object := range objectCh {
 		if object.Err != nil {
-			return medias, fmt.Errorf("[%w] failed to list bucket '%s'", object.Err, bucket)
+			return medias, fmt.Errorf("[%w] failed to list bucket '%s'", object.Err, container)
+		}
+
+		// objects are named relative to their container
+		object.Key = strings.TrimPrefix(object.Key, prefix)
+		if object.Key == labelsObject || strings.HasPrefix(object.Key, media.UploadPrefix) || strings.HasPrefix(object.Key, media.TrashPrefix) {
+			continue
 		}
 
 		if isThumbnail(object) {
 			thumbnailMap[filename(object.Key)] = object.Key
 		} else {
-			mediaMap[filename(object.Key)] = toEntity(object, bucket)
+			mediaMap[filename(object.Key)] = toEntity(object, container)
 		}
 	}
 
@@ -231,7 +346,25 @@ func (m *MinioRepo) ListBucket(ctx context.Context, bucket string) ([]entity.Med
 	return medias, nil
 }
 
-func (m *MinioRepo) SetBucketTagging(ctx context.Context, bucket string, tags map[string]string) error {
+func (m *MinioRepo) SetLabels(ctx context.Context, container string, tags map[string]string) error {
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return err
+	}
+
+	if len(prefix) > 0 {
+		exists, err := m.exists(ctx, bucket, prefix)
+		if err != nil {
+			return fmt.Errorf("%w internal error on endpoint %s", err, m.client.EndpointURL())
+		}
+
+		if !exists {
+			return fmt.Errorf("container %s does not exists on endpoint %s", container, m.client.EndpointURL())
+		}
+
+		return m.putLabels(ctx, bucket, prefix, tags)
+	}
+
 	// Create tags from a map.
 	bucketTags, err := miniotags.NewTags(tags, false)
 	if err != nil {
@@ -246,20 +379,262 @@ func (m *MinioRepo) SetBucketTagging(ctx context.Context, bucket string, tags ma
 	return nil
 }
 
-func (m *MinioRepo) GetBucketTagging(ctx context.Context, bucket string) (map[string]string, error) {
+func (m *MinioRepo) GetLabels(ctx context.Context, container string) (map[string]string, error) {
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prefix) > 0 {
+		return m.getLabels(ctx, bucket, prefix)
+	}
+
 	// get present tags
 	bucketTags, err := m.client.GetBucketTagging(ctx, bucket)
 	if err != nil {
+		// a bucket without tags has no labels
+		if minio.ToErrorResponse(err).Code == "NoSuchTagSet" {
+			return map[string]string{}, nil
+		}
 		return nil, fmt.Errorf("failed to get present tags from bucket '%s': %w", bucket, err)
 	}
 	return bucketTags.ToMap(), nil
 }
 
+// PresignGet returns a pre-signed url to download the object.
+func (m *MinioRepo) PresignGet(ctx context.Context, container, filename string, expiry time.Duration) (string, error) {
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := m.client.PresignedGetObject(ctx, bucket, prefix+filename, expiry, url.Values{})
+	if err != nil {
+		return "", fmt.Errorf("%w failed to presign download of file '%s/%s'", err, container, filename)
+	}
+
+	return u.String(), nil
+}
+
+// PresignPost returns a pre-signed url and the form fields to upload the object with a POST request.
+// The policy of the form rejects the objects larger than maxSize.
+func (m *MinioRepo) PresignPost(ctx context.Context, container, filename string, maxSize int64, expiry time.Duration) (string, map[string]string, error) {
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return "", nil, err
+	}
+
+	policy := minio.NewPostPolicy()
+	if err := policy.SetBucket(bucket); err != nil {
+		return "", nil, err
+	}
+
+	if err := policy.SetKey(prefix + filename); err != nil {
+		return "", nil, err
+	}
+
+	if err := policy.SetExpires(time.Now().UTC().Add(expiry)); err != nil {
+		return "", nil, err
+	}
+
+	if err := policy.SetContentLengthRange(1, maxSize); err != nil {
+		return "", nil, err
+	}
+
+	u, fields, err := m.client.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w failed to presign upload of file '%s/%s'", err, container, filename)
+	}
+
+	return u.String(), fields, nil
+}
+
+// Uploads returns the names of the objects uploaded with pre-signed urls.
+func (m *MinioRepo) Uploads(ctx context.Context, container string) ([]string, error) {
+	uploads := []string{}
+
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return uploads, err
+	}
+
+	for object := range m.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix + media.UploadPrefix, Recursive: true}) {
+		if object.Err != nil {
+			return uploads, fmt.Errorf("[%w] failed to list uploads of bucket '%s'", object.Err, container)
+		}
+
+		uploads = append(uploads, strings.TrimPrefix(object.Key, prefix))
+	}
+
+	return uploads, nil
+}
+
+// Containers returns the buckets and the containers of the shared bucket. The shared bucket is not a container.
+func (m *MinioRepo) Containers(ctx context.Context) ([]string, error) {
+	containers := []string{}
+
+	buckets, err := m.client.ListBuckets(ctx)
+	if err != nil {
+		return containers, fmt.Errorf("%w failed to list buckets on endpoint %s", err, m.client.EndpointURL())
+	}
+
+	sharedExists := false
+	for _, b := range buckets {
+		if b.Name == m.bucket {
+			sharedExists = true
+			continue
+		}
+
+		containers = append(containers, b.Name)
+	}
+
+	if !sharedExists {
+		return containers, nil
+	}
+
+	// the containers of the shared layout are the first level of prefixes
+	for object := range m.client.ListObjects(ctx, m.bucket, minio.ListObjectsOptions{Prefix: media.AlbumPrefix}) {
+		if object.Err != nil {
+			return containers, fmt.Errorf("[%w] failed to list bucket '%s'", object.Err, m.bucket)
+		}
+
+		if strings.HasSuffix(object.Key, "/") {
+			containers = append(containers, strings.TrimSuffix(object.Key, "/"))
+		}
+	}
+
+	return containers, nil
+}
+
+func (m *MinioRepo) Names(ctx context.Context, container string) ([]string, error) {
+	names := []string{}
+
+	bucket, prefix, err := m.locate(container)
+	if err != nil {
+		return names, err
+	}
+
+	exists, err := m.exists(ctx, bucket, prefix)
+	if err != nil {
+		return names, fmt.Errorf("%w internal error on endpoint %s", err, m.client.EndpointURL())
+	}
+
+	if !exists {
+		return names, fmt.Errorf("bucket %s does not exists on endpoint %s", container, m.client.EndpointURL())
+	}
+
+	for object := range m.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return names, fmt.Errorf("[%w] failed to list bucket '%s'", object.Err, container)
+		}
+
+		key := strings.TrimPrefix(object.Key, prefix)
+		if key == labelsObject || strings.HasPrefix(key, media.UploadPrefix) {
+			continue
+		}
+
+		names = append(names, key)
+	}
+
+	return names, nil
+}
+
+// locate returns the bucket and the prefix of the objects of the container.
+// The prefix is empty for the containers which are buckets.
+func (m *MinioRepo) locate(container string) (bucket, prefix string, err error) {
+	if !media.IsAlbumContainer(container) {
+		return container, "", nil
+	}
+
+	if len(m.bucket) == 0 {
+		return "", "", fmt.Errorf("container '%s' needs the shared bucket which is not configured", container)
+	}
+
+	return m.bucket, strings.TrimSuffix(container, "/") + "/", nil
+}
+
+// exists returns true if the container exists. A container of the shared layout exists as long as its labels exist.
+func (m *MinioRepo) exists(ctx context.Context, bucket, prefix string) (bool, error) {
+	if len(prefix) == 0 {
+		return m.client.BucketExists(ctx, bucket)
+	}
+
+	_, err := m.client.StatObject(ctx, bucket, prefix+labelsObject, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" || minio.ToErrorResponse(err).Code == "NoSuchBucket" {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// createPrefix creates a container of the shared layout. The shared bucket is created the first time.
+func (m *MinioRepo) createPrefix(ctx context.Context, bucket, prefix string, tags map[string]string) error {
+	bucketExists, err := m.client.BucketExists(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("%w failed to create bucket %s on endpoint %s", err, bucket, m.client.EndpointURL())
+	}
+
+	if !bucketExists {
+		if err := m.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+			return fmt.Errorf("%w failed to create bucket %s on endpoint %s", err, bucket, m.client.EndpointURL())
+		}
+	}
+
+	exists, err := m.exists(ctx, bucket, prefix)
+	if err != nil {
+		return fmt.Errorf("%w failed to create container %s on endpoint %s", err, prefix, m.client.EndpointURL())
+	}
+
+	if exists {
+		return fmt.Errorf("container %s already exists on endpoint %s", prefix, m.client.EndpointURL())
+	}
+
+	return m.putLabels(ctx, bucket, prefix, tags)
+}
+
+func (m *MinioRepo) putLabels(ctx context.Context, bucket, prefix string, tags map[string]string) error {
+	if tags == nil {
+		tags = map[string]string{}
+	}
+
+	content, err := json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.PutObject(ctx, bucket, prefix+labelsObject, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{ContentType: "application/json"})
+	if err != nil {
+		return fmt.Errorf("failed to set labels of container %s on endpoint %s: %+v", prefix, m.client.EndpointURL(), err)
+	}
+
+	return nil
+}
+
+func (m *MinioRepo) getLabels(ctx context.Context, bucket, prefix string) (map[string]string, error) {
+	r, err := m.client.GetObject(ctx, bucket, prefix+labelsObject, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels of container '%s': %w", prefix, err)
+	}
+	defer r.Close()
+
+	tags := make(map[string]string)
+	if err := json.NewDecoder(r).Decode(&tags); err != nil {
+		return nil, fmt.Errorf("failed to get labels of container '%s': %w", prefix, err)
+	}
+
+	return tags, nil
+}
+
 func toEntity(o minio.ObjectInfo, bucket string) entity.Media {
 	e := entity.Media{
-		Filename: o.Key,
-		Bucket:   bucket,
-		Metadata: o.UserMetadata,
+		Filename:   o.Key,
+		Bucket:     bucket,
+		Metadata:   o.UserMetadata,
+		UploadDate: o.LastModified,
+		Size:       o.Size,
 	}
 
 	if createTime, found := o.UserMetadata[dateKey]; found {
@@ -271,6 +646,19 @@ func toEntity(o minio.ObjectInfo, bucket string) entity.Media {
 		}
 	}
 
+	if location, found := parseLocation(o.UserMetadata); found {
+		e.Location = &location
+	}
+
+	if h, found := o.UserMetadata[hashKey]; found {
+		hash, err := strconv.ParseUint(h, 16, 64)
+		if err != nil {
+			zap.S().Errorw("failed to parse hash from metadata", "error", err, "hash", h)
+		} else {
+			e.Hash = &hash
+		}
+	}
+
 	if strings.Index(o.Key, "jpg") > 0 {
 		e.MediaType = entity.Photo
 	} else {
@@ -280,6 +668,30 @@ func toEntity(o minio.ObjectInfo, bucket string) entity.Media {
 	return e
 }
 
+// parseLocation returns the gps coordinates found in the metadata.
+func parseLocation(metadata map[string]string) (entity.GeoPoint, bool) {
+	lat, latFound := metadata[latitudeKey]
+	long, longFound := metadata[longitudeKey]
+
+	if !latFound || !longFound {
+		return entity.GeoPoint{}, false
+	}
+
+	latitude, err := strconv.ParseFloat(lat, 64)
+	if err != nil {
+		zap.S().Errorw("failed to parse latitude from metadata", "error", err, "latitude", lat)
+		return entity.GeoPoint{}, false
+	}
+
+	longitude, err := strconv.ParseFloat(long, 64)
+	if err != nil {
+		zap.S().Errorw("failed to parse longitude from metadata", "error", err, "longitude", long)
+		return entity.GeoPoint{}, false
+	}
+
+	return entity.GeoPoint{Latitude: latitude, Longitude: longitude}, true
+}
+
 func filename(objFilename string) string {
 	hasFolder := strings.HasPrefix(objFilename, "thumbnail") || strings.HasPrefix(objFilename, "photos")
 
@@ -0,0 +1,37 @@
+//go:build integration
+
+package minio
+
+import (
+	"os"
+	"testing"
+
+	minioclient "github.com/tupyy/gophoto/internal/clients/minio"
+	"github.com/tupyy/gophoto/internal/conf"
+	"github.com/tupyy/gophoto/internal/repos/storagetest"
+	"github.com/tupyy/gophoto/internal/services/media"
+)
+
+// TestConformance needs a MinIO server set with GPHOTOS_TEST_MINIO_URL, GPHOTOS_TEST_MINIO_ACCESS_ID and GPHOTOS_TEST_MINIO_SECRET_KEY.
+//
+//	go test -tags integration ./internal/repos/minio/
+func TestConformance(t *testing.T) {
+	url := os.Getenv("GPHOTOS_TEST_MINIO_URL")
+	if len(url) == 0 {
+		t.Skip("GPHOTOS_TEST_MINIO_URL is not set")
+	}
+
+	client, err := minioclient.New(conf.MinioConfig{
+		Url:             url,
+		AccessID:        os.Getenv("GPHOTOS_TEST_MINIO_ACCESS_ID"),
+		AccessSecretKey: os.Getenv("GPHOTOS_TEST_MINIO_SECRET_KEY"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := New(client, "conformance-shared")
+
+	t.Run("bucket layout", func(t *testing.T) { storagetest.Run(t, repo, "") })
+	t.Run("shared layout", func(t *testing.T) { storagetest.Run(t, repo, media.AlbumPrefix) })
+}
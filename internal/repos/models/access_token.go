@@ -0,0 +1,258 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null"
+	uuid "github.com/satori/go.uuid"
+)
+
+var (
+	_ = time.Second
+	_ = sql.LevelDefault
+	_ = null.Bool{}
+	_ = uuid.UUID{}
+)
+
+/*
+DB Table Details
+-------------------------------------
+
+
+Table: access_token
+[ 0] id                                             TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 1] owner                                          TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 2] name                                           TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 3] scope                                          TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 4] token_hash                                     TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 5] created_at                                     TIMESTAMP            null: false  primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: [timezone('UTC']
+[ 6] expires_at                                     TIMESTAMP            null: false  primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: []
+[ 7] last_used_at                                   TIMESTAMP            null: true   primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: []
+
+
+JSON Sample
+-------------------------------------
+{    "id": "cbq3ulvd0cqa9v3hmrbg",    "owner": "alice",    "name": "photo frame",    "scope": "read-only",    "token_hash": "LgRhZxWcVbNmQpTsKjDfYaEuI",    "created_at": "2022-05-01T10:00:00Z",    "expires_at": "2022-08-01T10:00:00Z",    "last_used_at": "2022-05-02T10:00:00Z"}
+
+
+
+*/
+
+// AccessToken struct is a row record of the access_token table in the gophoto database
+type AccessToken struct {
+	//[ 0] id                                             TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	ID string `gorm:"primary_key;column:id;type:TEXT;"`
+	//[ 1] owner                                          TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Owner string `gorm:"column:owner;type:TEXT;"`
+	//[ 2] name                                           TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Name string `gorm:"column:name;type:TEXT;"`
+	//[ 3] scope                                          TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Scope string `gorm:"column:scope;type:TEXT;"`
+	//[ 4] token_hash                                     TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	TokenHash string `gorm:"column:token_hash;type:TEXT;"`
+	//[ 5] created_at                                     TIMESTAMP            null: false  primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: [timezone('UTC']
+	CreatedAt time.Time `gorm:"column:created_at;type:TIMESTAMP;default:timezone('UTC';"`
+	//[ 6] expires_at                                     TIMESTAMP            null: false  primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: []
+	ExpiresAt time.Time `gorm:"column:expires_at;type:TIMESTAMP;"`
+	//[ 7] last_used_at                                   TIMESTAMP            null: true   primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: []
+	LastUsedAt sql.NullTime `gorm:"column:last_used_at;type:TIMESTAMP;"`
+}
+
+var access_tokenTableInfo = &TableInfo{
+	Name: "access_token",
+	Columns: []*ColumnInfo{
+
+		&ColumnInfo{
+			Index:              0,
+			Name:               "id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "ID",
+			GoFieldType:        "string",
+			JSONFieldName:      "id",
+			ProtobufFieldName:  "id",
+			ProtobufType:       "",
+			ProtobufPos:        1,
+		},
+
+		&ColumnInfo{
+			Index:              1,
+			Name:               "owner",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Owner",
+			GoFieldType:        "string",
+			JSONFieldName:      "owner",
+			ProtobufFieldName:  "owner",
+			ProtobufType:       "",
+			ProtobufPos:        2,
+		},
+
+		&ColumnInfo{
+			Index:              2,
+			Name:               "name",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Name",
+			GoFieldType:        "string",
+			JSONFieldName:      "name",
+			ProtobufFieldName:  "name",
+			ProtobufType:       "",
+			ProtobufPos:        3,
+		},
+
+		&ColumnInfo{
+			Index:              3,
+			Name:               "scope",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Scope",
+			GoFieldType:        "string",
+			JSONFieldName:      "scope",
+			ProtobufFieldName:  "scope",
+			ProtobufType:       "",
+			ProtobufPos:        4,
+		},
+
+		&ColumnInfo{
+			Index:              4,
+			Name:               "token_hash",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "TokenHash",
+			GoFieldType:        "string",
+			JSONFieldName:      "token_hash",
+			ProtobufFieldName:  "token_hash",
+			ProtobufType:       "",
+			ProtobufPos:        5,
+		},
+
+		&ColumnInfo{
+			Index:              5,
+			Name:               "created_at",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TIMESTAMP",
+			DatabaseTypePretty: "TIMESTAMP",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TIMESTAMP",
+			ColumnLength:       -1,
+			GoFieldName:        "CreatedAt",
+			GoFieldType:        "time.Time",
+			JSONFieldName:      "created_at",
+			ProtobufFieldName:  "created_at",
+			ProtobufType:       "",
+			ProtobufPos:        6,
+		},
+
+		&ColumnInfo{
+			Index:              6,
+			Name:               "expires_at",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TIMESTAMP",
+			DatabaseTypePretty: "TIMESTAMP",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TIMESTAMP",
+			ColumnLength:       -1,
+			GoFieldName:        "ExpiresAt",
+			GoFieldType:        "time.Time",
+			JSONFieldName:      "expires_at",
+			ProtobufFieldName:  "expires_at",
+			ProtobufType:       "",
+			ProtobufPos:        7,
+		},
+
+		&ColumnInfo{
+			Index:              7,
+			Name:               "last_used_at",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           true,
+			DatabaseTypeName:   "TIMESTAMP",
+			DatabaseTypePretty: "TIMESTAMP",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TIMESTAMP",
+			ColumnLength:       -1,
+			GoFieldName:        "LastUsedAt",
+			GoFieldType:        "sql.NullTime",
+			JSONFieldName:      "last_used_at",
+			ProtobufFieldName:  "last_used_at",
+			ProtobufType:       "",
+			ProtobufPos:        8,
+		},
+	},
+}
+
+// TableName sets the insert table name for this struct type
+func (a *AccessToken) TableName() string {
+	return "access_token"
+}
+
+// BeforeSave invoked before saving, return an error if field is not populated.
+func (a *AccessToken) BeforeSave() error {
+	return nil
+}
+
+// Prepare invoked before saving, can be used to populate fields etc.
+func (a *AccessToken) Prepare() {
+}
+
+// Validate invoked before performing action, return an error if field is not populated.
+func (a *AccessToken) Validate(action Action) error {
+	return nil
+}
+
+// TableInfo return table meta data
+func (a *AccessToken) TableInfo() *TableInfo {
+	return access_tokenTableInfo
+}
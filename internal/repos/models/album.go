@@ -29,11 +29,15 @@ Table: album
 [ 5] description                                    TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
 [ 6] location                                       TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
 [ 7] thumbnail                                      VARCHAR(200)         null: true   primary: false  isArray: false  auto: false  col: VARCHAR         len: 200     default: []
+[ 8] city                                           TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 9] region                                         TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[10] country                                        TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[11] encrypted                                      BOOLEAN              null: false  primary: false  isArray: false  auto: false  col: BOOLEAN         len: -1      default: [false]
 
 
 JSON Sample
 -------------------------------------
-{    "id": "vTySCPFdPkXQxEMhAJrpSIKPj",    "name": "SvNpbELMCnjVRqFtUapoghmqN",    "created_at": "2273-05-03T12:17:05.57289503+02:00",    "owner_id": "gHODeCvCfnMtMWHHZneEkNRSS",    "bucket": "ZgCpolOXoFjluvUSEyIqnGYLZ",    "description": "uINysTFZQrqjuLoqChVofRyuJ",    "location": "NHpETeEuZhNTomfntBhrySERw",    "thumbnail": "jeOHURrmAQgxiTCZblULgRtRc"}
+{    "id": "vTySCPFdPkXQxEMhAJrpSIKPj",    "name": "SvNpbELMCnjVRqFtUapoghmqN",    "created_at": "2273-05-03T12:17:05.57289503+02:00",    "owner_id": "gHODeCvCfnMtMWHHZneEkNRSS",    "bucket": "ZgCpolOXoFjluvUSEyIqnGYLZ",    "description": "uINysTFZQrqjuLoqChVofRyuJ",    "location": "NHpETeEuZhNTomfntBhrySERw",    "thumbnail": "jeOHURrmAQgxiTCZblULgRtRc",    "city": "kWcRqpLbXzTnVuMdHsGyeAJfo",    "region": "PxQmDnRbtLwkCzSjYfVhgaEuU",    "country": "yNfBvHcXqTrMpLkJdGsWaZeOi",    "encrypted": false}
 
 
 
@@ -57,6 +61,14 @@ type Album struct {
 	Location *string `gorm:"column:location;type:TEXT;"`
 	//[ 7] thumbnail                                      VARCHAR(200)         null: true   primary: false  isArray: false  auto: false  col: VARCHAR         len: 200     default: []
 	Thumbnail sql.NullString `gorm:"column:thumbnail;type:VARCHAR;size:200;"`
+	//[ 8] city                                           TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	City *string `gorm:"column:city;type:TEXT;"`
+	//[ 9] region                                         TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Region *string `gorm:"column:region;type:TEXT;"`
+	//[10] country                                        TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Country *string `gorm:"column:country;type:TEXT;"`
+	//[11] encrypted                                      BOOLEAN              null: false  primary: false  isArray: false  auto: false  col: BOOLEAN         len: -1      default: [false]
+	Encrypted bool `gorm:"column:encrypted;type:BOOLEAN;default:false;"`
 }
 
 var albumTableInfo = &TableInfo{
@@ -230,6 +242,90 @@ var albumTableInfo = &TableInfo{
 			ProtobufType:       "string",
 			ProtobufPos:        8,
 		},
+
+		&ColumnInfo{
+			Index:              8,
+			Name:               "city",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           true,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "City",
+			GoFieldType:        "*string",
+			JSONFieldName:      "city",
+			ProtobufFieldName:  "city",
+			ProtobufType:       "",
+			ProtobufPos:        9,
+		},
+
+		&ColumnInfo{
+			Index:              9,
+			Name:               "region",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           true,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Region",
+			GoFieldType:        "*string",
+			JSONFieldName:      "region",
+			ProtobufFieldName:  "region",
+			ProtobufType:       "",
+			ProtobufPos:        10,
+		},
+
+		&ColumnInfo{
+			Index:              10,
+			Name:               "country",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           true,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Country",
+			GoFieldType:        "*string",
+			JSONFieldName:      "country",
+			ProtobufFieldName:  "country",
+			ProtobufType:       "",
+			ProtobufPos:        11,
+		},
+
+		&ColumnInfo{
+			Index:              11,
+			Name:               "encrypted",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "BOOLEAN",
+			DatabaseTypePretty: "BOOLEAN",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "BOOLEAN",
+			ColumnLength:       -1,
+			GoFieldName:        "Encrypted",
+			GoFieldType:        "bool",
+			JSONFieldName:      "encrypted",
+			ProtobufFieldName:  "encrypted",
+			ProtobufType:       "bool",
+			ProtobufPos:        12,
+		},
 	},
 }
 
@@ -0,0 +1,234 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null"
+	uuid "github.com/satori/go.uuid"
+)
+
+var (
+	_ = time.Second
+	_ = sql.LevelDefault
+	_ = null.Bool{}
+	_ = uuid.UUID{}
+)
+
+/*
+DB Table Details
+-------------------------------------
+
+
+Table: comment
+[ 0] id                                             TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 1] album_id                                       TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 2] filename                                       TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 3] author_id                                      TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 4] content                                        TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 5] created_at                                     TIMESTAMP            null: false  primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: [timezone('UTC']
+[ 6] updated_at                                     TIMESTAMP            null: true   primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: []
+
+
+JSON Sample
+-------------------------------------
+{    "id": "fRJADOCrRlQzWBmOwncCaIyYF",    "album_id": "qEjYatwlaUFtLtHLnHhxVliLZ",    "filename": "TguAvwRxrznxdeiLDhCqWfnuC",    "author_id": "JqGxaBkrKYOXighNjoZiFbdXi",    "content": "iOaCoHVHBHDwTLpQjIJZGNOxp",    "created_at": "2912-01-10T12:17:05.57289503+02:00",    "updated_at": "2686-07-17T12:17:05.57289503+02:00"}
+
+
+
+*/
+
+// Comment struct is a row record of the comment table in the gophoto database
+type Comment struct {
+	//[ 0] id                                             TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	ID string `gorm:"primary_key;column:id;type:TEXT;"`
+	//[ 1] album_id                                       TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	AlbumID string `gorm:"column:album_id;type:TEXT;"`
+	//[ 2] filename                                       TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Filename string `gorm:"column:filename;type:TEXT;"`
+	//[ 3] author_id                                      TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	AuthorID string `gorm:"column:author_id;type:TEXT;"`
+	//[ 4] content                                        TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Content string `gorm:"column:content;type:TEXT;"`
+	//[ 5] created_at                                     TIMESTAMP            null: false  primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: [timezone('UTC']
+	CreatedAt time.Time `gorm:"column:created_at;type:TIMESTAMP;default:timezone('UTC';"`
+	//[ 6] updated_at                                     TIMESTAMP            null: true   primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: []
+	UpdatedAt sql.NullTime `gorm:"column:updated_at;type:TIMESTAMP;"`
+}
+
+var commentTableInfo = &TableInfo{
+	Name: "comment",
+	Columns: []*ColumnInfo{
+
+		&ColumnInfo{
+			Index:              0,
+			Name:               "id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "ID",
+			GoFieldType:        "string",
+			JSONFieldName:      "id",
+			ProtobufFieldName:  "id",
+			ProtobufType:       "",
+			ProtobufPos:        1,
+		},
+
+		&ColumnInfo{
+			Index:              1,
+			Name:               "album_id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "AlbumID",
+			GoFieldType:        "string",
+			JSONFieldName:      "album_id",
+			ProtobufFieldName:  "album_id",
+			ProtobufType:       "",
+			ProtobufPos:        2,
+		},
+
+		&ColumnInfo{
+			Index:              2,
+			Name:               "filename",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Filename",
+			GoFieldType:        "string",
+			JSONFieldName:      "filename",
+			ProtobufFieldName:  "filename",
+			ProtobufType:       "",
+			ProtobufPos:        3,
+		},
+
+		&ColumnInfo{
+			Index:              3,
+			Name:               "author_id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "AuthorID",
+			GoFieldType:        "string",
+			JSONFieldName:      "author_id",
+			ProtobufFieldName:  "author_id",
+			ProtobufType:       "",
+			ProtobufPos:        4,
+		},
+
+		&ColumnInfo{
+			Index:              4,
+			Name:               "content",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Content",
+			GoFieldType:        "string",
+			JSONFieldName:      "content",
+			ProtobufFieldName:  "content",
+			ProtobufType:       "",
+			ProtobufPos:        5,
+		},
+
+		&ColumnInfo{
+			Index:              5,
+			Name:               "created_at",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TIMESTAMP",
+			DatabaseTypePretty: "TIMESTAMP",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TIMESTAMP",
+			ColumnLength:       -1,
+			GoFieldName:        "CreatedAt",
+			GoFieldType:        "time.Time",
+			JSONFieldName:      "created_at",
+			ProtobufFieldName:  "created_at",
+			ProtobufType:       "",
+			ProtobufPos:        6,
+		},
+
+		&ColumnInfo{
+			Index:              6,
+			Name:               "updated_at",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           true,
+			DatabaseTypeName:   "TIMESTAMP",
+			DatabaseTypePretty: "TIMESTAMP",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TIMESTAMP",
+			ColumnLength:       -1,
+			GoFieldName:        "UpdatedAt",
+			GoFieldType:        "sql.NullTime",
+			JSONFieldName:      "updated_at",
+			ProtobufFieldName:  "updated_at",
+			ProtobufType:       "",
+			ProtobufPos:        7,
+		},
+	},
+}
+
+// TableName sets the insert table name for this struct type
+func (c *Comment) TableName() string {
+	return "comment"
+}
+
+// BeforeSave invoked before saving, return an error if field is not populated.
+func (c *Comment) BeforeSave() error {
+	return nil
+}
+
+// Prepare invoked before saving, can be used to populate fields etc.
+func (c *Comment) Prepare() {
+}
+
+// Validate invoked before performing action, return an error if field is not populated.
+func (c *Comment) Validate(action Action) error {
+	return nil
+}
+
+// TableInfo return table meta data
+func (c *Comment) TableInfo() *TableInfo {
+	return commentTableInfo
+}
@@ -0,0 +1,162 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null"
+	uuid "github.com/satori/go.uuid"
+)
+
+var (
+	_ = time.Second
+	_ = sql.LevelDefault
+	_ = null.Bool{}
+	_ = uuid.UUID{}
+)
+
+/*
+DB Table Details
+-------------------------------------
+
+
+Table: comment_history
+[ 0] id                                             INT4                 null: false  primary: true   isArray: false  auto: true   col: INT4            len: -1      default: []
+[ 1] comment_id                                     TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 2] content                                        TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 3] created_at                                     TIMESTAMP            null: false  primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: [timezone('UTC']
+
+
+JSON Sample
+-------------------------------------
+{    "id": 30,    "comment_id": "qZQOXAaZovKuadKVBCEPSzSLR",    "content": "EaHJVJWcNrneFnWcsjkgKSgGr",    "created_at": "2513-03-11T12:17:05.57289503+02:00"}
+
+
+
+*/
+
+// CommentHistory struct is a row record of the comment_history table in the gophoto database
+type CommentHistory struct {
+	//[ 0] id                                             INT4                 null: false  primary: true   isArray: false  auto: true   col: INT4            len: -1      default: []
+	ID int32 `gorm:"primary_key;AUTO_INCREMENT;column:id;type:INT4;"`
+	//[ 1] comment_id                                     TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	CommentID string `gorm:"column:comment_id;type:TEXT;"`
+	//[ 2] content                                        TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Content string `gorm:"column:content;type:TEXT;"`
+	//[ 3] created_at                                     TIMESTAMP            null: false  primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: [timezone('UTC']
+	CreatedAt time.Time `gorm:"column:created_at;type:TIMESTAMP;default:timezone('UTC';"`
+}
+
+var comment_historyTableInfo = &TableInfo{
+	Name: "comment_history",
+	Columns: []*ColumnInfo{
+
+		&ColumnInfo{
+			Index:              0,
+			Name:               "id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "INT4",
+			DatabaseTypePretty: "INT4",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    true,
+			IsArray:            false,
+			ColumnType:         "INT4",
+			ColumnLength:       -1,
+			GoFieldName:        "ID",
+			GoFieldType:        "int32",
+			JSONFieldName:      "id",
+			ProtobufFieldName:  "id",
+			ProtobufType:       "int32",
+			ProtobufPos:        1,
+		},
+
+		&ColumnInfo{
+			Index:              1,
+			Name:               "comment_id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "CommentID",
+			GoFieldType:        "string",
+			JSONFieldName:      "comment_id",
+			ProtobufFieldName:  "comment_id",
+			ProtobufType:       "",
+			ProtobufPos:        2,
+		},
+
+		&ColumnInfo{
+			Index:              2,
+			Name:               "content",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Content",
+			GoFieldType:        "string",
+			JSONFieldName:      "content",
+			ProtobufFieldName:  "content",
+			ProtobufType:       "",
+			ProtobufPos:        3,
+		},
+
+		&ColumnInfo{
+			Index:              3,
+			Name:               "created_at",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TIMESTAMP",
+			DatabaseTypePretty: "TIMESTAMP",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TIMESTAMP",
+			ColumnLength:       -1,
+			GoFieldName:        "CreatedAt",
+			GoFieldType:        "time.Time",
+			JSONFieldName:      "created_at",
+			ProtobufFieldName:  "created_at",
+			ProtobufType:       "",
+			ProtobufPos:        4,
+		},
+	},
+}
+
+// TableName sets the insert table name for this struct type
+func (c *CommentHistory) TableName() string {
+	return "comment_history"
+}
+
+// BeforeSave invoked before saving, return an error if field is not populated.
+func (c *CommentHistory) BeforeSave() error {
+	return nil
+}
+
+// Prepare invoked before saving, can be used to populate fields etc.
+func (c *CommentHistory) Prepare() {
+}
+
+// Validate invoked before performing action, return an error if field is not populated.
+func (c *CommentHistory) Validate(action Action) error {
+	return nil
+}
+
+// TableInfo return table meta data
+func (c *CommentHistory) TableInfo() *TableInfo {
+	return comment_historyTableInfo
+}
@@ -14,7 +14,8 @@ type Role string
    'album.read',
    'album.write',
    'album.edit',
-   'album.delete'
+   'album.delete',
+   'album.comment'
 */
 type PermissionID string
 
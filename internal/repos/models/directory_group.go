@@ -0,0 +1,114 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null"
+	uuid "github.com/satori/go.uuid"
+)
+
+var (
+	_ = time.Second
+	_ = sql.LevelDefault
+	_ = null.Bool{}
+	_ = uuid.UUID{}
+)
+
+/*
+DB Table Details
+-------------------------------------
+
+
+Table: directory_group
+[ 0] user_id                                        TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 1] name                                           TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+
+
+JSON Sample
+-------------------------------------
+{    "user_id": "cbq3ulvd0cqa9v3hmrc0",    "name": "family"}
+
+
+
+*/
+
+// DirectoryGroup struct is a row record of the directory_group table in the gophoto database
+type DirectoryGroup struct {
+	//[ 0] user_id                                        TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	UserID string `gorm:"primary_key;column:user_id;type:TEXT;"`
+	//[ 1] name                                           TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Name string `gorm:"primary_key;column:name;type:TEXT;"`
+}
+
+var directory_groupTableInfo = &TableInfo{
+	Name: "directory_group",
+	Columns: []*ColumnInfo{
+
+		&ColumnInfo{
+			Index:              0,
+			Name:               "user_id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "UserID",
+			GoFieldType:        "string",
+			JSONFieldName:      "user_id",
+			ProtobufFieldName:  "user_id",
+			ProtobufType:       "",
+			ProtobufPos:        1,
+		},
+
+		&ColumnInfo{
+			Index:              1,
+			Name:               "name",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Name",
+			GoFieldType:        "string",
+			JSONFieldName:      "name",
+			ProtobufFieldName:  "name",
+			ProtobufType:       "",
+			ProtobufPos:        2,
+		},
+	},
+}
+
+// TableName sets the insert table name for this struct type
+func (d *DirectoryGroup) TableName() string {
+	return "directory_group"
+}
+
+// BeforeSave invoked before saving, return an error if field is not populated.
+func (d *DirectoryGroup) BeforeSave() error {
+	return nil
+}
+
+// Prepare invoked before saving, can be used to populate fields etc.
+func (d *DirectoryGroup) Prepare() {
+}
+
+// Validate invoked before performing action, return an error if field is not populated.
+func (d *DirectoryGroup) Validate(action Action) error {
+	return nil
+}
+
+// TableInfo return table meta data
+func (d *DirectoryGroup) TableInfo() *TableInfo {
+	return directory_groupTableInfo
+}
@@ -0,0 +1,258 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null"
+	uuid "github.com/satori/go.uuid"
+)
+
+var (
+	_ = time.Second
+	_ = sql.LevelDefault
+	_ = null.Bool{}
+	_ = uuid.UUID{}
+)
+
+/*
+DB Table Details
+-------------------------------------
+
+
+Table: directory_user
+[ 0] id                                             TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 1] issuer                                         TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 2] subject                                        TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 3] username                                       TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 4] first_name                                     TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 5] last_name                                      TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 6] role                                           USER_DEFINED         null: false  primary: false  isArray: false  auto: false  col: USER_DEFINED    len: -1      default: []
+[ 7] can_share                                      BOOLEAN              null: false  primary: false  isArray: false  auto: false  col: BOOLEAN         len: -1      default: [false]
+
+
+JSON Sample
+-------------------------------------
+{    "id": "cbq3ulvd0cqa9v3hmrc0",    "issuer": "https://accounts.example.com",    "subject": "248289761001",    "username": "alice",    "first_name": "Alice",    "last_name": "Liddell",    "role": "editor",    "can_share": true}
+
+
+
+*/
+
+// DirectoryUser struct is a row record of the directory_user table in the gophoto database
+type DirectoryUser struct {
+	//[ 0] id                                             TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	ID string `gorm:"primary_key;column:id;type:TEXT;"`
+	//[ 1] issuer                                         TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Issuer string `gorm:"column:issuer;type:TEXT;"`
+	//[ 2] subject                                        TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Subject string `gorm:"column:subject;type:TEXT;"`
+	//[ 3] username                                       TEXT                 null: false  primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Username string `gorm:"column:username;type:TEXT;"`
+	//[ 4] first_name                                     TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	FirstName sql.NullString `gorm:"column:first_name;type:TEXT;"`
+	//[ 5] last_name                                      TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	LastName sql.NullString `gorm:"column:last_name;type:TEXT;"`
+	//[ 6] role                                           USER_DEFINED         null: false  primary: false  isArray: false  auto: false  col: USER_DEFINED    len: -1      default: []
+	Role string `gorm:"column:role;type:VARCHAR;"`
+	//[ 7] can_share                                      BOOLEAN              null: false  primary: false  isArray: false  auto: false  col: BOOLEAN         len: -1      default: [false]
+	CanShare bool `gorm:"column:can_share;type:BOOLEAN;default:false;"`
+}
+
+var directory_userTableInfo = &TableInfo{
+	Name: "directory_user",
+	Columns: []*ColumnInfo{
+
+		&ColumnInfo{
+			Index:              0,
+			Name:               "id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "ID",
+			GoFieldType:        "string",
+			JSONFieldName:      "id",
+			ProtobufFieldName:  "id",
+			ProtobufType:       "",
+			ProtobufPos:        1,
+		},
+
+		&ColumnInfo{
+			Index:              1,
+			Name:               "issuer",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Issuer",
+			GoFieldType:        "string",
+			JSONFieldName:      "issuer",
+			ProtobufFieldName:  "issuer",
+			ProtobufType:       "",
+			ProtobufPos:        2,
+		},
+
+		&ColumnInfo{
+			Index:              2,
+			Name:               "subject",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Subject",
+			GoFieldType:        "string",
+			JSONFieldName:      "subject",
+			ProtobufFieldName:  "subject",
+			ProtobufType:       "",
+			ProtobufPos:        3,
+		},
+
+		&ColumnInfo{
+			Index:              3,
+			Name:               "username",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Username",
+			GoFieldType:        "string",
+			JSONFieldName:      "username",
+			ProtobufFieldName:  "username",
+			ProtobufType:       "",
+			ProtobufPos:        4,
+		},
+
+		&ColumnInfo{
+			Index:              4,
+			Name:               "first_name",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           true,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "FirstName",
+			GoFieldType:        "sql.NullString",
+			JSONFieldName:      "first_name",
+			ProtobufFieldName:  "first_name",
+			ProtobufType:       "",
+			ProtobufPos:        5,
+		},
+
+		&ColumnInfo{
+			Index:              5,
+			Name:               "last_name",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           true,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "LastName",
+			GoFieldType:        "sql.NullString",
+			JSONFieldName:      "last_name",
+			ProtobufFieldName:  "last_name",
+			ProtobufType:       "",
+			ProtobufPos:        6,
+		},
+
+		&ColumnInfo{
+			Index:              6,
+			Name:               "role",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "USER_DEFINED",
+			DatabaseTypePretty: "USER_DEFINED",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "USER_DEFINED",
+			ColumnLength:       -1,
+			GoFieldName:        "Role",
+			GoFieldType:        "string",
+			JSONFieldName:      "role",
+			ProtobufFieldName:  "role",
+			ProtobufType:       "",
+			ProtobufPos:        7,
+		},
+
+		&ColumnInfo{
+			Index:              7,
+			Name:               "can_share",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "BOOLEAN",
+			DatabaseTypePretty: "BOOLEAN",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "BOOLEAN",
+			ColumnLength:       -1,
+			GoFieldName:        "CanShare",
+			GoFieldType:        "bool",
+			JSONFieldName:      "can_share",
+			ProtobufFieldName:  "can_share",
+			ProtobufType:       "",
+			ProtobufPos:        8,
+		},
+	},
+}
+
+// TableName sets the insert table name for this struct type
+func (d *DirectoryUser) TableName() string {
+	return "directory_user"
+}
+
+// BeforeSave invoked before saving, return an error if field is not populated.
+func (d *DirectoryUser) BeforeSave() error {
+	return nil
+}
+
+// Prepare invoked before saving, can be used to populate fields etc.
+func (d *DirectoryUser) Prepare() {
+}
+
+// Validate invoked before performing action, return an error if field is not populated.
+func (d *DirectoryUser) Validate(action Action) error {
+	return nil
+}
+
+// TableInfo return table meta data
+func (d *DirectoryUser) TableInfo() *TableInfo {
+	return directory_userTableInfo
+}
@@ -0,0 +1,234 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null"
+	uuid "github.com/satori/go.uuid"
+)
+
+var (
+	_ = time.Second
+	_ = sql.LevelDefault
+	_ = null.Bool{}
+	_ = uuid.UUID{}
+)
+
+/*
+DB Table Details
+-------------------------------------
+
+
+Table: media
+[ 0] album_id                                       TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 1] filename                                       TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 2] caption                                        TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 3] description                                    TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 4] city                                           TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 5] region                                         TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 6] country                                        TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+
+
+JSON Sample
+-------------------------------------
+{    "album_id": "TcsrHmfhUrHnkfbKfyOaHccvY",    "filename": "qLFFOKCUqiJXNXmEyNtkhefdG",    "caption": "onmfHARVxuwxkXripittoPDmC",    "description": "bPetKTHAXdLbbmNhrKcEgxqSl",    "city": "sRRfcnIzgsrdHCGdzYhDyubgr",    "region": "zqAiNpKyGzJWtgCRzCIzBUXBL",    "country": "ZgOxddMmxNiFOCwqoPEYCkXrl"}
+
+
+
+*/
+
+// Media struct is a row record of the media table in the gophoto database
+type Media struct {
+	//[ 0] album_id                                       TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	AlbumID string `gorm:"primary_key;column:album_id;type:TEXT;"`
+	//[ 1] filename                                       TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Filename string `gorm:"primary_key;column:filename;type:TEXT;"`
+	//[ 2] caption                                        TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Caption *string `gorm:"column:caption;type:TEXT;"`
+	//[ 3] description                                    TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Description *string `gorm:"column:description;type:TEXT;"`
+	//[ 4] city                                           TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	City *string `gorm:"column:city;type:TEXT;"`
+	//[ 5] region                                         TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Region *string `gorm:"column:region;type:TEXT;"`
+	//[ 6] country                                        TEXT                 null: true   primary: false  isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Country *string `gorm:"column:country;type:TEXT;"`
+}
+
+var mediaTableInfo = &TableInfo{
+	Name: "media",
+	Columns: []*ColumnInfo{
+
+		&ColumnInfo{
+			Index:              0,
+			Name:               "album_id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "AlbumID",
+			GoFieldType:        "string",
+			JSONFieldName:      "album_id",
+			ProtobufFieldName:  "album_id",
+			ProtobufType:       "",
+			ProtobufPos:        1,
+		},
+
+		&ColumnInfo{
+			Index:              1,
+			Name:               "filename",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Filename",
+			GoFieldType:        "string",
+			JSONFieldName:      "filename",
+			ProtobufFieldName:  "filename",
+			ProtobufType:       "",
+			ProtobufPos:        2,
+		},
+
+		&ColumnInfo{
+			Index:              2,
+			Name:               "caption",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           true,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Caption",
+			GoFieldType:        "*string",
+			JSONFieldName:      "caption",
+			ProtobufFieldName:  "caption",
+			ProtobufType:       "",
+			ProtobufPos:        3,
+		},
+
+		&ColumnInfo{
+			Index:              3,
+			Name:               "description",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           true,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Description",
+			GoFieldType:        "*string",
+			JSONFieldName:      "description",
+			ProtobufFieldName:  "description",
+			ProtobufType:       "",
+			ProtobufPos:        4,
+		},
+
+		&ColumnInfo{
+			Index:              4,
+			Name:               "city",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           true,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "City",
+			GoFieldType:        "*string",
+			JSONFieldName:      "city",
+			ProtobufFieldName:  "city",
+			ProtobufType:       "",
+			ProtobufPos:        5,
+		},
+
+		&ColumnInfo{
+			Index:              5,
+			Name:               "region",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           true,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Region",
+			GoFieldType:        "*string",
+			JSONFieldName:      "region",
+			ProtobufFieldName:  "region",
+			ProtobufType:       "",
+			ProtobufPos:        6,
+		},
+
+		&ColumnInfo{
+			Index:              6,
+			Name:               "country",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           true,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Country",
+			GoFieldType:        "*string",
+			JSONFieldName:      "country",
+			ProtobufFieldName:  "country",
+			ProtobufType:       "",
+			ProtobufPos:        7,
+		},
+	},
+}
+
+// TableName sets the insert table name for this struct type
+func (m *Media) TableName() string {
+	return "media"
+}
+
+// BeforeSave invoked before saving, return an error if field is not populated.
+func (m *Media) BeforeSave() error {
+	return nil
+}
+
+// Prepare invoked before saving, can be used to populate fields etc.
+func (m *Media) Prepare() {
+}
+
+// Validate invoked before performing action, return an error if field is not populated.
+func (m *Media) Validate(action Action) error {
+	return nil
+}
+
+// TableInfo return table meta data
+func (m *Media) TableInfo() *TableInfo {
+	return mediaTableInfo
+}
@@ -0,0 +1,162 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null"
+	uuid "github.com/satori/go.uuid"
+)
+
+var (
+	_ = time.Second
+	_ = sql.LevelDefault
+	_ = null.Bool{}
+	_ = uuid.UUID{}
+)
+
+/*
+DB Table Details
+-------------------------------------
+
+
+Table: media_favorites
+[ 0] album_id                                       TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 1] filename                                       TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 2] user_id                                        TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 3] created_at                                     TIMESTAMP            null: false  primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: [timezone('UTC']
+
+
+JSON Sample
+-------------------------------------
+{    "album_id": "DZeVeRFrnqUqnxSfUOevyDnko",    "filename": "biALyLkLbkbGoSSWtSuhqSmCS",    "user_id": "eDTEJtGHBnxBHtjhvCuRdqrxk",    "created_at": "2150-01-12T12:17:05.57289503+02:00"}
+
+
+
+*/
+
+// MediaFavorites struct is a row record of the media_favorites table in the gophoto database
+type MediaFavorites struct {
+	//[ 0] album_id                                       TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	AlbumID string `gorm:"primary_key;column:album_id;type:TEXT;"`
+	//[ 1] filename                                       TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Filename string `gorm:"primary_key;column:filename;type:TEXT;"`
+	//[ 2] user_id                                        TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	UserID string `gorm:"primary_key;column:user_id;type:TEXT;"`
+	//[ 3] created_at                                     TIMESTAMP            null: false  primary: false  isArray: false  auto: false  col: TIMESTAMP       len: -1      default: [timezone('UTC']
+	CreatedAt time.Time `gorm:"column:created_at;type:TIMESTAMP;default:timezone('UTC';"`
+}
+
+var media_favoritesTableInfo = &TableInfo{
+	Name: "media_favorites",
+	Columns: []*ColumnInfo{
+
+		&ColumnInfo{
+			Index:              0,
+			Name:               "album_id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "AlbumID",
+			GoFieldType:        "string",
+			JSONFieldName:      "album_id",
+			ProtobufFieldName:  "album_id",
+			ProtobufType:       "",
+			ProtobufPos:        1,
+		},
+
+		&ColumnInfo{
+			Index:              1,
+			Name:               "filename",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Filename",
+			GoFieldType:        "string",
+			JSONFieldName:      "filename",
+			ProtobufFieldName:  "filename",
+			ProtobufType:       "",
+			ProtobufPos:        2,
+		},
+
+		&ColumnInfo{
+			Index:              2,
+			Name:               "user_id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "UserID",
+			GoFieldType:        "string",
+			JSONFieldName:      "user_id",
+			ProtobufFieldName:  "user_id",
+			ProtobufType:       "",
+			ProtobufPos:        3,
+		},
+
+		&ColumnInfo{
+			Index:              3,
+			Name:               "created_at",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TIMESTAMP",
+			DatabaseTypePretty: "TIMESTAMP",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TIMESTAMP",
+			ColumnLength:       -1,
+			GoFieldName:        "CreatedAt",
+			GoFieldType:        "time.Time",
+			JSONFieldName:      "created_at",
+			ProtobufFieldName:  "created_at",
+			ProtobufType:       "",
+			ProtobufPos:        4,
+		},
+	},
+}
+
+// TableName sets the insert table name for this struct type
+func (m *MediaFavorites) TableName() string {
+	return "media_favorites"
+}
+
+// BeforeSave invoked before saving, return an error if field is not populated.
+func (m *MediaFavorites) BeforeSave() error {
+	return nil
+}
+
+// Prepare invoked before saving, can be used to populate fields etc.
+func (m *MediaFavorites) Prepare() {
+}
+
+// Validate invoked before performing action, return an error if field is not populated.
+func (m *MediaFavorites) Validate(action Action) error {
+	return nil
+}
+
+// TableInfo return table meta data
+func (m *MediaFavorites) TableInfo() *TableInfo {
+	return media_favoritesTableInfo
+}
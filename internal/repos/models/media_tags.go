@@ -0,0 +1,138 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null"
+	uuid "github.com/satori/go.uuid"
+)
+
+var (
+	_ = time.Second
+	_ = sql.LevelDefault
+	_ = null.Bool{}
+	_ = uuid.UUID{}
+)
+
+/*
+DB Table Details
+-------------------------------------
+
+
+Table: media_tags
+[ 0] album_id                                       TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 1] filename                                       TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 2] tag_id                                         TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+
+
+JSON Sample
+-------------------------------------
+{    "album_id": "tXOOfUPsQPDRAWUEySYhWzIHk",    "filename": "GfVnNZgYSloSIfYAomTOvzDnU",    "tag_id": "TZMLzDrLJSnPNZZxIplaWjXls"}
+
+
+
+*/
+
+// MediaTags struct is a row record of the media_tags table in the gophoto database
+type MediaTags struct {
+	//[ 0] album_id                                       TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	AlbumID string `gorm:"primary_key;column:album_id;type:TEXT;"`
+	//[ 1] filename                                       TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Filename string `gorm:"primary_key;column:filename;type:TEXT;"`
+	//[ 2] tag_id                                         TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	TagID string `gorm:"primary_key;column:tag_id;type:TEXT;"`
+}
+
+var media_tagsTableInfo = &TableInfo{
+	Name: "media_tags",
+	Columns: []*ColumnInfo{
+
+		&ColumnInfo{
+			Index:              0,
+			Name:               "album_id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "AlbumID",
+			GoFieldType:        "string",
+			JSONFieldName:      "album_id",
+			ProtobufFieldName:  "album_id",
+			ProtobufType:       "",
+			ProtobufPos:        1,
+		},
+
+		&ColumnInfo{
+			Index:              1,
+			Name:               "filename",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Filename",
+			GoFieldType:        "string",
+			JSONFieldName:      "filename",
+			ProtobufFieldName:  "filename",
+			ProtobufType:       "",
+			ProtobufPos:        2,
+		},
+
+		&ColumnInfo{
+			Index:              2,
+			Name:               "tag_id",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "TagID",
+			GoFieldType:        "string",
+			JSONFieldName:      "tag_id",
+			ProtobufFieldName:  "tag_id",
+			ProtobufType:       "",
+			ProtobufPos:        3,
+		},
+	},
+}
+
+// TableName sets the insert table name for this struct type
+func (m *MediaTags) TableName() string {
+	return "media_tags"
+}
+
+// BeforeSave invoked before saving, return an error if field is not populated.
+func (m *MediaTags) BeforeSave() error {
+	return nil
+}
+
+// Prepare invoked before saving, can be used to populate fields etc.
+func (m *MediaTags) Prepare() {
+}
+
+// Validate invoked before performing action, return an error if field is not populated.
+func (m *MediaTags) Validate(action Action) error {
+	return nil
+}
+
+// TableInfo return table meta data
+func (m *MediaTags) TableInfo() *TableInfo {
+	return media_tagsTableInfo
+}
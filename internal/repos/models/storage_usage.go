@@ -0,0 +1,114 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null"
+	uuid "github.com/satori/go.uuid"
+)
+
+var (
+	_ = time.Second
+	_ = sql.LevelDefault
+	_ = null.Bool{}
+	_ = uuid.UUID{}
+)
+
+/*
+DB Table Details
+-------------------------------------
+
+
+Table: storage_usage
+[ 0] bucket                                         TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+[ 1] bytes                                          INT8                 null: false  primary: false  isArray: false  auto: false  col: INT8            len: -1      default: [0]
+
+
+JSON Sample
+-------------------------------------
+{    "bucket": "qWbNhVJeYtKpLsDcRmZaFgXoU",    "bytes": 52}
+
+
+
+*/
+
+// StorageUsage struct is a row record of the storage_usage table in the gophoto database
+type StorageUsage struct {
+	//[ 0] bucket                                         TEXT                 null: false  primary: true   isArray: false  auto: false  col: TEXT            len: -1      default: []
+	Bucket string `gorm:"primary_key;column:bucket;type:TEXT;"`
+	//[ 1] bytes                                          INT8                 null: false  primary: false  isArray: false  auto: false  col: INT8            len: -1      default: [0]
+	Bytes int64 `gorm:"column:bytes;type:INT8;default:0;"`
+}
+
+var storage_usageTableInfo = &TableInfo{
+	Name: "storage_usage",
+	Columns: []*ColumnInfo{
+
+		&ColumnInfo{
+			Index:              0,
+			Name:               "bucket",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "TEXT",
+			DatabaseTypePretty: "TEXT",
+			IsPrimaryKey:       true,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "TEXT",
+			ColumnLength:       -1,
+			GoFieldName:        "Bucket",
+			GoFieldType:        "string",
+			JSONFieldName:      "bucket",
+			ProtobufFieldName:  "bucket",
+			ProtobufType:       "",
+			ProtobufPos:        1,
+		},
+
+		&ColumnInfo{
+			Index:              1,
+			Name:               "bytes",
+			Comment:            ``,
+			Notes:              ``,
+			Nullable:           false,
+			DatabaseTypeName:   "INT8",
+			DatabaseTypePretty: "INT8",
+			IsPrimaryKey:       false,
+			IsAutoIncrement:    false,
+			IsArray:            false,
+			ColumnType:         "INT8",
+			ColumnLength:       -1,
+			GoFieldName:        "Bytes",
+			GoFieldType:        "int64",
+			JSONFieldName:      "bytes",
+			ProtobufFieldName:  "bytes",
+			ProtobufType:       "int64",
+			ProtobufPos:        2,
+		},
+	},
+}
+
+// TableName sets the insert table name for this struct type
+func (s *StorageUsage) TableName() string {
+	return "storage_usage"
+}
+
+// BeforeSave invoked before saving, return an error if field is not populated.
+func (s *StorageUsage) BeforeSave() error {
+	return nil
+}
+
+// Prepare invoked before saving, can be used to populate fields etc.
+func (s *StorageUsage) Prepare() {
+}
+
+// Validate invoked before performing action, return an error if field is not populated.
+func (s *StorageUsage) Validate(action Action) error {
+	return nil
+}
+
+// TableInfo return table meta data
+func (s *StorageUsage) TableInfo() *TableInfo {
+	return storage_usageTableInfo
+}
@@ -0,0 +1,149 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/tupyy/gophoto/internal/common"
+	"github.com/tupyy/gophoto/internal/entity"
+	userFilters "github.com/tupyy/gophoto/internal/repos/filters/user"
+)
+
+var (
+	// ErrUsernameTaken is returned when the username of the claims belongs to the user of another account.
+	ErrUsernameTaken = errors.New("username belongs to another account")
+	// ErrUnknownAccount is returned when the account is not in the local directory.
+	ErrUnknownAccount = errors.New("account not found in the directory")
+	// ErrMissingSubject is returned when the claims have no subject.
+	ErrMissingSubject = errors.New("missing subject")
+)
+
+// Store records the users. A user is the account identified by the issuer and the subject of its tokens.
+type Store interface {
+	GetUsers(ctx context.Context, filters userFilters.Filters) ([]entity.User, error)
+	GetUserByID(ctx context.Context, id string) (entity.User, error)
+	GetUserByUsername(ctx context.Context, username string) (entity.User, error)
+	GetUserBySubject(ctx context.Context, issuer, subject string) (entity.User, error)
+	GetGroups(ctx context.Context) ([]entity.Group, error)
+	Save(ctx context.Context, user entity.User, issuer, subject string) error
+}
+
+// reader reads the users of the store.
+type reader struct {
+	store Store
+}
+
+// GetUsers returns the users passing the filters.
+func (r reader) GetUsers(ctx context.Context, filters userFilters.Filters) ([]entity.User, error) {
+	return r.store.GetUsers(ctx, filters)
+}
+
+// GetUserByID returns the user.
+func (r reader) GetUserByID(ctx context.Context, id string) (entity.User, error) {
+	return r.store.GetUserByID(ctx, id)
+}
+
+// GetGroups returns the groups of the users.
+func (r reader) GetGroups(ctx context.Context) ([]entity.Group, error) {
+	return r.store.GetGroups(ctx)
+}
+
+// ClaimsDirectory is the directory of the users of a generic oidc provider, which has no api to list its users.
+// The users are recorded in the store from the claims of their tokens each time they log in, so the directory holds
+// the users who logged in at least once, with their roles and groups at their last login.
+// The users are keyed on the subject of their tokens: the username, which the users may be able to change at the provider,
+// is not trusted to identify them and a login with the username of another account is rejected.
+type ClaimsDirectory struct {
+	reader
+	issuer string
+
+	lock sync.Mutex
+	// saved are the users as last saved by subject, to not write the store on each request.
+	saved map[string]entity.User
+}
+
+func NewClaimsDirectory(store Store, issuer string) *ClaimsDirectory {
+	return &ClaimsDirectory{reader: reader{store}, issuer: issuer, saved: make(map[string]entity.User)}
+}
+
+// Resolve records the user of the claims if it changed since it was last saved. The id of the user of the claims is the
+// subject, which is replaced by the local id of the user.
+func (d *ClaimsDirectory) Resolve(ctx context.Context, user entity.User) (entity.User, error) {
+	subject := user.ID
+	if subject == "" {
+		return entity.User{}, ErrMissingSubject
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if saved, found := d.saved[subject]; found {
+		user.ID = saved.ID
+		if reflect.DeepEqual(saved, user) {
+			return user, nil
+		}
+	}
+
+	local, err := d.store.GetUserBySubject(ctx, d.issuer, subject)
+	switch {
+	case err == nil:
+		user.ID = local.ID
+	case isNotFound(err):
+		user.ID = uuid.New().String()
+	default:
+		return entity.User{}, err
+	}
+
+	owner, err := d.store.GetUserByUsername(ctx, user.Username)
+	switch {
+	case err == nil && owner.ID != user.ID:
+		return entity.User{}, fmt.Errorf("%w: '%s'", ErrUsernameTaken, user.Username)
+	case err != nil && !isNotFound(err):
+		return entity.User{}, err
+	}
+
+	if err := d.store.Save(ctx, user, d.issuer, subject); err != nil {
+		return entity.User{}, err
+	}
+
+	d.saved[subject] = user
+
+	return user, nil
+}
+
+// LocalDirectory is the directory of the users managed in the store. Only the accounts added to the store can log in,
+// with the role and the groups of the store.
+type LocalDirectory struct {
+	reader
+	issuer string
+}
+
+func NewLocalDirectory(store Store, issuer string) *LocalDirectory {
+	return &LocalDirectory{reader: reader{store}, issuer: issuer}
+}
+
+// Resolve returns the local user of the account identified by the subject of the claims.
+func (d *LocalDirectory) Resolve(ctx context.Context, user entity.User) (entity.User, error) {
+	if user.ID == "" {
+		return entity.User{}, ErrMissingSubject
+	}
+
+	local, err := d.store.GetUserBySubject(ctx, d.issuer, user.ID)
+	if err != nil {
+		if isNotFound(err) {
+			return entity.User{}, fmt.Errorf("%w: '%s'", ErrUnknownAccount, user.Username)
+		}
+		return entity.User{}, err
+	}
+
+	return local, nil
+}
+
+func isNotFound(err error) bool {
+	var serr common.ServiceError
+	return errors.As(err, &serr) && serr.Cause == common.EntityNotFound
+}
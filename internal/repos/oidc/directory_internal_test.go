@@ -0,0 +1,160 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/common"
+	"github.com/tupyy/gophoto/internal/entity"
+	userFilters "github.com/tupyy/gophoto/internal/repos/filters/user"
+)
+
+const testIssuer = "https://accounts.example.com"
+
+type account struct {
+	issuer  string
+	subject string
+}
+
+type fakeStore struct {
+	users    map[string]entity.User
+	accounts map[account]string
+	saves    int
+	err      error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{users: make(map[string]entity.User), accounts: make(map[account]string)}
+}
+
+func (f *fakeStore) GetUsers(ctx context.Context, filters userFilters.Filters) ([]entity.User, error) {
+	users := []entity.User{}
+	for _, u := range f.users {
+		users = append(users, u)
+	}
+
+	return users, nil
+}
+
+func (f *fakeStore) GetUserByID(ctx context.Context, id string) (entity.User, error) {
+	u, found := f.users[id]
+	if !found {
+		return entity.User{}, common.NewEntityNotFound("user not found")
+	}
+
+	return u, nil
+}
+
+func (f *fakeStore) GetUserByUsername(ctx context.Context, username string) (entity.User, error) {
+	for _, u := range f.users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+
+	return entity.User{}, common.NewEntityNotFound("user not found")
+}
+
+func (f *fakeStore) GetUserBySubject(ctx context.Context, issuer, subject string) (entity.User, error) {
+	id, found := f.accounts[account{issuer, subject}]
+	if !found {
+		return entity.User{}, common.NewEntityNotFound("user not found")
+	}
+
+	return f.users[id], nil
+}
+
+func (f *fakeStore) GetGroups(ctx context.Context) ([]entity.Group, error) {
+	return []entity.Group{}, nil
+}
+
+func (f *fakeStore) Save(ctx context.Context, user entity.User, issuer, subject string) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.saves++
+	f.users[user.ID] = user
+	f.accounts[account{issuer, subject}] = user.ID
+
+	return nil
+}
+
+func TestClaimsDirectory(t *testing.T) {
+	store := newFakeStore()
+	directory := NewClaimsDirectory(store, testIssuer)
+
+	claims := entity.User{ID: "sub-alice", Username: "alice", Role: entity.RoleUser, Groups: []entity.Group{{Name: "family"}}}
+
+	alice, err := directory.Resolve(context.Background(), claims)
+	require.Nil(t, err)
+	assert.NotEqual(t, "sub-alice", alice.ID, "the user gets a local id")
+	assert.Equal(t, "alice", alice.Username)
+
+	for i := 0; i < 3; i++ {
+		user, err := directory.Resolve(context.Background(), claims)
+		require.Nil(t, err)
+		assert.Equal(t, alice, user)
+	}
+	assert.Equal(t, 1, store.saves, "the user is saved only when it changes")
+
+	claims.Role = entity.RoleEditor
+	claims.Groups = []entity.Group{{Name: "family"}, {Name: "friends"}}
+
+	user, err := directory.Resolve(context.Background(), claims)
+	require.Nil(t, err)
+	assert.Equal(t, alice.ID, user.ID, "the user is keyed on the subject")
+	assert.Equal(t, 2, store.saves)
+
+	saved, err := directory.GetUserByID(context.Background(), alice.ID)
+	require.Nil(t, err)
+	assert.Equal(t, entity.RoleEditor, saved.Role)
+
+	// the username can change at the provider
+	claims.Username = "alice.liddell"
+
+	user, err = directory.Resolve(context.Background(), claims)
+	require.Nil(t, err)
+	assert.Equal(t, alice.ID, user.ID)
+	assert.Equal(t, "alice.liddell", user.Username)
+
+	// another account cannot take the username
+	_, err = directory.Resolve(context.Background(), entity.User{ID: "sub-mallory", Username: "alice.liddell", Role: entity.RoleAdmin})
+	assert.True(t, errors.Is(err, ErrUsernameTaken))
+
+	saved, err = directory.GetUserByID(context.Background(), alice.ID)
+	require.Nil(t, err)
+	assert.Equal(t, "alice.liddell", saved.Username, "the user holding the username is kept")
+	assert.Len(t, store.users, 1)
+
+	_, err = directory.Resolve(context.Background(), entity.User{Username: "bob"})
+	assert.True(t, errors.Is(err, ErrMissingSubject))
+
+	store.err = errors.New("pg not available")
+
+	_, err = directory.Resolve(context.Background(), entity.User{ID: "sub-bob", Username: "bob"})
+	assert.NotNil(t, err, "the user is rejected if it cannot be saved")
+
+	_, err = directory.Resolve(context.Background(), claims)
+	assert.Nil(t, err, "the unchanged users are not saved again")
+}
+
+func TestLocalDirectory(t *testing.T) {
+	store := newFakeStore()
+	require.Nil(t, store.Save(context.Background(), entity.User{ID: "local", Username: "alice", Role: entity.RoleAdmin}, testIssuer, "sub-alice"))
+
+	directory := NewLocalDirectory(store, testIssuer)
+
+	user, err := directory.Resolve(context.Background(), entity.User{ID: "sub-alice", Username: "whatever", Role: entity.RoleUser})
+	require.Nil(t, err)
+	assert.Equal(t, entity.User{ID: "local", Username: "alice", Role: entity.RoleAdmin}, user, "the local user is used")
+
+	_, err = directory.Resolve(context.Background(), entity.User{ID: "sub-mallory", Username: "alice"})
+	assert.True(t, errors.Is(err, ErrUnknownAccount), "the username does not identify the user")
+
+	_, err = NewLocalDirectory(store, "https://other").Resolve(context.Background(), entity.User{ID: "sub-alice", Username: "alice"})
+	assert.True(t, errors.Is(err, ErrUnknownAccount), "the accounts of another issuer are unknown")
+}
@@ -0,0 +1,164 @@
+package accesstoken
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/common"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/repos/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// AccessTokenRepo holds the personal access tokens. Only the hash of the tokens is stored.
+type AccessTokenRepo struct {
+	db             *gorm.DB
+	client         pgclient.Client
+	circuitBreaker pgclient.CircuitBreaker
+}
+
+func NewPostgresRepo(client pgclient.Client) (*AccessTokenRepo, error) {
+	config := gorm.Config{
+		SkipDefaultTransaction: true, // No need transaction for those use cases.
+	}
+
+	gormDB, err := client.Open(config)
+	if err != nil {
+		return &AccessTokenRepo{}, err
+	}
+
+	return &AccessTokenRepo{gormDB, client, client.GetCircuitBreaker()}, nil
+}
+
+// Create saves the token with the hash of its secret.
+func (r *AccessTokenRepo) Create(ctx context.Context, token entity.AccessToken, hash string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while creating access token")
+	}
+
+	m := toModel(token)
+	m.TokenHash = hash
+
+	if tx := r.db.WithContext(ctx).Create(&m); tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to create access token of user '%s'", token.Owner))
+	}
+
+	return nil
+}
+
+// GetByHash returns the token with the hash.
+func (r *AccessTokenRepo) GetByHash(ctx context.Context, hash string) (entity.AccessToken, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return entity.AccessToken{}, common.NewPostgresNotAvailableError("pg not available while retrieving access token")
+	}
+
+	var m models.AccessToken
+	if tx := r.db.WithContext(ctx).Where("token_hash = ?", hash).First(&m); tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return entity.AccessToken{}, common.NewEntityNotFound("access token not found")
+		}
+		return entity.AccessToken{}, r.wrapError(tx.Error, "failed to get access token")
+	}
+
+	return fromModel(m), nil
+}
+
+// GetByOwner returns the tokens of the user sorted by creation date.
+func (r *AccessTokenRepo) GetByOwner(ctx context.Context, owner string) ([]entity.AccessToken, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return []entity.AccessToken{}, common.NewPostgresNotAvailableError("pg not available while retrieving access tokens")
+	}
+
+	var rows []models.AccessToken
+	if tx := r.db.WithContext(ctx).Where("owner = ?", owner).Order("created_at").Find(&rows); tx.Error != nil {
+		return []entity.AccessToken{}, r.wrapError(tx.Error, fmt.Sprintf("failed to get access tokens of user '%s'", owner))
+	}
+
+	tokens := make([]entity.AccessToken, 0, len(rows))
+	for _, m := range rows {
+		tokens = append(tokens, fromModel(m))
+	}
+
+	return tokens, nil
+}
+
+// Delete removes a token of the user.
+func (r *AccessTokenRepo) Delete(ctx context.Context, owner, id string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while removing access token")
+	}
+
+	tx := r.db.WithContext(ctx).Where("id = ? AND owner = ?", id, owner).Delete(&models.AccessToken{})
+	if tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to remove access token '%s'", id))
+	}
+
+	if tx.RowsAffected == 0 {
+		return common.NewEntityNotFound(fmt.Sprintf("access token '%s' not found", id))
+	}
+
+	return nil
+}
+
+// SetLastUsed saves the last time the token has been accepted.
+func (r *AccessTokenRepo) SetLastUsed(ctx context.Context, id string, at time.Time) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while updating access token")
+	}
+
+	tx := r.db.WithContext(ctx).Model(&models.AccessToken{}).Where("id = ?", id).Update("last_used_at", at)
+	if tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to update access token '%s'", id))
+	}
+
+	return nil
+}
+
+func toModel(e entity.AccessToken) models.AccessToken {
+	return models.AccessToken{
+		ID:         e.ID,
+		Owner:      e.Owner,
+		Name:       e.Name,
+		Scope:      e.Scope.String(),
+		CreatedAt:  e.CreatedAt,
+		ExpiresAt:  e.ExpiresAt,
+		LastUsedAt: sql.NullTime{Time: e.LastUsedAt, Valid: !e.LastUsedAt.IsZero()},
+	}
+}
+
+func fromModel(m models.AccessToken) entity.AccessToken {
+	e := entity.AccessToken{
+		ID:        m.ID,
+		Owner:     m.Owner,
+		Name:      m.Name,
+		Scope:     entity.TokenScope(m.Scope),
+		CreatedAt: m.CreatedAt,
+		ExpiresAt: m.ExpiresAt,
+	}
+
+	if m.LastUsedAt.Valid {
+		e.LastUsedAt = m.LastUsedAt.Time
+	}
+
+	return e
+}
+
+func (r *AccessTokenRepo) wrapError(err error, msg string) error {
+	if r.checkNetworkError(err) {
+		return common.NewPostgresNotAvailableError(msg)
+	}
+	return common.NewInternalError(err, msg)
+}
+
+func (r *AccessTokenRepo) checkNetworkError(err error) (isOpen bool) {
+	isOpen = r.circuitBreaker.BreakOnNetworkError(err)
+	if isOpen {
+		zap.S().Warn("circuit breaker is now open")
+	}
+	return
+}
@@ -18,7 +18,11 @@ type albumJoinRow struct {
 	OwnerID             string               `gorm:"column:owner_id;type:TEXT;"`
 	Description         *string              `gorm:"column:description;type:TEXT;"`
 	Location            *string              `gorm:"column:location;type:TEXT;"`
+	City                *string              `gorm:"column:city;type:TEXT;"`
+	Region              *string              `gorm:"column:region;type:TEXT;"`
+	Country             *string              `gorm:"column:country;type:TEXT;"`
 	Bucket              string               `gorm:"column:bucket;type:TEXT;"`
+	Encrypted           bool                 `gorm:"column:encrypted;type:BOOLEAN;"`
 	TagID               string               `gorm:"column:tag_id;type:TEXT"`
 	TagName             *string              `gorm:"column:tag_name;type:TEXT;"`
 	TagColor            *string              `gorm:"column:tag_color;tape:TEXT"`
@@ -37,6 +41,7 @@ func (ca albumJoinRow) ToEntity() (entity.Album, error) {
 		CreatedAt:        ca.CreatedAt,
 		Owner:            ca.OwnerID,
 		Bucket:           ca.Bucket,
+		Encrypted:        ca.Encrypted,
 		UserPermissions:  make([]entity.AlbumPermission, 0),
 		GroupPermissions: make([]entity.AlbumPermission, 0),
 	}
@@ -53,6 +58,8 @@ func (ca albumJoinRow) ToEntity() (entity.Album, error) {
 		album.Thumbnail = ca.Thumbnail.String
 	}
 
+	album.Place = toPlace(ca.City, ca.Region, ca.Country)
+
 	if len(ca.Permissions) > 0 {
 		permissions := []entity.Permission{}
 		for _, perm := range ca.Permissions {
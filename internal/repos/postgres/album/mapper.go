@@ -15,6 +15,10 @@ func toModel(e entity.Album) models.Album {
 		Description: &e.Description,
 		Location:    &e.Location,
 		Bucket:      e.Bucket,
+		City:        &e.Place.City,
+		Region:      &e.Place.Region,
+		Country:     &e.Place.Country,
+		Encrypted:   e.Encrypted,
 	}
 
 	if len(e.Thumbnail) == 0 {
@@ -33,6 +37,7 @@ func fromModel(m models.Album) entity.Album {
 		CreatedAt: m.CreatedAt,
 		Owner:     m.OwnerID,
 		Bucket:    m.Bucket,
+		Encrypted: m.Encrypted,
 	}
 
 	if m.Description != nil {
@@ -47,5 +52,25 @@ func fromModel(m models.Album) entity.Album {
 		e.Thumbnail = m.Thumbnail.String
 	}
 
+	e.Place = toPlace(m.City, m.Region, m.Country)
+
 	return e
 }
+
+func toPlace(city, region, country *string) entity.Place {
+	var place entity.Place
+
+	if city != nil {
+		place.City = *city
+	}
+
+	if region != nil {
+		place.Region = *region
+	}
+
+	if country != nil {
+		place.Country = *country
+	}
+
+	return place
+}
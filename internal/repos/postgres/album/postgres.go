@@ -39,8 +39,12 @@ func (a *AlbumPostgresRepo) Create(ctx context.Context, album entity.Album) (ent
 		return entity.Album{}, common.NewPostgresNotAvailableError("pg not available while creating album")
 	}
 
+	// the id is generated unless the album's container is already named after it
 	m := toModel(album)
-	m.ID = xid.New().String()
+	m.ID = album.ID
+	if len(m.ID) == 0 {
+		m.ID = xid.New().String()
+	}
 	album.ID = m.ID
 
 	result := a.db.WithContext(ctx).Create(&m)
@@ -90,6 +94,7 @@ func (a *AlbumPostgresRepo) Update(ctx context.Context, album entity.Album) (ent
 		CreatedAt:   album.CreatedAt,
 		Description: album.Description,
 		Location:    album.Location,
+		Place:       album.Place,
 		Owner:       album.Owner,
 		Bucket:      album.Bucket,
 		Thumbnail:   album.Thumbnail,
@@ -98,7 +103,8 @@ func (a *AlbumPostgresRepo) Update(ctx context.Context, album entity.Album) (ent
 	m := toModel(newAlbum)
 	m.ID = album.ID
 
-	result := a.db.WithContext(ctx).Save(&m)
+	// the media of an album stay encrypted or not for its whole life
+	result := a.db.WithContext(ctx).Omit("encrypted").Save(&m)
 	if result.Error != nil {
 		if a.checkNetworkError(tx.Error) {
 			return album, common.NewPostgresNotAvailableError("pg not available while updating album")
@@ -106,6 +112,8 @@ func (a *AlbumPostgresRepo) Update(ctx context.Context, album entity.Album) (ent
 		return album, common.NewInternalError(result.Error, fmt.Sprintf("failed to update album with id '%s'", album.ID))
 	}
 
+	album.Encrypted = ca.Encrypted
+
 	return album, nil
 }
 
@@ -0,0 +1,308 @@
+package comment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/xid"
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/common"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/repos/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type CommentRepo struct {
+	db             *gorm.DB
+	client         pgclient.Client
+	circuitBreaker pgclient.CircuitBreaker
+}
+
+func NewPostgresRepo(client pgclient.Client) (*CommentRepo, error) {
+	config := gorm.Config{
+		SkipDefaultTransaction: true, // No need transaction for those use cases.
+	}
+
+	gormDB, err := client.Open(config)
+	if err != nil {
+		return &CommentRepo{}, err
+	}
+
+	return &CommentRepo{gormDB, client, client.GetCircuitBreaker()}, nil
+}
+
+func (r *CommentRepo) Create(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return entity.Comment{}, common.NewPostgresNotAvailableError("pg not available while creating comment")
+	}
+
+	m := models.Comment{
+		ID:        xid.New().String(),
+		AlbumID:   comment.AlbumID,
+		Filename:  comment.Filename,
+		AuthorID:  comment.Author,
+		Content:   comment.Content,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if result := r.db.WithContext(ctx).Create(&m); result.Error != nil {
+		if r.checkNetworkError(result.Error) {
+			return entity.Comment{}, common.NewPostgresNotAvailableError("pg not available while creating comment")
+		}
+		return entity.Comment{}, common.NewInternalError(result.Error, "failed to create comment")
+	}
+
+	return toEntity(m), nil
+}
+
+// Update saves the new content of the comment. The old content is kept in the comment history.
+func (r *CommentRepo) Update(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return comment, common.NewPostgresNotAvailableError("pg not available while updating comment")
+	}
+
+	var updated models.Comment
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var old models.Comment
+		if err := tx.Where("id = ?", comment.ID).First(&old).Error; err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		history := models.CommentHistory{
+			CommentID: old.ID,
+			Content:   old.Content,
+			CreatedAt: now,
+		}
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+
+		old.Content = comment.Content
+		old.UpdatedAt = sql.NullTime{Time: now, Valid: true}
+		if err := tx.Save(&old).Error; err != nil {
+			return err
+		}
+
+		updated = old
+
+		return nil
+	})
+
+	if err != nil {
+		if r.checkNetworkError(err) {
+			return comment, common.NewPostgresNotAvailableError("pg not available while updating comment")
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return comment, common.NewEntityNotFound(fmt.Sprintf("comment '%s' not found", comment.ID))
+		}
+		return comment, common.NewInternalError(err, fmt.Sprintf("failed to update comment '%s'", comment.ID))
+	}
+
+	return toEntity(updated), nil
+}
+
+func (r *CommentRepo) Delete(ctx context.Context, id string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while removing comment")
+	}
+
+	if result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Comment{}); result.Error != nil {
+		if r.checkNetworkError(result.Error) {
+			return common.NewPostgresNotAvailableError("pg not available while removing comment")
+		}
+		return common.NewInternalError(result.Error, fmt.Sprintf("failed to delete comment with id '%s'", id))
+	}
+
+	return nil
+}
+
+func (r *CommentRepo) GetByID(ctx context.Context, id string) (entity.Comment, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return entity.Comment{}, common.NewPostgresNotAvailableError("pg not available while retrieving comment")
+	}
+
+	var m models.Comment
+	if tx := r.db.WithContext(ctx).Where("id = ?", id).First(&m); tx.Error != nil {
+		if r.checkNetworkError(tx.Error) {
+			return entity.Comment{}, common.NewPostgresNotAvailableError("pg not available while retrieving comment")
+		}
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return entity.Comment{}, common.NewEntityNotFound(fmt.Sprintf("comment '%s' not found", id))
+		}
+		return entity.Comment{}, common.NewInternalError(tx.Error, fmt.Sprintf("failed to fetch comment '%s'", id))
+	}
+
+	return toEntity(m), nil
+}
+
+// GetByMedia returns the comments of the media sorted by creation date.
+func (r *CommentRepo) GetByMedia(ctx context.Context, albumID, filename string) ([]entity.Comment, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return []entity.Comment{}, common.NewPostgresNotAvailableError("pg not available while retrieving comments")
+	}
+
+	var pgModels []models.Comment
+	tx := r.db.WithContext(ctx).
+		Where("album_id = ?", albumID).
+		Where("filename = ?", filename).
+		Order("created_at").
+		Find(&pgModels)
+
+	if tx.Error != nil {
+		if r.checkNetworkError(tx.Error) {
+			return []entity.Comment{}, common.NewPostgresNotAvailableError("pg not available while retrieving comments")
+		}
+		return []entity.Comment{}, common.NewInternalError(tx.Error, fmt.Sprintf("failed to fetch comments of '%s' in album '%s'", filename, albumID))
+	}
+
+	comments := make([]entity.Comment, 0, len(pgModels))
+	for _, m := range pgModels {
+		comments = append(comments, toEntity(m))
+	}
+
+	return comments, nil
+}
+
+// GetHistory returns the previous versions of the comment. The most recent version comes first.
+func (r *CommentRepo) GetHistory(ctx context.Context, commentID string) ([]entity.CommentRevision, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return []entity.CommentRevision{}, common.NewPostgresNotAvailableError("pg not available while retrieving comment history")
+	}
+
+	var pgModels []models.CommentHistory
+	tx := r.db.WithContext(ctx).
+		Where("comment_id = ?", commentID).
+		Order("created_at desc").
+		Find(&pgModels)
+
+	if tx.Error != nil {
+		if r.checkNetworkError(tx.Error) {
+			return []entity.CommentRevision{}, common.NewPostgresNotAvailableError("pg not available while retrieving comment history")
+		}
+		return []entity.CommentRevision{}, common.NewInternalError(tx.Error, fmt.Sprintf("failed to fetch history of comment '%s'", commentID))
+	}
+
+	revisions := make([]entity.CommentRevision, 0, len(pgModels))
+	for _, m := range pgModels {
+		revisions = append(revisions, entity.CommentRevision{
+			CommentID: m.CommentID,
+			Content:   m.Content,
+			CreatedAt: m.CreatedAt,
+		})
+	}
+
+	return revisions, nil
+}
+
+// AddReaction adds the reaction. It does nothing if the user has already reacted with the same kind.
+func (r *CommentRepo) AddReaction(ctx context.Context, reaction entity.Reaction) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while adding reaction")
+	}
+
+	m := models.Reaction{
+		AlbumID:   reaction.AlbumID,
+		Filename:  reaction.Filename,
+		UserID:    reaction.User,
+		Kind:      reaction.Kind,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	tx := r.db.WithContext(ctx).Exec(`INSERT INTO reaction (album_id, filename, user_id, kind, created_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`, m.AlbumID, m.Filename, m.UserID, m.Kind, m.CreatedAt)
+	if tx.Error != nil {
+		if r.checkNetworkError(tx.Error) {
+			return common.NewPostgresNotAvailableError("pg not available while adding reaction")
+		}
+		return common.NewInternalError(tx.Error, fmt.Sprintf("failed to add reaction to '%s' in album '%s'", reaction.Filename, reaction.AlbumID))
+	}
+
+	return nil
+}
+
+func (r *CommentRepo) RemoveReaction(ctx context.Context, reaction entity.Reaction) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while removing reaction")
+	}
+
+	tx := r.db.WithContext(ctx).
+		Where("album_id = ?", reaction.AlbumID).
+		Where("filename = ?", reaction.Filename).
+		Where("user_id = ?", reaction.User).
+		Where("kind = ?", reaction.Kind).
+		Delete(&models.Reaction{})
+
+	if tx.Error != nil {
+		if r.checkNetworkError(tx.Error) {
+			return common.NewPostgresNotAvailableError("pg not available while removing reaction")
+		}
+		return common.NewInternalError(tx.Error, fmt.Sprintf("failed to remove reaction from '%s' in album '%s'", reaction.Filename, reaction.AlbumID))
+	}
+
+	return nil
+}
+
+func (r *CommentRepo) GetReactions(ctx context.Context, albumID, filename string) ([]entity.Reaction, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return []entity.Reaction{}, common.NewPostgresNotAvailableError("pg not available while retrieving reactions")
+	}
+
+	var pgModels []models.Reaction
+	tx := r.db.WithContext(ctx).
+		Where("album_id = ?", albumID).
+		Where("filename = ?", filename).
+		Order("created_at").
+		Find(&pgModels)
+
+	if tx.Error != nil {
+		if r.checkNetworkError(tx.Error) {
+			return []entity.Reaction{}, common.NewPostgresNotAvailableError("pg not available while retrieving reactions")
+		}
+		return []entity.Reaction{}, common.NewInternalError(tx.Error, fmt.Sprintf("failed to fetch reactions of '%s' in album '%s'", filename, albumID))
+	}
+
+	reactions := make([]entity.Reaction, 0, len(pgModels))
+	for _, m := range pgModels {
+		reactions = append(reactions, entity.Reaction{
+			AlbumID:   m.AlbumID,
+			Filename:  m.Filename,
+			User:      m.UserID,
+			Kind:      m.Kind,
+			CreatedAt: m.CreatedAt,
+		})
+	}
+
+	return reactions, nil
+}
+
+func (r *CommentRepo) checkNetworkError(err error) (isOpen bool) {
+	isOpen = r.circuitBreaker.BreakOnNetworkError(err)
+	if isOpen {
+		zap.S().Warn("circuit breaker is now open")
+	}
+	return
+}
+
+func toEntity(m models.Comment) entity.Comment {
+	c := entity.Comment{
+		ID:        m.ID,
+		AlbumID:   m.AlbumID,
+		Filename:  m.Filename,
+		Author:    m.AuthorID,
+		Content:   m.Content,
+		CreatedAt: m.CreatedAt,
+	}
+
+	if m.UpdatedAt.Valid {
+		updatedAt := m.UpdatedAt.Time
+		c.UpdatedAt = &updatedAt
+	}
+
+	return c
+}
@@ -0,0 +1,236 @@
+package directory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/common"
+	"github.com/tupyy/gophoto/internal/entity"
+	userFilters "github.com/tupyy/gophoto/internal/repos/filters/user"
+	"github.com/tupyy/gophoto/internal/repos/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DirectoryRepo is the local directory of the users, kept in postgres. It holds the roles and the groups of the users
+// when the identity provider does not.
+type DirectoryRepo struct {
+	db             *gorm.DB
+	client         pgclient.Client
+	circuitBreaker pgclient.CircuitBreaker
+}
+
+func NewPostgresRepo(client pgclient.Client) (*DirectoryRepo, error) {
+	config := gorm.Config{
+		SkipDefaultTransaction: true, // No need transaction for those use cases.
+	}
+
+	gormDB, err := client.Open(config)
+	if err != nil {
+		return &DirectoryRepo{}, err
+	}
+
+	return &DirectoryRepo{gormDB, client, client.GetCircuitBreaker()}, nil
+}
+
+// GetUsers returns the users passing the filters, sorted by username.
+func (r *DirectoryRepo) GetUsers(ctx context.Context, filters userFilters.Filters) ([]entity.User, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return []entity.User{}, common.NewPostgresNotAvailableError("pg not available while retrieving users")
+	}
+
+	var rows []models.DirectoryUser
+	if tx := r.db.WithContext(ctx).Order("username").Find(&rows); tx.Error != nil {
+		return []entity.User{}, r.wrapError(tx.Error, "failed to get users")
+	}
+
+	var groups []models.DirectoryGroup
+	if tx := r.db.WithContext(ctx).Order("name").Find(&groups); tx.Error != nil {
+		return []entity.User{}, r.wrapError(tx.Error, "failed to get groups of users")
+	}
+
+	users := make([]entity.User, 0, len(rows))
+	for _, m := range rows {
+		u := fromModel(m, groups)
+
+		pass := true
+		for _, filter := range filters {
+			if !filter(u) {
+				pass = false
+				break
+			}
+		}
+
+		if pass {
+			users = append(users, u)
+		}
+	}
+
+	return users, nil
+}
+
+// GetUserByID returns the user with its groups.
+func (r *DirectoryRepo) GetUserByID(ctx context.Context, id string) (entity.User, error) {
+	return r.getUser(ctx, id, "id = ?", id)
+}
+
+// GetUserByUsername returns the user with its groups.
+func (r *DirectoryRepo) GetUserByUsername(ctx context.Context, username string) (entity.User, error) {
+	return r.getUser(ctx, username, "username = ?", username)
+}
+
+// GetUserBySubject returns the user of the account identified by the subject of its tokens at the issuer.
+func (r *DirectoryRepo) GetUserBySubject(ctx context.Context, issuer, subject string) (entity.User, error) {
+	return r.getUser(ctx, subject, "issuer = ? AND subject = ?", issuer, subject)
+}
+
+func (r *DirectoryRepo) getUser(ctx context.Context, value string, query string, args ...interface{}) (entity.User, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return entity.User{}, common.NewPostgresNotAvailableError("pg not available while retrieving user")
+	}
+
+	var m models.DirectoryUser
+	if tx := r.db.WithContext(ctx).Where(query, args...).First(&m); tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return entity.User{}, common.NewEntityNotFound(fmt.Sprintf("user '%s' not found", value))
+		}
+		return entity.User{}, r.wrapError(tx.Error, fmt.Sprintf("failed to get user '%s'", value))
+	}
+
+	var groups []models.DirectoryGroup
+	if tx := r.db.WithContext(ctx).Where("user_id = ?", m.ID).Order("name").Find(&groups); tx.Error != nil {
+		return entity.User{}, r.wrapError(tx.Error, fmt.Sprintf("failed to get groups of user '%s'", value))
+	}
+
+	return fromModel(m, groups), nil
+}
+
+// GetGroups returns the groups having at least one user.
+func (r *DirectoryRepo) GetGroups(ctx context.Context) ([]entity.Group, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return []entity.Group{}, common.NewPostgresNotAvailableError("pg not available while retrieving groups")
+	}
+
+	var names []string
+	if tx := r.db.WithContext(ctx).Model(&models.DirectoryGroup{}).Distinct("name").Order("name").Pluck("name", &names); tx.Error != nil {
+		return []entity.Group{}, r.wrapError(tx.Error, "failed to get groups")
+	}
+
+	groups := make([]entity.Group, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, entity.Group{Name: name})
+	}
+
+	return groups, nil
+}
+
+// Save creates or updates the user of the account and replaces its groups. It fails if the username belongs to another user.
+func (r *DirectoryRepo) Save(ctx context.Context, user entity.User, issuer, subject string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while saving user")
+	}
+
+	m := toModel(user)
+	m.Issuer = issuer
+	m.Subject = subject
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"issuer", "subject", "username", "first_name", "last_name", "role", "can_share"}),
+		}).Create(&m).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", m.ID).Delete(&models.DirectoryGroup{}).Error; err != nil {
+			return err
+		}
+
+		for _, g := range user.Groups {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.DirectoryGroup{UserID: m.ID, Name: g.Name}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return r.wrapError(err, fmt.Sprintf("failed to save user '%s'", user.Username))
+	}
+
+	return nil
+}
+
+// Delete removes the user and its groups.
+func (r *DirectoryRepo) Delete(ctx context.Context, username string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while removing user")
+	}
+
+	tx := r.db.WithContext(ctx).Where("username = ?", username).Delete(&models.DirectoryUser{})
+	if tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to remove user '%s'", username))
+	}
+
+	if tx.RowsAffected == 0 {
+		return common.NewEntityNotFound(fmt.Sprintf("user '%s' not found", username))
+	}
+
+	return nil
+}
+
+func toModel(u entity.User) models.DirectoryUser {
+	role := u.Role
+	if role == "" {
+		role = entity.RoleUser
+	}
+
+	return models.DirectoryUser{
+		ID:        u.ID,
+		Username:  u.Username,
+		FirstName: sql.NullString{String: u.FirstName, Valid: u.FirstName != ""},
+		LastName:  sql.NullString{String: u.LastName, Valid: u.LastName != ""},
+		Role:      role.String(),
+		CanShare:  u.CanShare,
+	}
+}
+
+// fromModel maps the user with its groups found among the groups, which are sorted by name.
+func fromModel(m models.DirectoryUser, groups []models.DirectoryGroup) entity.User {
+	u := entity.User{
+		ID:        m.ID,
+		Username:  m.Username,
+		FirstName: m.FirstName.String,
+		LastName:  m.LastName.String,
+		Role:      entity.Role(m.Role),
+		CanShare:  m.CanShare,
+		Groups:    []entity.Group{},
+	}
+
+	for _, g := range groups {
+		if g.UserID == m.ID {
+			u.Groups = append(u.Groups, entity.Group{Name: g.Name})
+		}
+	}
+
+	return u
+}
+
+func (r *DirectoryRepo) wrapError(err error, msg string) error {
+	if r.checkNetworkError(err) {
+		return common.NewPostgresNotAvailableError(msg)
+	}
+	return common.NewInternalError(err, msg)
+}
+
+func (r *DirectoryRepo) checkNetworkError(err error) (isOpen bool) {
+	isOpen = r.circuitBreaker.BreakOnNetworkError(err)
+	if isOpen {
+		zap.S().Warn("circuit breaker is now open")
+	}
+	return
+}
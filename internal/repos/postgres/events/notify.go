@@ -0,0 +1,128 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/rs/xid"
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/entity"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	channel = "gphotos_events"
+
+	reconnectInterval = 5 * time.Second
+)
+
+// payload is the message sent with NOTIFY.
+type payload struct {
+	// Origin - id of the replica which published the event.
+	Origin    string    `json:"origin"`
+	Kind      string    `json:"kind"`
+	AlbumID   string    `json:"album_id,omitempty"`
+	Bucket    string    `json:"bucket"`
+	Filename  string    `json:"filename,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NotifyTransport forwards events between replicas using postgres LISTEN/NOTIFY.
+type NotifyTransport struct {
+	db     *gorm.DB
+	params pgclient.ClientParams
+	origin string
+}
+
+func NewNotifyTransport(client pgclient.Client, params pgclient.ClientParams) (*NotifyTransport, error) {
+	config := gorm.Config{
+		SkipDefaultTransaction: true, // No need transaction for those use cases.
+	}
+
+	gormDB, err := client.Open(config)
+	if err != nil {
+		return &NotifyTransport{}, err
+	}
+
+	return &NotifyTransport{db: gormDB, params: params, origin: xid.New().String()}, nil
+}
+
+func (n *NotifyTransport) Send(ctx context.Context, event entity.Event) error {
+	p := payload{
+		Origin:    n.origin,
+		Kind:      string(event.Kind),
+		AlbumID:   event.AlbumID,
+		Bucket:    event.Bucket,
+		Filename:  event.Filename,
+		CreatedAt: event.CreatedAt,
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %v", err)
+	}
+
+	return n.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", channel, string(data)).Error
+}
+
+// Listen opens a dedicated connection used only for LISTEN. The connection is reopened if it is lost.
+func (n *NotifyTransport) Listen(ctx context.Context, handler func(event entity.Event)) error {
+	for {
+		err := n.listen(ctx, handler)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		zap.S().Errorw("lost listen connection to postgres", "error", err, "channel", channel)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(reconnectInterval):
+		}
+	}
+}
+
+func (n *NotifyTransport) listen(ctx context.Context, handler func(event entity.Event)) error {
+	conn, err := pgx.Connect(ctx, n.params.DSN())
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, fmt.Sprintf("LISTEN %s", channel)); err != nil {
+		return err
+	}
+
+	zap.S().Infow("listening to postgres notifications", "channel", channel)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var p payload
+		if err := json.Unmarshal([]byte(notification.Payload), &p); err != nil {
+			zap.S().Warnw("failed to unmarshal notification", "error", err, "payload", notification.Payload)
+			continue
+		}
+
+		// events published by this replica have already been dispatched
+		if p.Origin == n.origin {
+			continue
+		}
+
+		handler(entity.Event{
+			Kind:      entity.EventKind(p.Kind),
+			AlbumID:   p.AlbumID,
+			Bucket:    p.Bucket,
+			Filename:  p.Filename,
+			CreatedAt: p.CreatedAt,
+		})
+	}
+}
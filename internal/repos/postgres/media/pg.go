@@ -0,0 +1,357 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/common"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/repos/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MediaRepo holds the user editable data of the media. The content itself is kept in the object store.
+type MediaRepo struct {
+	db             *gorm.DB
+	client         pgclient.Client
+	circuitBreaker pgclient.CircuitBreaker
+}
+
+func NewPostgresRepo(client pgclient.Client) (*MediaRepo, error) {
+	config := gorm.Config{
+		SkipDefaultTransaction: true, // No need transaction for those use cases.
+	}
+
+	gormDB, err := client.Open(config)
+	if err != nil {
+		return &MediaRepo{}, err
+	}
+
+	return &MediaRepo{gormDB, client, client.GetCircuitBreaker()}, nil
+}
+
+// GetByAlbum returns the metadata of all the media of the album. Favorite is set from the point of view of user.
+// The media are mapped by filename.
+func (r *MediaRepo) GetByAlbum(ctx context.Context, albumID, user string) (map[string]entity.Media, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return map[string]entity.Media{}, common.NewPostgresNotAvailableError("pg not available while retrieving media")
+	}
+
+	medias := make(map[string]entity.Media)
+	get := func(filename string) entity.Media {
+		if m, found := medias[filename]; found {
+			return m
+		}
+		return entity.Media{Filename: filename, Tags: []entity.Tag{}}
+	}
+
+	var mediaModels []models.Media
+	if tx := r.db.WithContext(ctx).Where("album_id = ?", albumID).Find(&mediaModels); tx.Error != nil {
+		return map[string]entity.Media{}, r.wrapError(tx.Error, fmt.Sprintf("failed to fetch media of album '%s'", albumID))
+	}
+
+	for _, mm := range mediaModels {
+		m := get(mm.Filename)
+		if mm.Caption != nil {
+			m.Caption = *mm.Caption
+		}
+		if mm.Description != nil {
+			m.Description = *mm.Description
+		}
+		if mm.City != nil {
+			m.Place.City = *mm.City
+		}
+		if mm.Region != nil {
+			m.Place.Region = *mm.Region
+		}
+		if mm.Country != nil {
+			m.Place.Country = *mm.Country
+		}
+		medias[mm.Filename] = m
+	}
+
+	var favorites []models.MediaFavorites
+	tx := r.db.WithContext(ctx).
+		Where("album_id = ?", albumID).
+		Where("user_id = ?", user).
+		Find(&favorites)
+	if tx.Error != nil {
+		return map[string]entity.Media{}, r.wrapError(tx.Error, fmt.Sprintf("failed to fetch favorites of album '%s'", albumID))
+	}
+
+	for _, f := range favorites {
+		m := get(f.Filename)
+		m.Favorite = true
+		medias[f.Filename] = m
+	}
+
+	tags := []struct {
+		Filename string  `gorm:"column:filename;type:TEXT;"`
+		ID       string  `gorm:"column:id;type:TEXT;"`
+		Name     string  `gorm:"column:name;type:TEXT;"`
+		Color    *string `gorm:"column:color;type:TEXT;"`
+		UserID   string  `gorm:"column:user_id;type:TEXT;"`
+	}{}
+
+	tx = r.db.WithContext(ctx).Table("media_tags").
+		Select("media_tags.filename, tag.id, tag.name, tag.color, tag.user_id").
+		Joins("JOIN tag ON (tag.id = media_tags.tag_id)").
+		Where("media_tags.album_id = ?", albumID).
+		Order("tag.name").
+		Find(&tags)
+	if tx.Error != nil {
+		return map[string]entity.Media{}, r.wrapError(tx.Error, fmt.Sprintf("failed to fetch tags of album '%s'", albumID))
+	}
+
+	for _, t := range tags {
+		m := get(t.Filename)
+		m.Tags = append(m.Tags, entity.Tag{
+			ID:     t.ID,
+			Name:   t.Name,
+			Color:  t.Color,
+			UserID: t.UserID,
+		})
+		medias[t.Filename] = m
+	}
+
+	return medias, nil
+}
+
+// Update saves caption and description of the media.
+func (r *MediaRepo) Update(ctx context.Context, albumID string, media entity.Media) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while updating media")
+	}
+
+	m := models.Media{
+		AlbumID:     albumID,
+		Filename:    media.Filename,
+		Caption:     &media.Caption,
+		Description: &media.Description,
+	}
+
+	tx := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "album_id"}, {Name: "filename"}},
+		DoUpdates: clause.AssignmentColumns([]string{"caption", "description"}),
+	}).Create(&m)
+	if tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to update media '%s' of album '%s'", media.Filename, albumID))
+	}
+
+	return nil
+}
+
+// SetPlace saves the names of the place where the media has been captured.
+func (r *MediaRepo) SetPlace(ctx context.Context, albumID, filename string, place entity.Place) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while updating media")
+	}
+
+	m := models.Media{
+		AlbumID:  albumID,
+		Filename: filename,
+		City:     &place.City,
+		Region:   &place.Region,
+		Country:  &place.Country,
+	}
+
+	tx := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "album_id"}, {Name: "filename"}},
+		DoUpdates: clause.AssignmentColumns([]string{"city", "region", "country"}),
+	}).Create(&m)
+	if tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to set place of media '%s' of album '%s'", filename, albumID))
+	}
+
+	return nil
+}
+
+// Delete removes the metadata, the favorites, the tags, the comments with their history and the reactions of the media.
+func (r *MediaRepo) Delete(ctx context.Context, albumID, filename string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while removing media")
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		comments := tx.Model(&models.Comment{}).Select("id").Where("album_id = ?", albumID).Where("filename = ?", filename)
+		if err := tx.Where("comment_id IN (?)", comments).Delete(&models.CommentHistory{}).Error; err != nil {
+			return err
+		}
+
+		for _, m := range []interface{}{&models.Media{}, &models.MediaFavorites{}, &models.MediaTags{}, &models.Comment{}, &models.Reaction{}} {
+			if err := tx.Where("album_id = ?", albumID).Where("filename = ?", filename).Delete(m).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return r.wrapError(err, fmt.Sprintf("failed to delete media '%s' of album '%s'", filename, albumID))
+	}
+
+	return nil
+}
+
+// Move moves the metadata, the favorites, the tags, the comments and the reactions of the media to another album.
+// Either all the media are moved or none.
+func (r *MediaRepo) Move(ctx context.Context, srcAlbumID, dstAlbumID string, filenames []string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while moving media")
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, m := range []interface{}{&models.Media{}, &models.MediaFavorites{}, &models.MediaTags{}, &models.Comment{}, &models.Reaction{}} {
+			if err := tx.Model(m).Where("album_id = ?", srcAlbumID).Where("filename IN ?", filenames).Update("album_id", dstAlbumID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return r.wrapError(err, fmt.Sprintf("failed to move media from album '%s' to album '%s'", srcAlbumID, dstAlbumID))
+	}
+
+	return nil
+}
+
+// Copy copies the caption, the description, the place and the tags of the media to another album.
+// Favorites, comments and reactions stay with the original media. Either all the media are copied or none.
+func (r *MediaRepo) Copy(ctx context.Context, srcAlbumID, dstAlbumID string, filenames []string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while copying media")
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`INSERT INTO media (album_id, filename, caption, description, city, region, country)
+			SELECT ?, filename, caption, description, city, region, country FROM media WHERE album_id = ? AND filename IN ?`,
+			dstAlbumID, srcAlbumID, filenames).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`INSERT INTO media_tags (album_id, filename, tag_id)
+			SELECT ?, filename, tag_id FROM media_tags WHERE album_id = ? AND filename IN ?`,
+			dstAlbumID, srcAlbumID, filenames).Error
+	})
+	if err != nil {
+		return r.wrapError(err, fmt.Sprintf("failed to copy media from album '%s' to album '%s'", srcAlbumID, dstAlbumID))
+	}
+
+	return nil
+}
+
+func (r *MediaRepo) SetFavorite(ctx context.Context, albumID, filename, user string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while setting favorite")
+	}
+
+	m := models.MediaFavorites{
+		AlbumID:   albumID,
+		Filename:  filename,
+		UserID:    user,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if tx := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&m); tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to set media '%s' as favorite", filename))
+	}
+
+	return nil
+}
+
+func (r *MediaRepo) RemoveFavorite(ctx context.Context, albumID, filename, user string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while removing favorite")
+	}
+
+	tx := r.db.WithContext(ctx).
+		Where("album_id = ?", albumID).
+		Where("filename = ?", filename).
+		Where("user_id = ?", user).
+		Delete(&models.MediaFavorites{})
+	if tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to remove media '%s' from favorites", filename))
+	}
+
+	return nil
+}
+
+func (r *MediaRepo) Associate(ctx context.Context, albumID, filename, tagID string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while tagging media")
+	}
+
+	m := models.MediaTags{
+		AlbumID:  albumID,
+		Filename: filename,
+		TagID:    tagID,
+	}
+
+	if tx := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&m); tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to associate tag '%s' with media '%s'", tagID, filename))
+	}
+
+	return nil
+}
+
+func (r *MediaRepo) Dissociate(ctx context.Context, albumID, filename, tagID string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while untagging media")
+	}
+
+	tx := r.db.WithContext(ctx).
+		Where("album_id = ?", albumID).
+		Where("filename = ?", filename).
+		Where("tag_id = ?", tagID).
+		Delete(&models.MediaTags{})
+	if tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to dissociate tag '%s' from media '%s'", tagID, filename))
+	}
+
+	return nil
+}
+
+func (r *MediaRepo) GetRatings(ctx context.Context, albumID string) (map[string]int, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return map[string]int{}, common.NewPostgresNotAvailableError("pg not available while retrieving ratings")
+	}
+
+	rows := []struct {
+		Filename string `gorm:"column:filename;type:TEXT;"`
+		Rating   int    `gorm:"column:rating;type:INT4;"`
+	}{}
+
+	tx := r.db.WithContext(ctx).Raw(`SELECT filename, count(*) as rating FROM (
+			SELECT filename FROM media_favorites WHERE album_id = ?
+			UNION ALL
+			SELECT filename FROM reaction WHERE album_id = ?
+		) as ratings GROUP BY filename`, albumID, albumID).Scan(&rows)
+	if tx.Error != nil {
+		return map[string]int{}, r.wrapError(tx.Error, fmt.Sprintf("failed to fetch ratings of album '%s'", albumID))
+	}
+
+	ratings := make(map[string]int, len(rows))
+	for _, row := range rows {
+		ratings[row.Filename] = row.Rating
+	}
+
+	return ratings, nil
+}
+
+func (r *MediaRepo) wrapError(err error, msg string) error {
+	if r.checkNetworkError(err) {
+		return common.NewPostgresNotAvailableError(msg)
+	}
+	return common.NewInternalError(err, msg)
+}
+
+func (r *MediaRepo) checkNetworkError(err error) (isOpen bool) {
+	isOpen = r.circuitBreaker.BreakOnNetworkError(err)
+	if isOpen {
+		zap.S().Warn("circuit breaker is now open")
+	}
+	return
+}
@@ -0,0 +1,125 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+
+	pgclient "github.com/tupyy/gophoto/internal/clients/pg"
+	"github.com/tupyy/gophoto/internal/common"
+	"github.com/tupyy/gophoto/internal/repos/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UsageRepo holds the size of the objects of each bucket.
+type UsageRepo struct {
+	db             *gorm.DB
+	client         pgclient.Client
+	circuitBreaker pgclient.CircuitBreaker
+}
+
+func NewPostgresRepo(client pgclient.Client) (*UsageRepo, error) {
+	config := gorm.Config{
+		SkipDefaultTransaction: true, // No need transaction for those use cases.
+	}
+
+	gormDB, err := client.Open(config)
+	if err != nil {
+		return &UsageRepo{}, err
+	}
+
+	return &UsageRepo{gormDB, client, client.GetCircuitBreaker()}, nil
+}
+
+// Add adds delta bytes to the usage of the bucket. The usage never goes below zero.
+func (r *UsageRepo) Add(ctx context.Context, bucket string, delta int64) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while updating usage")
+	}
+
+	u := models.StorageUsage{Bucket: bucket}
+	if delta > 0 {
+		u.Bytes = delta
+	}
+
+	tx := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bucket"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"bytes": gorm.Expr("GREATEST(storage_usage.bytes + ?, 0)", delta)}),
+	}).Create(&u)
+	if tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to update usage of bucket '%s'", bucket))
+	}
+
+	return nil
+}
+
+// Set replaces the usage of the bucket.
+func (r *UsageRepo) Set(ctx context.Context, bucket string, bytes int64) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while updating usage")
+	}
+
+	u := models.StorageUsage{Bucket: bucket, Bytes: bytes}
+
+	tx := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bucket"}},
+		DoUpdates: clause.AssignmentColumns([]string{"bytes"}),
+	}).Create(&u)
+	if tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to set usage of bucket '%s'", bucket))
+	}
+
+	return nil
+}
+
+// Delete removes the usage of the bucket.
+func (r *UsageRepo) Delete(ctx context.Context, bucket string) error {
+	if !r.circuitBreaker.IsAvailable() {
+		return common.NewPostgresNotAvailableError("pg not available while removing usage")
+	}
+
+	if tx := r.db.WithContext(ctx).Where("bucket = ?", bucket).Delete(&models.StorageUsage{}); tx.Error != nil {
+		return r.wrapError(tx.Error, fmt.Sprintf("failed to remove usage of bucket '%s'", bucket))
+	}
+
+	return nil
+}
+
+// Get returns the usage of the buckets mapped by bucket. Buckets without usage are missing.
+func (r *UsageRepo) Get(ctx context.Context, buckets []string) (map[string]int64, error) {
+	if !r.circuitBreaker.IsAvailable() {
+		return map[string]int64{}, common.NewPostgresNotAvailableError("pg not available while retrieving usage")
+	}
+
+	usage := make(map[string]int64, len(buckets))
+	if len(buckets) == 0 {
+		return usage, nil
+	}
+
+	var rows []models.StorageUsage
+	if tx := r.db.WithContext(ctx).Where("bucket IN ?", buckets).Find(&rows); tx.Error != nil {
+		return map[string]int64{}, r.wrapError(tx.Error, "failed to fetch usage")
+	}
+
+	for _, row := range rows {
+		usage[row.Bucket] = row.Bytes
+	}
+
+	return usage, nil
+}
+
+func (r *UsageRepo) wrapError(err error, msg string) error {
+	if r.checkNetworkError(err) {
+		return common.NewPostgresNotAvailableError(msg)
+	}
+	return common.NewInternalError(err, msg)
+}
+
+func (r *UsageRepo) checkNetworkError(err error) (isOpen bool) {
+	isOpen = r.circuitBreaker.BreakOnNetworkError(err)
+	if isOpen {
+		zap.S().Warn("circuit breaker is now open")
+	}
+	return
+}
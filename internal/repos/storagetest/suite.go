@@ -0,0 +1,213 @@
+// Package storagetest holds the conformance tests every storage backend must pass.
+package storagetest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/media"
+)
+
+// Run runs the conformance tests against the storage. The containers created by the tests are named prefix followed
+// by a random name and are deleted at the end. The prefix is either empty or media.AlbumPrefix for the shared layout.
+func Run(t *testing.T, storage media.Storage, prefix string) {
+	ctx := context.Background()
+
+	container := func(t *testing.T) string {
+		suffix := make([]byte, 4)
+		_, err := rand.Read(suffix)
+		require.Nil(t, err)
+
+		// valid for the local filesystem as well as a bucket name
+		name := prefix + "conformance-" + hex.EncodeToString(suffix)
+
+		require.Nil(t, storage.CreateContainer(ctx, name, map[string]string{"album/name": "conformance"}))
+		t.Cleanup(func() { storage.DeleteContainer(ctx, name) })
+
+		return name
+	}
+
+	put := func(t *testing.T, c, name, content string, metadata map[string]string) {
+		require.Nil(t, storage.Put(ctx, c, name, int64(len(content)), strings.NewReader(content), metadata))
+	}
+
+	get := func(t *testing.T, c, name string) (string, map[string]string) {
+		r, metadata, err := storage.Get(ctx, c, name)
+		require.Nil(t, err)
+
+		content, err := io.ReadAll(r)
+		require.Nil(t, err)
+
+		return string(content), metadata
+	}
+
+	t.Run("container", func(t *testing.T) {
+		c := container(t)
+
+		assert.NotNil(t, storage.CreateContainer(ctx, c, map[string]string{}), "creating an existing container must fail")
+
+		labels, err := storage.GetLabels(ctx, c)
+		require.Nil(t, err)
+		assert.Equal(t, "conformance", labels["album/name"])
+
+		labels["album/deleted_at"] = "now"
+		require.Nil(t, storage.SetLabels(ctx, c, labels))
+
+		labels, err = storage.GetLabels(ctx, c)
+		require.Nil(t, err)
+		assert.Equal(t, map[string]string{"album/name": "conformance", "album/deleted_at": "now"}, labels)
+
+		put(t, c, "photos/a.jpg", "a", map[string]string{})
+
+		require.Nil(t, storage.DeleteContainer(ctx, c))
+
+		_, err = storage.List(ctx, c)
+		assert.NotNil(t, err, "listing a deleted container must fail")
+
+		assert.Nil(t, storage.DeleteContainer(ctx, c), "deleting a missing container must not fail")
+	})
+
+	t.Run("put and get", func(t *testing.T) {
+		c := container(t)
+
+		put(t, c, "photos/a.jpg", "content of a", map[string]string{"date": "2022:05:01 10:00:00", "phash": "ff"})
+
+		content, metadata := get(t, c, "photos/a.jpg")
+		assert.Equal(t, "content of a", content)
+		assert.Equal(t, "2022:05:01 10:00:00", metadata["Date"])
+		assert.Equal(t, "ff", metadata["Phash"])
+
+		// objects are replaced
+		put(t, c, "photos/a.jpg", "new content", map[string]string{})
+
+		content, _ = get(t, c, "photos/a.jpg")
+		assert.Equal(t, "new content", content)
+
+		size, err := storage.Size(ctx, c, "photos/a.jpg")
+		require.Nil(t, err)
+		assert.Equal(t, int64(len("new content")), size)
+
+		_, _, err = storage.Get(ctx, c, "photos/missing.jpg")
+		assert.NotNil(t, err, "getting a missing object must fail")
+
+		_, err = storage.Size(ctx, c, "photos/missing.jpg")
+		assert.NotNil(t, err, "getting the size of a missing object must fail")
+
+		err = storage.Put(ctx, prefix+"conformance-missing", "photos/a.jpg", 1, strings.NewReader("a"), map[string]string{})
+		assert.NotNil(t, err, "putting an object to a missing container must fail")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		c := container(t)
+
+		put(t, c, "photos/a.jpg", "content of a", map[string]string{
+			"date":      "2022:05:01 10:00:00",
+			"latitude":  "48.858000",
+			"longitude": "2.294000",
+			"phash":     "ff",
+		})
+		put(t, c, "thumbnail/a.jpg", "thumb", map[string]string{})
+		put(t, c, "photos/b.jpg", "content of b", map[string]string{})
+		put(t, c, media.UploadPrefix+"x/c.jpg", "upload", map[string]string{})
+		put(t, c, media.TrashPrefix+"x/photos/d.jpg", "trashed", map[string]string{})
+		put(t, c, media.TrashPrefix+"x/thumbnail/d.jpg", "thumb", map[string]string{})
+
+		medias, err := storage.List(ctx, c)
+		require.Nil(t, err)
+		require.Equal(t, 2, len(medias))
+
+		byName := make(map[string]entity.Media)
+		for _, m := range medias {
+			byName[m.Filename] = m
+		}
+
+		a := byName["photos/a.jpg"]
+		assert.Equal(t, c, a.Bucket)
+		assert.Equal(t, "thumbnail/a.jpg", a.Thumbnail)
+		assert.Equal(t, entity.Photo, a.MediaType)
+		assert.Equal(t, int64(12), a.Size)
+		assert.Equal(t, time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC), a.CreateDate)
+		require.NotNil(t, a.Location)
+		assert.Equal(t, 48.858, a.Location.Latitude)
+		assert.Equal(t, 2.294, a.Location.Longitude)
+		require.NotNil(t, a.Hash)
+		assert.Equal(t, uint64(0xff), *a.Hash)
+		assert.False(t, a.UploadDate.IsZero())
+
+		b := byName["photos/b.jpg"]
+		assert.Equal(t, "", b.Thumbnail)
+		assert.True(t, b.CreateDate.IsZero())
+		assert.Nil(t, b.Location)
+		assert.Nil(t, b.Hash)
+	})
+
+	t.Run("names", func(t *testing.T) {
+		c := container(t)
+
+		put(t, c, "photos/a.jpg", "content of a", map[string]string{})
+		put(t, c, "thumbnail/a.jpg", "thumb", map[string]string{})
+		put(t, c, "thumbnail/orphan.jpg", "thumb", map[string]string{})
+		put(t, c, media.UploadPrefix+"x/b.jpg", "upload", map[string]string{})
+		put(t, c, media.TrashPrefix+"x/photos/c.jpg", "trashed", map[string]string{})
+
+		names, err := storage.Names(ctx, c)
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []string{"photos/a.jpg", "thumbnail/a.jpg", "thumbnail/orphan.jpg", media.TrashPrefix + "x/photos/c.jpg"}, names)
+
+		containers, err := storage.Containers(ctx)
+		require.Nil(t, err)
+		assert.Contains(t, containers, c)
+
+		require.Nil(t, storage.DeleteContainer(ctx, c))
+
+		containers, err = storage.Containers(ctx)
+		require.Nil(t, err)
+		assert.NotContains(t, containers, c)
+
+		_, err = storage.Names(ctx, c)
+		assert.NotNil(t, err, "listing a deleted container must fail")
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		src, dst := container(t), container(t)
+
+		put(t, src, "photos/a.jpg", "content of a", map[string]string{"date": "2022:05:01 10:00:00"})
+
+		require.Nil(t, storage.Copy(ctx, src, "photos/a.jpg", dst, "photos/a.jpg"))
+		require.Nil(t, storage.Copy(ctx, src, "photos/a.jpg", src, "photos/copy.jpg"))
+
+		content, metadata := get(t, dst, "photos/a.jpg")
+		assert.Equal(t, "content of a", content)
+		assert.Equal(t, "2022:05:01 10:00:00", metadata["Date"])
+
+		content, _ = get(t, src, "photos/copy.jpg")
+		assert.Equal(t, "content of a", content)
+
+		assert.NotNil(t, storage.Copy(ctx, src, "photos/missing.jpg", dst, "photos/missing.jpg"), "copying a missing object must fail")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		c := container(t)
+
+		put(t, c, "photos/a.jpg", "content of a", map[string]string{})
+
+		require.Nil(t, storage.Delete(ctx, c, "photos/a.jpg"))
+
+		_, _, err := storage.Get(ctx, c, "photos/a.jpg")
+		assert.NotNil(t, err)
+
+		medias, err := storage.List(ctx, c)
+		require.Nil(t, err)
+		assert.Equal(t, 0, len(medias))
+
+		assert.Nil(t, storage.Delete(ctx, c, "photos/a.jpg"), "deleting a missing object must not fail")
+	})
+}
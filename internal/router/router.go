@@ -24,6 +24,7 @@ func InitEngine(server *gin.Engine, store sessions.Store, authenticator auth.Aut
 	server.LoadHTMLFiles("static/index.html")
 
 	server.Use(gin.Recovery())
+	server.Use(middlewares...)
 	server.Use(auth.FakeAuthMiddleware())
 
 	// set auth callback
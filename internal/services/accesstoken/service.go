@@ -0,0 +1,169 @@
+package accesstoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/tupyy/gophoto/internal/common"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services"
+	"go.uber.org/zap"
+)
+
+const (
+	// Prefix starts the personal access tokens so they can be told apart from the other bearer tokens and found by
+	// the secret scanners.
+	Prefix = "gpat_"
+
+	// DefaultLifetime is the lifetime of the tokens created without expiration date.
+	DefaultLifetime = 90 * 24 * time.Hour
+	// MaxLifetime is the longest lifetime of a token.
+	MaxLifetime = 365 * 24 * time.Hour
+
+	secretSize    = 32
+	maxNameLength = 100
+	// lastUsedResolution - the last use of a token is saved at most once per resolution
+	lastUsedResolution = time.Minute
+)
+
+// AccessTokenRepository holds the tokens with the hash of their secret.
+type AccessTokenRepository interface {
+	// Create saves the token with the hash of its secret.
+	Create(ctx context.Context, token entity.AccessToken, hash string) error
+	// GetByHash returns the token with the hash.
+	GetByHash(ctx context.Context, hash string) (entity.AccessToken, error)
+	// GetByOwner returns the tokens of the user.
+	GetByOwner(ctx context.Context, owner string) ([]entity.AccessToken, error)
+	// Delete removes a token of the user.
+	Delete(ctx context.Context, owner, id string) error
+	// SetLastUsed saves the last time the token has been accepted.
+	SetLastUsed(ctx context.Context, id string, at time.Time) error
+}
+
+// Service manages the personal access tokens of the users.
+type Service struct {
+	repo AccessTokenRepository
+	now  func() time.Time
+}
+
+func New(repo AccessTokenRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// Create creates a token for the user. It returns the token and its secret, which is not saved and cannot be
+// retrieved later. The token expires after DefaultLifetime if expiresAt is zero.
+func (s *Service) Create(ctx context.Context, owner entity.User, name string, scope entity.TokenScope, expiresAt time.Time) (entity.AccessToken, string, error) {
+	now := s.now()
+
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(DefaultLifetime)
+	}
+
+	name = strings.TrimSpace(name)
+
+	switch {
+	case len(name) == 0 || len(name) > maxNameLength:
+		return entity.AccessToken{}, "", fmt.Errorf("%w: the name must have between 1 and %d characters", services.ErrInvalidAccessToken, maxNameLength)
+	case !expiresAt.After(now):
+		return entity.AccessToken{}, "", fmt.Errorf("%w: the expiration date is in the past", services.ErrInvalidAccessToken)
+	case expiresAt.After(now.Add(MaxLifetime)):
+		return entity.AccessToken{}, "", fmt.Errorf("%w: the token cannot be valid more than %s", services.ErrInvalidAccessToken, MaxLifetime)
+	}
+
+	if _, err := entity.NewTokenScope(scope.String()); err != nil {
+		return entity.AccessToken{}, "", fmt.Errorf("%w: scope '%s'", services.ErrInvalidAccessToken, scope)
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return entity.AccessToken{}, "", err
+	}
+
+	token := entity.AccessToken{
+		ID:        xid.New().String(),
+		Owner:     owner.Username,
+		Name:      name,
+		Scope:     scope,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+
+	if err := s.repo.Create(ctx, token, hash(secret)); err != nil {
+		return entity.AccessToken{}, "", err
+	}
+
+	return token, secret, nil
+}
+
+// List returns the tokens of the user, the expired ones included.
+func (s *Service) List(ctx context.Context, owner string) ([]entity.AccessToken, error) {
+	return s.repo.GetByOwner(ctx, owner)
+}
+
+// Revoke removes a token of the user. The token is rejected from now on.
+func (s *Service) Revoke(ctx context.Context, owner, id string) error {
+	if err := s.repo.Delete(ctx, owner, id); err != nil {
+		var serr common.ServiceError
+		if errors.As(err, &serr) && serr.Cause == common.EntityNotFound {
+			return fmt.Errorf("%w: access token '%s'", services.ErrNotFound, id)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Authenticate returns the token of the secret. It fails with ErrUnknownAccessToken if the token does not exist or is expired.
+func (s *Service) Authenticate(ctx context.Context, secret string) (entity.AccessToken, error) {
+	if !strings.HasPrefix(secret, Prefix) {
+		return entity.AccessToken{}, services.ErrUnknownAccessToken
+	}
+
+	token, err := s.repo.GetByHash(ctx, hash(secret))
+	if err != nil {
+		var serr common.ServiceError
+		if errors.As(err, &serr) && serr.Cause == common.EntityNotFound {
+			return entity.AccessToken{}, services.ErrUnknownAccessToken
+		}
+		return entity.AccessToken{}, err
+	}
+
+	now := s.now()
+
+	if token.Expired(now) {
+		return entity.AccessToken{}, fmt.Errorf("%w: token '%s' expired at %s", services.ErrUnknownAccessToken, token.ID, token.ExpiresAt.Format(time.RFC3339))
+	}
+
+	if now.Sub(token.LastUsedAt) >= lastUsedResolution {
+		if err := s.repo.SetLastUsed(ctx, token.ID, now); err != nil {
+			zap.S().Warnw("failed to save last use of access token", "error", err, "token_id", token.ID)
+		} else {
+			token.LastUsedAt = now
+		}
+	}
+
+	return token, nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return Prefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hash returns the hash of the secret saved instead of the secret. The secrets are random so they do not need a slow hash.
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
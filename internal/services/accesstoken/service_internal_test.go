@@ -0,0 +1,154 @@
+package accesstoken
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/common"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services"
+)
+
+type fakeRepo struct {
+	tokens   map[string]entity.AccessToken
+	lastUsed int
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{tokens: make(map[string]entity.AccessToken)}
+}
+
+func (f *fakeRepo) Create(ctx context.Context, token entity.AccessToken, hash string) error {
+	f.tokens[hash] = token
+	return nil
+}
+
+func (f *fakeRepo) GetByHash(ctx context.Context, hash string) (entity.AccessToken, error) {
+	t, ok := f.tokens[hash]
+	if !ok {
+		return entity.AccessToken{}, common.NewEntityNotFound("access token not found")
+	}
+
+	return t, nil
+}
+
+func (f *fakeRepo) GetByOwner(ctx context.Context, owner string) ([]entity.AccessToken, error) {
+	tokens := []entity.AccessToken{}
+	for _, t := range f.tokens {
+		if t.Owner == owner {
+			tokens = append(tokens, t)
+		}
+	}
+
+	return tokens, nil
+}
+
+func (f *fakeRepo) Delete(ctx context.Context, owner, id string) error {
+	for h, t := range f.tokens {
+		if t.ID == id && t.Owner == owner {
+			delete(f.tokens, h)
+			return nil
+		}
+	}
+
+	return common.NewEntityNotFound("access token not found")
+}
+
+func (f *fakeRepo) SetLastUsed(ctx context.Context, id string, at time.Time) error {
+	for h, t := range f.tokens {
+		if t.ID == id {
+			t.LastUsedAt = at
+			f.tokens[h] = t
+			f.lastUsed++
+		}
+	}
+
+	return nil
+}
+
+func TestCreate(t *testing.T) {
+	now := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	s := New(newFakeRepo())
+	s.now = func() time.Time { return now }
+
+	alice := entity.User{Username: "alice"}
+
+	token, secret, err := s.Create(context.Background(), alice, " frame ", entity.ScopeReadOnly, time.Time{})
+	require.Nil(t, err)
+	assert.True(t, strings.HasPrefix(secret, Prefix))
+	assert.Equal(t, "frame", token.Name)
+	assert.Equal(t, "alice", token.Owner)
+	assert.Equal(t, now.Add(DefaultLifetime), token.ExpiresAt)
+
+	_, other, err := s.Create(context.Background(), alice, "frame", entity.ScopeReadOnly, time.Time{})
+	require.Nil(t, err)
+	assert.NotEqual(t, secret, other)
+
+	invalid := []struct {
+		name      string
+		scope     entity.TokenScope
+		expiresAt time.Time
+	}{
+		{"", entity.ScopeReadOnly, time.Time{}},
+		{strings.Repeat("a", maxNameLength+1), entity.ScopeReadOnly, time.Time{}},
+		{"frame", entity.TokenScope("root"), time.Time{}},
+		{"frame", entity.ScopeUpload, now.Add(-time.Hour)},
+		{"frame", entity.ScopeUpload, now.Add(MaxLifetime + time.Hour)},
+	}
+
+	for _, i := range invalid {
+		_, _, err := s.Create(context.Background(), alice, i.name, i.scope, i.expiresAt)
+		assert.True(t, errors.Is(err, services.ErrInvalidAccessToken), "%+v", i)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	now := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	repo := newFakeRepo()
+	s := New(repo)
+	s.now = func() time.Time { return now }
+
+	token, secret, err := s.Create(context.Background(), entity.User{Username: "alice"}, "frame", entity.ScopeUpload, now.Add(time.Hour))
+	require.Nil(t, err)
+
+	found, err := s.Authenticate(context.Background(), secret)
+	require.Nil(t, err)
+	assert.Equal(t, token.ID, found.ID)
+	assert.Equal(t, now, found.LastUsedAt)
+
+	// the last use is saved once per minute
+	now = now.Add(30 * time.Second)
+	_, err = s.Authenticate(context.Background(), secret)
+	require.Nil(t, err)
+	assert.Equal(t, 1, repo.lastUsed)
+
+	now = now.Add(time.Minute)
+	_, err = s.Authenticate(context.Background(), secret)
+	require.Nil(t, err)
+	assert.Equal(t, 2, repo.lastUsed)
+
+	for _, unknown := range []string{"", "bearer", Prefix + "unknown", strings.TrimPrefix(secret, Prefix)} {
+		_, err = s.Authenticate(context.Background(), unknown)
+		assert.True(t, errors.Is(err, services.ErrUnknownAccessToken), unknown)
+	}
+
+	now = token.ExpiresAt
+	_, err = s.Authenticate(context.Background(), secret)
+	assert.True(t, errors.Is(err, services.ErrUnknownAccessToken), "expired tokens must be rejected")
+
+	now = token.CreatedAt
+	require.Nil(t, s.Revoke(context.Background(), "alice", token.ID))
+
+	_, err = s.Authenticate(context.Background(), secret)
+	assert.True(t, errors.Is(err, services.ErrUnknownAccessToken), "revoked tokens must be rejected")
+
+	err = s.Revoke(context.Background(), "alice", token.ID)
+	assert.True(t, errors.Is(err, services.ErrNotFound))
+}
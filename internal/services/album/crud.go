@@ -2,11 +2,13 @@ package album
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/rs/xid"
 	"github.com/tupyy/gophoto/internal/entity"
 	"github.com/tupyy/gophoto/internal/services"
 	"github.com/tupyy/gophoto/internal/services/media"
@@ -38,15 +40,23 @@ type AlbumRepository interface {
 	GetByGroups(ctx context.Context, groups []string) ([]entity.Album, error)
 }
 
+// EventPublisher publishes album events.
+type EventPublisher interface {
+	Publish(ctx context.Context, event entity.Event)
+}
+
 const forbittenChar = "_$"
 
 type Service struct {
 	albumRepo    AlbumRepository
 	mediaService *media.Service
+	publisher    EventPublisher
+	// layout - layout of the new albums
+	layout media.Layout
 }
 
-func New(albumRepo AlbumRepository, media *media.Service) *Service {
-	return &Service{albumRepo, media}
+func New(albumRepo AlbumRepository, mediaService *media.Service, publisher EventPublisher, layout media.Layout) *Service {
+	return &Service{albumRepo, mediaService, publisher, layout}
 }
 
 func (s *Service) Create(ctx context.Context, newAlbum entity.Album) (entity.Album, error) {
@@ -60,13 +70,27 @@ func (s *Service) Create(ctx context.Context, newAlbum entity.Album) (entity.Alb
 
 	newAlbum.Bucket = fmt.Sprintf("%s-%s", n, bucketID[:8])
 
+	// the id is known before the album is saved so it can name the album's container
+	if s.layout == media.SharedLayout {
+		newAlbum.ID = xid.New().String()
+		newAlbum.Bucket = media.AlbumContainer(newAlbum.ID)
+	}
+
 	tags := map[string]string{
 		"album/name":     newAlbum.Name,
 		"album/date":     newAlbum.CreatedAt.Format(time.RFC3339),
 		"owner/username": newAlbum.Owner,
 	}
 	// create the bucket
-	if err := s.mediaService.CreateBucket(ctx, newAlbum.Bucket, tags); err != nil {
+	createBucket := s.mediaService.CreateBucket
+	if newAlbum.Encrypted {
+		createBucket = s.mediaService.CreateEncryptedBucket
+	}
+
+	if err := createBucket(ctx, newAlbum.Bucket, tags); err != nil {
+		if errors.Is(err, services.ErrEncryptionDisabled) {
+			return entity.Album{}, fmt.Errorf("%w: album '%s'", err, newAlbum.Name)
+		}
 		return entity.Album{}, fmt.Errorf("%w '%s': %v", services.ErrCreateBucket, newAlbum.Name, err)
 	}
 
@@ -75,6 +99,8 @@ func (s *Service) Create(ctx context.Context, newAlbum entity.Album) (entity.Alb
 		return entity.Album{}, fmt.Errorf("%w '%s': %v", services.ErrCreateAlbum, newAlbum.Name, err)
 	}
 
+	s.publish(ctx, entity.EventAlbumCreated, album)
+
 	return album, nil
 }
 
@@ -84,6 +110,8 @@ func (s *Service) Update(ctx context.Context, album entity.Album) (entity.Album,
 		return album, fmt.Errorf("%w '%s': %v", services.ErrUpdateAlbum, album.ID, err)
 	}
 
+	s.publish(ctx, entity.EventAlbumUpdated, album)
+
 	return album, nil
 }
 
@@ -98,6 +126,8 @@ func (s *Service) Delete(ctx context.Context, album entity.Album) error {
 		return fmt.Errorf("%w '%s': %v", services.ErrDeleteAlbum, album.ID, err)
 	}
 
+	s.publish(ctx, entity.EventAlbumDeleted, album)
+
 	return nil
 }
 
@@ -110,5 +140,21 @@ func (s *Service) SetPermissions(ctx context.Context, album entity.Album, permis
 	if err := s.albumRepo.SetPermissions(ctx, album.ID, permissions); err != nil {
 		return err
 	}
+
+	s.publish(ctx, entity.EventAlbumUpdated, album)
+
 	return nil
 }
+
+func (s *Service) publish(ctx context.Context, kind entity.EventKind, album entity.Album) {
+	if s.publisher == nil {
+		return
+	}
+
+	s.publisher.Publish(ctx, entity.Event{
+		Kind:      kind,
+		AlbumID:   album.ID,
+		Bucket:    album.Bucket,
+		CreatedAt: time.Now(),
+	})
+}
@@ -0,0 +1,59 @@
+package album
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services"
+)
+
+// Locate finds the place of the album's photos and sets the place of the album to the place where most of the photos have been taken.
+// Encrypted albums are not located: their place would be stored in the clear. The album's photos must be loaded.
+func (s *Service) Locate(ctx context.Context, album entity.Album) (entity.Album, error) {
+	if album.Encrypted {
+		return album, nil
+	}
+
+	photos, err := s.mediaService.WithMetadata(ctx, album.ID, album.Owner, album.Photos)
+	if err != nil {
+		return album, fmt.Errorf("%w '%s': %v", services.ErrUpdateAlbum, album.ID, err)
+	}
+
+	photos, err = s.mediaService.Geocode(ctx, album, photos)
+	if err != nil {
+		return album, fmt.Errorf("%w '%s': %v", services.ErrUpdateAlbum, album.ID, err)
+	}
+
+	place := MainPlace(photos)
+	if place == album.Place {
+		return album, nil
+	}
+
+	album.Place = place
+
+	return s.Update(ctx, album)
+}
+
+// MainPlace returns the place of most of the media. Ties are broken by name so the result does not depend on the order of the media.
+func MainPlace(medias []entity.Media) entity.Place {
+	counts := make(map[entity.Place]int)
+	for _, m := range medias {
+		if !m.Place.IsZero() {
+			counts[m.Place]++
+		}
+	}
+
+	var (
+		main  entity.Place
+		count int
+	)
+
+	for place, c := range counts {
+		if c > count || (c == count && place.String() < main.String()) {
+			main, count = place, c
+		}
+	}
+
+	return main
+}
@@ -0,0 +1,55 @@
+package album
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services"
+	"github.com/tupyy/gophoto/internal/services/media"
+)
+
+// SetThumbnail sets the photo as cover of the album.
+func (s *Service) SetThumbnail(ctx context.Context, album entity.Album, photo entity.Media) (entity.Album, error) {
+	album.Thumbnail = media.CoverName(photo)
+
+	return s.Update(ctx, album)
+}
+
+// BestThumbnail returns the best rated photo of the album without changing the cover. It returns false if the album has no photo.
+// The album's photos must be loaded.
+func (s *Service) BestThumbnail(ctx context.Context, album entity.Album) (entity.Media, bool, error) {
+	ratings, err := s.mediaService.Ratings(ctx, album.ID)
+	if err != nil {
+		return entity.Media{}, false, err
+	}
+
+	photo, found := media.PickCover(album.Photos, ratings)
+
+	return photo, found, nil
+}
+
+// PickThumbnail sets the best rated photo of the album as cover.
+// The album's photos must be loaded. If the album has no photo, the cover is removed.
+func (s *Service) PickThumbnail(ctx context.Context, album entity.Album) (entity.Album, error) {
+	photo, found, err := s.BestThumbnail(ctx, album)
+	if err != nil {
+		return album, fmt.Errorf("%w '%s': %v", services.ErrUpdateAlbum, album.ID, err)
+	}
+
+	if !found {
+		if len(album.Thumbnail) == 0 {
+			return album, nil
+		}
+
+		album.Thumbnail = ""
+
+		return s.Update(ctx, album)
+	}
+
+	if album.Thumbnail == media.CoverName(photo) {
+		return album, nil
+	}
+
+	return s.SetThumbnail(ctx, album, photo)
+}
@@ -0,0 +1,163 @@
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+// ManifestName - name of the manifest inside the archive.
+const ManifestName = "manifest.json"
+
+// Opener opens the content of a media of the bucket.
+type Opener func(ctx context.Context, bucket, filename string) (io.Reader, error)
+
+// Manifest describes the album and the photos of the archive.
+type Manifest struct {
+	Album  AlbumManifest   `json:"album"`
+	Photos []PhotoManifest `json:"photos"`
+}
+
+type AlbumManifest struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Location    string    `json:"location,omitempty"`
+	Owner       string    `json:"owner"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PhotoManifest struct {
+	// Filename - name of the photo inside the archive
+	Filename    string            `json:"filename"`
+	Caption     string            `json:"caption,omitempty"`
+	Description string            `json:"description,omitempty"`
+	CaptureDate *time.Time        `json:"capture_date,omitempty"`
+	UploadDate  time.Time         `json:"upload_date"`
+	Size        int64             `json:"size"`
+	Tags        []string          `json:"tags,omitempty"`
+	Location    *LocationManifest `json:"location,omitempty"`
+	Place       string            `json:"place,omitempty"`
+}
+
+type LocationManifest struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// NewManifest describes the album and the photos. The photos must have been filled with their metadata.
+func NewManifest(album entity.Album, photos []entity.Media) Manifest {
+	m := Manifest{
+		Album: AlbumManifest{
+			Name:        album.Name,
+			Description: album.Description,
+			Location:    album.Location,
+			Owner:       album.Owner,
+			CreatedAt:   album.CreatedAt,
+		},
+		Photos: make([]PhotoManifest, 0, len(photos)),
+	}
+
+	for _, p := range photos {
+		pm := PhotoManifest{
+			Filename:    EntryName(p),
+			Caption:     p.Caption,
+			Description: p.Description,
+			UploadDate:  p.UploadDate,
+			Size:        p.Size,
+			Place:       p.Place.String(),
+		}
+
+		if p.Location != nil {
+			pm.Location = &LocationManifest{Latitude: p.Location.Latitude, Longitude: p.Location.Longitude}
+		}
+
+		if !p.CreateDate.IsZero() {
+			createDate := p.CreateDate
+			pm.CaptureDate = &createDate
+		}
+
+		for _, t := range p.Tags {
+			pm.Tags = append(pm.Tags, t.Name)
+		}
+
+		m.Photos = append(m.Photos, pm)
+	}
+
+	return m
+}
+
+// EntryName returns the name of the photo inside the archive.
+func EntryName(m entity.Media) string {
+	return path.Base(m.Filename)
+}
+
+// Write writes a zip archive of the photos to w. Each photo is copied from the store to w as it is read so the archive
+// is never held in memory. Photos are stored without compression since jpg files do not compress.
+// The manifest is added at the end of the archive if not nil.
+func Write(ctx context.Context, w io.Writer, photos []entity.Media, open Opener, manifest *Manifest) error {
+	zw := zip.NewWriter(w)
+
+	for _, p := range photos {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := writePhoto(ctx, zw, p, open); err != nil {
+			return err
+		}
+	}
+
+	if manifest != nil {
+		entry, err := zw.Create(ManifestName)
+		if err != nil {
+			return fmt.Errorf("failed to add manifest: %v", err)
+		}
+
+		encoder := json.NewEncoder(entry)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(manifest); err != nil {
+			return fmt.Errorf("failed to write manifest: %v", err)
+		}
+	}
+
+	return zw.Close()
+}
+
+func writePhoto(ctx context.Context, zw *zip.Writer, p entity.Media, open Opener) error {
+	r, err := open(ctx, p.Bucket, p.Filename)
+	if err != nil {
+		return fmt.Errorf("failed to open photo '%s': %v", p.Filename, err)
+	}
+
+	if closer, ok := r.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	header := &zip.FileHeader{
+		Name:   EntryName(p),
+		Method: zip.Store,
+	}
+
+	modified := p.CreateDate
+	if modified.IsZero() {
+		modified = p.UploadDate
+	}
+	header.Modified = modified
+
+	entry, err := zw.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("failed to add photo '%s': %v", p.Filename, err)
+	}
+
+	if _, err := io.Copy(entry, r); err != nil {
+		return fmt.Errorf("failed to write photo '%s': %v", p.Filename, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+func TestWrite(t *testing.T) {
+	content := map[string]string{
+		"photos/a.jpg": "content of a",
+		"photos/b.jpg": "content of b",
+	}
+
+	open := func(ctx context.Context, bucket, filename string) (io.Reader, error) {
+		c, found := content[filename]
+		if !found {
+			return nil, errors.New("not found")
+		}
+		return strings.NewReader(c), nil
+	}
+
+	photos := []entity.Media{
+		{Bucket: "bucket", Filename: "photos/a.jpg", Caption: "first", CreateDate: time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)},
+		{Bucket: "bucket", Filename: "photos/b.jpg", Tags: []entity.Tag{{Name: "holidays"}}},
+	}
+
+	manifest := NewManifest(entity.Album{Name: "album", Owner: "bob"}, photos)
+
+	var buf bytes.Buffer
+	assert.Nil(t, Write(context.Background(), &buf, photos, open, &manifest))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(zr.File))
+
+	assert.Equal(t, "a.jpg", zr.File[0].Name)
+	assert.Equal(t, zip.Store, zr.File[0].Method)
+	assert.Equal(t, "content of a", read(t, zr.File[0]))
+	assert.Equal(t, "b.jpg", zr.File[1].Name)
+	assert.Equal(t, "content of b", read(t, zr.File[1]))
+
+	assert.Equal(t, ManifestName, zr.File[2].Name)
+
+	var m Manifest
+	assert.Nil(t, json.Unmarshal([]byte(read(t, zr.File[2])), &m))
+	assert.Equal(t, "album", m.Album.Name)
+	assert.Equal(t, 2, len(m.Photos))
+	assert.Equal(t, "first", m.Photos[0].Caption)
+	assert.NotNil(t, m.Photos[0].CaptureDate)
+	assert.Nil(t, m.Photos[1].CaptureDate)
+	assert.Equal(t, []string{"holidays"}, m.Photos[1].Tags)
+}
+
+func TestWriteWithoutManifest(t *testing.T) {
+	open := func(ctx context.Context, bucket, filename string) (io.Reader, error) {
+		return strings.NewReader("content"), nil
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, Write(context.Background(), &buf, []entity.Media{{Filename: "photos/a.jpg"}}, open, nil))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(zr.File))
+}
+
+func read(t *testing.T, f *zip.File) string {
+	r, err := f.Open()
+	assert.Nil(t, err)
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	assert.Nil(t, err)
+
+	return string(content)
+}
@@ -0,0 +1,341 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+// FormatVersion is the version of the archive layout. It is increased each time the layout changes in a way
+// older versions of restore cannot read.
+const FormatVersion = 1
+
+const (
+	manifestName  = "manifest.json"
+	objectsFolder = "objects"
+)
+
+var (
+	// ErrNoManifest is returned when the archive has no manifest.
+	ErrNoManifest = errors.New("archive has no manifest")
+	// ErrChecksum is returned when the content of an object does not match its checksum.
+	ErrChecksum = errors.New("checksum mismatch")
+	// ErrEncryptedAlbums is returned when encrypted albums would be exported without being asked to.
+	ErrEncryptedAlbums = errors.New("encrypted albums are written decrypted to the archive")
+)
+
+// Manifest describes the content of an archive.
+type Manifest struct {
+	Version   int           `json:"version"`
+	CreatedAt time.Time     `json:"created_at"`
+	Albums    []AlbumRecord `json:"albums"`
+	Tags      []TagRecord   `json:"tags"`
+}
+
+// AlbumRecord holds the rows of an album. The media of an encrypted album are decrypted in the archive and encrypted again by restore.
+type AlbumRecord struct {
+	ID               string             `json:"id"`
+	Name             string             `json:"name"`
+	CreatedAt        time.Time          `json:"created_at"`
+	Owner            string             `json:"owner"`
+	Description      string             `json:"description,omitempty"`
+	Location         string             `json:"location,omitempty"`
+	Thumbnail        string             `json:"thumbnail,omitempty"`
+	Encrypted        bool               `json:"encrypted,omitempty"`
+	UserPermissions  []PermissionRecord `json:"user_permissions"`
+	GroupPermissions []PermissionRecord `json:"group_permissions"`
+	// Tags - ids of the tags associated with the album
+	Tags  []string      `json:"tags"`
+	Media []MediaRecord `json:"media"`
+}
+
+// PermissionRecord holds the permissions of an user or a group.
+type PermissionRecord struct {
+	Owner       string   `json:"owner"`
+	Permissions []string `json:"permissions"`
+}
+
+// MediaRecord holds the metadata of a media and the objects stored for it.
+type MediaRecord struct {
+	Filename    string `json:"filename"`
+	Caption     string `json:"caption,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Tags - ids of the tags associated with the media
+	Tags    []string       `json:"tags"`
+	Objects []ObjectRecord `json:"objects"`
+}
+
+// ObjectRecord describes an object of the store saved in the archive.
+type ObjectRecord struct {
+	Name     string            `json:"name"`
+	Size     int64             `json:"size"`
+	SHA256   string            `json:"sha256"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// TagRecord holds a tag.
+type TagRecord struct {
+	ID    string  `json:"id"`
+	Owner string  `json:"owner"`
+	Name  string  `json:"name"`
+	Color *string `json:"color,omitempty"`
+}
+
+// Objects returns the objects of all the albums mapped by the name of their archive entry.
+func (m Manifest) Objects() map[string]ObjectRecord {
+	objects := make(map[string]ObjectRecord)
+	for _, album := range m.Albums {
+		for _, media := range album.Media {
+			for _, o := range media.Objects {
+				objects[EntryName(album.ID, o.Name)] = o
+			}
+		}
+	}
+
+	return objects
+}
+
+// NewAlbumRecord returns the record of the album without its media.
+func NewAlbumRecord(album entity.Album, tags []entity.Tag) AlbumRecord {
+	record := AlbumRecord{
+		ID:               album.ID,
+		Name:             album.Name,
+		CreatedAt:        album.CreatedAt,
+		Owner:            album.Owner,
+		Description:      album.Description,
+		Location:         album.Location,
+		Thumbnail:        album.Thumbnail,
+		Encrypted:        album.Encrypted,
+		UserPermissions:  permissionRecords(album.UserPermissions),
+		GroupPermissions: permissionRecords(album.GroupPermissions),
+		Tags:             make([]string, 0, len(tags)),
+		Media:            []MediaRecord{},
+	}
+
+	for _, t := range tags {
+		record.Tags = append(record.Tags, t.ID)
+	}
+
+	return record
+}
+
+// NewTagRecord returns the record of the tag.
+func NewTagRecord(tag entity.Tag) TagRecord {
+	return TagRecord{
+		ID:    tag.ID,
+		Owner: tag.UserID,
+		Name:  tag.Name,
+		Color: tag.Color,
+	}
+}
+
+// ToEntity returns the permissions of the record. The owner is renamed with owner.
+func (r PermissionRecord) ToEntity(kind string, owner func(string) string) (entity.AlbumPermission, error) {
+	permission := entity.AlbumPermission{
+		OwnerID:     owner(r.Owner),
+		OwnerKind:   kind,
+		Permissions: make([]entity.Permission, 0, len(r.Permissions)),
+	}
+
+	for _, p := range r.Permissions {
+		perm, err := entity.NewPermission(p)
+		if err != nil {
+			return entity.AlbumPermission{}, fmt.Errorf("%w '%s'", err, p)
+		}
+
+		permission.Permissions = append(permission.Permissions, perm)
+	}
+
+	return permission, nil
+}
+
+func permissionRecords(permissions []entity.AlbumPermission) []PermissionRecord {
+	records := make([]PermissionRecord, 0, len(permissions))
+	for _, p := range permissions {
+		record := PermissionRecord{Owner: p.OwnerID, Permissions: make([]string, 0, len(p.Permissions))}
+		for _, perm := range p.Permissions {
+			record.Permissions = append(record.Permissions, perm.String())
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// Writer writes an archive. The objects are written first and the manifest is written by Close,
+// so an archive can be written in one pass.
+type Writer struct {
+	tw *tar.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{tar.NewWriter(w)}
+}
+
+// WriteObject writes the object of the album read from r. The returned record holds the checksum of the object.
+func (w *Writer) WriteObject(albumID, name string, size int64, metadata map[string]string, r io.Reader) (ObjectRecord, error) {
+	err := w.tw.WriteHeader(&tar.Header{
+		Name:     EntryName(albumID, name),
+		Mode:     0644,
+		Size:     size,
+		ModTime:  time.Now(),
+		Typeflag: tar.TypeReg,
+	})
+	if err != nil {
+		return ObjectRecord{}, err
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(w.tw, io.TeeReader(r, h)); err != nil {
+		return ObjectRecord{}, fmt.Errorf("failed to write object '%s': %w", name, err)
+	}
+
+	return ObjectRecord{
+		Name:     name,
+		Size:     size,
+		SHA256:   hex.EncodeToString(h.Sum(nil)),
+		Metadata: metadata,
+	}, nil
+}
+
+// Close writes the manifest and closes the archive. The version of the manifest is set to FormatVersion.
+func (w *Writer) Close(manifest Manifest) error {
+	manifest.Version = FormatVersion
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = w.tw.WriteHeader(&tar.Header{
+		Name:     manifestName,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  manifest.CreatedAt,
+		Typeflag: tar.TypeReg,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.tw.Write(data); err != nil {
+		return err
+	}
+
+	return w.tw.Close()
+}
+
+// ReadManifest reads the manifest of the archive.
+func ReadManifest(r io.Reader) (Manifest, error) {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return Manifest{}, ErrNoManifest
+		}
+
+		if err != nil {
+			return Manifest{}, err
+		}
+
+		if header.Name != manifestName {
+			continue
+		}
+
+		var manifest Manifest
+		if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+			return Manifest{}, fmt.Errorf("failed to read manifest: %w", err)
+		}
+
+		if manifest.Version < 1 || manifest.Version > FormatVersion {
+			return Manifest{}, fmt.Errorf("archive version %d is not supported", manifest.Version)
+		}
+
+		return manifest, nil
+	}
+}
+
+// ReadObjects calls fn for each object of the archive found in objects. The content of an object is checked against
+// its checksum before fn is called. Objects of the archive which are not in objects are skipped and
+// an error is returned if one of the objects is missing from the archive.
+func ReadObjects(r io.Reader, objects map[string]ObjectRecord, fn func(albumID string, object ObjectRecord, content []byte) error) error {
+	seen := make(map[string]struct{})
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		object, found := objects[header.Name]
+		if !found {
+			continue
+		}
+
+		albumID, _, ok := splitEntryName(header.Name)
+		if !ok {
+			continue
+		}
+
+		var buf bytes.Buffer
+		h := sha256.New()
+		if _, err := io.Copy(io.MultiWriter(&buf, h), tr); err != nil {
+			return fmt.Errorf("failed to read object '%s': %w", header.Name, err)
+		}
+
+		if int64(buf.Len()) != object.Size || hex.EncodeToString(h.Sum(nil)) != object.SHA256 {
+			return fmt.Errorf("%w for object '%s'", ErrChecksum, header.Name)
+		}
+
+		if err := fn(albumID, object, buf.Bytes()); err != nil {
+			return err
+		}
+
+		seen[header.Name] = struct{}{}
+	}
+
+	missing := make([]string, 0)
+	for name := range objects {
+		if _, found := seen[name]; !found {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("%d objects are missing from the archive: %s", len(missing), strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// EntryName returns the name of the archive entry holding the object of the album.
+func EntryName(albumID, name string) string {
+	return path.Join(objectsFolder, albumID, name)
+}
+
+func splitEntryName(entry string) (albumID, name string, ok bool) {
+	parts := strings.SplitN(entry, "/", 3)
+	if len(parts) != 3 || parts[0] != objectsFolder {
+		return "", "", false
+	}
+
+	return parts[1], parts[2], true
+}
@@ -0,0 +1,124 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+func TestArchive(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := NewWriter(&buf)
+
+	photo, err := w.WriteObject("album1", "photos/a.jpg", 12, map[string]string{"Date": "2022:05:01 10:00:00"}, strings.NewReader("content of a"))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), photo.Size)
+	assert.Equal(t, 64, len(photo.SHA256))
+
+	thumbnail, err := w.WriteObject("album1", "thumbnail/a.jpg", 5, nil, strings.NewReader("thumb"))
+	assert.Nil(t, err)
+
+	album := NewAlbumRecord(entity.Album{
+		ID:    "album1",
+		Name:  "holidays",
+		Owner: "bob",
+		UserPermissions: []entity.AlbumPermission{
+			{OwnerID: "alice", OwnerKind: "user", Permissions: []entity.Permission{entity.PermissionReadAlbum, entity.PermissionWriteAlbum}},
+		},
+	}, []entity.Tag{{ID: "tag1"}})
+	album.Media = append(album.Media, MediaRecord{Filename: "photos/a.jpg", Caption: "beach", Objects: []ObjectRecord{photo, thumbnail}})
+
+	assert.Nil(t, w.Close(Manifest{CreatedAt: time.Now(), Albums: []AlbumRecord{album}}))
+
+	manifest, err := ReadManifest(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, FormatVersion, manifest.Version)
+	assert.Equal(t, 1, len(manifest.Albums))
+	assert.Equal(t, []string{"tag1"}, manifest.Albums[0].Tags)
+	assert.Equal(t, "beach", manifest.Albums[0].Media[0].Caption)
+
+	permission, err := manifest.Albums[0].UserPermissions[0].ToEntity("user", func(o string) string { return "new-" + o })
+	assert.Nil(t, err)
+	assert.Equal(t, "new-alice", permission.OwnerID)
+	assert.Equal(t, []entity.Permission{entity.PermissionReadAlbum, entity.PermissionWriteAlbum}, permission.Permissions)
+
+	objects := manifest.Objects()
+	assert.Equal(t, 2, len(objects))
+
+	content := make(map[string]string)
+	err = ReadObjects(bytes.NewReader(buf.Bytes()), objects, func(albumID string, object ObjectRecord, c []byte) error {
+		assert.Equal(t, "album1", albumID)
+		content[object.Name] = string(c)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"photos/a.jpg": "content of a", "thumbnail/a.jpg": "thumb"}, content)
+	assert.Equal(t, "2022:05:01 10:00:00", objects[EntryName("album1", "photos/a.jpg")].Metadata["Date"])
+}
+
+func TestReadObjectsChecksum(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := NewWriter(&buf)
+	object, err := w.WriteObject("album1", "photos/a.jpg", 12, nil, strings.NewReader("content of a"))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close(Manifest{}))
+
+	object.SHA256 = strings.Repeat("0", 64)
+	objects := map[string]ObjectRecord{EntryName("album1", "photos/a.jpg"): object}
+
+	called := false
+	err = ReadObjects(bytes.NewReader(buf.Bytes()), objects, func(albumID string, object ObjectRecord, c []byte) error {
+		called = true
+		return nil
+	})
+	assert.True(t, errors.Is(err, ErrChecksum))
+	assert.False(t, called)
+}
+
+func TestReadObjectsMissing(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := NewWriter(&buf)
+	assert.Nil(t, w.Close(Manifest{}))
+
+	objects := map[string]ObjectRecord{EntryName("album1", "photos/a.jpg"): {Name: "photos/a.jpg"}}
+
+	err := ReadObjects(bytes.NewReader(buf.Bytes()), objects, func(albumID string, object ObjectRecord, c []byte) error {
+		return nil
+	})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "objects/album1/photos/a.jpg")
+}
+
+func TestReadManifest(t *testing.T) {
+	var buf bytes.Buffer
+
+	tw := tar.NewWriter(&buf)
+	assert.Nil(t, tw.Close())
+
+	_, err := ReadManifest(bytes.NewReader(buf.Bytes()))
+	assert.True(t, errors.Is(err, ErrNoManifest))
+
+	// archives written by a newer version are rejected
+	data, _ := json.Marshal(Manifest{Version: FormatVersion + 1})
+
+	buf.Reset()
+	tw = tar.NewWriter(&buf)
+	assert.Nil(t, tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}))
+	_, err = tw.Write(data)
+	assert.Nil(t, err)
+	assert.Nil(t, tw.Close())
+
+	_, err = ReadManifest(bytes.NewReader(buf.Bytes()))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "not supported")
+}
@@ -0,0 +1,324 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/album"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"github.com/tupyy/gophoto/internal/services/tag"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	albumService *album.Service
+	mediaService *media.Service
+	tagService   *tag.Service
+}
+
+func New(albumService *album.Service, mediaService *media.Service, tagService *tag.Service) *Service {
+	return &Service{albumService, mediaService, tagService}
+}
+
+// Export writes an archive of all the albums to w. Favorites, comments and reactions are not exported.
+// The media of the encrypted albums are written decrypted to the archive so they are exported only if decrypt is true.
+// Otherwise ErrEncryptedAlbums is returned before anything is written.
+func (s *Service) Export(ctx context.Context, w io.Writer, decrypt bool) (Manifest, error) {
+	admin := entity.User{Role: entity.RoleAdmin}
+
+	albums, _, err := s.albumService.Query().SharedAlbums(true).All(ctx, admin)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to get albums: %w", err)
+	}
+
+	if !decrypt {
+		encrypted := []string{}
+		for _, a := range albums {
+			if a.Encrypted {
+				encrypted = append(encrypted, a.Name)
+			}
+		}
+
+		if len(encrypted) > 0 {
+			sort.Strings(encrypted)
+			return Manifest{}, fmt.Errorf("%w: %s", ErrEncryptedAlbums, strings.Join(encrypted, ", "))
+		}
+	}
+
+	sort.Slice(albums, func(i, j int) bool { return albums[i].CreatedAt.Before(albums[j].CreatedAt) })
+
+	manifest := Manifest{
+		CreatedAt: time.Now().UTC(),
+		Albums:    make([]AlbumRecord, 0, len(albums)),
+		Tags:      []TagRecord{},
+	}
+
+	tags := make(map[string]entity.Tag)
+
+	aw := NewWriter(w)
+	for _, a := range albums {
+		record, err := s.exportAlbum(ctx, aw, a, tags)
+		if err != nil {
+			return Manifest{}, err
+		}
+
+		manifest.Albums = append(manifest.Albums, record)
+	}
+
+	for _, t := range tags {
+		manifest.Tags = append(manifest.Tags, NewTagRecord(t))
+	}
+
+	sort.Slice(manifest.Tags, func(i, j int) bool { return manifest.Tags[i].ID < manifest.Tags[j].ID })
+
+	if err := aw.Close(manifest); err != nil {
+		return Manifest{}, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	manifest.Version = FormatVersion
+
+	return manifest, nil
+}
+
+// exportAlbum writes the objects of the album and returns its record. The tags found are added to tags.
+func (s *Service) exportAlbum(ctx context.Context, w *Writer, a entity.Album, tags map[string]entity.Tag) (AlbumRecord, error) {
+	albumTags, err := s.tagService.GetByAlbum(ctx, a.ID)
+	if err != nil {
+		return AlbumRecord{}, fmt.Errorf("failed to get tags of album '%s': %w", a.ID, err)
+	}
+
+	for _, t := range albumTags {
+		tags[t.ID] = t
+	}
+
+	medias, err := s.mediaService.ListBucket(ctx, a.Bucket)
+	if err != nil {
+		return AlbumRecord{}, err
+	}
+
+	medias, err = s.mediaService.WithMetadata(ctx, a.ID, "", medias)
+	if err != nil {
+		return AlbumRecord{}, fmt.Errorf("failed to get media metadata of album '%s': %w", a.ID, err)
+	}
+
+	record := NewAlbumRecord(a, albumTags)
+	for _, m := range medias {
+		mr := MediaRecord{
+			Filename:    m.Filename,
+			Caption:     m.Caption,
+			Description: m.Description,
+			Tags:        make([]string, 0, len(m.Tags)),
+			Objects:     []ObjectRecord{},
+		}
+
+		for _, t := range m.Tags {
+			tags[t.ID] = t
+			mr.Tags = append(mr.Tags, t.ID)
+		}
+
+		names := []string{m.Filename}
+		if len(m.Thumbnail) > 0 {
+			names = append(names, m.Thumbnail)
+		}
+
+		for _, name := range names {
+			object, err := s.exportObject(ctx, w, a, name)
+			if err != nil {
+				return AlbumRecord{}, err
+			}
+
+			mr.Objects = append(mr.Objects, object)
+		}
+
+		record.Media = append(record.Media, mr)
+	}
+
+	zap.S().Infow("album exported", "album_id", a.ID, "name", a.Name, "media", len(record.Media))
+
+	return record, nil
+}
+
+func (s *Service) exportObject(ctx context.Context, w *Writer, a entity.Album, name string) (ObjectRecord, error) {
+	r, metadata, err := s.mediaService.GetPhoto(ctx, a.Bucket, name)
+	if err != nil {
+		return ObjectRecord{}, fmt.Errorf("failed to get object '%s/%s': %w", a.Bucket, name, err)
+	}
+
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return ObjectRecord{}, fmt.Errorf("failed to get size of object '%s/%s': %w", a.Bucket, name, err)
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return ObjectRecord{}, fmt.Errorf("failed to read object '%s/%s': %w", a.Bucket, name, err)
+	}
+
+	return w.WriteObject(a.ID, name, size, metadata, r)
+}
+
+// Restore creates the albums of the archive read from r. The archive is read twice: once for the manifest and
+// once for the objects. owners maps the owners of the archive, usernames or user ids, to the ones of this installation.
+// Owners not found in owners are kept as they are.
+func (s *Service) Restore(ctx context.Context, r io.ReadSeeker, owners map[string]string) ([]entity.Album, error) {
+	manifest, err := ReadManifest(r)
+	if err != nil {
+		return []entity.Album{}, err
+	}
+
+	owner := func(o string) string {
+		if newOwner, found := owners[o]; found {
+			return newOwner
+		}
+		return o
+	}
+
+	tags, err := s.restoreTags(ctx, manifest.Tags, owner)
+	if err != nil {
+		return []entity.Album{}, err
+	}
+
+	albums := make(map[string]entity.Album)
+	created := make([]entity.Album, 0, len(manifest.Albums))
+	for _, record := range manifest.Albums {
+		a, err := s.restoreAlbum(ctx, record, tags, owner)
+		if err != nil {
+			return created, err
+		}
+
+		albums[record.ID] = a
+		created = append(created, a)
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return created, err
+	}
+
+	err = ReadObjects(r, manifest.Objects(), func(albumID string, object ObjectRecord, content []byte) error {
+		a := albums[albumID]
+		return s.mediaService.Put(ctx, a.Bucket, object.Name, object.Size, bytes.NewReader(content), object.Metadata)
+	})
+	if err != nil {
+		return created, err
+	}
+
+	for i, record := range manifest.Albums {
+		a, err := s.restoreMetadata(ctx, albums[record.ID], record, tags)
+		if err != nil {
+			return created, err
+		}
+
+		created[i] = a
+	}
+
+	return created, nil
+}
+
+// restoreTags finds or creates the tags of the archive. It returns the tags mapped by their id in the archive.
+func (s *Service) restoreTags(ctx context.Context, records []TagRecord, owner func(string) string) (map[string]entity.Tag, error) {
+	tags := make(map[string]entity.Tag)
+	for _, record := range records {
+		t, err := s.tagService.GetByName(ctx, owner(record.Owner), record.Name)
+		if err != nil {
+			t, err = s.tagService.Create(ctx, entity.Tag{
+				UserID: owner(record.Owner),
+				Name:   record.Name,
+				Color:  record.Color,
+			})
+			if err != nil {
+				return tags, fmt.Errorf("failed to restore tag '%s': %w", record.Name, err)
+			}
+		}
+
+		tags[record.ID] = t
+	}
+
+	return tags, nil
+}
+
+// restoreAlbum creates the album with its permissions and tags.
+func (s *Service) restoreAlbum(ctx context.Context, record AlbumRecord, tags map[string]entity.Tag, owner func(string) string) (entity.Album, error) {
+	a, err := s.albumService.Create(ctx, entity.Album{
+		Name:        record.Name,
+		CreatedAt:   record.CreatedAt,
+		Owner:       owner(record.Owner),
+		Description: record.Description,
+		Location:    record.Location,
+		Encrypted:   record.Encrypted,
+	})
+	if err != nil {
+		return entity.Album{}, err
+	}
+
+	permissions := make([]entity.AlbumPermission, 0, len(record.UserPermissions)+len(record.GroupPermissions))
+	for _, p := range record.UserPermissions {
+		permission, err := p.ToEntity("user", owner)
+		if err != nil {
+			return a, err
+		}
+		permissions = append(permissions, permission)
+	}
+
+	noop := func(o string) string { return o }
+	for _, p := range record.GroupPermissions {
+		permission, err := p.ToEntity("group", noop)
+		if err != nil {
+			return a, err
+		}
+		permissions = append(permissions, permission)
+	}
+
+	if len(permissions) > 0 {
+		if err := s.albumService.SetPermissions(ctx, a, permissions); err != nil {
+			return a, fmt.Errorf("failed to restore permissions of album '%s': %w", a.Name, err)
+		}
+	}
+
+	for _, id := range record.Tags {
+		if err := s.tagService.Associate(ctx, tags[id], a.ID); err != nil {
+			return a, err
+		}
+	}
+
+	zap.S().Infow("album restored", "album_id", a.ID, "archive_album_id", record.ID, "name", a.Name)
+
+	return a, nil
+}
+
+// restoreMetadata restores the metadata of the album's media, the thumbnail and the place of the album once its objects are restored.
+func (s *Service) restoreMetadata(ctx context.Context, a entity.Album, record AlbumRecord, tags map[string]entity.Tag) (entity.Album, error) {
+	for _, mr := range record.Media {
+		if len(mr.Caption) > 0 || len(mr.Description) > 0 {
+			m := entity.Media{Filename: mr.Filename, Caption: mr.Caption, Description: mr.Description}
+			if err := s.mediaService.UpdateMetadata(ctx, a.ID, m); err != nil {
+				return a, fmt.Errorf("failed to restore metadata of '%s': %w", mr.Filename, err)
+			}
+		}
+
+		for _, id := range mr.Tags {
+			if err := s.mediaService.Tag(ctx, a.ID, mr.Filename, tags[id]); err != nil {
+				return a, err
+			}
+		}
+	}
+
+	if len(record.Thumbnail) > 0 {
+		a.Thumbnail = record.Thumbnail
+		if _, err := s.albumService.Update(ctx, a); err != nil {
+			return a, err
+		}
+	}
+
+	// places are not exported. They are found again from the location of the photos.
+	a, err := s.albumService.Query().First(ctx, a.ID)
+	if err != nil {
+		return a, err
+	}
+
+	return s.albumService.Locate(ctx, a)
+}
@@ -0,0 +1,135 @@
+//go:build integration
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	minioclient "github.com/tupyy/gophoto/internal/clients/minio"
+	"github.com/tupyy/gophoto/internal/conf"
+	"github.com/tupyy/gophoto/internal/entity"
+	miniorepo "github.com/tupyy/gophoto/internal/repos/minio"
+	albumrepo "github.com/tupyy/gophoto/internal/repos/postgres/album"
+	mediarepo "github.com/tupyy/gophoto/internal/repos/postgres/media"
+	tagrepo "github.com/tupyy/gophoto/internal/repos/postgres/tag"
+	"github.com/tupyy/gophoto/internal/services/album"
+	"github.com/tupyy/gophoto/internal/services/geocoding"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"github.com/tupyy/gophoto/internal/services/tag"
+	"github.com/tupyy/gophoto/internal/utils/pgtestcontainer"
+)
+
+// TestExportRestore exports an installation and restores it into a fresh one. It needs docker and a MinIO server
+// set with GPHOTOS_TEST_MINIO_URL, GPHOTOS_TEST_MINIO_ACCESS_ID and GPHOTOS_TEST_MINIO_SECRET_KEY.
+//
+//	go test -tags integration ./internal/services/backup/
+func TestExportRestore(t *testing.T) {
+	url := os.Getenv("GPHOTOS_TEST_MINIO_URL")
+	if len(url) == 0 {
+		t.Skip("GPHOTOS_TEST_MINIO_URL is not set")
+	}
+
+	ctx := context.Background()
+
+	source, sourceMedia := newInstallation(ctx, t, url)
+	destination, destinationMedia := newInstallation(ctx, t, url)
+
+	// populate the source installation
+	a, err := source.albumService.Create(ctx, entity.Album{Name: "holidays", Owner: "bob", CreatedAt: time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)})
+	require.Nil(t, err)
+
+	require.Nil(t, source.albumService.SetPermissions(ctx, a, []entity.AlbumPermission{
+		{OwnerID: "alice", OwnerKind: "user", Permissions: []entity.Permission{entity.PermissionReadAlbum}},
+	}))
+
+	require.Nil(t, sourceMedia.Put(ctx, a.Bucket, "photos/a.jpg", 12, strings.NewReader("content of a"), map[string]string{"phash": "ff"}))
+	require.Nil(t, sourceMedia.Put(ctx, a.Bucket, "thumbnail/a.jpg", 5, strings.NewReader("thumb"), map[string]string{}))
+	require.Nil(t, sourceMedia.UpdateMetadata(ctx, a.ID, entity.Media{Filename: "photos/a.jpg", Caption: "beach"}))
+
+	summer, err := source.tagService.Create(ctx, entity.Tag{UserID: "bob-id", Name: "summer"})
+	require.Nil(t, err)
+	require.Nil(t, source.tagService.Associate(ctx, summer, a.ID))
+	require.Nil(t, sourceMedia.Tag(ctx, a.ID, "photos/a.jpg", summer))
+
+	var archive bytes.Buffer
+	manifest, err := source.Export(ctx, &archive, false)
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(manifest.Albums))
+
+	albums, err := destination.Restore(ctx, bytes.NewReader(archive.Bytes()), map[string]string{"bob": "robert", "bob-id": "robert-id"})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(albums))
+
+	restored, err := destination.albumService.Query().First(ctx, albums[0].ID)
+	require.Nil(t, err)
+	assert.Equal(t, "holidays", restored.Name)
+	assert.Equal(t, "robert", restored.Owner)
+	assert.NotEqual(t, a.Bucket, restored.Bucket)
+	assert.Equal(t, 1, len(restored.UserPermissions))
+	assert.Equal(t, "alice", restored.UserPermissions[0].OwnerID)
+
+	tags, err := destination.tagService.GetByAlbum(ctx, restored.ID)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(tags))
+	assert.Equal(t, "robert-id", tags[0].UserID)
+
+	medias, err := destinationMedia.WithMetadata(ctx, restored.ID, "", restored.Photos)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(medias))
+	assert.Equal(t, "beach", medias[0].Caption)
+	assert.Equal(t, 1, len(medias[0].Tags))
+
+	r, metadata, err := destinationMedia.GetPhoto(ctx, restored.Bucket, "photos/a.jpg")
+	require.Nil(t, err)
+	content, err := io.ReadAll(r)
+	require.Nil(t, err)
+	assert.Equal(t, "content of a", string(content))
+	assert.Equal(t, "ff", metadata["Phash"])
+}
+
+// newInstallation starts an empty database and returns the backup service of an installation using it.
+func newInstallation(ctx context.Context, t *testing.T, minioURL string) (*Service, *media.Service) {
+	setup, err := filepath.Abs("../../../sql/setup/02_setup.sql")
+	require.Nil(t, err)
+
+	container, err := pgtestcontainer.NewPostgreSQLContainer(ctx, pgtestcontainer.PostgreSQLContainerRequest{
+		BindMounts: map[string]string{setup: "/docker-entrypoint-initdb.d/02_setup.sql"},
+	})
+	require.Nil(t, err)
+	t.Cleanup(func() { container.Container.Terminate(ctx) })
+
+	client, err := container.GetInitialClient(ctx)
+	require.Nil(t, err)
+
+	mclient, err := minioclient.New(conf.MinioConfig{
+		Url:             minioURL,
+		AccessID:        os.Getenv("GPHOTOS_TEST_MINIO_ACCESS_ID"),
+		AccessSecretKey: os.Getenv("GPHOTOS_TEST_MINIO_SECRET_KEY"),
+	})
+	require.Nil(t, err)
+
+	albumRepo, err := albumrepo.NewPostgresRepo(client)
+	require.Nil(t, err)
+
+	mediaRepo, err := mediarepo.NewPostgresRepo(client)
+	require.Nil(t, err)
+
+	tagRepo, err := tagrepo.NewPostgresRepo(client)
+	require.Nil(t, err)
+
+	geocoder, err := geocoding.Default()
+	require.Nil(t, err)
+
+	mediaService := media.New(miniorepo.New(mclient, ""), mediaRepo, geocoder, nil)
+
+	return New(album.New(albumRepo, mediaService, nil, media.BucketLayout), mediaService, tag.New(tagRepo)), mediaService
+}
@@ -0,0 +1,143 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services"
+)
+
+const (
+	// maxContentLength is the maximum length of a comment.
+	maxContentLength = 2000
+)
+
+// reactionKinds holds the supported reactions.
+var reactionKinds = map[string]struct{}{
+	"like":  {},
+	"love":  {},
+	"laugh": {},
+	"wow":   {},
+	"sad":   {},
+}
+
+type CommentRepository interface {
+	// Create -- create the comment.
+	Create(ctx context.Context, comment entity.Comment) (entity.Comment, error)
+	// Update -- update the content of the comment and keep the old content in history.
+	Update(ctx context.Context, comment entity.Comment) (entity.Comment, error)
+	// Delete -- delete the comment and its history.
+	Delete(ctx context.Context, id string) error
+	// GetByID -- fetch the comment by id.
+	GetByID(ctx context.Context, id string) (entity.Comment, error)
+	// GetByMedia -- fetch all the comments of a media.
+	GetByMedia(ctx context.Context, albumID, filename string) ([]entity.Comment, error)
+	// GetHistory -- fetch the previous versions of the comment.
+	GetHistory(ctx context.Context, commentID string) ([]entity.CommentRevision, error)
+	// AddReaction -- add a reaction to a media.
+	AddReaction(ctx context.Context, reaction entity.Reaction) error
+	// RemoveReaction -- remove a reaction from a media.
+	RemoveReaction(ctx context.Context, reaction entity.Reaction) error
+	// GetReactions -- fetch all the reactions of a media.
+	GetReactions(ctx context.Context, albumID, filename string) ([]entity.Reaction, error)
+}
+
+type Service struct {
+	repo CommentRepository
+}
+
+func New(r CommentRepository) *Service {
+	return &Service{repo: r}
+}
+
+func (s *Service) Get(ctx context.Context, albumID, filename string) ([]entity.Comment, error) {
+	return s.repo.GetByMedia(ctx, albumID, filename)
+}
+
+func (s *Service) GetByID(ctx context.Context, id string) (entity.Comment, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *Service) History(ctx context.Context, comment entity.Comment) ([]entity.CommentRevision, error) {
+	return s.repo.GetHistory(ctx, comment.ID)
+}
+
+func (s *Service) Create(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	if err := validateContent(comment.Content); err != nil {
+		return entity.Comment{}, fmt.Errorf("%w: %v", services.ErrInvalidComment, err)
+	}
+
+	newComment, err := s.repo.Create(ctx, comment)
+	if err != nil {
+		return entity.Comment{}, fmt.Errorf("%w '%s': %v", services.ErrCreateComment, comment.Filename, err)
+	}
+
+	return newComment, nil
+}
+
+// Update saves the new content of the comment. Only the author can edit the comment.
+func (s *Service) Update(ctx context.Context, comment entity.Comment, user entity.User) (entity.Comment, error) {
+	if comment.Author != user.Username {
+		return comment, fmt.Errorf("%w: only the author can edit the comment", services.ErrForbiddenComment)
+	}
+
+	if err := validateContent(comment.Content); err != nil {
+		return comment, fmt.Errorf("%w: %v", services.ErrInvalidComment, err)
+	}
+
+	updated, err := s.repo.Update(ctx, comment)
+	if err != nil {
+		return comment, fmt.Errorf("%w '%s': %v", services.ErrUpdateComment, comment.ID, err)
+	}
+
+	return updated, nil
+}
+
+// Delete removes the comment of a media of the album. The comment can be removed by its author or by the owner of the album.
+func (s *Service) Delete(ctx context.Context, album entity.Album, comment entity.Comment, user entity.User) error {
+	if comment.Author != user.Username && album.Owner != user.Username {
+		return fmt.Errorf("%w: only the author or the owner of the album can delete the comment", services.ErrForbiddenComment)
+	}
+
+	if err := s.repo.Delete(ctx, comment.ID); err != nil {
+		return fmt.Errorf("%w '%s': %v", services.ErrDeleteComment, comment.ID, err)
+	}
+
+	return nil
+}
+
+func (s *Service) Reactions(ctx context.Context, albumID, filename string) ([]entity.Reaction, error) {
+	return s.repo.GetReactions(ctx, albumID, filename)
+}
+
+func (s *Service) React(ctx context.Context, reaction entity.Reaction) error {
+	if !IsValidReaction(reaction.Kind) {
+		return fmt.Errorf("%w '%s'", services.ErrInvalidReaction, reaction.Kind)
+	}
+
+	return s.repo.AddReaction(ctx, reaction)
+}
+
+func (s *Service) Unreact(ctx context.Context, reaction entity.Reaction) error {
+	return s.repo.RemoveReaction(ctx, reaction)
+}
+
+// IsValidReaction returns true if the kind of reaction is supported.
+func IsValidReaction(kind string) bool {
+	_, found := reactionKinds[kind]
+	return found
+}
+
+func validateContent(content string) error {
+	if len(strings.TrimSpace(content)) == 0 {
+		return fmt.Errorf("comment is empty")
+	}
+
+	if len(content) > maxContentLength {
+		return fmt.Errorf("comment exceeds %d characters", maxContentLength)
+	}
+
+	return nil
+}
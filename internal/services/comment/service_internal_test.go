@@ -0,0 +1,230 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services"
+)
+
+// memRepo keeps the comments, their history and the reactions in memory.
+type memRepo struct {
+	comments  map[string]entity.Comment
+	history   map[string][]entity.CommentRevision
+	reactions []entity.Reaction
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{comments: make(map[string]entity.Comment), history: make(map[string][]entity.CommentRevision)}
+}
+
+func (m *memRepo) Create(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	comment.ID = fmt.Sprintf("comment-%d", len(m.comments)+1)
+	comment.CreatedAt = time.Now()
+	m.comments[comment.ID] = comment
+
+	return comment, nil
+}
+
+func (m *memRepo) Update(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	old, found := m.comments[comment.ID]
+	if !found {
+		return entity.Comment{}, services.ErrNotFound
+	}
+
+	now := time.Now()
+	m.history[comment.ID] = append(m.history[comment.ID], entity.CommentRevision{CommentID: comment.ID, Content: old.Content, CreatedAt: now})
+
+	old.Content, old.UpdatedAt = comment.Content, &now
+	m.comments[comment.ID] = old
+
+	return old, nil
+}
+
+func (m *memRepo) Delete(ctx context.Context, id string) error {
+	delete(m.comments, id)
+	delete(m.history, id)
+	return nil
+}
+
+func (m *memRepo) GetByID(ctx context.Context, id string) (entity.Comment, error) {
+	comment, found := m.comments[id]
+	if !found {
+		return entity.Comment{}, services.ErrNotFound
+	}
+
+	return comment, nil
+}
+
+func (m *memRepo) GetByMedia(ctx context.Context, albumID, filename string) ([]entity.Comment, error) {
+	comments := []entity.Comment{}
+	for _, comment := range m.comments {
+		if comment.AlbumID == albumID && comment.Filename == filename {
+			comments = append(comments, comment)
+		}
+	}
+
+	return comments, nil
+}
+
+func (m *memRepo) GetHistory(ctx context.Context, commentID string) ([]entity.CommentRevision, error) {
+	return m.history[commentID], nil
+}
+
+func (m *memRepo) AddReaction(ctx context.Context, reaction entity.Reaction) error {
+	m.reactions = append(m.reactions, reaction)
+	return nil
+}
+
+func (m *memRepo) RemoveReaction(ctx context.Context, reaction entity.Reaction) error {
+	kept := m.reactions[:0]
+	for _, r := range m.reactions {
+		if r.AlbumID != reaction.AlbumID || r.Filename != reaction.Filename || r.User != reaction.User || r.Kind != reaction.Kind {
+			kept = append(kept, r)
+		}
+	}
+	m.reactions = kept
+
+	return nil
+}
+
+func (m *memRepo) GetReactions(ctx context.Context, albumID, filename string) ([]entity.Reaction, error) {
+	reactions := []entity.Reaction{}
+	for _, r := range m.reactions {
+		if r.AlbumID == albumID && r.Filename == filename {
+			reactions = append(reactions, r)
+		}
+	}
+
+	return reactions, nil
+}
+
+func TestCreate(t *testing.T) {
+	s := New(newMemRepo())
+
+	comment, err := s.Create(context.Background(), entity.Comment{AlbumID: "album", Filename: "photos/a.jpg", Author: "bob", Content: "nice"})
+	require.Nil(t, err)
+	assert.NotEmpty(t, comment.ID)
+	assert.Nil(t, comment.UpdatedAt)
+
+	for _, content := range []string{"", "  \n", strings.Repeat("a", maxContentLength+1)} {
+		_, err := s.Create(context.Background(), entity.Comment{AlbumID: "album", Filename: "photos/a.jpg", Author: "bob", Content: content})
+		assert.True(t, errors.Is(err, services.ErrInvalidComment), "content of %d characters", len(content))
+	}
+
+	comments, err := s.Get(context.Background(), "album", "photos/a.jpg")
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(comments))
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+	bob, alice := entity.User{Username: "bob"}, entity.User{Username: "alice"}
+
+	s := New(newMemRepo())
+
+	comment, err := s.Create(ctx, entity.Comment{AlbumID: "album", Filename: "photos/a.jpg", Author: "bob", Content: "first"})
+	require.Nil(t, err)
+
+	comment.Content = "second"
+	updated, err := s.Update(ctx, comment, bob)
+	require.Nil(t, err)
+	assert.Equal(t, "second", updated.Content)
+	assert.NotNil(t, updated.UpdatedAt)
+
+	updated.Content = "third"
+	_, err = s.Update(ctx, updated, bob)
+	require.Nil(t, err)
+
+	history, err := s.History(ctx, comment)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(history))
+	assert.Equal(t, "first", history[0].Content)
+	assert.Equal(t, "second", history[1].Content)
+
+	// only the author can edit the comment, even the owner of the album cannot
+	updated.Content = "not mine"
+	_, err = s.Update(ctx, updated, alice)
+	assert.True(t, errors.Is(err, services.ErrForbiddenComment))
+
+	// an invalid edit is not saved
+	updated.Content = " "
+	_, err = s.Update(ctx, updated, bob)
+	assert.True(t, errors.Is(err, services.ErrInvalidComment))
+
+	saved, err := s.GetByID(ctx, comment.ID)
+	require.Nil(t, err)
+	assert.Equal(t, "third", saved.Content)
+
+	history, err = s.History(ctx, comment)
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(history))
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	album := entity.Album{ID: "album", Owner: "alice"}
+
+	data := []struct {
+		user    string
+		allowed bool
+	}{
+		{user: "bob", allowed: true},    // author
+		{user: "alice", allowed: true},  // owner of the album
+		{user: "carol", allowed: false}, // neither
+	}
+
+	for idx, d := range data {
+		repo := newMemRepo()
+		s := New(repo)
+
+		comment, err := s.Create(ctx, entity.Comment{AlbumID: album.ID, Filename: "photos/a.jpg", Author: "bob", Content: "nice"})
+		require.Nil(t, err)
+
+		comment.Content = "edited"
+		_, err = s.Update(ctx, comment, entity.User{Username: "bob"})
+		require.Nil(t, err)
+
+		err = s.Delete(ctx, album, comment, entity.User{Username: d.user})
+		if !d.allowed {
+			assert.True(t, errors.Is(err, services.ErrForbiddenComment), "test %d", idx)
+			assert.Equal(t, 1, len(repo.comments), "test %d", idx)
+			continue
+		}
+
+		assert.Nil(t, err, "test %d", idx)
+		assert.Equal(t, 0, len(repo.comments), "test %d", idx)
+		assert.Equal(t, 0, len(repo.history), "the history must be deleted with the comment, test %d", idx)
+	}
+}
+
+func TestReact(t *testing.T) {
+	ctx := context.Background()
+	s := New(newMemRepo())
+
+	like := entity.Reaction{AlbumID: "album", Filename: "photos/a.jpg", User: "bob", Kind: "like"}
+	require.Nil(t, s.React(ctx, like))
+
+	for _, kind := range []string{"", "dislike", "LIKE"} {
+		err := s.React(ctx, entity.Reaction{AlbumID: "album", Filename: "photos/a.jpg", User: "bob", Kind: kind})
+		assert.True(t, errors.Is(err, services.ErrInvalidReaction), "kind '%s'", kind)
+	}
+
+	reactions, err := s.Reactions(ctx, "album", "photos/a.jpg")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(reactions))
+	assert.Equal(t, "like", reactions[0].Kind)
+
+	require.Nil(t, s.Unreact(ctx, like))
+
+	reactions, err = s.Reactions(ctx, "album", "photos/a.jpg")
+	require.Nil(t, err)
+	assert.Equal(t, 0, len(reactions))
+}
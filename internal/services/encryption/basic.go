@@ -1,93 +1,129 @@
 package encryption
 
 import (
-	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/tupyy/gophoto/internal/conf"
 )
 
 const (
 	nonceSize = 12
+
+	// legacyKeyID is the id of the key derived from the encryption key.
+	legacyKeyID = "k0"
+)
+
+var (
+	generator     *Generator
+	generatorErr  error
+	generatorOnce sync.Once
 )
 
+// Generator turns the ids of the resources into opaque tokens for the urls and back. A token is the id of the key
+// which has encrypted it followed by the encrypted id. The nonce is random so the same id yields a different token each time.
+// The tokens are resolved as long as their key is in the keyring: to rotate the keys, a new key is added and made active,
+// and the old key is removed once the links issued with it can be retired.
+//
+// The encryption key of the configuration is the legacy key. While it is set, the hex tokens it has encrypted in a
+// deterministic way are resolved and a key derived from it, with id k0, is part of the keyring. The derived key is the
+// active key if no id key is configured.
 type Generator struct {
-	key []byte
+	keys *Keyring
+	// legacy - decrypts the hex tokens. They are retired if nil.
+	legacy cipher.AEAD
 }
 
+// New returns the generator of the configuration. It is created once.
 func New() (*Generator, error) {
-	if conf.GetEncryptionKey() == "" {
-		return nil, errors.New("encryption key is missing")
-	}
-	return newGenerator(conf.GetEncryptionKey()), nil
-}
+	generatorOnce.Do(func() {
+		generator, generatorErr = NewGenerator(conf.GetIDKeysConfig(), conf.GetEncryptionKey())
+	})
 
-func newGenerator(key string) *Generator {
-	return &Generator{key: []byte(key)}
+	return generator, generatorErr
 }
 
-// EncryptData encrypts the data with the provided key. The result is determinist.
-func (g *Generator) Encrypt(data string) (string, error) {
-	hash := sha256.Sum256(bytes.NewBufferString(data).Bytes())
+// NewGenerator returns a generator with the id keys and the legacy key. Either of them can be missing but not both.
+func NewGenerator(c conf.KeyringConfig, legacyKey string) (*Generator, error) {
+	if !c.Enabled() && len(legacyKey) == 0 {
+		return nil, errors.New("encryption key is missing")
+	}
 
-	nonce := make([]byte, nonceSize)
-	copy(nonce, hash[:nonceSize])
+	g := &Generator{}
 
-	return g.encryptDataWithNonce(data, nonce)
-}
+	if c.Enabled() {
+		keys, err := KeyringFromConfig(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id keys: %w", err)
+		}
+		g.keys = keys
+	}
 
-// DecryptData decrypts the data with the provided key.
-func (g *Generator) Decrypt(data string) (string, error) {
-	aesgcm, err := g.getAEAD()
-	if err != nil {
-		return "", err
+	if len(legacyKey) == 0 {
+		return g, nil
 	}
 
-	nonce, ciphertext, err := parseData(data)
+	block, err := aes.NewCipher([]byte(legacyKey))
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
 	}
 
-	hexEncodedText, err := aesgcm.Open(nil, nonce, ciphertext, nil)
+	g.legacy, err = cipher.NewGCM(block)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// b, err := hex.DecodeString(string(hexEncodedText))
-	// if err != nil {
-	// 	return "", err
-	// }
+	derived := sha256.Sum256([]byte("gophoto/id/" + legacyKey))
 
-	ret := string(hexEncodedText)
+	if g.keys == nil {
+		g.keys, err = NewKeyring(map[string][]byte{legacyKeyID: derived[:]}, legacyKeyID)
+		return g, err
+	}
 
-	return ret, nil
+	if err := g.keys.add(legacyKeyID, derived[:]); err != nil {
+		return nil, fmt.Errorf("key id '%s' is reserved for the key derived from the encryption key: %w", legacyKeyID, err)
+	}
+
+	return g, nil
 }
 
-func (g *Generator) encryptDataWithNonce(data string, nonce []byte) (string, error) {
-	aesgcm, err := g.getAEAD()
-	if err != nil {
-		return "", err
-	}
+// Encrypt returns a token of the data encrypted with the active key.
+func (g *Generator) Encrypt(data string) (string, error) {
+	return g.keys.Seal([]byte(data))
+}
 
-	ciphertext := aesgcm.Seal(nil, nonce, []byte(data), nil)
+// Decrypt returns the data of a token encrypted with any of the keys, or the data of a legacy hex token.
+func (g *Generator) Decrypt(data string) (string, error) {
+	if strings.Contains(data, sealSeparator) {
+		plaintext, err := g.keys.Open(data)
+		if err != nil {
+			return "", err
+		}
 
-	ret := fmt.Sprintf("%x%x", nonce, ciphertext)
+		return string(plaintext), nil
+	}
 
-	return ret, nil
-}
+	if g.legacy == nil {
+		return "", errors.New("legacy tokens are retired")
+	}
 
-func (g *Generator) getAEAD() (cipher.AEAD, error) {
-	block, err := aes.NewCipher(g.key)
+	nonce, ciphertext, err := parseData(data)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return cipher.NewGCM(block)
+	plaintext, err := g.legacy.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
 }
 
 func parseData(data string) ([]byte, []byte, error) {
@@ -96,5 +132,9 @@ func parseData(data string) ([]byte, []byte, error) {
 		return nil, nil, err
 	}
 
+	if len(databytes) < nonceSize {
+		return nil, nil, errors.New("token too short")
+	}
+
 	return databytes[:nonceSize], databytes[nonceSize:], nil
 }
@@ -0,0 +1,88 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/conf"
+)
+
+const legacyKey = "0123456789abcdef0123456789abcdef"
+
+// legacyToken encrypts the data like the generator did before the keyring.
+func legacyToken(g *Generator, data string) string {
+	hash := sha256.Sum256([]byte(data))
+	nonce := hash[:nonceSize]
+
+	return fmt.Sprintf("%x%x", nonce, g.legacy.Seal(nil, nonce, []byte(data), nil))
+}
+
+func keys(active string, ids ...string) conf.KeyringConfig {
+	c := conf.KeyringConfig{ActiveKey: active, MasterKeys: make(map[string]string)}
+	for i, id := range ids {
+		c.MasterKeys[id] = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{byte(i + 1)}, KeySize))
+	}
+
+	return c
+}
+
+func TestGenerator(t *testing.T) {
+	_, err := NewGenerator(conf.KeyringConfig{}, "")
+	assert.NotNil(t, err)
+
+	legacy, err := NewGenerator(conf.KeyringConfig{}, legacyKey)
+	require.Nil(t, err)
+
+	old := legacyToken(legacy, "album-id")
+
+	token, err := legacy.Encrypt("album-id")
+	require.Nil(t, err)
+	assert.True(t, strings.HasPrefix(token, legacyKeyID+"."))
+
+	other, err := legacy.Encrypt("album-id")
+	require.Nil(t, err)
+	assert.NotEqual(t, token, other, "the tokens must not be deterministic")
+
+	for _, tk := range []string{old, token, other} {
+		id, err := legacy.Decrypt(tk)
+		require.Nil(t, err)
+		assert.Equal(t, "album-id", id)
+	}
+
+	// the id keys are added: the tokens issued before are still resolved
+	rotated, err := NewGenerator(keys("b", "a", "b"), legacyKey)
+	require.Nil(t, err)
+
+	for _, tk := range []string{old, token} {
+		id, err := rotated.Decrypt(tk)
+		require.Nil(t, err)
+		assert.Equal(t, "album-id", id)
+	}
+
+	token, err = rotated.Encrypt("album-id")
+	require.Nil(t, err)
+	assert.True(t, strings.HasPrefix(token, "b."))
+
+	// the legacy key is retired
+	retired, err := NewGenerator(keys("b", "a", "b"), "")
+	require.Nil(t, err)
+
+	_, err = retired.Decrypt(old)
+	assert.NotNil(t, err)
+
+	id, err := retired.Decrypt(token)
+	require.Nil(t, err)
+	assert.Equal(t, "album-id", id)
+
+	_, err = retired.Decrypt("00")
+	assert.NotNil(t, err)
+
+	_, err = NewGenerator(keys(legacyKeyID, legacyKeyID), legacyKey)
+	assert.NotNil(t, err, "the id of the derived key is reserved")
+}
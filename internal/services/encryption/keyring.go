@@ -0,0 +1,195 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/tupyy/gophoto/internal/conf"
+)
+
+const (
+	// KeySize is the size of the master keys: AES-256.
+	KeySize = 32
+
+	sealSeparator = "."
+)
+
+var keyIDRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Keyring seals data with the active master key and opens data sealed with any of its master keys.
+// The sealed data is prefixed with the id of the key so the master keys can be rotated: a new key is added and made active,
+// the data sealed with the old key is sealed again, then the old key is removed.
+type Keyring struct {
+	keys   map[string]cipher.AEAD
+	active string
+}
+
+// keyFile is the content of the file holding the master keys.
+type keyFile struct {
+	ActiveKey string            `json:"active_key"`
+	Keys      map[string]string `json:"keys"`
+}
+
+// NewKeyring returns a keyring with the master keys mapped by id. The keys must be 32 bytes long.
+func NewKeyring(keys map[string][]byte, active string) (*Keyring, error) {
+	if _, found := keys[active]; !found {
+		return nil, fmt.Errorf("active key '%s' not found", active)
+	}
+
+	k := &Keyring{keys: make(map[string]cipher.AEAD, len(keys)), active: active}
+
+	for id, key := range keys {
+		if err := k.add(id, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return k, nil
+}
+
+// ParseKeyring returns a keyring with the base64 encoded master keys mapped by id.
+func ParseKeyring(keys map[string]string, active string) (*Keyring, error) {
+	decoded := make(map[string][]byte, len(keys))
+
+	for id, key := range keys {
+		d, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode key '%s': %w", id, err)
+		}
+
+		decoded[id] = d
+	}
+
+	return NewKeyring(decoded, active)
+}
+
+// LoadKeyring reads the master keys from a json file like:
+//
+//	{"active_key": "2022-05", "keys": {"2022-01": "<base64 key>", "2022-05": "<base64 key>"}}
+func LoadKeyring(path string) (*Keyring, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f keyFile
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse key file '%s': %w", path, err)
+	}
+
+	return ParseKeyring(f.Keys, f.ActiveKey)
+}
+
+// KeyringFromConfig returns the keyring of the key file of the configuration, or of its keys if no key file is set.
+func KeyringFromConfig(c conf.KeyringConfig) (*Keyring, error) {
+	if len(c.KeyFile) > 0 {
+		return LoadKeyring(c.KeyFile)
+	}
+
+	return ParseKeyring(c.MasterKeys, c.ActiveKey)
+}
+
+// GenerateKey returns a new random master key, base64 encoded like the keys of the configuration.
+func GenerateKey() (string, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// Active returns the id of the active master key.
+func (k *Keyring) Active() string {
+	return k.active
+}
+
+// Seal encrypts the data with the active master key. The result is url safe and is different at each call.
+func (k *Keyring) Seal(data []byte) (string, error) {
+	aead := k.keys[k.active]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, data, []byte(k.active))
+
+	return k.active + sealSeparator + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts the data sealed with any of the master keys.
+func (k *Keyring) Open(sealed string) ([]byte, error) {
+	id, data, err := k.parse(sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	aead := k.keys[id]
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("sealed data too short")
+	}
+
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(id))
+}
+
+// IsActive returns true if the data has been sealed with the active master key.
+func (k *Keyring) IsActive(sealed string) bool {
+	id, _, err := k.parse(sealed)
+	return err == nil && id == k.active
+}
+
+// add adds a master key which opens data but does not seal it.
+func (k *Keyring) add(id string, key []byte) error {
+	if !keyIDRegexp.MatchString(id) {
+		return fmt.Errorf("invalid key id '%s'", id)
+	}
+
+	if _, found := k.keys[id]; found {
+		return fmt.Errorf("duplicate key id '%s'", id)
+	}
+
+	if len(key) != KeySize {
+		return fmt.Errorf("key '%s' must be %d bytes long, got %d", id, KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+
+	k.keys[id] = aead
+
+	return nil
+}
+
+func (k *Keyring) parse(sealed string) (string, []byte, error) {
+	parts := strings.SplitN(sealed, sealSeparator, 2)
+	if len(parts) != 2 {
+		return "", nil, errors.New("sealed data without key id")
+	}
+
+	if _, found := k.keys[parts[0]]; !found {
+		return "", nil, fmt.Errorf("unknown key '%s'", parts[0])
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode sealed data: %w", err)
+	}
+
+	return parts[0], data, nil
+}
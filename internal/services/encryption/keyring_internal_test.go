@@ -0,0 +1,87 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring(t *testing.T) {
+	oldKey, newKey := bytes.Repeat([]byte{1}, KeySize), bytes.Repeat([]byte{2}, KeySize)
+
+	old, err := NewKeyring(map[string][]byte{"old": oldKey}, "old")
+	require.Nil(t, err)
+
+	sealed, err := old.Seal([]byte("data key"))
+	require.Nil(t, err)
+	assert.True(t, old.IsActive(sealed))
+
+	again, err := old.Seal([]byte("data key"))
+	require.Nil(t, err)
+	assert.NotEqual(t, sealed, again)
+
+	rotated, err := NewKeyring(map[string][]byte{"old": oldKey, "new": newKey}, "new")
+	require.Nil(t, err)
+	assert.Equal(t, "new", rotated.Active())
+
+	// data sealed with the old key is still opened
+	data, err := rotated.Open(sealed)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("data key"), data)
+	assert.False(t, rotated.IsActive(sealed))
+
+	resealed, err := rotated.Seal(data)
+	require.Nil(t, err)
+	assert.True(t, rotated.IsActive(resealed))
+
+	_, err = old.Open(resealed)
+	assert.NotNil(t, err, "data sealed with an unknown key must not be opened")
+
+	// the key id cannot be swapped
+	tampered := "new" + sealed[len("old"):]
+	_, err = rotated.Open(tampered)
+	assert.NotNil(t, err)
+
+	_, err = rotated.Open("garbage")
+	assert.NotNil(t, err)
+}
+
+func TestNewKeyring(t *testing.T) {
+	_, err := NewKeyring(map[string][]byte{"a": make([]byte, KeySize)}, "b")
+	assert.NotNil(t, err, "the active key must be in the keyring")
+
+	_, err = NewKeyring(map[string][]byte{"a": make([]byte, 16)}, "a")
+	assert.NotNil(t, err, "the keys must be 32 bytes long")
+
+	_, err = NewKeyring(map[string][]byte{"a.b": make([]byte, KeySize)}, "a.b")
+	assert.NotNil(t, err, "the key id must not contain the separator")
+}
+
+func TestLoadKeyring(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, KeySize))
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	require.Nil(t, os.WriteFile(path, []byte(`{"active_key": "k1", "keys": {"k1": "`+key+`"}}`), 0600))
+
+	k, err := LoadKeyring(path)
+	require.Nil(t, err)
+	assert.Equal(t, "k1", k.Active())
+
+	require.Nil(t, os.WriteFile(path, []byte(`{"active_key": "k1", "keys": {"k1": "not base64"}}`), 0600))
+
+	_, err = LoadKeyring(path)
+	assert.NotNil(t, err)
+}
+
+func TestGenerateKey(t *testing.T) {
+	key, err := GenerateKey()
+	require.Nil(t, err)
+
+	_, err = ParseKeyring(map[string]string{"new": key}, "new")
+	assert.Nil(t, err)
+}
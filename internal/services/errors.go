@@ -20,3 +20,41 @@ var (
 
 	ErrNotFound = errors.New("resource not found")
 )
+
+// Media service errors
+var (
+	// ErrInvalidMedia means the file is not a media which can be processed.
+	ErrInvalidMedia = errors.New("invalid media")
+	// ErrPresignDisabled means the pre-signed urls are not enabled or not supported by the storage.
+	ErrPresignDisabled = errors.New("pre-signed urls are not enabled")
+	// ErrQuotaExceeded means the media cannot be saved without exceeding the storage quota of the album's owner.
+	ErrQuotaExceeded = errors.New("storage quota exceeded")
+	// ErrEncryptionDisabled means no master key is configured to create or read the encrypted albums.
+	ErrEncryptionDisabled = errors.New("album encryption is not enabled")
+	// ErrMediaExists means a media with the same name is already in the album.
+	ErrMediaExists = errors.New("media already exists")
+)
+
+// Comment service errors
+var (
+	// ErrCreateComment means the comment cannot be created.
+	ErrCreateComment = errors.New("failed to create comment")
+	// ErrUpdateComment means the comment cannot be updated.
+	ErrUpdateComment = errors.New("failed to update comment")
+	// ErrDeleteComment means the comment cannot be deleted.
+	ErrDeleteComment = errors.New("failed to delete comment")
+	// ErrInvalidComment means the content of the comment is not valid.
+	ErrInvalidComment = errors.New("invalid comment")
+	// ErrInvalidReaction means the kind of reaction is not supported.
+	ErrInvalidReaction = errors.New("invalid reaction")
+	// ErrForbiddenComment means the user is not allowed to edit or delete the comment.
+	ErrForbiddenComment = errors.New("comment access denied")
+)
+
+// Access token service errors
+var (
+	// ErrInvalidAccessToken means the name, the scope or the expiration date of the token to create is not valid.
+	ErrInvalidAccessToken = errors.New("invalid access token")
+	// ErrUnknownAccessToken means the token presented is unknown or expired.
+	ErrUnknownAccessToken = errors.New("unknown or expired access token")
+)
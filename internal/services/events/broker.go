@@ -0,0 +1,119 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/tupyy/gophoto/internal/entity"
+	"go.uber.org/zap"
+)
+
+const (
+	subscriberBufferSize = 64
+)
+
+// Transport forwards events between broker instances running on different replicas.
+type Transport interface {
+	// Send sends the event to the other replicas.
+	Send(ctx context.Context, event entity.Event) error
+	// Listen blocks until ctx is done and calls handler for every event received from other replicas.
+	Listen(ctx context.Context, handler func(event entity.Event)) error
+}
+
+// FilterFunc returns true if the subscriber is interested in the event.
+type FilterFunc func(event entity.Event) bool
+
+type subscriber struct {
+	filter FilterFunc
+	ch     chan entity.Event
+}
+
+// Broker is an in-process publish/subscribe broker.
+// If a transport is set, published events are forwarded to the other replicas and
+// events received from them are dispatched to the local subscribers.
+type Broker struct {
+	lock        sync.RWMutex
+	subscribers map[string]*subscriber
+	transport   Transport
+}
+
+func NewBroker(transport Transport) *Broker {
+	return &Broker{
+		subscribers: make(map[string]*subscriber),
+		transport:   transport,
+	}
+}
+
+// Start listens to the transport until ctx is done. It does nothing if the broker has no transport.
+func (b *Broker) Start(ctx context.Context) {
+	if b.transport == nil {
+		return
+	}
+
+	go func() {
+		if err := b.transport.Listen(ctx, b.dispatch); err != nil && ctx.Err() == nil {
+			zap.S().Errorw("event transport stopped", "error", err)
+		}
+	}()
+}
+
+// Publish dispatches the event to local subscribers and forwards it to the other replicas.
+func (b *Broker) Publish(ctx context.Context, event entity.Event) {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	b.dispatch(event)
+
+	if b.transport != nil {
+		if err := b.transport.Send(ctx, event); err != nil {
+			zap.S().Errorw("failed to forward event", "error", err, "kind", event.Kind, "bucket", event.Bucket)
+		}
+	}
+}
+
+// Subscribe returns a channel which receives all the events accepted by the filter.
+// The cancel function must be called to release the subscription.
+func (b *Broker) Subscribe(filter FilterFunc) (<-chan entity.Event, func()) {
+	id := xid.New().String()
+	sub := &subscriber{
+		filter: filter,
+		ch:     make(chan entity.Event, subscriberBufferSize),
+	}
+
+	b.lock.Lock()
+	b.subscribers[id] = sub
+	b.lock.Unlock()
+
+	cancel := func() {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+
+		if _, found := b.subscribers[id]; found {
+			delete(b.subscribers, id)
+			close(sub.ch)
+		}
+	}
+
+	return sub.ch, cancel
+}
+
+func (b *Broker) dispatch(event entity.Event) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for id, sub := range b.subscribers {
+		if sub.filter != nil && !sub.filter(event) {
+			continue
+		}
+
+		// never block the publisher because of a slow subscriber
+		select {
+		case sub.ch <- event:
+		default:
+			zap.S().Warnw("subscriber too slow. event dropped", "subscriber", id, "kind", event.Kind)
+		}
+	}
+}
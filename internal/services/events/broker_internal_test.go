@@ -0,0 +1,181 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+// memTransport records the events sent and delivers the events pushed on received.
+type memTransport struct {
+	lock     sync.Mutex
+	sent     []entity.Event
+	received chan entity.Event
+}
+
+func (m *memTransport) Send(ctx context.Context, event entity.Event) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.sent = append(m.sent, event)
+
+	return nil
+}
+
+func (m *memTransport) Listen(ctx context.Context, handler func(event entity.Event)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e := <-m.received:
+			handler(e)
+		}
+	}
+}
+
+func receive(t *testing.T, ch <-chan entity.Event) entity.Event {
+	select {
+	case e, more := <-ch:
+		require.True(t, more, "channel closed")
+		return e
+	case <-time.After(time.Second):
+		require.FailNow(t, "no event received")
+	}
+
+	return entity.Event{}
+}
+
+func assertEmpty(t *testing.T, ch <-chan entity.Event) {
+	select {
+	case e, more := <-ch:
+		if more {
+			assert.Fail(t, "unexpected event", "kind %s", e.Kind)
+		}
+	default:
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker(nil)
+
+	events, cancel := b.Subscribe(func(e entity.Event) bool { return e.Bucket == "a" })
+
+	b.Publish(ctx, entity.Event{Kind: entity.EventPhotoAdded, Bucket: "b"})
+	b.Publish(ctx, entity.Event{Kind: entity.EventPhotoAdded, Bucket: "a", Filename: "photos/a.jpg"})
+
+	e := receive(t, events)
+	assert.Equal(t, "photos/a.jpg", e.Filename)
+	assert.False(t, e.CreatedAt.IsZero(), "the date must be set")
+	assertEmpty(t, events)
+
+	cancel()
+
+	_, more := <-events
+	assert.False(t, more, "the channel must be closed")
+
+	// no event is delivered once unsubscribed and cancel can be called again
+	b.Publish(ctx, entity.Event{Kind: entity.EventPhotoAdded, Bucket: "a"})
+	cancel()
+	assert.Equal(t, 0, len(b.subscribers))
+}
+
+func TestFanOut(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker(nil)
+
+	all, cancelAll := b.Subscribe(nil)
+	defer cancelAll()
+
+	a, cancelA := b.Subscribe(func(e entity.Event) bool { return e.Bucket == "a" })
+	defer cancelA()
+
+	other, cancelOther := b.Subscribe(nil)
+	cancelOther()
+
+	b.Publish(ctx, entity.Event{Kind: entity.EventPhotoAdded, Bucket: "a"})
+	b.Publish(ctx, entity.Event{Kind: entity.EventPhotoRemoved, Bucket: "b"})
+
+	assert.Equal(t, "a", receive(t, all).Bucket)
+	assert.Equal(t, "b", receive(t, all).Bucket)
+	assert.Equal(t, "a", receive(t, a).Bucket)
+	assertEmpty(t, a)
+	assertEmpty(t, other)
+}
+
+func TestSlowConsumer(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker(nil)
+
+	slow, cancelSlow := b.Subscribe(nil)
+	defer cancelSlow()
+
+	fast, cancelFast := b.Subscribe(nil)
+	defer cancelFast()
+
+	total := subscriberBufferSize * 2
+	received := make(chan int)
+	go func() {
+		count := 0
+		for range fast {
+			count++
+			if count == total {
+				break
+			}
+		}
+		received <- count
+	}()
+
+	// the publisher is never blocked by the subscriber which does not read
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < total; i++ {
+			b.Publish(ctx, entity.Event{Kind: entity.EventPhotoAdded, Bucket: "a"})
+			// let the fast subscriber keep up
+			time.Sleep(time.Millisecond)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "publisher blocked by a slow subscriber")
+	}
+
+	assert.Equal(t, total, <-received)
+	assert.Equal(t, subscriberBufferSize, len(slow), "the events beyond the buffer of the slow subscriber are dropped")
+}
+
+func TestTransport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	transport := &memTransport{received: make(chan entity.Event)}
+	b := NewBroker(transport)
+	b.Start(ctx)
+
+	events, unsubscribe := b.Subscribe(nil)
+	defer unsubscribe()
+
+	// published events are forwarded to the other replicas
+	b.Publish(ctx, entity.Event{Kind: entity.EventPhotoAdded, Bucket: "a"})
+	assert.Equal(t, "a", receive(t, events).Bucket)
+
+	transport.lock.Lock()
+	assert.Equal(t, 1, len(transport.sent))
+	transport.lock.Unlock()
+
+	// events of the other replicas are dispatched but not sent back
+	transport.received <- entity.Event{Kind: entity.EventPhotoRemoved, Bucket: "b"}
+	assert.Equal(t, "b", receive(t, events).Bucket)
+
+	transport.lock.Lock()
+	assert.Equal(t, 1, len(transport.sent))
+	transport.lock.Unlock()
+}
@@ -0,0 +1,129 @@
+package fsck
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/media"
+)
+
+const (
+	thumbnailFolder = "thumbnail/"
+	photoFolder     = "photos/"
+)
+
+// checkObjects pairs the media with their thumbnails by filename like the storage does. It returns the media without
+// thumbnail, the thumbnails without media and the metadata of missing media, each sorted. The objects in the trash are
+// not checked and the metadata of the trashed media is kept until they are purged.
+func checkObjects(names, metadata, trashed []string) (missingThumbnails, orphanedThumbnails, orphanedMetadata []string) {
+	medias := make(map[string]string)
+	thumbnails := make(map[string]string)
+
+	for _, name := range names {
+		if strings.HasPrefix(name, media.TrashPrefix) {
+			continue
+		}
+
+		if strings.HasPrefix(name, thumbnailFolder) {
+			thumbnails[filename(name)] = name
+		} else {
+			medias[filename(name)] = name
+		}
+	}
+
+	missingThumbnails, orphanedThumbnails, orphanedMetadata = []string{}, []string{}, []string{}
+
+	for k, name := range medias {
+		if _, found := thumbnails[k]; !found {
+			missingThumbnails = append(missingThumbnails, name)
+		}
+	}
+
+	for k, name := range thumbnails {
+		if _, found := medias[k]; !found {
+			orphanedThumbnails = append(orphanedThumbnails, name)
+		}
+	}
+
+	// the metadata is saved by the name of the media's object
+	objects := make(map[string]struct{}, len(medias)+len(trashed))
+	for _, name := range medias {
+		objects[name] = struct{}{}
+	}
+
+	for _, name := range trashed {
+		objects[name] = struct{}{}
+	}
+
+	for _, name := range metadata {
+		if _, found := objects[name]; !found {
+			orphanedMetadata = append(orphanedMetadata, name)
+		}
+	}
+
+	sort.Strings(missingThumbnails)
+	sort.Strings(orphanedThumbnails)
+	sort.Strings(orphanedMetadata)
+
+	return
+}
+
+// expiredTrash returns the expired media of the trash, and the filenames whose metadata must be kept when they are
+// purged: the media of the bucket and the media still in the trash.
+func expiredTrash(names []string, trash []media.TrashedMedia) (expired []media.TrashedMedia, kept map[string]struct{}) {
+	expired = []media.TrashedMedia{}
+	kept = make(map[string]struct{})
+
+	for _, name := range names {
+		if !strings.HasPrefix(name, media.TrashPrefix) {
+			kept[name] = struct{}{}
+		}
+	}
+
+	for _, t := range trash {
+		if t.Expired() {
+			expired = append(expired, t)
+		} else {
+			kept[t.Filename] = struct{}{}
+		}
+	}
+
+	return
+}
+
+// matchBucket returns the only orphaned bucket labeled with the name and the owner of the album.
+func matchBucket(a entity.Album, orphans map[string]map[string]string) (string, bool) {
+	matches := []string{}
+	for bucket, labels := range orphans {
+		if labels[nameLabel] == a.Name && labels[ownerLabel] == a.Owner {
+			matches = append(matches, bucket)
+		}
+	}
+
+	if len(matches) != 1 {
+		return "", false
+	}
+
+	return matches[0], true
+}
+
+// filename returns the name shared by a photo and its thumbnail.
+func filename(name string) string {
+	if strings.HasPrefix(name, thumbnailFolder) || strings.HasPrefix(name, photoFolder) {
+		return strings.SplitN(name, "/", 2)[1]
+	}
+
+	return name
+}
+
+func sortedKeys(m map[string]map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
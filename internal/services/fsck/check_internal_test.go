@@ -0,0 +1,75 @@
+package fsck
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/media"
+)
+
+func TestCheckObjects(t *testing.T) {
+	names := []string{
+		"photos/a.jpg",
+		"thumbnail/a.jpg",
+		"photos/b.jpg",
+		"thumbnail/c.jpg",
+		"d.jpg",
+		media.TrashPrefix + "cb7ca7gl6rtj4fo9ch2g/photos/f.jpg",
+		media.TrashPrefix + "cb7ca7gl6rtj4fo9ch2g/thumbnail/f.jpg",
+	}
+	metadata := []string{"photos/a.jpg", "photos/c.jpg", "photos/e.jpg", "photos/f.jpg"}
+
+	missingThumbnails, orphanedThumbnails, orphanedMetadata := checkObjects(names, metadata, []string{"photos/f.jpg"})
+
+	assert.Equal(t, []string{"d.jpg", "photos/b.jpg"}, missingThumbnails)
+	assert.Equal(t, []string{"thumbnail/c.jpg"}, orphanedThumbnails)
+	assert.Equal(t, []string{"photos/c.jpg", "photos/e.jpg"}, orphanedMetadata)
+
+	missingThumbnails, orphanedThumbnails, orphanedMetadata = checkObjects([]string{}, []string{}, []string{})
+	assert.Equal(t, 0, len(missingThumbnails)+len(orphanedThumbnails)+len(orphanedMetadata))
+}
+
+func TestExpiredTrash(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-media.TrashRetention - time.Hour)
+
+	names := []string{"photos/a.jpg", "thumbnail/a.jpg", media.TrashPrefix + "x/photos/b.jpg"}
+	trash := []media.TrashedMedia{
+		{ID: "1", Filename: "photos/b.jpg", TrashedAt: now},
+		{ID: "2", Filename: "photos/a.jpg", TrashedAt: old},
+		{ID: "3", Filename: "photos/b.jpg", TrashedAt: old},
+		{ID: "4", Filename: "photos/c.jpg", TrashedAt: old},
+	}
+
+	expired, kept := expiredTrash(names, trash)
+
+	require.Equal(t, 3, len(expired))
+	assert.Equal(t, "2", expired[0].ID)
+	assert.Equal(t, "3", expired[1].ID)
+	assert.Equal(t, "4", expired[2].ID)
+
+	// the metadata of c is the only one which can be removed
+	assert.Equal(t, map[string]struct{}{"photos/a.jpg": {}, "thumbnail/a.jpg": {}, "photos/b.jpg": {}}, kept)
+}
+
+func TestMatchBucket(t *testing.T) {
+	a := entity.Album{Name: "holidays", Owner: "alice"}
+
+	orphans := map[string]map[string]string{
+		"holidays-1234": {nameLabel: "holidays", ownerLabel: "alice"},
+		"holidays-5678": {nameLabel: "holidays", ownerLabel: "bob"},
+	}
+
+	bucket, found := matchBucket(a, orphans)
+	assert.True(t, found)
+	assert.Equal(t, "holidays-1234", bucket)
+
+	// the album cannot be told apart
+	orphans["holidays-9999"] = map[string]string{nameLabel: "holidays", ownerLabel: "alice"}
+
+	_, found = matchBucket(a, orphans)
+	assert.False(t, found)
+}
@@ -0,0 +1,297 @@
+package fsck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/album"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"go.uber.org/zap"
+)
+
+// Kind is the class of an inconsistency between the albums, the buckets and the metadata of the media.
+type Kind string
+
+const (
+	// OrphanedBucket is a bucket of no album which is not marked as deleted. It is marked as deleted when repaired.
+	OrphanedBucket Kind = "orphaned_bucket"
+	// MissingBucket is the missing bucket of an album. The album is linked to the orphaned bucket with the album's name
+	// and owner when repaired, or an empty bucket is created, encrypted with a new data key for the encrypted albums.
+	MissingBucket Kind = "missing_bucket"
+	// MissingThumbnail is a media without thumbnail. The thumbnail is created when repaired.
+	MissingThumbnail Kind = "missing_thumbnail"
+	// OrphanedThumbnail is a thumbnail without media. It is deleted when repaired.
+	OrphanedThumbnail Kind = "orphaned_thumbnail"
+	// OrphanedMetadata is the caption, description, place or tags of a missing media. They are deleted when repaired.
+	OrphanedMetadata Kind = "orphaned_metadata"
+	// ExpiredTrash is a media kept in the trash for media.TrashRetention. It is purged with its metadata when repaired,
+	// unless a media with the same name is in the album or in the trash again.
+	ExpiredTrash Kind = "expired_trash"
+	// StaleUpload is a photo uploaded with a pre-signed url and not completed for a day. It is not counted in the usage
+	// of the owner. It is deleted when repaired.
+	StaleUpload Kind = "stale_upload"
+)
+
+const (
+	nameLabel      = "album/name"
+	dateLabel      = "album/date"
+	ownerLabel     = "owner/username"
+	deletedAtLabel = "album/deleted_at"
+)
+
+// Issue is an inconsistency found by the check.
+type Issue struct {
+	Kind Kind
+	// Bucket - bucket of the album or orphaned bucket
+	Bucket string
+	// AlbumID - empty for the orphaned buckets
+	AlbumID string
+	// Name - name of the object or filename of the media. Empty for the issues of the buckets.
+	Name string
+	// Action - what has been done to repair the issue. Empty if the issue has not been repaired.
+	Action string
+	// Err - error of the repair
+	Err error
+}
+
+// Repaired returns true if the issue has been repaired.
+func (i Issue) Repaired() bool {
+	return len(i.Action) > 0 && i.Err == nil
+}
+
+// Service checks that the albums, their buckets, the objects of the buckets and the metadata of the media agree.
+type Service struct {
+	albumService *album.Service
+	mediaService *media.Service
+}
+
+func New(albumService *album.Service, mediaService *media.Service) *Service {
+	return &Service{albumService, mediaService}
+}
+
+// Check returns the inconsistencies found and repairs them if repair is true. A failed repair does not stop the check.
+// The buckets without the labels set when an album is created are not considered, in case the storage is shared with others.
+func (s *Service) Check(ctx context.Context, repair bool) ([]Issue, error) {
+	admin := entity.User{Role: entity.RoleAdmin}
+
+	albums, _, err := s.albumService.Query().SharedAlbums(true).All(ctx, admin)
+	if err != nil {
+		return []Issue{}, fmt.Errorf("failed to get albums: %w", err)
+	}
+
+	sort.Slice(albums, func(i, j int) bool { return albums[i].CreatedAt.Before(albums[j].CreatedAt) })
+
+	orphans, existing, err := s.orphanedBuckets(ctx, albums)
+	if err != nil {
+		return []Issue{}, err
+	}
+
+	issues := []Issue{}
+
+	for _, a := range albums {
+		if _, found := existing[a.Bucket]; !found {
+			issue := Issue{Kind: MissingBucket, Bucket: a.Bucket, AlbumID: a.ID}
+			if repair {
+				a, issue = s.repairBucket(ctx, a, issue, orphans)
+			}
+			issues = append(issues, issue)
+
+			// the objects of the album are checked once the album has a bucket again
+			if !issue.Repaired() {
+				continue
+			}
+		}
+
+		albumIssues, err := s.checkAlbum(ctx, a, repair)
+		if err != nil {
+			return issues, err
+		}
+		issues = append(issues, albumIssues...)
+	}
+
+	for _, bucket := range sortedKeys(orphans) {
+		issue := Issue{Kind: OrphanedBucket, Bucket: bucket}
+		if repair {
+			issue.Action = "marked as deleted"
+			issue.Err = s.mediaService.DeleteBucket(ctx, bucket)
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+// orphanedBuckets returns the labels of the buckets of no album mapped by bucket, and all the existing buckets.
+func (s *Service) orphanedBuckets(ctx context.Context, albums []entity.Album) (map[string]map[string]string, map[string]struct{}, error) {
+	buckets, err := s.mediaService.Buckets(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list buckets: %w", err)
+	}
+
+	used := make(map[string]struct{}, len(albums))
+	for _, a := range albums {
+		used[a.Bucket] = struct{}{}
+	}
+
+	existing := make(map[string]struct{}, len(buckets))
+	orphans := make(map[string]map[string]string)
+
+	for _, bucket := range buckets {
+		existing[bucket] = struct{}{}
+
+		if _, found := used[bucket]; found {
+			continue
+		}
+
+		labels, err := s.mediaService.BucketLabels(ctx, bucket)
+		if err != nil {
+			zap.S().Warnw("failed to get labels of bucket", "error", err, "bucket", bucket)
+			continue
+		}
+
+		if _, found := labels[nameLabel]; !found {
+			continue
+		}
+
+		// the bucket of a deleted album
+		if _, found := labels[deletedAtLabel]; found {
+			continue
+		}
+
+		orphans[bucket] = labels
+	}
+
+	return orphans, existing, nil
+}
+
+// repairBucket links the album to the only orphaned bucket labeled with the album's name and owner, or creates an empty bucket.
+// The linked bucket is not an orphan anymore.
+func (s *Service) repairBucket(ctx context.Context, a entity.Album, issue Issue, orphans map[string]map[string]string) (entity.Album, Issue) {
+	if bucket, found := matchBucket(a, orphans); found {
+		linked := a
+		linked.Bucket = bucket
+
+		updated, err := s.albumService.Update(ctx, linked)
+		if err != nil {
+			issue.Action, issue.Err = fmt.Sprintf("linked to bucket '%s'", bucket), err
+			return a, issue
+		}
+
+		delete(orphans, bucket)
+		issue.Action = fmt.Sprintf("linked to bucket '%s'", bucket)
+
+		return updated, issue
+	}
+
+	labels := map[string]string{
+		nameLabel:  a.Name,
+		dateLabel:  a.CreatedAt.Format(time.RFC3339),
+		ownerLabel: a.Owner,
+	}
+
+	issue.Action = "created empty bucket"
+	if a.Encrypted {
+		issue.Err = s.mediaService.CreateEncryptedBucket(ctx, a.Bucket, labels)
+	} else {
+		issue.Err = s.mediaService.CreateBucket(ctx, a.Bucket, labels)
+	}
+
+	return a, issue
+}
+
+// checkAlbum checks the thumbnails of the album's media, the metadata of the missing media, the expired trash and the uploads
+// never completed.
+func (s *Service) checkAlbum(ctx context.Context, a entity.Album, repair bool) ([]Issue, error) {
+	names, err := s.mediaService.Objects(ctx, a.Bucket)
+	if err != nil {
+		return []Issue{}, fmt.Errorf("failed to list bucket '%s' of album '%s': %w", a.Bucket, a.ID, err)
+	}
+
+	metadata, err := s.mediaService.MetadataFilenames(ctx, a.ID)
+	if err != nil {
+		return []Issue{}, fmt.Errorf("failed to get metadata of album '%s': %w", a.ID, err)
+	}
+
+	uploads, err := s.mediaService.StaleUploads(ctx, a.Bucket)
+	if err != nil {
+		return []Issue{}, fmt.Errorf("failed to list uploads of album '%s': %w", a.ID, err)
+	}
+
+	trash, err := s.mediaService.Trashed(ctx, a.Bucket)
+	if err != nil {
+		return []Issue{}, fmt.Errorf("failed to list trash of album '%s': %w", a.ID, err)
+	}
+
+	trashed := make([]string, 0, len(trash))
+	for _, t := range trash {
+		trashed = append(trashed, t.Filename)
+	}
+
+	missingThumbnails, orphanedThumbnails, orphanedMetadata := checkObjects(names, metadata, trashed)
+
+	issues := make([]Issue, 0, len(missingThumbnails)+len(orphanedThumbnails)+len(orphanedMetadata))
+
+	for _, name := range missingThumbnails {
+		issue := Issue{Kind: MissingThumbnail, Bucket: a.Bucket, AlbumID: a.ID, Name: name}
+		if repair {
+			issue.Action = "thumbnail created"
+			issue.Err = s.mediaService.CreateThumbnail(ctx, a.Bucket, name)
+		}
+		issues = append(issues, issue)
+	}
+
+	for _, name := range orphanedThumbnails {
+		issue := Issue{Kind: OrphanedThumbnail, Bucket: a.Bucket, AlbumID: a.ID, Name: name}
+		if repair {
+			issue.Action = "deleted"
+			issue.Err = s.mediaService.RemoveObject(ctx, a.Bucket, name)
+		}
+		issues = append(issues, issue)
+	}
+
+	for _, name := range orphanedMetadata {
+		issue := Issue{Kind: OrphanedMetadata, Bucket: a.Bucket, AlbumID: a.ID, Name: name}
+		if repair {
+			issue.Action = "deleted"
+			issue.Err = s.mediaService.DeleteMetadata(ctx, a.ID, name)
+		}
+		issues = append(issues, issue)
+	}
+
+	expired, kept := expiredTrash(names, trash)
+	for _, t := range expired {
+		issue := Issue{Kind: ExpiredTrash, Bucket: a.Bucket, AlbumID: a.ID, Name: t.Filename}
+		if repair {
+			issue.Action = "purged"
+			issue.Err = s.purge(ctx, a, t, kept)
+		}
+		issues = append(issues, issue)
+	}
+
+	for _, name := range uploads {
+		issue := Issue{Kind: StaleUpload, Bucket: a.Bucket, AlbumID: a.ID, Name: name}
+		if repair {
+			issue.Action = "deleted"
+			issue.Err = s.mediaService.CancelUpload(ctx, a.Bucket, name)
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+// purge removes the expired media from the trash, and its metadata unless the filename is kept.
+func (s *Service) purge(ctx context.Context, a entity.Album, t media.TrashedMedia, kept map[string]struct{}) error {
+	if err := s.mediaService.Purge(ctx, a.Bucket, t); err != nil {
+		return err
+	}
+
+	if _, found := kept[t.Filename]; found {
+		return nil
+	}
+
+	return s.mediaService.DeleteMetadata(ctx, a.ID, t.Filename)
+}
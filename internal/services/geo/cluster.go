@@ -0,0 +1,139 @@
+package geo
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/timeline"
+)
+
+const (
+	MaxZoom = 20
+	// cellsPerTile - number of grid cells on each side of a map tile.
+	// A tile at zoom z covers 360/2^z degrees of longitude.
+	cellsPerTile = 4
+)
+
+// Cluster groups the photos which are close to each other at a zoom level.
+type Cluster struct {
+	// Center - mean position of the photos
+	Center entity.GeoPoint
+	// Bounds - smallest box containing all the photos
+	Bounds entity.BoundingBox
+	Count  int
+	// Cover - most recent photo of the cluster
+	Cover timeline.Item
+}
+
+type cell struct {
+	x, y int
+}
+
+// Clusters groups the geolocated items found inside the box on a grid whose cells shrink as the zoom increases.
+// Items must be sorted most recent first. Clusters are sorted by size, biggest first.
+func Clusters(items []timeline.Item, bbox entity.BoundingBox, zoom int) []Cluster {
+	size := CellSize(zoom)
+
+	clusters := make(map[cell]*Cluster)
+	sums := make(map[cell]*entity.GeoPoint)
+
+	for _, item := range items {
+		p := item.Photo.Location
+		if p == nil || !bbox.Contains(*p) {
+			continue
+		}
+
+		key := cell{
+			x: int(math.Floor((p.Longitude + 180) / size)),
+			y: int(math.Floor((p.Latitude + 90) / size)),
+		}
+
+		c, found := clusters[key]
+		if !found {
+			clusters[key] = &Cluster{
+				Bounds: entity.BoundingBox{South: p.Latitude, West: p.Longitude, North: p.Latitude, East: p.Longitude},
+				Count:  1,
+				Cover:  item,
+			}
+			sums[key] = &entity.GeoPoint{Latitude: p.Latitude, Longitude: p.Longitude}
+
+			continue
+		}
+
+		c.Count++
+		c.Bounds = extend(c.Bounds, *p)
+		sums[key].Latitude += p.Latitude
+		sums[key].Longitude += p.Longitude
+	}
+
+	list := make([]Cluster, 0, len(clusters))
+	for key, c := range clusters {
+		c.Center = entity.GeoPoint{
+			Latitude:  sums[key].Latitude / float64(c.Count),
+			Longitude: sums[key].Longitude / float64(c.Count),
+		}
+		list = append(list, *c)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+
+		if list[i].Center.Latitude != list[j].Center.Latitude {
+			return list[i].Center.Latitude < list[j].Center.Latitude
+		}
+
+		return list[i].Center.Longitude < list[j].Center.Longitude
+	})
+
+	return list
+}
+
+// Bounds returns the smallest box containing all the geolocated media and the number of geolocated media.
+func Bounds(medias []entity.Media) (entity.BoundingBox, int) {
+	var (
+		bbox  entity.BoundingBox
+		count int
+	)
+
+	for _, m := range medias {
+		if m.Location == nil {
+			continue
+		}
+
+		p := *m.Location
+		if count == 0 {
+			bbox = entity.BoundingBox{South: p.Latitude, West: p.Longitude, North: p.Latitude, East: p.Longitude}
+		} else {
+			bbox = extend(bbox, p)
+		}
+
+		count++
+	}
+
+	return bbox, count
+}
+
+// CellSize returns the size in degrees of the grid cells at the zoom level.
+func CellSize(zoom int) float64 {
+	if zoom < 0 {
+		zoom = 0
+	}
+
+	if zoom > MaxZoom {
+		zoom = MaxZoom
+	}
+
+	return 360 / float64(int(1)<<zoom) / cellsPerTile
+}
+
+func extend(b entity.BoundingBox, p entity.GeoPoint) entity.BoundingBox {
+	return entity.BoundingBox{
+		South: math.Min(b.South, p.Latitude),
+		West:  math.Min(b.West, p.Longitude),
+		North: math.Max(b.North, p.Latitude),
+		East:  math.Max(b.East, p.Longitude),
+	}
+}
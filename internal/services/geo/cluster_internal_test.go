@@ -0,0 +1,73 @@
+package geo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/timeline"
+)
+
+func TestClusters(t *testing.T) {
+	items := []timeline.Item{
+		newItem("paris-1.jpg", 48.8566, 2.3522),
+		newItem("paris-2.jpg", 48.8606, 2.3376),
+		newItem("lyon.jpg", 45.7640, 4.8357),
+		newItem("tokyo.jpg", 35.6762, 139.6503),
+		{Photo: entity.Media{Filename: "nogps.jpg"}},
+	}
+
+	// at low zoom France is one cluster
+	clusters := Clusters(items, entity.World, 1)
+	assert.Len(t, clusters, 2)
+	assert.Equal(t, 3, clusters[0].Count)
+	assert.Equal(t, "paris-1.jpg", clusters[0].Cover.Photo.Filename)
+	assert.Equal(t, entity.BoundingBox{South: 45.7640, West: 2.3376, North: 48.8606, East: 4.8357}, clusters[0].Bounds)
+	assert.Equal(t, 1, clusters[1].Count)
+
+	// at high zoom Paris and Lyon are split
+	clusters = Clusters(items, entity.World, 8)
+	assert.Len(t, clusters, 3)
+	assert.Equal(t, 2, clusters[0].Count)
+	assert.InDelta(t, 48.8586, clusters[0].Center.Latitude, 0.0001)
+
+	// only the items inside the box are clustered
+	europe := entity.BoundingBox{South: 35, West: -10, North: 70, East: 40}
+	clusters = Clusters(items, europe, 1)
+	assert.Len(t, clusters, 1)
+	assert.Equal(t, 3, clusters[0].Count)
+
+	// box crossing the antimeridian
+	pacific := entity.BoundingBox{South: -60, West: 120, North: 60, East: -120}
+	clusters = Clusters(items, pacific, 1)
+	assert.Len(t, clusters, 1)
+	assert.Equal(t, "tokyo.jpg", clusters[0].Cover.Photo.Filename)
+}
+
+func TestBounds(t *testing.T) {
+	medias := []entity.Media{
+		{Filename: "nogps.jpg"},
+		{Filename: "a.jpg", Location: &entity.GeoPoint{Latitude: 10, Longitude: 20}},
+		{Filename: "b.jpg", Location: &entity.GeoPoint{Latitude: -5, Longitude: 30}},
+	}
+
+	bbox, count := Bounds(medias)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, entity.BoundingBox{South: -5, West: 20, North: 10, East: 30}, bbox)
+	assert.Equal(t, entity.GeoPoint{Latitude: 2.5, Longitude: 25}, bbox.Center())
+
+	_, count = Bounds(medias[:1])
+	assert.Equal(t, 0, count)
+}
+
+func newItem(filename string, lat, long float64) timeline.Item {
+	return timeline.Item{
+		Photo: entity.Media{
+			MediaType:  entity.Photo,
+			Filename:   filename,
+			CreateDate: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			Location:   &entity.GeoPoint{Latitude: lat, Longitude: long},
+		},
+	}
+}
@@ -0,0 +1,213 @@
+package geocoding
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+const (
+	// maxDistance - places farther than this distance in km are not used.
+	maxDistance = 100.0
+	earthRadius = 6371.0
+	// kmPerDegree - length of a degree of latitude in km.
+	kmPerDegree = 111.2
+)
+
+var (
+	//go:embed data/cities.txt
+	defaultCities []byte
+	//go:embed data/admin1CodesASCII.txt
+	defaultRegions []byte
+	//go:embed data/countryInfo.txt
+	defaultCountries []byte
+)
+
+type city struct {
+	name    string
+	lat     float64
+	long    float64
+	country string
+	region  string
+}
+
+type cell struct {
+	lat, long int
+}
+
+// Geocoder finds the nearest city of a location. It works offline using the GeoNames datasets.
+// A sample of the datasets is bundled with the binary. The full datasets can be loaded with LoadFiles:
+// the cities of more than 15000 inhabitants (cities15000.txt) are a good trade-off between precision and memory.
+type Geocoder struct {
+	cities []city
+	// index of the cities by 1 degree cells
+	index     map[cell][]int
+	regions   map[string]string
+	countries map[string]string
+}
+
+// Default returns a geocoder using the sample of the GeoNames datasets bundled with the binary.
+func Default() (*Geocoder, error) {
+	return Load(bytes.NewReader(defaultCities), bytes.NewReader(defaultRegions), bytes.NewReader(defaultCountries))
+}
+
+// LoadFiles returns a geocoder using the GeoNames files (e.g. cities15000.txt, admin1CodesASCII.txt and countryInfo.txt).
+func LoadFiles(citiesFile, regionsFile, countriesFile string) (*Geocoder, error) {
+	readers := make([]io.Reader, 0, 3)
+	for _, f := range []string{citiesFile, regionsFile, countriesFile} {
+		file, err := os.Open(f)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		readers = append(readers, file)
+	}
+
+	return Load(readers[0], readers[1], readers[2])
+}
+
+// Load reads the cities, the first level administrative divisions and the countries in GeoNames format.
+func Load(cities, regions, countries io.Reader) (*Geocoder, error) {
+	g := &Geocoder{
+		index:     make(map[cell][]int),
+		regions:   make(map[string]string),
+		countries: make(map[string]string),
+	}
+
+	// geonameid, name, asciiname, alternatenames, latitude, longitude, feature class, feature code, country code, cc2, admin1 code, ...
+	err := readTSV(cities, 11, func(fields []string) error {
+		lat, err := strconv.ParseFloat(fields[4], 64)
+		if err != nil {
+			return fmt.Errorf("invalid latitude '%s' of city '%s'", fields[4], fields[1])
+		}
+
+		long, err := strconv.ParseFloat(fields[5], 64)
+		if err != nil {
+			return fmt.Errorf("invalid longitude '%s' of city '%s'", fields[5], fields[1])
+		}
+
+		c := city{name: fields[1], lat: lat, long: long, country: fields[8], region: fields[10]}
+		key := cellOf(lat, long)
+		g.index[key] = append(g.index[key], len(g.cities))
+		g.cities = append(g.cities, c)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cities: %w", err)
+	}
+
+	// code (country.admin1), name, asciiname, geonameid
+	err = readTSV(regions, 2, func(fields []string) error {
+		g.regions[fields[0]] = fields[1]
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read regions: %w", err)
+	}
+
+	// ISO, ISO3, ISO-Numeric, fips, Country, ...
+	err = readTSV(countries, 5, func(fields []string) error {
+		g.countries[fields[0]] = fields[4]
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read countries: %w", err)
+	}
+
+	return g, nil
+}
+
+// Lookup returns the place of the nearest city. It returns false if there is no city close enough.
+func (g *Geocoder) Lookup(p entity.GeoPoint) (entity.Place, bool) {
+	latRadius := int(math.Ceil(maxDistance / kmPerDegree))
+
+	// meridians get closer to each other toward the poles
+	longRadius := 180
+	if cos := math.Cos(p.Latitude * math.Pi / 180); cos > 0.01 {
+		longRadius = int(math.Min(180, math.Ceil(maxDistance/(kmPerDegree*cos))))
+	}
+
+	origin := cellOf(p.Latitude, p.Longitude)
+	nearest, nearestDistance := -1, maxDistance
+
+	for lat := origin.lat - latRadius; lat <= origin.lat+latRadius; lat++ {
+		for long := origin.long - longRadius; long <= origin.long+longRadius; long++ {
+			for _, i := range g.index[cell{lat, wrap(long)}] {
+				c := g.cities[i]
+				if d := distance(p.Latitude, p.Longitude, c.lat, c.long); d <= nearestDistance {
+					nearest, nearestDistance = i, d
+				}
+			}
+		}
+	}
+
+	if nearest < 0 {
+		return entity.Place{}, false
+	}
+
+	c := g.cities[nearest]
+
+	return entity.Place{
+		City:    c.name,
+		Region:  g.regions[fmt.Sprintf("%s.%s", c.country, c.region)],
+		Country: g.countries[c.country],
+	}, true
+}
+
+func cellOf(lat, long float64) cell {
+	return cell{int(math.Floor(lat)), wrap(int(math.Floor(long)))}
+}
+
+// wrap returns the longitude cell in [-180, 180).
+func wrap(long int) int {
+	return ((long+180)%360+360)%360 - 180
+}
+
+// distance returns the great-circle distance in km.
+func distance(lat1, long1, lat2, long2 float64) float64 {
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLong := (long2 - long1) * toRad
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLong/2)*math.Sin(dLong/2)
+
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// readTSV calls fn with the fields of each line. Empty lines and comments are skipped.
+func readTSV(r io.Reader, minFields int, fn func(fields []string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		text := scanner.Text()
+		if len(strings.TrimSpace(text)) == 0 || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		if len(fields) < minFields {
+			return fmt.Errorf("line %d: expected at least %d fields got %d", line, minFields, len(fields))
+		}
+
+		if err := fn(fields); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,73 @@
+package geocoding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+func TestLookup(t *testing.T) {
+	// sample of the GeoNames datasets bundled with the binary
+	g, err := Default()
+	assert.Nil(t, err)
+
+	loaded, err := LoadFiles("data/cities.txt", "data/admin1CodesASCII.txt", "data/countryInfo.txt")
+	assert.Nil(t, err)
+	assert.Equal(t, g, loaded, "the files must be loaded like the bundled sample")
+
+	data := []struct {
+		point    entity.GeoPoint
+		expected entity.Place
+		found    bool
+	}{
+		{
+			// eiffel tower
+			point:    entity.GeoPoint{Latitude: 48.8584, Longitude: 2.2945},
+			expected: entity.Place{City: "Paris", Region: "Île-de-France", Country: "France"},
+			found:    true,
+		},
+		{
+			// aiguille du midi is closer to Chamonix than to Annecy
+			point:    entity.GeoPoint{Latitude: 45.8786, Longitude: 6.8873},
+			expected: entity.Place{City: "Chamonix-Mont-Blanc", Region: "Auvergne-Rhône-Alpes", Country: "France"},
+			found:    true,
+		},
+		{
+			point:    entity.GeoPoint{Latitude: -33.8568, Longitude: 151.2153},
+			expected: entity.Place{City: "Sydney", Region: "New South Wales", Country: "Australia"},
+			found:    true,
+		},
+		{
+			// middle of the atlantic ocean
+			point: entity.GeoPoint{Latitude: 30, Longitude: -40},
+			found: false,
+		},
+	}
+
+	for idx, d := range data {
+		place, found := g.Lookup(d.point)
+		assert.Equal(t, d.found, found, "test %d", idx)
+		assert.Equal(t, d.expected, place, "test %d", idx)
+	}
+}
+
+func TestLookupAcrossAntimeridian(t *testing.T) {
+	cities := "1\tEast\tEast\t\t-17.0\t179.9\tP\tPPL\tFJ\t\t01\n" +
+		"2\tFar\tFar\t\t-17.0\t170.0\tP\tPPL\tFJ\t\t01\n"
+	regions := "FJ.01\tCentral\tCentral\t0\n"
+	countries := "#ISO\tISO3\tISO-Numeric\tfips\tCountry\nFJ\tFJI\t242\tFJ\tFiji\n"
+
+	g, err := Load(strings.NewReader(cities), strings.NewReader(regions), strings.NewReader(countries))
+	assert.Nil(t, err)
+
+	place, found := g.Lookup(entity.GeoPoint{Latitude: -17.0, Longitude: -179.9})
+	assert.True(t, found)
+	assert.Equal(t, entity.Place{City: "East", Region: "Central", Country: "Fiji"}, place)
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	_, err := Load(strings.NewReader("1\tParis\tParis\n"), strings.NewReader(""), strings.NewReader(""))
+	assert.NotNil(t, err)
+}
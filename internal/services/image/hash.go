@@ -0,0 +1,44 @@
+package image
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+
+	"github.com/disintegration/imaging"
+)
+
+// DHash computes the difference hash of the image. Each bit tells if a pixel is brighter than its right neighbour
+// in a 9x8 grayscale version of the image, so resized or re-encoded copies have the same or a close hash.
+func DHash(r io.ReadSeeker) (uint64, error) {
+	if _, err := r.Seek(0, 0); err != nil {
+		return 0, fmt.Errorf("failed to hash image: %v", err)
+	}
+
+	img, err := imaging.Decode(r, imaging.AutoOrientation(true))
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode image: %v", err)
+	}
+
+	small := imaging.Grayscale(imaging.Resize(img, 9, 8, imaging.Box))
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			left := small.Pix[small.PixOffset(x, y)]
+			right := small.Pix[small.PixOffset(x+1, y)]
+
+			hash <<= 1
+			if left > right {
+				hash |= 1
+			}
+		}
+	}
+
+	return hash, nil
+}
+
+// Distance returns the number of different bits between two hashes.
+func Distance(h1, h2 uint64) int {
+	return bits.OnesCount64(h1 ^ h2)
+}
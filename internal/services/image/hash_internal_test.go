@@ -0,0 +1,52 @@
+package image
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDHash(t *testing.T) {
+	gradient := newImage(360, 240, func(x, y int) uint8 { return uint8(x * 255 / 360) })
+	hash, err := DHash(encode(t, gradient))
+	assert.Nil(t, err)
+
+	// a resized copy has the same hash
+	resized, err := DHash(encode(t, imaging.Resize(gradient, 120, 80, imaging.Lanczos)))
+	assert.Nil(t, err)
+	assert.LessOrEqual(t, Distance(hash, resized), 2)
+
+	// a different image has a distant hash
+	reversed := newImage(360, 240, func(x, y int) uint8 { return uint8(255 - x*255/360) })
+	other, err := DHash(encode(t, reversed))
+	assert.Nil(t, err)
+	assert.Greater(t, Distance(hash, other), 32)
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, Distance(0xff, 0xff))
+	assert.Equal(t, 2, Distance(0b1010, 0b0110))
+	assert.Equal(t, 64, Distance(0, ^uint64(0)))
+}
+
+func newImage(width, height int, gray func(x, y int) uint8) image.Image {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetGray(x, y, color.Gray{Y: gray(x, y)})
+		}
+	}
+
+	return img
+}
+
+func encode(t *testing.T, img image.Image) *bytes.Reader {
+	var buf bytes.Buffer
+	assert.Nil(t, imaging.Encode(&buf, img, imaging.JPEG, imaging.JPEGQuality(90)))
+
+	return bytes.NewReader(buf.Bytes())
+}
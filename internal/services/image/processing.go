@@ -5,12 +5,26 @@ import (
 	_ "image/gif"
 	_ "image/jpeg"
 	"io"
+	"strconv"
 
 	"github.com/disintegration/imaging"
 	"github.com/rwcarlsen/goexif/exif"
 	"go.uber.org/zap"
 )
 
+// keys of the metadata returned by Metadata.
+const (
+	ModelKey     = "model"
+	DateKey      = "date"
+	LatitudeKey  = "latitude"
+	LongitudeKey = "longitude"
+	// HashKey is the key of the perceptual hash saved with the photo. It is not returned by Metadata.
+	HashKey = "phash"
+)
+
+// DateFormat is the format of the date returned by Metadata.
+const DateFormat = "2006:01:02 15:04:05"
+
 // Process encode the image as jpg and create a thumbnail.
 func Process(r io.ReadSeeker, imgWriter io.Writer) error {
 	if _, err := r.Seek(0, 0); err != nil {
@@ -74,7 +88,7 @@ func Metadata(r io.ReadSeeker) (map[string]string, error) {
 		return nil, fmt.Errorf("%w parse camera model", err)
 	}
 
-	metadata["model"] = model
+	metadata[ModelKey] = model
 
 	tm, err := x.Get(exif.DateTime)
 	if err != nil {
@@ -86,7 +100,13 @@ func Metadata(r io.ReadSeeker) (map[string]string, error) {
 		return nil, fmt.Errorf("%w parse date time", err)
 	}
 
-	metadata["date"] = date
+	metadata[DateKey] = date
+
+	// gps data is optional
+	if lat, long, err := x.LatLong(); err == nil {
+		metadata[LatitudeKey] = strconv.FormatFloat(lat, 'f', 6, 64)
+		metadata[LongitudeKey] = strconv.FormatFloat(long, 'f', 6, 64)
+	}
 
 	return metadata, nil
 }
@@ -0,0 +1,252 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/album"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"go.uber.org/zap"
+)
+
+type Options struct {
+	// Owner - username of the owner of the created albums
+	Owner string
+	// Workers - number of photos uploaded at the same time
+	Workers int
+	// DryRun - only report what would be imported
+	DryRun bool
+	// Progress - receives a line for each imported file. Nothing is written if nil.
+	Progress io.Writer
+}
+
+// Report sums up an import.
+type Report struct {
+	// Albums - number of created albums
+	Albums int
+	// Imported - number of imported files
+	Imported int
+	// Skipped - number of files imported by a previous run
+	Skipped int
+	// Failed - files which cannot be imported mapped to the error
+	Failed map[string]error
+}
+
+type Importer struct {
+	albumService *album.Service
+	mediaService *media.Service
+	state        *State
+	opts         Options
+}
+
+func New(albumService *album.Service, mediaService *media.Service, state *State, opts Options) *Importer {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+
+	return &Importer{albumService, mediaService, state, opts}
+}
+
+type job struct {
+	album entity.Album
+	file  string
+	photo *Photo
+}
+
+// Run imports the folders found by Scan. Each folder is imported into an album created on the first run.
+// Files which fail are reported and the import goes on. They are imported again by the next run.
+func (i *Importer) Run(ctx context.Context, fsys fs.FS, folders []Folder) (Report, error) {
+	report := Report{Failed: make(map[string]error)}
+
+	jobs := []job{}
+	albums := make(map[string]entity.Album)
+	for _, f := range folders {
+		a, created, err := i.album(ctx, f)
+		if err != nil {
+			return report, err
+		}
+
+		if created {
+			report.Albums++
+		}
+
+		pending := 0
+		for _, file := range f.Files {
+			if i.state.IsImported(file) {
+				report.Skipped++
+				continue
+			}
+
+			j := job{album: a, file: file}
+			if p, found := f.Photos[file]; found {
+				j.photo = &p
+			}
+
+			jobs = append(jobs, j)
+			pending++
+		}
+
+		if pending > 0 {
+			albums[a.ID] = a
+		}
+
+		i.progressf("%s: %d photos to import into album '%s'\n", f.Path, pending, f.Name)
+	}
+
+	if i.opts.DryRun {
+		report.Imported = len(jobs)
+		return report, nil
+	}
+
+	var (
+		lock  sync.Mutex
+		done  int
+		queue = make(chan job)
+		wg    sync.WaitGroup
+	)
+
+	for w := 0; w < i.opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := range queue {
+				err := i.importFile(ctx, fsys, j)
+
+				lock.Lock()
+				done++
+				if err != nil {
+					report.Failed[j.file] = err
+					i.progressf("[%d/%d] %s: %v\n", done, len(jobs), j.file, err)
+				} else {
+					report.Imported++
+					i.progressf("[%d/%d] %s\n", done, len(jobs), j.file)
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	for _, j := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- j
+	}
+	close(queue)
+	wg.Wait()
+
+	// the place and the cover of the albums change with their photos
+	for _, a := range albums {
+		i.refresh(ctx, a.ID)
+	}
+
+	return report, ctx.Err()
+}
+
+// album returns the album of the folder. The album is created if the folder has not been imported before.
+func (i *Importer) album(ctx context.Context, f Folder) (entity.Album, bool, error) {
+	if id, found := i.state.AlbumID(f.Path); found {
+		a, err := i.albumService.Query().First(ctx, id)
+		if err != nil {
+			return entity.Album{}, false, fmt.Errorf("failed to get album '%s' of folder '%s': %v", id, f.Path, err)
+		}
+
+		return a, false, nil
+	}
+
+	if i.opts.DryRun {
+		return entity.Album{Name: f.Name}, true, nil
+	}
+
+	a, err := i.albumService.Create(ctx, entity.Album{
+		Name:        f.Name,
+		CreatedAt:   time.Now(),
+		Owner:       i.opts.Owner,
+		Description: f.Description,
+	})
+	if err != nil {
+		return entity.Album{}, false, fmt.Errorf("failed to create album for folder '%s': %v", f.Path, err)
+	}
+
+	if err := i.state.SetAlbumID(f.Path, a.ID); err != nil {
+		return entity.Album{}, false, err
+	}
+
+	return a, true, nil
+}
+
+func (i *Importer) importFile(ctx context.Context, fsys fs.FS, j job) error {
+	f, err := fsys.Open(j.file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r, ok := f.(io.ReadSeeker)
+	if !ok {
+		content, err := io.ReadAll(f)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(content)
+	}
+
+	if j.photo == nil {
+		if err := i.mediaService.Save(ctx, j.album.Bucket, path.Base(j.file), r, media.Photo); err != nil {
+			return err
+		}
+
+		return i.state.SetImported(j.file)
+	}
+
+	photoName, err := i.mediaService.SaveWithMetadata(ctx, j.album.Bucket, path.Base(j.file), r, j.photo.CreateDate, j.photo.Location)
+	if err != nil {
+		return err
+	}
+
+	if len(j.photo.Caption) > 0 {
+		if err := i.mediaService.UpdateMetadata(ctx, j.album.ID, entity.Media{Filename: photoName, Caption: j.photo.Caption}); err != nil {
+			return fmt.Errorf("failed to save caption: %v", err)
+		}
+	}
+
+	return i.state.SetImported(j.file)
+}
+
+// refresh updates the place of the album and picks its cover. Failures are only logged.
+func (i *Importer) refresh(ctx context.Context, albumID string) {
+	a, err := i.albumService.Query().First(ctx, albumID)
+	if err != nil {
+		zap.S().Warnw("failed to get album", "error", err, "album_id", albumID)
+		return
+	}
+
+	a, err = i.albumService.Locate(ctx, a)
+	if err != nil {
+		zap.S().Warnw("failed to locate album", "error", err, "album_id", albumID)
+	}
+
+	if len(a.Thumbnail) > 0 {
+		return
+	}
+
+	if _, err := i.albumService.PickThumbnail(ctx, a); err != nil {
+		zap.S().Warnw("failed to pick thumbnail", "error", err, "album_id", albumID)
+	}
+}
+
+func (i *Importer) progressf(format string, args ...interface{}) {
+	if i.opts.Progress == nil {
+		return
+	}
+
+	fmt.Fprintf(i.opts.Progress, format, args...)
+}
@@ -0,0 +1,62 @@
+package importer
+
+import (
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScan(t *testing.T) {
+	fsys := fstest.MapFS{
+		"root.jpg":                  {},
+		"2019/summer/a.JPG":         {},
+		"2019/summer/b.jpeg":        {},
+		"2019/summer/notes.txt":     {},
+		"2019/winter/c.png":         {},
+		"2019/empty/readme.md":      {},
+		".thumbnails/d.jpg":         {},
+		"2019/summer/.hidden.jpg":   {},
+		"2020/a.jpg":                {},
+		"2020/nested/deeper/e.jpeg": {},
+	}
+
+	folders, err := Scan(fsys, "nas")
+	assert.Nil(t, err)
+
+	paths := []string{}
+	for _, f := range folders {
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{".", "2019/summer", "2019/winter", "2020", "2020/nested/deeper"}, paths)
+
+	assert.Equal(t, "nas", folders[0].Name)
+	assert.Equal(t, []string{"root.jpg"}, folders[0].Files)
+	assert.Equal(t, "2019 - summer", folders[1].Name)
+	assert.Equal(t, []string{"2019/summer/a.JPG", "2019/summer/b.jpeg"}, folders[1].Files)
+	assert.Equal(t, "2020 - nested - deeper", folders[4].Name)
+}
+
+func TestState(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "import.state")
+
+	s, err := OpenState(filename)
+	assert.Nil(t, err)
+
+	assert.Nil(t, s.SetAlbumID("2019/summer", "album1"))
+	assert.Nil(t, s.SetImported("2019/summer/a.jpg"))
+	assert.True(t, s.IsImported("2019/summer/a.jpg"))
+	assert.Nil(t, s.Close())
+
+	// resume
+	s, err = OpenState(filename)
+	assert.Nil(t, err)
+	defer s.Close()
+
+	id, found := s.AlbumID("2019/summer")
+	assert.True(t, found)
+	assert.Equal(t, "album1", id)
+	assert.True(t, s.IsImported("2019/summer/a.jpg"))
+	assert.False(t, s.IsImported("2019/summer/b.jpg"))
+}
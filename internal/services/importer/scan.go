@@ -0,0 +1,110 @@
+package importer
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+// extensions of the imported files.
+var extensions = map[string]struct{}{
+	".jpg":  {},
+	".jpeg": {},
+	".png":  {},
+}
+
+// Folder is a directory holding photos. Each folder is imported as an album.
+type Folder struct {
+	// Path - path of the folder relative to the imported directory. The imported directory itself is ".".
+	Path string
+	// Name - name of the album
+	Name string
+	// Description - description of the album (optional)
+	Description string
+	// Files - paths of the photos relative to the imported directory
+	Files []string
+	// Photos - metadata of the photos known from another source than their EXIF data mapped by path (optional)
+	Photos map[string]Photo
+}
+
+// Photo holds the metadata of a photo found next to it.
+type Photo struct {
+	Caption string
+	// CreateDate - capture date. It overrides the date of the EXIF data if set.
+	CreateDate time.Time
+	// Location - where the photo has been captured. It overrides the location of the EXIF data if set.
+	Location *entity.GeoPoint
+}
+
+// Scan walks the directory tree and returns the folders holding photos sorted by path.
+// Hidden files and directories are skipped. rootName is the name of the album made of the photos at the root of the tree.
+func Scan(fsys fs.FS, rootName string) ([]Folder, error) {
+	folders := make(map[string]*Folder)
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if p != "." && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		if !Supported(p) {
+			return nil
+		}
+
+		dir := path.Dir(p)
+
+		f, found := folders[dir]
+		if !found {
+			f = &Folder{Path: dir, Name: AlbumName(dir, rootName)}
+			folders[dir] = f
+		}
+
+		f.Files = append(f.Files, p)
+
+		return nil
+	})
+	if err != nil {
+		return []Folder{}, err
+	}
+
+	sorted := make([]Folder, 0, len(folders))
+	for _, f := range folders {
+		sort.Strings(f.Files)
+		sorted = append(sorted, *f)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Path < sorted[j].Path
+	})
+
+	return sorted, nil
+}
+
+// Supported returns true if the file can be imported.
+func Supported(name string) bool {
+	_, found := extensions[strings.ToLower(path.Ext(name))]
+	return found
+}
+
+// AlbumName returns the name of the album of the folder. Nested folders are joined with " - ".
+func AlbumName(dir, rootName string) string {
+	if dir == "." {
+		return rootName
+	}
+
+	return strings.ReplaceAll(dir, "/", " - ")
+}
@@ -0,0 +1,171 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// State remembers the albums created and the files imported so an interrupted import can be resumed.
+// It is kept in a file where each change is appended as a json line so saving stays cheap with a large number of files.
+type State struct {
+	lock     sync.Mutex
+	file     *os.File
+	albums   map[string]string
+	imported map[string]struct{}
+}
+
+type stateEntry struct {
+	Folder  string `json:"folder,omitempty"`
+	AlbumID string `json:"album_id,omitempty"`
+	File    string `json:"file,omitempty"`
+}
+
+// OpenState reads the state file and opens it to append the next changes. The file is created if it does not exist.
+func OpenState(filename string) (*State, error) {
+	s := &State{
+		albums:   make(map[string]string),
+		imported: make(map[string]struct{}),
+	}
+
+	if err := s.read(filename); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state file '%s': %v", filename, err)
+	}
+
+	s.file = f
+
+	// start on a new line if the last change has been cut
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		if _, err := f.Write([]byte{'\n'}); err != nil {
+			return nil, fmt.Errorf("failed to write state file '%s': %v", filename, err)
+		}
+	}
+
+	return s, nil
+}
+
+// ReadState reads the state file without saving the next changes. It is used by dry runs.
+// The state is empty if the file does not exist.
+func ReadState(filename string) (*State, error) {
+	s := &State{
+		albums:   make(map[string]string),
+		imported: make(map[string]struct{}),
+	}
+
+	if err := s.read(filename); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *State) read(filename string) error {
+	f, err := os.Open(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read state file '%s': %v", filename, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var e stateEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// a line cut by a crash only loses one change. The file is imported again.
+			zap.S().Warnw("skip invalid line of state file", "error", err, "filename", filename, "line", line)
+			continue
+		}
+
+		s.apply(e)
+	}
+
+	return scanner.Err()
+}
+
+func (s *State) apply(e stateEntry) {
+	if len(e.Folder) > 0 {
+		s.albums[e.Folder] = e.AlbumID
+	}
+
+	if len(e.File) > 0 {
+		s.imported[e.File] = struct{}{}
+	}
+}
+
+// AlbumID returns the id of the album created for the folder.
+func (s *State) AlbumID(folder string) (string, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	id, found := s.albums[folder]
+
+	return id, found
+}
+
+// SetAlbumID saves the id of the album created for the folder.
+func (s *State) SetAlbumID(folder, albumID string) error {
+	return s.append(stateEntry{Folder: folder, AlbumID: albumID})
+}
+
+// IsImported returns true if the file has been imported.
+func (s *State) IsImported(file string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, found := s.imported[file]
+
+	return found
+}
+
+// SetImported saves that the file has been imported.
+func (s *State) SetImported(file string) error {
+	return s.append(stateEntry{File: file})
+}
+
+// Close closes the state file.
+func (s *State) Close() error {
+	if s.file == nil {
+		return nil
+	}
+
+	return s.file.Close()
+}
+
+func (s *State) append(e stateEntry) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.apply(e)
+
+	if s.file == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to save state: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package media
+
+import "github.com/tupyy/gophoto/internal/entity"
+
+// PickCover returns the best rated photo. Ratings are mapped by filename.
+// Photos with the same rating are ranked by upload date, first uploaded first.
+// It returns false if there is no photo.
+func PickCover(medias []entity.Media, ratings map[string]int) (entity.Media, bool) {
+	var (
+		best  entity.Media
+		found bool
+	)
+
+	for _, m := range medias {
+		if m.MediaType != entity.Photo {
+			continue
+		}
+
+		if !found {
+			best, found = m, true
+			continue
+		}
+
+		r, bestRating := ratings[m.Filename], ratings[best.Filename]
+		switch {
+		case r > bestRating:
+			best = m
+		case r == bestRating && m.UploadDate.Before(best.UploadDate):
+			best = m
+		case r == bestRating && m.UploadDate.Equal(best.UploadDate) && m.Filename < best.Filename:
+			best = m
+		}
+	}
+
+	return best, found
+}
+
+// CoverName returns the name of the object used as album cover for the media.
+// The thumbnail is preferred because it is smaller.
+func CoverName(m entity.Media) string {
+	if len(m.Thumbnail) > 0 {
+		return m.Thumbnail
+	}
+
+	return m.Filename
+}
+
+// IsCover returns true if the media is used as cover.
+func IsCover(m entity.Media, cover string) bool {
+	return len(cover) > 0 && (m.Filename == cover || m.Thumbnail == cover)
+}
@@ -0,0 +1,46 @@
+package media
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+func TestPickCover(t *testing.T) {
+	medias := []entity.Media{
+		{Filename: "photos/b.jpg", MediaType: entity.Photo, UploadDate: createDate(2022, 1, 2)},
+		{Filename: "photos/a.jpg", MediaType: entity.Photo, UploadDate: createDate(2022, 1, 2)},
+		{Filename: "photos/c.jpg", MediaType: entity.Photo, UploadDate: createDate(2022, 1, 3)},
+		{Filename: "videos/d.mp4", MediaType: entity.Video, UploadDate: createDate(2022, 1, 1)},
+	}
+
+	data := []struct {
+		ratings  map[string]int
+		expected string
+	}{
+		{
+			// first uploaded photo. same upload date are sorted by filename.
+			ratings:  map[string]int{},
+			expected: "photos/a.jpg",
+		},
+		{
+			ratings:  map[string]int{"photos/c.jpg": 2, "photos/b.jpg": 1},
+			expected: "photos/c.jpg",
+		},
+		{
+			// videos cannot be used as cover
+			ratings:  map[string]int{"videos/d.mp4": 10, "photos/b.jpg": 1},
+			expected: "photos/b.jpg",
+		},
+	}
+
+	for idx, d := range data {
+		cover, found := PickCover(medias, d.ratings)
+		assert.True(t, found, "test %d", idx)
+		assert.Equal(t, d.expected, cover.Filename, "test %d", idx)
+	}
+
+	_, found := PickCover([]entity.Media{}, map[string]int{})
+	assert.False(t, found)
+}
@@ -0,0 +1,446 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services"
+	"github.com/tupyy/gophoto/internal/services/image"
+	"go.uber.org/zap"
+)
+
+// DataKeyLabel is the label of an encrypted container holding its data key wrapped by a master key.
+const DataKeyLabel = "album/data_key"
+
+const (
+	dataKeySize = 32
+	// encryptedMagic starts the encrypted objects. It is followed by the nonce and the sealed content.
+	encryptedMagic = "GPE1"
+	// encryptedOverhead - size added by the encryption to the objects
+	encryptedOverhead = len(encryptedMagic) + 12 + 16
+	// sealedMetadataKey is the only metadata of the encrypted objects. It holds their metadata encrypted.
+	sealedMetadataKey = "sealed"
+)
+
+// KeyWrapper protects the data keys of the encrypted containers with master keys.
+type KeyWrapper interface {
+	// Seal wraps the data with the active master key.
+	Seal(data []byte) (string, error)
+	// Open unwraps the data wrapped with any of the master keys.
+	Open(sealed string) ([]byte, error)
+	// IsActive returns true if the data has been wrapped with the active master key.
+	IsActive(sealed string) bool
+}
+
+// dataKey is the data key of a container. It is nil for the containers which are not encrypted.
+type dataKey struct {
+	aead cipher.AEAD
+}
+
+// cryptStorage encrypts the objects of the containers labeled with a data key using AES-256-GCM. The objects of the other
+// containers are left untouched. The metadata of the objects (date, location and hash of the photos) is encrypted too and
+// both are bound to the container and the name of the object so a sealed object cannot be swapped with another one.
+// The labels of the containers are not encrypted.
+type cryptStorage struct {
+	Storage
+	// keys - unwrap the data keys. The encrypted containers cannot be read nor written if nil.
+	keys KeyWrapper
+
+	lock     sync.Mutex
+	dataKeys map[string]*dataKey
+}
+
+// presigningCryptStorage issues pre-signed urls for the containers which are not encrypted.
+type presigningCryptStorage struct {
+	*cryptStorage
+	presigner Presigner
+}
+
+func newCryptStorage(storage Storage, keys KeyWrapper) Storage {
+	c := &cryptStorage{Storage: storage, keys: keys, dataKeys: make(map[string]*dataKey)}
+
+	if presigner, ok := storage.(Presigner); ok {
+		return &presigningCryptStorage{c, presigner}
+	}
+
+	return c
+}
+
+func (c *cryptStorage) Get(ctx context.Context, container, name string) (io.ReadSeeker, map[string]string, error) {
+	dk, err := c.dataKey(ctx, container)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, metadata, err := c.Storage.Get(ctx, container, name)
+	if err != nil || dk == nil {
+		return r, metadata, err
+	}
+
+	if closer, ok := r.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	sealed, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := decrypt(dk.aead, sealed, additionalData(container, name))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt '%s' of '%s': %w", name, container, err)
+	}
+
+	metadata, err = openMetadata(dk.aead, container, name, metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bytes.NewReader(content), metadata, nil
+}
+
+func (c *cryptStorage) Put(ctx context.Context, container, name string, size int64, r io.Reader, metadata map[string]string) error {
+	dk, err := c.dataKey(ctx, container)
+	if err != nil {
+		return err
+	}
+
+	if dk == nil {
+		return c.Storage.Put(ctx, container, name, size, r, metadata)
+	}
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	sealed, err := encrypt(dk.aead, content, additionalData(container, name))
+	if err != nil {
+		return err
+	}
+
+	sealedMetadata, err := sealMetadata(dk.aead, container, name, metadata)
+	if err != nil {
+		return err
+	}
+
+	return c.Storage.Put(ctx, container, name, int64(len(sealed)), bytes.NewReader(sealed), sealedMetadata)
+}
+
+// Size returns the size of the object before encryption.
+func (c *cryptStorage) Size(ctx context.Context, container, name string) (int64, error) {
+	dk, err := c.dataKey(ctx, container)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := c.Storage.Size(ctx, container, name)
+	if err != nil || dk == nil {
+		return size, err
+	}
+
+	if size < int64(encryptedOverhead) {
+		return 0, fmt.Errorf("object '%s/%s' is too short to be encrypted", container, name)
+	}
+
+	return size - int64(encryptedOverhead), nil
+}
+
+// List returns the size and the metadata of the media before encryption.
+func (c *cryptStorage) List(ctx context.Context, container string) ([]entity.Media, error) {
+	dk, err := c.dataKey(ctx, container)
+	if err != nil {
+		return nil, err
+	}
+
+	medias, err := c.Storage.List(ctx, container)
+	if err != nil || dk == nil {
+		return medias, err
+	}
+
+	for i := range medias {
+		if medias[i].Size >= int64(encryptedOverhead) {
+			medias[i].Size -= int64(encryptedOverhead)
+		}
+
+		metadata, err := openMetadata(dk.aead, container, medias[i].Filename, medias[i].Metadata)
+		if err != nil {
+			// the media is listed without its metadata
+			zap.S().Errorw("failed to decrypt metadata", "error", err, "container", container, "filename", medias[i].Filename)
+			continue
+		}
+		fillMetadata(&medias[i], metadata)
+	}
+
+	return medias, nil
+}
+
+// Copy copies the object as is if both containers are not encrypted, otherwise the object is decrypted and encrypted again:
+// the encrypted objects are bound to their container and name.
+func (c *cryptStorage) Copy(ctx context.Context, srcContainer, srcName, dstContainer, dstName string) error {
+	src, err := c.dataKey(ctx, srcContainer)
+	if err != nil {
+		return err
+	}
+
+	dst, err := c.dataKey(ctx, dstContainer)
+	if err != nil {
+		return err
+	}
+
+	if src == nil && dst == nil {
+		return c.Storage.Copy(ctx, srcContainer, srcName, dstContainer, dstName)
+	}
+
+	r, metadata, err := c.Get(ctx, srcContainer, srcName)
+	if err != nil {
+		return err
+	}
+
+	if closer, ok := r.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return c.Put(ctx, dstContainer, dstName, size, r, metadata)
+}
+
+func (c *cryptStorage) CreateContainer(ctx context.Context, container string, labels map[string]string) error {
+	defer c.forget(container)
+	return c.Storage.CreateContainer(ctx, container, labels)
+}
+
+func (c *cryptStorage) DeleteContainer(ctx context.Context, container string) error {
+	defer c.forget(container)
+	return c.Storage.DeleteContainer(ctx, container)
+}
+
+func (c *cryptStorage) SetLabels(ctx context.Context, container string, labels map[string]string) error {
+	defer c.forget(container)
+	return c.Storage.SetLabels(ctx, container, labels)
+}
+
+func (p *presigningCryptStorage) PresignGet(ctx context.Context, container, name string, expiry time.Duration) (string, error) {
+	if err := p.checkPlain(ctx, container); err != nil {
+		return "", err
+	}
+
+	return p.presigner.PresignGet(ctx, container, name, expiry)
+}
+
+func (p *presigningCryptStorage) PresignPost(ctx context.Context, container, name string, maxSize int64, expiry time.Duration) (string, map[string]string, error) {
+	if err := p.checkPlain(ctx, container); err != nil {
+		return "", nil, err
+	}
+
+	return p.presigner.PresignPost(ctx, container, name, maxSize, expiry)
+}
+
+func (p *presigningCryptStorage) Uploads(ctx context.Context, container string) ([]string, error) {
+	return p.presigner.Uploads(ctx, container)
+}
+
+// checkPlain fails if the container is encrypted: the clients would transfer the encrypted objects.
+func (p *presigningCryptStorage) checkPlain(ctx context.Context, container string) error {
+	dk, err := p.dataKey(ctx, container)
+	if err != nil {
+		return err
+	}
+
+	if dk != nil {
+		return fmt.Errorf("%w: the album is encrypted", services.ErrPresignDisabled)
+	}
+
+	return nil
+}
+
+// dataKey returns the unwrapped data key of the container, or nil if the container is not encrypted.
+func (c *cryptStorage) dataKey(ctx context.Context, container string) (*dataKey, error) {
+	c.lock.Lock()
+	dk, found := c.dataKeys[container]
+	c.lock.Unlock()
+
+	if found {
+		return dk, nil
+	}
+
+	labels, err := c.Storage.GetLabels(ctx, container)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, found := labels[DataKeyLabel]
+	if found {
+		if c.keys == nil {
+			return nil, fmt.Errorf("%w: cannot read the data key of '%s'", services.ErrEncryptionDisabled, container)
+		}
+
+		key, err := c.keys.Open(wrapped)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unwrap the data key of '%s': %w", container, err)
+		}
+
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+
+		dk = &dataKey{aead: aead}
+	}
+
+	c.lock.Lock()
+	c.dataKeys[container] = dk
+	c.lock.Unlock()
+
+	return dk, nil
+}
+
+func (c *cryptStorage) forget(container string) {
+	c.lock.Lock()
+	delete(c.dataKeys, container)
+	c.lock.Unlock()
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("data key must be %d bytes long, got %d", dataKeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// additionalData binds the encrypted content to its object.
+func additionalData(container, name string) []byte {
+	return []byte(container + "/" + name)
+}
+
+func encrypt(aead cipher.AEAD, content, additionalData []byte) ([]byte, error) {
+	sealed := make([]byte, len(encryptedMagic)+aead.NonceSize(), len(encryptedMagic)+aead.NonceSize()+len(content)+aead.Overhead())
+	copy(sealed, encryptedMagic)
+
+	nonce := sealed[len(encryptedMagic):]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(sealed, nonce, content, additionalData), nil
+}
+
+func decrypt(aead cipher.AEAD, sealed, additionalData []byte) ([]byte, error) {
+	if len(sealed) < len(encryptedMagic)+aead.NonceSize() || string(sealed[:len(encryptedMagic)]) != encryptedMagic {
+		return nil, errors.New("object is not encrypted")
+	}
+
+	sealed = sealed[len(encryptedMagic):]
+
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+}
+
+// sealMetadata returns the metadata of the object encrypted under sealedMetadataKey.
+func sealMetadata(aead cipher.AEAD, container, name string, metadata map[string]string) (map[string]string, error) {
+	if len(metadata) == 0 {
+		return map[string]string{}, nil
+	}
+
+	content, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := encrypt(aead, content, additionalData(container, name+"#metadata"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{sealedMetadataKey: base64.RawURLEncoding.EncodeToString(sealed)}, nil
+}
+
+// openMetadata returns the metadata of the object sealed by sealMetadata.
+func openMetadata(aead cipher.AEAD, container, name string, metadata map[string]string) (map[string]string, error) {
+	value, found := lookupMetadata(metadata, sealedMetadataKey)
+	if !found {
+		return map[string]string{}, nil
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the metadata of '%s' of '%s': %w", name, container, err)
+	}
+
+	content, err := decrypt(aead, sealed, additionalData(container, name+"#metadata"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the metadata of '%s' of '%s': %w", name, container, err)
+	}
+
+	opened := make(map[string]string)
+	if err := json.Unmarshal(content, &opened); err != nil {
+		return nil, fmt.Errorf("failed to decode the metadata of '%s' of '%s': %w", name, container, err)
+	}
+
+	return opened, nil
+}
+
+// lookupMetadata returns the value of the metadata key. The storages change the case of the keys and may prefix them.
+func lookupMetadata(metadata map[string]string, key string) (string, bool) {
+	for k, v := range metadata {
+		if strings.TrimPrefix(strings.ToLower(k), "x-amz-meta-") == key {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// fillMetadata sets the metadata of the media and the date, the location and the hash it holds.
+// The storages do it while listing but they only see the sealed metadata of the encrypted objects.
+func fillMetadata(m *entity.Media, metadata map[string]string) {
+	m.Metadata = metadata
+
+	if date, found := lookupMetadata(metadata, image.DateKey); found {
+		if t, err := time.Parse(image.DateFormat, date); err == nil {
+			m.CreateDate = t
+		}
+	}
+
+	lat, latFound := lookupMetadata(metadata, image.LatitudeKey)
+	long, longFound := lookupMetadata(metadata, image.LongitudeKey)
+	if latFound && longFound {
+		latitude, latErr := strconv.ParseFloat(lat, 64)
+		longitude, longErr := strconv.ParseFloat(long, 64)
+		if latErr == nil && longErr == nil {
+			m.Location = &entity.GeoPoint{Latitude: latitude, Longitude: longitude}
+		}
+	}
+
+	if h, found := lookupMetadata(metadata, image.HashKey); found {
+		if hash, err := strconv.ParseUint(h, 16, 64); err == nil {
+			m.Hash = &hash
+		}
+	}
+}
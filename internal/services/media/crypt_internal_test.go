@@ -0,0 +1,318 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services"
+	"github.com/tupyy/gophoto/internal/services/encryption"
+	"github.com/tupyy/gophoto/internal/services/image"
+)
+
+// memStorage keeps the containers in memory.
+type memStorage struct {
+	Storage
+	labels  map[string]map[string]string
+	objects map[string]map[string][]byte
+	// metadata of the objects mapped by container and name
+	metadata map[string]map[string]string
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{
+		labels:   make(map[string]map[string]string),
+		objects:  make(map[string]map[string][]byte),
+		metadata: make(map[string]map[string]string),
+	}
+}
+
+func (m *memStorage) Get(ctx context.Context, container, name string) (io.ReadSeeker, map[string]string, error) {
+	content, found := m.objects[container][name]
+	if !found {
+		return nil, nil, errors.New("not found")
+	}
+
+	metadata := make(map[string]string)
+	for k, v := range m.metadata[container+"/"+name] {
+		metadata[k] = v
+	}
+
+	return bytes.NewReader(content), metadata, nil
+}
+
+func (m *memStorage) Size(ctx context.Context, container, name string) (int64, error) {
+	content, found := m.objects[container][name]
+	if !found {
+		return 0, errors.New("not found")
+	}
+
+	return int64(len(content)), nil
+}
+
+func (m *memStorage) Put(ctx context.Context, container, name string, size int64, r io.Reader, metadata map[string]string) error {
+	if _, found := m.objects[container]; !found {
+		return errors.New("container not found")
+	}
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	if int64(len(content)) != size {
+		return errors.New("wrong size")
+	}
+
+	m.objects[container][name] = content
+	m.metadata[container+"/"+name] = metadata
+
+	return nil
+}
+
+func (m *memStorage) List(ctx context.Context, container string) ([]entity.Media, error) {
+	medias := []entity.Media{}
+	for name, content := range m.objects[container] {
+		medias = append(medias, entity.Media{Filename: name, Bucket: container, Size: int64(len(content)), Metadata: m.metadata[container+"/"+name]})
+	}
+
+	return medias, nil
+}
+
+func (m *memStorage) Copy(ctx context.Context, srcContainer, srcName, dstContainer, dstName string) error {
+	m.objects[dstContainer][dstName] = m.objects[srcContainer][srcName]
+	m.metadata[dstContainer+"/"+dstName] = m.metadata[srcContainer+"/"+srcName]
+	return nil
+}
+
+func (m *memStorage) Delete(ctx context.Context, container, name string) error {
+	delete(m.objects[container], name)
+	delete(m.metadata, container+"/"+name)
+	return nil
+}
+
+func (m *memStorage) Names(ctx context.Context, container string) ([]string, error) {
+	names := []string{}
+	for name := range m.objects[container] {
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+func (m *memStorage) CreateContainer(ctx context.Context, container string, labels map[string]string) error {
+	m.objects[container] = make(map[string][]byte)
+	return m.SetLabels(ctx, container, labels)
+}
+
+func (m *memStorage) SetLabels(ctx context.Context, container string, labels map[string]string) error {
+	m.labels[container] = make(map[string]string)
+	for k, v := range labels {
+		m.labels[container][k] = v
+	}
+
+	return nil
+}
+
+func (m *memStorage) GetLabels(ctx context.Context, container string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for k, v := range m.labels[container] {
+		labels[k] = v
+	}
+
+	return labels, nil
+}
+
+func (m *memStorage) PresignGet(ctx context.Context, container, name string, expiry time.Duration) (string, error) {
+	return "http://minio/" + container + "/" + name, nil
+}
+
+func (m *memStorage) PresignPost(ctx context.Context, container, name string, maxSize int64, expiry time.Duration) (string, map[string]string, error) {
+	return "http://minio/" + container, map[string]string{"key": name}, nil
+}
+
+func (m *memStorage) Uploads(ctx context.Context, container string) ([]string, error) {
+	return []string{}, nil
+}
+
+func read(t *testing.T, s *Service, bucket, name string) string {
+	r, _, err := s.GetPhoto(context.Background(), bucket, name)
+	require.Nil(t, err)
+
+	content, err := io.ReadAll(r)
+	require.Nil(t, err)
+
+	return string(content)
+}
+
+func TestEncryption(t *testing.T) {
+	ctx := context.Background()
+	oldKey, newKey := bytes.Repeat([]byte{1}, encryption.KeySize), bytes.Repeat([]byte{2}, encryption.KeySize)
+
+	keys, err := encryption.NewKeyring(map[string][]byte{"old": oldKey}, "old")
+	require.Nil(t, err)
+
+	storage := newMemStorage()
+	s := New(storage, nil, nil, nil)
+	s.EnableEncryption(keys)
+	require.Nil(t, s.EnablePresignedURLs(time.Minute, 1024))
+
+	require.Nil(t, s.CreateEncryptedBucket(ctx, "enc", map[string]string{"album/name": "enc"}))
+	require.Nil(t, s.CreateEncryptedBucket(ctx, "other", map[string]string{}))
+	require.Nil(t, s.CreateBucket(ctx, "plain", map[string]string{}))
+
+	content := "content of a"
+	metadata := map[string]string{image.DateKey: "2021:06:01 10:00:00", image.LatitudeKey: "48.858400", image.LongitudeKey: "2.294500", image.HashKey: "ff"}
+	require.Nil(t, s.Put(ctx, "enc", "photos/a.jpg", int64(len(content)), strings.NewReader(content), metadata))
+	require.Nil(t, s.Put(ctx, "plain", "photos/b.jpg", int64(len(content)), strings.NewReader(content), map[string]string{}))
+
+	t.Run("at rest", func(t *testing.T) {
+		assert.True(t, strings.HasPrefix(string(storage.objects["enc"]["photos/a.jpg"]), encryptedMagic))
+		assert.NotContains(t, string(storage.objects["enc"]["photos/a.jpg"]), content)
+		assert.Equal(t, content, string(storage.objects["plain"]["photos/b.jpg"]))
+
+		assert.Equal(t, content, read(t, s, "enc", "photos/a.jpg"))
+		assert.Equal(t, content, read(t, s, "plain", "photos/b.jpg"))
+
+		// the metadata is sealed too
+		require.Equal(t, 1, len(storage.metadata["enc/photos/a.jpg"]))
+		for _, k := range []string{image.DateKey, image.LatitudeKey, image.LongitudeKey} {
+			assert.NotContains(t, storage.metadata["enc/photos/a.jpg"][sealedMetadataKey], metadata[k])
+		}
+
+		_, opened, err := s.repo.Get(ctx, "enc", "photos/a.jpg")
+		require.Nil(t, err)
+		assert.Equal(t, metadata, opened)
+
+		medias, err := s.repo.List(ctx, "enc")
+		require.Nil(t, err)
+		require.Equal(t, 1, len(medias))
+		assert.Equal(t, int64(len(content)), medias[0].Size)
+		assert.Equal(t, time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC), medias[0].CreateDate)
+		assert.Equal(t, &entity.GeoPoint{Latitude: 48.8584, Longitude: 2.2945}, medias[0].Location)
+		require.NotNil(t, medias[0].Hash)
+		assert.Equal(t, uint64(0xff), *medias[0].Hash)
+
+		size, err := s.repo.Size(ctx, "enc", "photos/a.jpg")
+		require.Nil(t, err)
+		assert.Equal(t, int64(len(content)), size, "the size before encryption must be returned")
+
+		size, err = s.repo.Size(ctx, "plain", "photos/b.jpg")
+		require.Nil(t, err)
+		assert.Equal(t, int64(len(content)), size)
+
+		labels, err := s.BucketLabels(ctx, "enc")
+		require.Nil(t, err)
+		assert.Equal(t, "enc", labels["album/name"])
+		assert.True(t, keys.IsActive(labels[DataKeyLabel]))
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		// to a bucket with another key
+		require.Nil(t, s.repo.Copy(ctx, "enc", "photos/a.jpg", "other", "photos/a.jpg"))
+		assert.Equal(t, content, read(t, s, "other", "photos/a.jpg"))
+
+		// to a plain bucket
+		require.Nil(t, s.repo.Copy(ctx, "enc", "photos/a.jpg", "plain", "photos/a.jpg"))
+		assert.Equal(t, content, string(storage.objects["plain"]["photos/a.jpg"]))
+
+		// from a plain bucket
+		require.Nil(t, s.repo.Copy(ctx, "plain", "photos/b.jpg", "enc", "photos/b.jpg"))
+		assert.Equal(t, content, read(t, s, "enc", "photos/b.jpg"))
+
+		// the copied bucket shares the data key but the objects are bound to their container
+		require.Nil(t, s.CopyBucket(ctx, "enc", "enc-copy"))
+		assert.Equal(t, content, read(t, s, "enc-copy", "photos/a.jpg"))
+
+		_, copied, err := s.repo.Get(ctx, "enc-copy", "photos/a.jpg")
+		require.Nil(t, err)
+		assert.Equal(t, metadata, copied)
+	})
+
+	t.Run("swap", func(t *testing.T) {
+		// a sealed object moved to another name or container cannot be read
+		storage.objects["enc"]["photos/swapped.jpg"] = storage.objects["enc"]["photos/a.jpg"]
+		_, _, err := s.GetPhoto(ctx, "enc", "photos/swapped.jpg")
+		assert.NotNil(t, err)
+
+		storage.objects["enc-copy"]["photos/b.jpg"] = storage.objects["enc"]["photos/a.jpg"]
+		_, _, err = s.GetPhoto(ctx, "enc-copy", "photos/b.jpg")
+		assert.NotNil(t, err)
+
+		// and neither can its metadata
+		storage.metadata["enc/photos/b.jpg"] = storage.metadata["enc/photos/a.jpg"]
+		_, _, err = s.GetPhoto(ctx, "enc", "photos/b.jpg")
+		assert.NotNil(t, err)
+
+		delete(storage.objects["enc"], "photos/swapped.jpg")
+		storage.metadata["enc/photos/b.jpg"] = map[string]string{}
+		assert.Equal(t, content, read(t, s, "enc", "photos/b.jpg"))
+	})
+
+	t.Run("presign", func(t *testing.T) {
+		_, _, err := s.PresignDownload(ctx, "enc", "photos/a.jpg")
+		assert.True(t, errors.Is(err, services.ErrPresignDisabled))
+
+		_, err = s.PresignUpload(ctx, "enc", "a.jpg")
+		assert.True(t, errors.Is(err, services.ErrPresignDisabled))
+
+		_, _, err = s.PresignDownload(ctx, "plain", "photos/b.jpg")
+		assert.Nil(t, err)
+	})
+
+	t.Run("rotate", func(t *testing.T) {
+		rotated, err := encryption.NewKeyring(map[string][]byte{"old": oldKey, "new": newKey}, "new")
+		require.Nil(t, err)
+
+		r := New(storage, nil, nil, nil)
+		r.EnableEncryption(rotated)
+
+		before := storage.objects["enc"]["photos/a.jpg"]
+
+		ok, err := r.RotateBucketKey(ctx, "enc")
+		require.Nil(t, err)
+		assert.True(t, ok)
+
+		ok, err = r.RotateBucketKey(ctx, "enc")
+		require.Nil(t, err)
+		assert.False(t, ok, "the data key is already wrapped with the active key")
+
+		ok, err = r.RotateBucketKey(ctx, "plain")
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		// the media are not encrypted again
+		assert.Equal(t, before, storage.objects["enc"]["photos/a.jpg"])
+		assert.Equal(t, map[string]string{"album/name": "enc", DataKeyLabel: storage.labels["enc"][DataKeyLabel]}, storage.labels["enc"])
+
+		// the old key can be removed
+		onlyNew, err := encryption.NewKeyring(map[string][]byte{"new": newKey}, "new")
+		require.Nil(t, err)
+
+		n := New(storage, nil, nil, nil)
+		n.EnableEncryption(onlyNew)
+		assert.Equal(t, content, read(t, n, "enc", "photos/a.jpg"))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		d := New(storage, nil, nil, nil)
+		d.EnableEncryption(nil)
+
+		_, _, err := d.GetPhoto(ctx, "enc", "photos/a.jpg")
+		assert.True(t, errors.Is(err, services.ErrEncryptionDisabled))
+
+		err = d.Put(ctx, "enc", "photos/c.jpg", 1, strings.NewReader("c"), map[string]string{})
+		assert.True(t, errors.Is(err, services.ErrEncryptionDisabled))
+
+		assert.True(t, errors.Is(d.CreateEncryptedBucket(ctx, "new", map[string]string{}), services.ErrEncryptionDisabled))
+
+		assert.Equal(t, content, read(t, d, "plain", "photos/b.jpg"))
+	})
+}
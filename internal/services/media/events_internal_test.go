@@ -0,0 +1,52 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	stdimage "image"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+// memPublisher records the events published.
+type memPublisher struct {
+	events []entity.Event
+}
+
+func (m *memPublisher) Publish(ctx context.Context, event entity.Event) {
+	m.events = append(m.events, event)
+}
+
+func TestThumbnailEvents(t *testing.T) {
+	ctx := context.Background()
+
+	var photo bytes.Buffer
+	require.Nil(t, jpeg.Encode(&photo, stdimage.NewRGBA(stdimage.Rect(0, 0, 64, 64)), nil))
+
+	storage := newMemStorage()
+	require.Nil(t, storage.CreateContainer(ctx, "bucket", map[string]string{}))
+	storage.objects["bucket"]["photos/a.jpg"] = photo.Bytes()
+
+	publisher := &memPublisher{}
+	s := New(storage, nil, nil, publisher)
+
+	// the plain listing has no side effect
+	medias, err := s.List(ctx, "bucket")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(medias))
+	assert.NotContains(t, storage.objects["bucket"], "thumbnail/a.jpg")
+
+	// the missing thumbnails are created while listing the bucket but a read publishes nothing
+	_, err = s.ListBucket(ctx, "bucket")
+	require.Nil(t, err)
+	assert.Contains(t, storage.objects["bucket"], "thumbnail/a.jpg")
+	assert.Equal(t, 0, len(publisher.events))
+
+	require.Nil(t, s.CreateThumbnail(ctx, "bucket", "photos/a.jpg"))
+	require.Equal(t, 1, len(publisher.events))
+	assert.Equal(t, entity.EventThumbnailCreated, publisher.events[0].Kind)
+}
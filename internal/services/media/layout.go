@@ -0,0 +1,26 @@
+package media
+
+import "strings"
+
+// Layout is the way the objects of the albums are laid out in the storage.
+type Layout string
+
+const (
+	// BucketLayout stores each album in its own bucket. It is the default layout.
+	BucketLayout Layout = "bucket"
+	// SharedLayout stores all the albums in one bucket under the prefix albums/<album_id>/.
+	SharedLayout Layout = "shared"
+)
+
+// AlbumPrefix is the prefix of the containers of the shared layout.
+const AlbumPrefix = "albums/"
+
+// AlbumContainer returns the container of the album in the shared layout.
+func AlbumContainer(albumID string) string {
+	return AlbumPrefix + albumID
+}
+
+// IsAlbumContainer returns true if the container belongs to the shared layout.
+func IsAlbumContainer(container string) bool {
+	return strings.HasPrefix(container, AlbumPrefix) && len(container) > len(AlbumPrefix)
+}
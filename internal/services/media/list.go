@@ -0,0 +1,144 @@
+package media
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Filter selects media by capture date and type.
+type Filter struct {
+	// From - keep the media captured at or after this date
+	From *time.Time
+	// To - keep the media captured before this date
+	To *time.Time
+	// MediaType - keep only the media of this type
+	MediaType *entity.MediaType
+}
+
+// Match returns true if the media passes the filter.
+// The upload date is used for media without capture date.
+func (f Filter) Match(m entity.Media) bool {
+	if f.MediaType != nil && m.MediaType != *f.MediaType {
+		return false
+	}
+
+	date := m.CreateDate
+	if date.IsZero() {
+		date = m.UploadDate
+	}
+
+	if f.From != nil && date.Before(*f.From) {
+		return false
+	}
+
+	if f.To != nil && !date.Before(*f.To) {
+		return false
+	}
+
+	return true
+}
+
+// Apply returns the media passing the filter.
+func (f Filter) Apply(medias []entity.Media) []entity.Media {
+	filtered := make([]entity.Media, 0, len(medias))
+	for _, m := range medias {
+		if f.Match(m) {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered
+}
+
+// cursor holds the sort key of the last media of a page.
+type cursor struct {
+	Sort     SortType  `json:"s"`
+	Order    SortOrder `json:"o"`
+	Filename string    `json:"f"`
+	Date     time.Time `json:"d"`
+	Size     int64     `json:"z,omitempty"`
+}
+
+// Paginate returns the page of media which comes right after the cursor and the cursor of the next page.
+// The media must be sorted with the same sort type and order as the ones used to get the cursor.
+// Because the cursor holds the key of the last media instead of an offset, pages remain stable while media are added or removed.
+// An empty cursor returns the first page and an empty next cursor means there are no more pages.
+func Paginate(medias []entity.Media, sortType SortType, order SortOrder, after string, size int) ([]entity.Media, string, error) {
+	start := 0
+
+	if len(after) > 0 {
+		last, err := decodeCursor(after, sortType, order)
+		if err != nil {
+			return []entity.Media{}, "", err
+		}
+
+		less := lessFunc(sortType, order)
+
+		start = len(medias)
+		for i, m := range medias {
+			if less(last, m) {
+				start = i
+				break
+			}
+		}
+	}
+
+	if size <= 0 || start+size >= len(medias) {
+		return medias[start:], "", nil
+	}
+
+	page := medias[start : start+size]
+
+	return page, encodeCursor(page[len(page)-1], sortType, order), nil
+}
+
+func encodeCursor(m entity.Media, sortType SortType, order SortOrder) string {
+	c := cursor{
+		Sort:     sortType,
+		Order:    order,
+		Filename: m.Filename,
+	}
+
+	switch sortType {
+	case SortByCaptureDate:
+		c.Date = m.CreateDate
+	case SortByUploadDate:
+		c.Date = m.UploadDate
+	case SortBySize:
+		c.Size = m.Size
+	}
+
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor returns a media holding the sort key of the cursor.
+func decodeCursor(s string, sortType SortType, order SortOrder) (entity.Media, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return entity.Media{}, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return entity.Media{}, ErrInvalidCursor
+	}
+
+	if c.Sort != sortType || c.Order != order {
+		return entity.Media{}, ErrInvalidCursor
+	}
+
+	return entity.Media{
+		Filename:   c.Filename,
+		CreateDate: c.Date,
+		UploadDate: c.Date,
+		Size:       c.Size,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package media
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+func TestPaginate(t *testing.T) {
+	medias := make([]entity.Media, 0, 10)
+	for i := 0; i < 10; i++ {
+		medias = append(medias, entity.Media{
+			Filename:   fmt.Sprintf("photos/%02d.jpg", i),
+			CreateDate: createDate(2022, 1, i+1),
+			Size:       int64(100 * (i % 3)),
+		})
+	}
+
+	data := []struct {
+		sortType SortType
+		order    SortOrder
+		expected []string
+	}{
+		{
+			sortType: SortByCaptureDate,
+			order:    ReverseOrder,
+			expected: []string{"photos/09.jpg", "photos/08.jpg", "photos/07.jpg", "photos/06.jpg", "photos/05.jpg", "photos/04.jpg", "photos/03.jpg", "photos/02.jpg", "photos/01.jpg", "photos/00.jpg"},
+		},
+		{
+			sortType: SortByFilename,
+			order:    NormalOrder,
+			expected: []string{"photos/00.jpg", "photos/01.jpg", "photos/02.jpg", "photos/03.jpg", "photos/04.jpg", "photos/05.jpg", "photos/06.jpg", "photos/07.jpg", "photos/08.jpg", "photos/09.jpg"},
+		},
+		{
+			// same size are sorted by filename
+			sortType: SortBySize,
+			order:    NormalOrder,
+			expected: []string{"photos/00.jpg", "photos/03.jpg", "photos/06.jpg", "photos/09.jpg", "photos/01.jpg", "photos/04.jpg", "photos/07.jpg", "photos/02.jpg", "photos/05.jpg", "photos/08.jpg"},
+		},
+	}
+
+	for idx, d := range data {
+		sorted := append([]entity.Media{}, medias...)
+		Sort(sorted, d.sortType, d.order)
+
+		filenames := []string{}
+		cursor := ""
+		for {
+			page, next, err := Paginate(sorted, d.sortType, d.order, cursor, 3)
+			assert.Nil(t, err, "test %d", idx)
+			assert.LessOrEqual(t, len(page), 3, "test %d", idx)
+
+			for _, m := range page {
+				filenames = append(filenames, m.Filename)
+			}
+
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+
+		assert.Equal(t, d.expected, filenames, "test %d", idx)
+	}
+}
+
+func TestPaginateStableWhileUploading(t *testing.T) {
+	medias := []entity.Media{
+		{Filename: "photos/a.jpg", CreateDate: createDate(2022, 1, 5)},
+		{Filename: "photos/b.jpg", CreateDate: createDate(2022, 1, 4)},
+		{Filename: "photos/c.jpg", CreateDate: createDate(2022, 1, 3)},
+		{Filename: "photos/d.jpg", CreateDate: createDate(2022, 1, 2)},
+	}
+
+	page, next, err := Paginate(medias, SortByCaptureDate, ReverseOrder, "", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "photos/b.jpg", page[1].Filename)
+
+	// a newer photo is uploaded between two pages
+	medias = append([]entity.Media{{Filename: "photos/new.jpg", CreateDate: createDate(2022, 1, 6)}}, medias...)
+
+	page, next, err = Paginate(medias, SortByCaptureDate, ReverseOrder, next, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(page))
+	assert.Equal(t, "photos/c.jpg", page[0].Filename)
+	assert.Equal(t, "photos/d.jpg", page[1].Filename)
+	assert.Equal(t, "", next)
+}
+
+func TestPaginateInvalidCursor(t *testing.T) {
+	medias := []entity.Media{
+		{Filename: "photos/a.jpg"},
+		{Filename: "photos/b.jpg"},
+		{Filename: "photos/c.jpg"},
+	}
+
+	_, next, err := Paginate(medias, SortByFilename, NormalOrder, "", 1)
+	assert.Nil(t, err)
+
+	// cursor built for another sort
+	_, _, err = Paginate(medias, SortBySize, NormalOrder, next, 1)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, _, err = Paginate(medias, SortByFilename, NormalOrder, "not a cursor", 1)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestFilter(t *testing.T) {
+	photo := entity.Photo
+	from := createDate(2022, 1, 1)
+	to := createDate(2022, 2, 1)
+
+	data := []struct {
+		filter   Filter
+		media    entity.Media
+		expected bool
+	}{
+		{
+			filter:   Filter{From: &from, To: &to},
+			media:    entity.Media{CreateDate: createDate(2022, 1, 15)},
+			expected: true,
+		},
+		{
+			filter:   Filter{From: &from, To: &to},
+			media:    entity.Media{CreateDate: to},
+			expected: false,
+		},
+		{
+			// upload date is used if there is no capture date
+			filter:   Filter{From: &from},
+			media:    entity.Media{UploadDate: createDate(2021, 12, 31)},
+			expected: false,
+		},
+		{
+			filter:   Filter{MediaType: &photo},
+			media:    entity.Media{MediaType: entity.Video},
+			expected: false,
+		},
+		{
+			filter:   Filter{MediaType: &photo, To: &to},
+			media:    entity.Media{MediaType: entity.Photo, CreateDate: from},
+			expected: true,
+		},
+	}
+
+	for idx, d := range data {
+		assert.Equal(t, d.expected, d.filter.Match(d.media), "test %d", idx)
+	}
+}
+
+func createDate(year, month, day int) time.Time {
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
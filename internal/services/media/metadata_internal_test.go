@@ -0,0 +1,265 @@
+package media
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+// memMedia is the data of a media kept by memMediaRepo.
+type memMedia struct {
+	caption     string
+	description string
+	place       entity.Place
+	favorites   map[string]struct{}
+	tags        map[string]struct{}
+	// reactions - number of comments and reactions
+	reactions int
+}
+
+// memMediaRepo keeps the data of the media in memory, mapped by album id and filename.
+type memMediaRepo struct {
+	medias map[string]map[string]*memMedia
+}
+
+func newMemMediaRepo() *memMediaRepo {
+	return &memMediaRepo{medias: make(map[string]map[string]*memMedia)}
+}
+
+func (m *memMediaRepo) media(albumID, filename string) *memMedia {
+	if _, found := m.medias[albumID]; !found {
+		m.medias[albumID] = make(map[string]*memMedia)
+	}
+
+	md, found := m.medias[albumID][filename]
+	if !found {
+		md = &memMedia{favorites: make(map[string]struct{}), tags: make(map[string]struct{})}
+		m.medias[albumID][filename] = md
+	}
+
+	return md
+}
+
+func (m *memMediaRepo) GetByAlbum(ctx context.Context, albumID, user string) (map[string]entity.Media, error) {
+	medias := make(map[string]entity.Media)
+	for filename, md := range m.medias[albumID] {
+		_, favorite := md.favorites[user]
+
+		tags := make([]entity.Tag, 0, len(md.tags))
+		for id := range md.tags {
+			tags = append(tags, entity.Tag{ID: id})
+		}
+		sort.Slice(tags, func(i, j int) bool { return tags[i].ID < tags[j].ID })
+
+		medias[filename] = entity.Media{
+			Filename:    filename,
+			Caption:     md.caption,
+			Description: md.description,
+			Place:       md.place,
+			Favorite:    favorite,
+			Tags:        tags,
+		}
+	}
+
+	return medias, nil
+}
+
+func (m *memMediaRepo) Update(ctx context.Context, albumID string, media entity.Media) error {
+	md := m.media(albumID, media.Filename)
+	md.caption, md.description = media.Caption, media.Description
+
+	return nil
+}
+
+func (m *memMediaRepo) Delete(ctx context.Context, albumID, filename string) error {
+	delete(m.medias[albumID], filename)
+	return nil
+}
+
+func (m *memMediaRepo) SetFavorite(ctx context.Context, albumID, filename, user string) error {
+	m.media(albumID, filename).favorites[user] = struct{}{}
+	return nil
+}
+
+func (m *memMediaRepo) RemoveFavorite(ctx context.Context, albumID, filename, user string) error {
+	delete(m.media(albumID, filename).favorites, user)
+	return nil
+}
+
+func (m *memMediaRepo) Associate(ctx context.Context, albumID, filename, tagID string) error {
+	m.media(albumID, filename).tags[tagID] = struct{}{}
+	return nil
+}
+
+func (m *memMediaRepo) Dissociate(ctx context.Context, albumID, filename, tagID string) error {
+	delete(m.media(albumID, filename).tags, tagID)
+	return nil
+}
+
+func (m *memMediaRepo) GetRatings(ctx context.Context, albumID string) (map[string]int, error) {
+	ratings := make(map[string]int)
+	for filename, md := range m.medias[albumID] {
+		ratings[filename] = len(md.favorites) + md.reactions
+	}
+
+	return ratings, nil
+}
+
+func (m *memMediaRepo) Move(ctx context.Context, srcAlbumID, dstAlbumID string, filenames []string) error {
+	for _, filename := range filenames {
+		if md, found := m.medias[srcAlbumID][filename]; found {
+			*m.media(dstAlbumID, filename) = *md
+			delete(m.medias[srcAlbumID], filename)
+		}
+	}
+
+	return nil
+}
+
+func (m *memMediaRepo) Copy(ctx context.Context, srcAlbumID, dstAlbumID string, filenames []string) error {
+	for _, filename := range filenames {
+		if md, found := m.medias[srcAlbumID][filename]; found {
+			copied := m.media(dstAlbumID, filename)
+			copied.caption, copied.description, copied.place = md.caption, md.description, md.place
+			for id := range md.tags {
+				copied.tags[id] = struct{}{}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *memMediaRepo) SetPlace(ctx context.Context, albumID, filename string, place entity.Place) error {
+	m.media(albumID, filename).place = place
+	return nil
+}
+
+func TestMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	repo := newMemMediaRepo()
+	s := New(newMemStorage(), repo, nil, nil)
+
+	medias := []entity.Media{{Filename: "photos/a.jpg"}, {Filename: "photos/b.jpg"}}
+
+	t.Run("caption", func(t *testing.T) {
+		require.Nil(t, s.UpdateMetadata(ctx, "album", entity.Media{Filename: "photos/a.jpg", Caption: "beach", Description: "at noon"}))
+
+		enriched, err := s.WithMetadata(ctx, "album", "bob", medias)
+		require.Nil(t, err)
+		require.Equal(t, 2, len(enriched))
+		assert.Equal(t, "beach", enriched[0].Caption)
+		assert.Equal(t, "at noon", enriched[0].Description)
+		assert.Equal(t, "", enriched[1].Caption)
+		assert.NotNil(t, enriched[1].Tags, "the media without metadata have no tags")
+
+		// the metadata of an album is not visible from another one
+		enriched, err = s.WithMetadata(ctx, "other", "bob", medias)
+		require.Nil(t, err)
+		assert.Equal(t, "", enriched[0].Caption)
+	})
+
+	t.Run("favorite", func(t *testing.T) {
+		require.Nil(t, s.SetFavorite(ctx, "album", "photos/b.jpg", "bob", true))
+
+		enriched, err := s.WithMetadata(ctx, "album", "bob", medias)
+		require.Nil(t, err)
+		assert.False(t, enriched[0].Favorite)
+		assert.True(t, enriched[1].Favorite)
+
+		// favorites are per user
+		enriched, err = s.WithMetadata(ctx, "album", "alice", medias)
+		require.Nil(t, err)
+		assert.False(t, enriched[1].Favorite)
+
+		ratings, err := s.Ratings(ctx, "album")
+		require.Nil(t, err)
+		assert.Equal(t, 1, ratings["photos/b.jpg"])
+
+		require.Nil(t, s.SetFavorite(ctx, "album", "photos/b.jpg", "bob", false))
+
+		enriched, err = s.WithMetadata(ctx, "album", "bob", medias)
+		require.Nil(t, err)
+		assert.False(t, enriched[1].Favorite)
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		summer, winter := entity.Tag{ID: "summer"}, entity.Tag{ID: "winter"}
+
+		require.Nil(t, s.Tag(ctx, "album", "photos/a.jpg", summer))
+		require.Nil(t, s.Tag(ctx, "album", "photos/a.jpg", winter))
+		require.Nil(t, s.Tag(ctx, "album", "photos/a.jpg", winter))
+
+		enriched, err := s.WithMetadata(ctx, "album", "bob", medias)
+		require.Nil(t, err)
+		assert.Equal(t, []entity.Tag{summer, winter}, enriched[0].Tags)
+
+		require.Nil(t, s.Untag(ctx, "album", "photos/a.jpg", summer))
+
+		enriched, err = s.WithMetadata(ctx, "album", "bob", medias)
+		require.Nil(t, err)
+		assert.Equal(t, []entity.Tag{winter}, enriched[0].Tags)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.Nil(t, s.SetFavorite(ctx, "album", "photos/a.jpg", "bob", true))
+		repo.media("album", "photos/a.jpg").reactions = 2
+
+		names, err := s.MetadataFilenames(ctx, "album")
+		require.Nil(t, err)
+		sort.Strings(names)
+		assert.Equal(t, []string{"photos/a.jpg", "photos/b.jpg"}, names)
+
+		require.Nil(t, s.DeleteMetadata(ctx, "album", "photos/a.jpg"))
+
+		names, err = s.MetadataFilenames(ctx, "album")
+		require.Nil(t, err)
+		assert.Equal(t, []string{"photos/b.jpg"}, names)
+
+		ratings, err := s.Ratings(ctx, "album")
+		require.Nil(t, err)
+		assert.Equal(t, 0, ratings["photos/a.jpg"], "the favorites and the reactions of the deleted media must be removed")
+
+		enriched, err := s.WithMetadata(ctx, "album", "bob", medias)
+		require.Nil(t, err)
+		assert.Equal(t, "", enriched[0].Caption)
+		assert.False(t, enriched[0].Favorite)
+		assert.Equal(t, 0, len(enriched[0].Tags))
+	})
+}
+
+// fixedGeocoder finds the same place everywhere.
+type fixedGeocoder struct {
+	place entity.Place
+}
+
+func (f fixedGeocoder) Lookup(point entity.GeoPoint) (entity.Place, bool) {
+	return f.place, true
+}
+
+func TestGeocode(t *testing.T) {
+	ctx := context.Background()
+	paris := entity.Place{City: "Paris", Country: "France"}
+
+	repo := newMemMediaRepo()
+	s := New(newMemStorage(), repo, fixedGeocoder{paris}, nil)
+
+	medias := []entity.Media{{Filename: "photos/a.jpg", Location: &entity.GeoPoint{Latitude: 48.8584, Longitude: 2.2945}}, {Filename: "photos/b.jpg"}}
+
+	geocoded, err := s.Geocode(ctx, entity.Album{ID: "album"}, medias)
+	require.Nil(t, err)
+	assert.Equal(t, paris, geocoded[0].Place)
+	assert.True(t, geocoded[1].Place.IsZero(), "the media without location have no place")
+	assert.Equal(t, paris, repo.medias["album"]["photos/a.jpg"].place)
+
+	// the places of the media of encrypted albums are not saved
+	geocoded, err = s.Geocode(ctx, entity.Album{ID: "encrypted", Encrypted: true}, medias)
+	require.Nil(t, err)
+	assert.Equal(t, paris, geocoded[0].Place)
+	assert.Equal(t, 0, len(repo.medias["encrypted"]))
+}
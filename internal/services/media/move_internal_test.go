@@ -0,0 +1,156 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+// moveStorage fails to copy the object named failCopy.
+type moveStorage struct {
+	*memStorage
+	failCopy string
+}
+
+func (m *moveStorage) Copy(ctx context.Context, srcContainer, srcName, dstContainer, dstName string) error {
+	if dstName == m.failCopy {
+		return errors.New("copy failed")
+	}
+
+	return m.memStorage.Copy(ctx, srcContainer, srcName, dstContainer, dstName)
+}
+
+// failingMediaRepo fails to move and copy the data of the media.
+type failingMediaRepo struct {
+	*memMediaRepo
+}
+
+func (f *failingMediaRepo) Move(ctx context.Context, srcAlbumID, dstAlbumID string, filenames []string) error {
+	return errors.New("move failed")
+}
+
+func (f *failingMediaRepo) Copy(ctx context.Context, srcAlbumID, dstAlbumID string, filenames []string) error {
+	return errors.New("copy failed")
+}
+
+func names(m *memStorage, container string) []string {
+	names := []string{}
+	for name := range m.objects[container] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func TestMoveCopy(t *testing.T) {
+	ctx := context.Background()
+
+	src := entity.Album{ID: "src", Bucket: "src-bucket"}
+	dst := entity.Album{ID: "dst", Bucket: "dst-bucket"}
+	medias := []entity.Media{
+		{Filename: "photos/a.jpg", Thumbnail: "thumbnail/a.jpg"},
+		{Filename: "photos/b.jpg", Thumbnail: "thumbnail/b.jpg"},
+	}
+
+	// setup returns a service with the media in the source album.
+	setup := func(t *testing.T, failCopy string, repo MediaRepository) (*Service, *memStorage) {
+		storage := newMemStorage()
+		for _, bucket := range []string{src.Bucket, dst.Bucket} {
+			require.Nil(t, storage.CreateContainer(ctx, bucket, map[string]string{}))
+		}
+
+		for _, name := range []string{"photos/a.jpg", "thumbnail/a.jpg", "photos/b.jpg", "thumbnail/b.jpg"} {
+			storage.objects[src.Bucket][name] = []byte(name)
+		}
+
+		return New(&moveStorage{memStorage: storage, failCopy: failCopy}, repo, nil, nil), storage
+	}
+
+	all := []string{"photos/a.jpg", "photos/b.jpg", "thumbnail/a.jpg", "thumbnail/b.jpg"}
+
+	t.Run("move", func(t *testing.T) {
+		repo := newMemMediaRepo()
+		require.Nil(t, repo.Update(ctx, src.ID, entity.Media{Filename: "photos/a.jpg", Caption: "beach"}))
+
+		s, storage := setup(t, "", repo)
+		require.Nil(t, s.Move(ctx, src, dst, medias))
+
+		assert.Equal(t, []string{}, names(storage, src.Bucket))
+		assert.Equal(t, all, names(storage, dst.Bucket))
+		assert.Equal(t, "beach", repo.medias[dst.ID]["photos/a.jpg"].caption)
+		assert.Equal(t, 0, len(repo.medias[src.ID]))
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		repo := newMemMediaRepo()
+		require.Nil(t, repo.Update(ctx, src.ID, entity.Media{Filename: "photos/a.jpg", Caption: "beach"}))
+
+		s, storage := setup(t, "", repo)
+		require.Nil(t, s.Copy(ctx, src, dst, medias))
+
+		assert.Equal(t, all, names(storage, src.Bucket))
+		assert.Equal(t, all, names(storage, dst.Bucket))
+		assert.Equal(t, "beach", repo.medias[dst.ID]["photos/a.jpg"].caption)
+		assert.Equal(t, "beach", repo.medias[src.ID]["photos/a.jpg"].caption)
+	})
+
+	t.Run("copy failing partway", func(t *testing.T) {
+		// the photo of b is copied but not its thumbnail
+		for _, op := range []func(s *Service) error{
+			func(s *Service) error { return s.Move(ctx, src, dst, medias) },
+			func(s *Service) error { return s.Copy(ctx, src, dst, medias) },
+		} {
+			s, storage := setup(t, "thumbnail/b.jpg", newMemMediaRepo())
+
+			assert.NotNil(t, op(s))
+			assert.Equal(t, []string{}, names(storage, dst.Bucket), "the destination must be cleaned")
+			assert.Equal(t, all, names(storage, src.Bucket))
+		}
+	})
+
+	t.Run("metadata failing", func(t *testing.T) {
+		repo := newMemMediaRepo()
+		require.Nil(t, repo.Update(ctx, src.ID, entity.Media{Filename: "photos/a.jpg", Caption: "beach"}))
+
+		s, storage := setup(t, "", &failingMediaRepo{repo})
+		assert.NotNil(t, s.Move(ctx, src, dst, medias))
+
+		assert.Equal(t, []string{}, names(storage, dst.Bucket), "the copies must be removed")
+		assert.Equal(t, all, names(storage, src.Bucket), "the source must be intact")
+		assert.Equal(t, "beach", repo.medias[src.ID]["photos/a.jpg"].caption)
+
+		s, storage = setup(t, "", &failingMediaRepo{repo})
+		assert.NotNil(t, s.Copy(ctx, src, dst, medias))
+
+		assert.Equal(t, []string{}, names(storage, dst.Bucket), "the copies must be removed")
+		assert.Equal(t, all, names(storage, src.Bucket))
+	})
+
+	t.Run("existing filename", func(t *testing.T) {
+		s, storage := setup(t, "", newMemMediaRepo())
+		storage.objects[dst.Bucket]["photos/b.jpg"] = []byte("other b")
+
+		target := dst
+		target.Photos = []entity.Media{{Filename: "photos/b.jpg"}}
+
+		assert.NotNil(t, s.Move(ctx, src, target, medias))
+		assert.NotNil(t, s.Copy(ctx, src, target, medias))
+
+		assert.Equal(t, []string{"photos/b.jpg"}, names(storage, dst.Bucket), "the photo of the target must be kept")
+		assert.Equal(t, "other b", string(storage.objects[dst.Bucket]["photos/b.jpg"]))
+		assert.Equal(t, all, names(storage, src.Bucket))
+	})
+
+	t.Run("same album", func(t *testing.T) {
+		s, storage := setup(t, "", newMemMediaRepo())
+
+		assert.NotNil(t, s.Move(ctx, src, src, medias))
+		assert.Equal(t, all, names(storage, src.Bucket))
+	})
+}
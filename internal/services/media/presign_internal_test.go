@@ -0,0 +1,143 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/services"
+)
+
+// presignStorage keeps the objects in memory. Only the methods used by the uploads are implemented.
+type presignStorage struct {
+	Storage
+	objects map[string][]byte
+}
+
+func (p *presignStorage) Get(ctx context.Context, container, name string) (io.ReadSeeker, map[string]string, error) {
+	content, found := p.objects[container+"/"+name]
+	if !found {
+		return nil, nil, errors.New("not found")
+	}
+
+	return bytes.NewReader(content), map[string]string{}, nil
+}
+
+func (p *presignStorage) Delete(ctx context.Context, container, name string) error {
+	delete(p.objects, container+"/"+name)
+	return nil
+}
+
+func (p *presignStorage) PresignGet(ctx context.Context, container, name string, expiry time.Duration) (string, error) {
+	return "http://minio/" + container + "/" + name, nil
+}
+
+func (p *presignStorage) PresignPost(ctx context.Context, container, name string, maxSize int64, expiry time.Duration) (string, map[string]string, error) {
+	return "http://minio/" + container, map[string]string{"key": name, "max-size": strconv.FormatInt(maxSize, 10)}, nil
+}
+
+func (p *presignStorage) Uploads(ctx context.Context, container string) ([]string, error) {
+	uploads := []string{}
+	for key := range p.objects {
+		if name := strings.TrimPrefix(key, container+"/"); name != key && strings.HasPrefix(name, UploadPrefix) {
+			uploads = append(uploads, name)
+		}
+	}
+
+	return uploads, nil
+}
+
+// plainStorage cannot presign.
+type plainStorage struct {
+	Storage
+}
+
+func TestPresign(t *testing.T) {
+	ctx := context.Background()
+
+	disabled := New(&plainStorage{}, nil, nil, nil)
+	assert.True(t, errors.Is(disabled.EnablePresignedURLs(time.Minute, 1024), services.ErrPresignDisabled))
+
+	_, _, err := disabled.PresignDownload(ctx, "album", "photos/a.jpg")
+	assert.True(t, errors.Is(err, services.ErrPresignDisabled))
+
+	storage := &presignStorage{objects: make(map[string][]byte)}
+	s := New(storage, nil, nil, nil)
+
+	// not enabled yet
+	_, err = s.PresignUpload(ctx, "album", "a.jpg")
+	assert.True(t, errors.Is(err, services.ErrPresignDisabled))
+
+	assert.NotNil(t, s.EnablePresignedURLs(time.Minute, 0), "uploads must have a size limit")
+	require.Nil(t, s.EnablePresignedURLs(time.Minute, 1024))
+
+	u, expiresAt, err := s.PresignDownload(ctx, "album", "photos/a.jpg")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://minio/album/photos/a.jpg", u)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, time.Second)
+
+	presigned, err := s.PresignUpload(ctx, "album", "a.jpg")
+	assert.Nil(t, err)
+	upload := presigned.Upload
+	assert.True(t, strings.HasPrefix(upload, UploadPrefix))
+	assert.True(t, strings.HasSuffix(upload, "/a.jpg"))
+	assert.Equal(t, "http://minio/album", presigned.URL)
+	assert.Equal(t, map[string]string{"key": upload, "max-size": "1024"}, presigned.Fields)
+	assert.Equal(t, int64(1024), presigned.MaxSize)
+
+	other, err := s.PresignUpload(ctx, "album", "a.jpg")
+	assert.Nil(t, err)
+	assert.NotEqual(t, upload, other.Upload, "uploads of the same photo must not overwrite each other")
+
+	_, err = s.PresignUpload(ctx, "album", "../a.jpg")
+	assert.True(t, errors.Is(err, services.ErrInvalidMedia))
+
+	// only uploads can be completed
+	_, err = s.CompleteUpload(ctx, "album", "photos/a.jpg")
+	assert.True(t, errors.Is(err, services.ErrNotFound))
+
+	// not uploaded yet
+	_, err = s.CompleteUpload(ctx, "album", upload)
+	assert.True(t, errors.Is(err, services.ErrNotFound))
+
+	storage.objects["album/"+upload] = []byte("not a photo")
+
+	_, err = s.CompleteUpload(ctx, "album", upload)
+	assert.True(t, errors.Is(err, services.ErrInvalidMedia))
+	assert.Equal(t, 0, len(storage.objects), "the failed upload must be removed")
+}
+
+func TestStaleUploads(t *testing.T) {
+	ctx := context.Background()
+
+	stale, err := New(&plainStorage{}, nil, nil, nil).StaleUploads(ctx, "album")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(stale))
+
+	old := UploadPrefix + xid.NewWithTime(time.Now().Add(-StaleUploadAge-time.Hour)).String() + "/a.jpg"
+	recent := UploadPrefix + xid.New().String() + "/b.jpg"
+	unknown := UploadPrefix + "c.jpg"
+
+	storage := &presignStorage{objects: map[string][]byte{
+		"album/" + old:       []byte("a"),
+		"album/" + recent:    []byte("b"),
+		"album/" + unknown:   []byte("c"),
+		"album/photos/d.jpg": []byte("d"),
+		"other/" + old:       []byte("a"),
+	}}
+	s := New(storage, nil, nil, nil)
+
+	stale, err = s.StaleUploads(ctx, "album")
+	assert.Nil(t, err)
+	sort.Strings(stale)
+	assert.Equal(t, []string{unknown, old}, stale)
+}
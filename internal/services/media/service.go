@@ -3,36 +3,98 @@ package media
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"fmt"
 	"io"
 	"path"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/rs/xid"
 	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services"
 	"github.com/tupyy/gophoto/internal/services/image"
 	"go.uber.org/zap"
 )
 
-// Store describe photo store operations
-type MinioRepository interface {
-	// GetFile returns a reader to file.
-	GetFile(ctx context.Context, bucket, filename string) (io.ReadSeeker, map[string]string, error)
-	// PutFile save a file to a bucket.
-	PutFile(ctx context.Context, bucket, filename string, size int64, r io.Reader, metadata map[string]string) error
-	// ListFiles list the content of a bucket
-	ListBucket(ctx context.Context, bucket string) ([]entity.Media, error)
-	// DeleteFile deletes a file from a bucket.
-	DeleteFile(ctx context.Context, bucket, filename string) error
-	// CreateBucket create a bucket.
-	CreateBucket(ctx context.Context, bucket string, tags map[string]string) error
-	// DeleteBucket removes bucket.
-	DeleteBucket(ctx context.Context, bucket string) error
-	// set tags to bucket
-	SetBucketTagging(ctx context.Context, bucket string, tags map[string]string) error
-	// get bucket tags
-	GetBucketTagging(ctx context.Context, buclet string) (map[string]string, error)
+// Storage stores the objects of the media. The objects of an album are grouped in a container named after the album's bucket.
+// A container is a bucket for MinIO and a directory for the local filesystem. Containers of the shared layout are named
+// albums/<album_id> and are prefixes of one bucket.
+type Storage interface {
+	// Get returns a reader to the object and its metadata.
+	Get(ctx context.Context, container, name string) (io.ReadSeeker, map[string]string, error)
+	// Size returns the size of an object without reading it.
+	Size(ctx context.Context, container, name string) (int64, error)
+	// Put saves an object to a container.
+	Put(ctx context.Context, container, name string, size int64, r io.Reader, metadata map[string]string) error
+	// List returns the media of a container together with their thumbnails. The uploads and the trashed media are left out.
+	List(ctx context.Context, container string) ([]entity.Media, error)
+	// Copy copies an object, possibly to another container. The metadata of the object is copied too.
+	Copy(ctx context.Context, srcContainer, srcName, dstContainer, dstName string) error
+	// Delete deletes an object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, container, name string) error
+	// CreateContainer creates a container with its labels. It fails if the container exists.
+	CreateContainer(ctx context.Context, container string, labels map[string]string) error
+	// DeleteContainer removes a container and all its objects. Deleting a missing container is not an error.
+	DeleteContainer(ctx context.Context, container string) error
+	// SetLabels replaces the labels of a container.
+	SetLabels(ctx context.Context, container string, labels map[string]string) error
+	// GetLabels returns the labels of a container.
+	GetLabels(ctx context.Context, container string) (map[string]string, error)
+	// Containers returns the names of all the containers, the containers of the deleted albums included.
+	Containers(ctx context.Context) ([]string, error)
+	// Names returns the names of all the objects of a container, even the objects which are not media like the trashed media.
+	// The uploads are left out.
+	Names(ctx context.Context, container string) ([]string, error)
+}
+
+// Presigner issues pre-signed urls to transfer the objects directly between the clients and the storage.
+type Presigner interface {
+	// PresignGet returns a url to download the object.
+	PresignGet(ctx context.Context, container, name string, expiry time.Duration) (string, error)
+	// PresignPost returns a url and the form fields to upload the object with a POST request. The storage rejects
+	// the objects larger than maxSize.
+	PresignPost(ctx context.Context, container, name string, maxSize int64, expiry time.Duration) (string, map[string]string, error)
+	// Uploads returns the names of the objects uploaded with pre-signed urls which are not completed yet.
+	Uploads(ctx context.Context, container string) ([]string, error)
+}
+
+// MediaRepository holds the data of the media which is not kept in the object store.
+type MediaRepository interface {
+	// GetByAlbum returns the metadata of the album's media mapped by filename.
+	GetByAlbum(ctx context.Context, albumID, user string) (map[string]entity.Media, error)
+	// Update saves the caption and the description of the media.
+	Update(ctx context.Context, albumID string, media entity.Media) error
+	// Delete removes all the data of the media.
+	Delete(ctx context.Context, albumID, filename string) error
+	// SetFavorite marks the media as favorite for the user.
+	SetFavorite(ctx context.Context, albumID, filename, user string) error
+	// RemoveFavorite removes the media from the user's favorites.
+	RemoveFavorite(ctx context.Context, albumID, filename, user string) error
+	// Associate associates a tag with the media.
+	Associate(ctx context.Context, albumID, filename, tagID string) error
+	// Dissociate removes a tag from the media.
+	Dissociate(ctx context.Context, albumID, filename, tagID string) error
+	// GetRatings returns the number of favorites and reactions of the album's media mapped by filename.
+	GetRatings(ctx context.Context, albumID string) (map[string]int, error)
+	// Move moves all the data of the media to another album.
+	Move(ctx context.Context, srcAlbumID, dstAlbumID string, filenames []string) error
+	// Copy copies the metadata and the tags of the media to another album.
+	Copy(ctx context.Context, srcAlbumID, dstAlbumID string, filenames []string) error
+	// SetPlace saves the names of the place where the media has been captured.
+	SetPlace(ctx context.Context, albumID, filename string, place entity.Place) error
+}
+
+// Geocoder finds the place of a location.
+type Geocoder interface {
+	Lookup(p entity.GeoPoint) (entity.Place, bool)
+}
+
+// EventPublisher publishes media events.
+type EventPublisher interface {
+	Publish(ctx context.Context, event entity.Event)
 }
 
 type MediaType int
@@ -42,35 +104,365 @@ const (
 	Video
 )
 
+// UploadPrefix is the prefix of the objects uploaded with pre-signed urls. They are not media until the upload is completed.
+const UploadPrefix = "uploads/"
+
+// StaleUploadAge is the age of the uploads considered abandoned. They are not counted in the usage until completed
+// so they are removed by fsck.
+const StaleUploadAge = 24 * time.Hour
+
+// PresignedUpload is a pre-signed url to upload a photo with a POST request.
+type PresignedUpload struct {
+	// URL - where the form is posted
+	URL string
+	// Fields - form fields to post with the photo
+	Fields map[string]string
+	// Upload - name of the object to complete the upload with
+	Upload string
+	// MaxSize - size limit of the photo
+	MaxSize   int64
+	ExpiresAt time.Time
+}
+
 type Service struct {
-	repo MinioRepository
+	repo      Storage
+	mediaRepo MediaRepository
+	geocoder  Geocoder
+	publisher EventPublisher
+	// presignExpiry - validity of the pre-signed urls. They are disabled if zero.
+	presignExpiry time.Duration
+	// presignMaxSize - size limit of the photos uploaded with pre-signed urls
+	presignMaxSize int64
+	// keys - wrap the data keys of the encrypted buckets. The encrypted buckets cannot be created if nil.
+	keys KeyWrapper
+}
+
+func New(repo Storage, mediaRepo MediaRepository, geocoder Geocoder, publisher EventPublisher) *Service {
+	return &Service{repo: repo, mediaRepo: mediaRepo, geocoder: geocoder, publisher: publisher}
+}
+
+// EnablePresignedURLs lets the clients transfer the media directly from and to the storage with urls valid for expiry.
+// The photos uploaded are limited to maxSize bytes. It fails if the storage cannot issue pre-signed urls.
+func (s *Service) EnablePresignedURLs(expiry time.Duration, maxSize int64) error {
+	if _, ok := s.repo.(Presigner); !ok {
+		return fmt.Errorf("%w: the storage does not support them", services.ErrPresignDisabled)
+	}
+
+	if expiry <= 0 {
+		return fmt.Errorf("invalid expiry of pre-signed urls: %s", expiry)
+	}
+
+	if maxSize <= 0 {
+		return fmt.Errorf("invalid size limit of pre-signed uploads: %d", maxSize)
+	}
+
+	s.presignExpiry = expiry
+	s.presignMaxSize = maxSize
+
+	return nil
+}
+
+// EnableEncryption encrypts the media of the buckets created with CreateEncryptedBucket, transparently for the callers.
+// The data key of each bucket is wrapped by keys. If keys is nil, the encrypted buckets can be neither created nor read.
+// It must be called before EnablePresignedURLs.
+func (s *Service) EnableEncryption(keys KeyWrapper) {
+	s.keys = keys
+	s.repo = newCryptStorage(s.repo, keys)
+}
+
+// PresignDownload returns a pre-signed url to download the media and the date when it expires.
+func (s *Service) PresignDownload(ctx context.Context, bucket, filename string) (string, time.Time, error) {
+	presigner, ok := s.repo.(Presigner)
+	if !ok || s.presignExpiry == 0 {
+		return "", time.Time{}, services.ErrPresignDisabled
+	}
+
+	expiresAt := time.Now().Add(s.presignExpiry)
+
+	u, err := presigner.PresignGet(ctx, bucket, filename, s.presignExpiry)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return u, expiresAt, nil
+}
+
+// PresignUpload returns a pre-signed url to upload a photo of at most the size limit. The photo is saved by CompleteUpload
+// once uploaded.
+func (s *Service) PresignUpload(ctx context.Context, bucket, filename string) (PresignedUpload, error) {
+	presigner, ok := s.repo.(Presigner)
+	if !ok || s.presignExpiry == 0 {
+		return PresignedUpload{}, services.ErrPresignDisabled
+	}
+
+	if len(filename) == 0 || strings.ContainsAny(filename, `/\`) {
+		return PresignedUpload{}, fmt.Errorf("%w: invalid filename '%s'", services.ErrInvalidMedia, filename)
+	}
+
+	// each upload has its own folder so two uploads of the same photo do not overwrite each other.
+	// The xid holds the creation date of the upload.
+	upload := fmt.Sprintf("%s%s/%s", UploadPrefix, xid.New().String(), filename)
+	expiresAt := time.Now().Add(s.presignExpiry)
+
+	u, fields, err := presigner.PresignPost(ctx, bucket, upload, s.presignMaxSize, s.presignExpiry)
+	if err != nil {
+		return PresignedUpload{}, err
+	}
+
+	return PresignedUpload{URL: u, Fields: fields, Upload: upload, MaxSize: s.presignMaxSize, ExpiresAt: expiresAt}, nil
+}
+
+// CompleteUpload processes a photo uploaded with a pre-signed url like Save and removes the upload.
+// It returns the name of the saved photo.
+func (s *Service) CompleteUpload(ctx context.Context, bucket, upload string) (string, error) {
+	if !strings.HasPrefix(upload, UploadPrefix) {
+		return "", fmt.Errorf("%w: upload '%s'", services.ErrNotFound, upload)
+	}
+
+	r, _, err := s.repo.Get(ctx, bucket, upload)
+	if err != nil {
+		return "", fmt.Errorf("%w: upload '%s': %v", services.ErrNotFound, upload, err)
+	}
+
+	photoName, err := s.savePhoto(ctx, bucket, path.Base(upload), r, nil)
+
+	// a failed upload cannot be completed again
+	if rerr := s.repo.Delete(ctx, bucket, upload); rerr != nil {
+		zap.S().Errorw("failed to remove upload", "error", rerr, "bucket", bucket, "upload", upload)
+	}
+
+	return photoName, err
+}
+
+// UploadSize returns the size of a photo uploaded with a pre-signed url.
+func (s *Service) UploadSize(ctx context.Context, bucket, upload string) (int64, error) {
+	if !strings.HasPrefix(upload, UploadPrefix) {
+		return 0, fmt.Errorf("%w: upload '%s'", services.ErrNotFound, upload)
+	}
+
+	r, _, err := s.repo.Get(ctx, bucket, upload)
+	if err != nil {
+		return 0, fmt.Errorf("%w: upload '%s': %v", services.ErrNotFound, upload, err)
+	}
+
+	if c, ok := r.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	return r.Seek(0, io.SeekEnd)
+}
+
+// UploadMaxSize returns the size limit of the photos uploaded with pre-signed urls.
+func (s *Service) UploadMaxSize() int64 {
+	return s.presignMaxSize
+}
+
+// StaleUploads returns the uploads of the bucket created more than StaleUploadAge ago and never completed.
+func (s *Service) StaleUploads(ctx context.Context, bucket string) ([]string, error) {
+	presigner, ok := s.repo.(Presigner)
+	if !ok {
+		return []string{}, nil
+	}
+
+	uploads, err := presigner.Uploads(ctx, bucket)
+	if err != nil {
+		return []string{}, err
+	}
+
+	stale := []string{}
+	for _, upload := range uploads {
+		if uploadAge(upload) >= StaleUploadAge {
+			stale = append(stale, upload)
+		}
+	}
+
+	return stale, nil
+}
+
+// uploadAge returns the age of the upload from the xid of its folder. The objects which are not named like the uploads
+// have no age and are stale.
+func uploadAge(upload string) time.Duration {
+	parts := strings.SplitN(strings.TrimPrefix(upload, UploadPrefix), "/", 2)
+
+	id, err := xid.FromString(parts[0])
+	if err != nil {
+		return StaleUploadAge
+	}
+
+	return time.Since(id.Time())
 }
 
-func New(repo MinioRepository) *Service {
-	return &Service{repo}
+// CancelUpload removes a photo uploaded with a pre-signed url without saving it.
+func (s *Service) CancelUpload(ctx context.Context, bucket, upload string) error {
+	if !strings.HasPrefix(upload, UploadPrefix) {
+		return fmt.Errorf("%w: upload '%s'", services.ErrNotFound, upload)
+	}
+
+	return s.repo.Delete(ctx, bucket, upload)
 }
 
 func (s *Service) CreateBucket(ctx context.Context, bucket string, tags map[string]string) error {
-	return s.repo.CreateBucket(ctx, bucket, tags)
+	return s.repo.CreateContainer(ctx, bucket, tags)
+}
+
+// CreateEncryptedBucket creates a bucket whose media are encrypted with a new data key. The data key is saved wrapped
+// by the active master key in the labels of the bucket.
+func (s *Service) CreateEncryptedBucket(ctx context.Context, bucket string, tags map[string]string) error {
+	if s.keys == nil {
+		return services.ErrEncryptionDisabled
+	}
+
+	key := make([]byte, dataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+
+	wrapped, err := s.keys.Seal(key)
+	if err != nil {
+		return fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	labels := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		labels[k] = v
+	}
+	labels[DataKeyLabel] = wrapped
+
+	return s.repo.CreateContainer(ctx, bucket, labels)
+}
+
+// RotateBucketKey wraps the data key of an encrypted bucket with the active master key. The media are not encrypted again.
+// It returns false if the bucket is not encrypted or its data key is already wrapped with the active master key.
+func (s *Service) RotateBucketKey(ctx context.Context, bucket string) (bool, error) {
+	labels, err := s.repo.GetLabels(ctx, bucket)
+	if err != nil {
+		return false, err
+	}
+
+	wrapped, found := labels[DataKeyLabel]
+	if !found {
+		return false, nil
+	}
+
+	if s.keys == nil {
+		return false, services.ErrEncryptionDisabled
+	}
+
+	if s.keys.IsActive(wrapped) {
+		return false, nil
+	}
+
+	key, err := s.keys.Open(wrapped)
+	if err != nil {
+		return false, fmt.Errorf("failed to unwrap data key of bucket '%s': %w", bucket, err)
+	}
+
+	labels[DataKeyLabel], err = s.keys.Seal(key)
+	if err != nil {
+		return false, fmt.Errorf("failed to wrap data key of bucket '%s': %w", bucket, err)
+	}
+
+	if err := s.repo.SetLabels(ctx, bucket, labels); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 // DeleteBucket does not delete the bucket. Only set the tags delete_at
 func (s *Service) DeleteBucket(ctx context.Context, bucket string) error {
-	bucketTags, err := s.repo.GetBucketTagging(ctx, bucket)
+	bucketTags, err := s.repo.GetLabels(ctx, bucket)
 	if err != nil {
 		return err
 	}
 	bucketTags["album/deleted_at"] = time.Now().Format(time.RFC3339)
-	return s.repo.SetBucketTagging(ctx, bucket, bucketTags)
+	return s.repo.SetLabels(ctx, bucket, bucketTags)
+}
+
+// Buckets returns the names of all the buckets, the buckets of the deleted albums included.
+func (s *Service) Buckets(ctx context.Context) ([]string, error) {
+	return s.repo.Containers(ctx)
+}
+
+// BucketLabels returns the labels of the bucket.
+func (s *Service) BucketLabels(ctx context.Context, bucket string) (map[string]string, error) {
+	return s.repo.GetLabels(ctx, bucket)
+}
+
+// Objects returns the names of all the objects of a bucket. Unlike ListBucket, the thumbnails without photo are returned
+// and the missing thumbnails are not created.
+func (s *Service) Objects(ctx context.Context, bucket string) ([]string, error) {
+	return s.repo.Names(ctx, bucket)
+}
+
+// RemoveBucket removes the bucket and all its objects. Unlike DeleteBucket, nothing is left to be recovered.
+func (s *Service) RemoveBucket(ctx context.Context, bucket string) error {
+	return s.repo.DeleteContainer(ctx, bucket)
+}
+
+// CopyBucket copies the labels and all the media of a bucket, with their thumbnails and the trashed media, to a new bucket.
+// The source bucket is left untouched. The new bucket is removed if the copy fails.
+func (s *Service) CopyBucket(ctx context.Context, src, dst string) error {
+	labels, err := s.repo.GetLabels(ctx, src)
+	if err != nil {
+		return fmt.Errorf("failed to get labels of bucket '%s': %v", src, err)
+	}
+
+	medias, err := s.repo.List(ctx, src)
+	if err != nil {
+		return fmt.Errorf("failed to list bucket '%s': %v", src, err)
+	}
+
+	trash, err := s.Trashed(ctx, src)
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for _, m := range medias {
+		names = append(names, objects(m)...)
+	}
+	for _, trashed := range trash {
+		names = append(names, trashed.Objects...)
+	}
+
+	if err := s.repo.CreateContainer(ctx, dst, labels); err != nil {
+		return fmt.Errorf("failed to create bucket '%s': %v", dst, err)
+	}
+
+	for _, f := range names {
+		if err := s.repo.Copy(ctx, src, f, dst, f); err != nil {
+			if rerr := s.repo.DeleteContainer(ctx, dst); rerr != nil {
+				zap.S().Errorw("failed to remove bucket", "error", rerr, "bucket", dst)
+			}
+			return fmt.Errorf("failed to copy media '%s' to bucket '%s': %v", f, dst, err)
+		}
+	}
+
+	return nil
+}
+
+// List returns the media of the bucket sorted like ListBucket but without creating the missing thumbnails.
+// It has no side effect and it is meant for the listings spanning several albums.
+func (s *Service) List(ctx context.Context, bucket string) ([]entity.Media, error) {
+	media, err := s.repo.List(ctx, bucket)
+	if err != nil {
+		return []entity.Media{}, fmt.Errorf("failed to list bucket '%s': %v", bucket, err)
+	}
+
+	ms := newSorter(media)
+	sort.Sort(ms)
+
+	return ms.medias, nil
 }
 
 func (s *Service) ListBucket(ctx context.Context, bucket string) ([]entity.Media, error) {
-	media, err := s.repo.ListBucket(ctx, bucket)
+	media, err := s.repo.List(ctx, bucket)
 	if err != nil {
 		return []entity.Media{}, fmt.Errorf("failed to list bucket '%s': %v", bucket, err)
 	}
 
-	// if a media has no thumbnail, create it now
+	// if a media has no thumbnail, create it now. It is not published: the media is only read.
 	for _, m := range media {
 		if len(m.Thumbnail) == 0 {
 			r, _, err := s.GetPhoto(ctx, m.Bucket, m.Filename)
@@ -94,8 +486,24 @@ func (s *Service) ListBucket(ctx context.Context, bucket string) ([]entity.Media
 	return ms.medias, nil
 }
 
+// CreateThumbnail creates the thumbnail of a photo, replacing the existing one.
+func (s *Service) CreateThumbnail(ctx context.Context, bucket, filename string) error {
+	r, _, err := s.GetPhoto(ctx, bucket, filename)
+	if err != nil {
+		return fmt.Errorf("failed to get photo '%s': %w", filename, err)
+	}
+
+	if err := createThumbnail(ctx, s.repo, bucket, filename, r); err != nil {
+		return err
+	}
+
+	s.publish(ctx, entity.EventThumbnailCreated, bucket, filename)
+
+	return nil
+}
+
 func (s *Service) GetPhoto(ctx context.Context, bucket, filename string) (io.ReadSeeker, map[string]string, error) {
-	r, metadata, err := s.repo.GetFile(ctx, bucket, filename)
+	r, metadata, err := s.repo.Get(ctx, bucket, filename)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -106,15 +514,8 @@ func (s *Service) GetPhoto(ctx context.Context, bucket, filename string) (io.Rea
 func (s *Service) Save(ctx context.Context, bucket, filename string, r io.ReadSeeker, mediaType MediaType) error {
 	switch mediaType {
 	case Photo:
-		if err := processPhoto(ctx, s.repo, bucket, filename, r); err != nil {
-			return err
-		}
-
-		if err := createThumbnail(ctx, s.repo, bucket, filename, r); err != nil {
-			return err
-		}
-
-		return nil
+		_, err := s.savePhoto(ctx, bucket, filename, r, nil)
+		return err
 	case Video:
 		return fmt.Errorf("not implementated")
 	default:
@@ -122,25 +523,312 @@ func (s *Service) Save(ctx context.Context, bucket, filename string, r io.ReadSe
 	}
 }
 
+// SaveWithMetadata saves a photo like Save. The capture date and the location override the ones of the EXIF data
+// when they are set, and a photo without EXIF data is accepted if its capture date is set. It returns the name of the saved photo.
+func (s *Service) SaveWithMetadata(ctx context.Context, bucket, filename string, r io.ReadSeeker, createDate time.Time, location *entity.GeoPoint) (string, error) {
+	overrides := make(map[string]string)
+	if !createDate.IsZero() {
+		overrides[image.DateKey] = createDate.Format(image.DateFormat)
+	}
+
+	if location != nil {
+		overrides[image.LatitudeKey] = strconv.FormatFloat(location.Latitude, 'f', 6, 64)
+		overrides[image.LongitudeKey] = strconv.FormatFloat(location.Longitude, 'f', 6, 64)
+	}
+
+	return s.savePhoto(ctx, bucket, filename, r, overrides)
+}
+
+func (s *Service) savePhoto(ctx context.Context, bucket, filename string, r io.ReadSeeker, overrides map[string]string) (string, error) {
+	photoName, err := processPhoto(ctx, s.repo, bucket, filename, r, overrides)
+	if err != nil {
+		return "", err
+	}
+
+	s.publish(ctx, entity.EventPhotoAdded, bucket, photoName)
+
+	if err := createThumbnail(ctx, s.repo, bucket, filename, r); err != nil {
+		return "", err
+	}
+
+	s.publish(ctx, entity.EventThumbnailCreated, bucket, photoName)
+
+	return photoName, nil
+}
+
+// Put saves an object as it is, without processing it. It is used to restore objects saved by Save.
+func (s *Service) Put(ctx context.Context, bucket, filename string, size int64, r io.Reader, metadata map[string]string) error {
+	return s.repo.Put(ctx, bucket, filename, size, r, metadata)
+}
+
+// RemoveObject removes an object as it is. It is used to remove the objects which are not media, like orphaned thumbnails.
+func (s *Service) RemoveObject(ctx context.Context, bucket, name string) error {
+	return s.repo.Delete(ctx, bucket, name)
+}
+
 func (s *Service) Delete(ctx context.Context, bucket, filename string) error {
 	if strings.Index(filename, "/") > 0 {
 		parts := strings.Split(filename, "/")
 
 		// delete thumbnail
-		if err := s.repo.DeleteFile(ctx, bucket, fmt.Sprintf("thumbnail/%s", parts[len(parts)-1])); err != nil {
+		if err := s.repo.Delete(ctx, bucket, fmt.Sprintf("thumbnail/%s", parts[len(parts)-1])); err != nil {
 			return err
 		}
 
 	}
 
-	return s.repo.DeleteFile(ctx, bucket, filename)
+	if err := s.repo.Delete(ctx, bucket, filename); err != nil {
+		return err
+	}
+
+	s.publish(ctx, entity.EventPhotoRemoved, bucket, filename)
+
+	return nil
+}
+
+// Move moves the media and their thumbnails to the bucket of another album together with their metadata.
+// The objects are copied on the server side and removed from the source bucket only once all the metadata has been moved,
+// so a failure leaves all the media in the source album.
+func (s *Service) Move(ctx context.Context, src, dst entity.Album, medias []entity.Media) error {
+	if err := s.copyObjects(ctx, src, dst, medias); err != nil {
+		return err
+	}
+
+	if err := s.mediaRepo.Move(ctx, src.ID, dst.ID, filenames(medias)); err != nil {
+		s.removeObjects(ctx, dst.Bucket, medias)
+		return err
+	}
+
+	// the media are now in the destination album. Leftovers in the source bucket are only logged.
+	s.removeObjects(ctx, src.Bucket, medias)
+
+	for _, m := range medias {
+		s.publish(ctx, entity.EventPhotoRemoved, src.Bucket, m.Filename)
+		s.publish(ctx, entity.EventPhotoAdded, dst.Bucket, m.Filename)
+	}
+
+	return nil
+}
+
+// Copy copies the media and their thumbnails to the bucket of another album together with their caption, description, place and tags.
+// A failure leaves no copy in the destination album.
+func (s *Service) Copy(ctx context.Context, src, dst entity.Album, medias []entity.Media) error {
+	if err := s.copyObjects(ctx, src, dst, medias); err != nil {
+		return err
+	}
+
+	if err := s.mediaRepo.Copy(ctx, src.ID, dst.ID, filenames(medias)); err != nil {
+		s.removeObjects(ctx, dst.Bucket, medias)
+		return err
+	}
+
+	for _, m := range medias {
+		s.publish(ctx, entity.EventPhotoAdded, dst.Bucket, m.Filename)
+	}
+
+	return nil
+}
+
+// copyObjects copies the media and their thumbnails to the bucket of the destination album.
+// Nothing is left in the destination bucket if one copy fails.
+func (s *Service) copyObjects(ctx context.Context, src, dst entity.Album, medias []entity.Media) error {
+	if src.ID == dst.ID {
+		return fmt.Errorf("media are already in album '%s'", dst.ID)
+	}
+
+	existing := make(map[string]struct{}, len(dst.Photos))
+	for _, photo := range dst.Photos {
+		existing[photo.Filename] = struct{}{}
+	}
+
+	for _, m := range medias {
+		if _, found := existing[m.Filename]; found {
+			return fmt.Errorf("media '%s' already exists in bucket '%s'", m.Filename, dst.Bucket)
+		}
+	}
+
+	for i, m := range medias {
+		for _, f := range objects(m) {
+			if err := s.repo.Copy(ctx, src.Bucket, f, dst.Bucket, f); err != nil {
+				// the media being copied may be half copied
+				s.removeObjects(ctx, dst.Bucket, medias[:i+1])
+				return fmt.Errorf("failed to copy media '%s' to bucket '%s': %v", f, dst.Bucket, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// removeObjects removes the media and their thumbnails from the bucket. Failures are only logged.
+func (s *Service) removeObjects(ctx context.Context, bucket string, medias []entity.Media) {
+	for _, m := range medias {
+		for _, f := range objects(m) {
+			if err := s.repo.Delete(ctx, bucket, f); err != nil {
+				zap.S().Errorw("failed to remove media", "error", err, "bucket", bucket, "filename", f)
+			}
+		}
+	}
+}
+
+// objects returns the names of the objects of the media.
+func objects(m entity.Media) []string {
+	if len(m.Thumbnail) == 0 {
+		return []string{m.Filename}
+	}
+
+	return []string{m.Filename, m.Thumbnail}
+}
+
+func filenames(medias []entity.Media) []string {
+	names := make([]string, 0, len(medias))
+	for _, m := range medias {
+		names = append(names, m.Filename)
+	}
+
+	return names
+}
+
+// WithMetadata fills caption, description, place, tags and the user's favorite flag of the album's media.
+func (s *Service) WithMetadata(ctx context.Context, albumID, user string, medias []entity.Media) ([]entity.Media, error) {
+	metadata, err := s.mediaRepo.GetByAlbum(ctx, albumID, user)
+	if err != nil {
+		return medias, err
+	}
+
+	enriched := make([]entity.Media, 0, len(medias))
+	for _, m := range medias {
+		m.Tags = []entity.Tag{}
+		if md, found := metadata[m.Filename]; found {
+			m.Caption = md.Caption
+			m.Description = md.Description
+			m.Favorite = md.Favorite
+			m.Tags = md.Tags
+			m.Place = md.Place
+		}
+		enriched = append(enriched, m)
+	}
+
+	return enriched, nil
 }
 
-func processPhoto(ctx context.Context, repo MinioRepository, bucket, filename string, r io.ReadSeeker) error {
+func (s *Service) UpdateMetadata(ctx context.Context, albumID string, media entity.Media) error {
+	return s.mediaRepo.Update(ctx, albumID, media)
+}
+
+// MetadataFilenames returns the filenames of the album's media having a caption, a description, a place or tags.
+func (s *Service) MetadataFilenames(ctx context.Context, albumID string) ([]string, error) {
+	metadata, err := s.mediaRepo.GetByAlbum(ctx, albumID, "")
+	if err != nil {
+		return []string{}, err
+	}
+
+	names := make([]string, 0, len(metadata))
+	for filename := range metadata {
+		names = append(names, filename)
+	}
+
+	return names, nil
+}
+
+// DeleteMetadata removes caption, description, favorites, tags, comments and reactions of the media.
+func (s *Service) DeleteMetadata(ctx context.Context, albumID, filename string) error {
+	return s.mediaRepo.Delete(ctx, albumID, filename)
+}
+
+func (s *Service) SetFavorite(ctx context.Context, albumID, filename, user string, favorite bool) error {
+	if favorite {
+		return s.mediaRepo.SetFavorite(ctx, albumID, filename, user)
+	}
+
+	return s.mediaRepo.RemoveFavorite(ctx, albumID, filename, user)
+}
+
+func (s *Service) Tag(ctx context.Context, albumID, filename string, tag entity.Tag) error {
+	if err := s.mediaRepo.Associate(ctx, albumID, filename, tag.ID); err != nil {
+		return fmt.Errorf("associate tag '%s' with media '%s': %+v", tag.ID, filename, err)
+	}
+
+	return nil
+}
+
+func (s *Service) Untag(ctx context.Context, albumID, filename string, tag entity.Tag) error {
+	if err := s.mediaRepo.Dissociate(ctx, albumID, filename, tag.ID); err != nil {
+		return fmt.Errorf("dissociate tag '%s' from media '%s': %+v", tag.ID, filename, err)
+	}
+
+	return nil
+}
+
+// Geocode finds the place of the geolocated media which have no place yet and saves it.
+// The places of the media of encrypted albums are not saved: they would be stored in the clear.
+// The media must have been filled with WithMetadata.
+func (s *Service) Geocode(ctx context.Context, album entity.Album, medias []entity.Media) ([]entity.Media, error) {
+	geocoded := make([]entity.Media, 0, len(medias))
+	for _, m := range medias {
+		if m.Location != nil && m.Place.IsZero() {
+			if place, found := s.geocoder.Lookup(*m.Location); found {
+				if !album.Encrypted {
+					if err := s.mediaRepo.SetPlace(ctx, album.ID, m.Filename, place); err != nil {
+						return medias, fmt.Errorf("set place of media '%s': %+v", m.Filename, err)
+					}
+				}
+
+				m.Place = place
+			}
+		}
+
+		geocoded = append(geocoded, m)
+	}
+
+	return geocoded, nil
+}
+
+// Ratings returns how many times each media of the album has been marked as favorite or got a reaction.
+func (s *Service) Ratings(ctx context.Context, albumID string) (map[string]int, error) {
+	return s.mediaRepo.GetRatings(ctx, albumID)
+}
+
+// Hash returns the perceptual hash of the photo. Photos uploaded before hashes were computed are hashed
+// from their thumbnail and the hash is not saved.
+func (s *Service) Hash(ctx context.Context, m entity.Media) (uint64, error) {
+	if m.Hash != nil {
+		return *m.Hash, nil
+	}
+
+	filename := m.Thumbnail
+	if len(filename) == 0 {
+		filename = m.Filename
+	}
+
+	r, _, err := s.repo.Get(ctx, m.Bucket, filename)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get photo '%s': %v", filename, err)
+	}
+
+	return image.DHash(r)
+}
+
+func (s *Service) publish(ctx context.Context, kind entity.EventKind, bucket, filename string) {
+	if s.publisher == nil {
+		return
+	}
+
+	s.publisher.Publish(ctx, entity.Event{
+		Kind:      kind,
+		Bucket:    bucket,
+		Filename:  filename,
+		CreatedAt: time.Now(),
+	})
+}
+
+// processPhoto encodes the photo as jpg and saves it to the bucket. It returns the name of the saved object.
+// overrides replace the metadata found in the EXIF data. The EXIF data is optional only if the date is overridden.
+func processPhoto(ctx context.Context, repo Storage, bucket, filename string, r io.ReadSeeker, overrides map[string]string) (string, error) {
 	var imgBuffer bytes.Buffer
 
 	if err := image.Process(r, &imgBuffer); err != nil {
-		return fmt.Errorf("failed to process image: %v", err)
+		return "", fmt.Errorf("%w: failed to process image: %v", services.ErrInvalidMedia, err)
 	}
 
 	basename := strings.Split(filename, ".")[0]
@@ -149,17 +837,33 @@ func processPhoto(ctx context.Context, repo MinioRepository, bucket, filename st
 
 	metadata, err := image.Metadata(r)
 	if err != nil {
-		return err
+		if _, found := overrides[image.DateKey]; !found {
+			return "", err
+		}
+
+		metadata = make(map[string]string)
 	}
 
-	if err := repo.PutFile(ctx, bucket, fmt.Sprintf("photos/%s.jpg", basename), int64(imgBuffer.Len()), &imgBuffer, metadata); err != nil {
-		return fmt.Errorf("failed to copy processed image to bucket '%s': %v", bucket, err)
+	for k, v := range overrides {
+		metadata[k] = v
 	}
 
-	return nil
+	hash, err := image.DHash(bytes.NewReader(imgBuffer.Bytes()))
+	if err != nil {
+		return "", err
+	}
+
+	metadata[image.HashKey] = strconv.FormatUint(hash, 16)
+
+	photoName := fmt.Sprintf("photos/%s.jpg", basename)
+	if err := repo.Put(ctx, bucket, photoName, int64(imgBuffer.Len()), &imgBuffer, metadata); err != nil {
+		return "", fmt.Errorf("failed to copy processed image to bucket '%s': %v", bucket, err)
+	}
+
+	return photoName, nil
 }
 
-func createThumbnail(ctx context.Context, repo MinioRepository, bucket, filename string, r io.ReadSeeker) error {
+func createThumbnail(ctx context.Context, repo Storage, bucket, filename string, r io.ReadSeeker) error {
 	var imgThumbnailBuffer bytes.Buffer
 
 	if err := image.CreateThumbnail(r, &imgThumbnailBuffer); err != nil {
@@ -170,7 +874,7 @@ func createThumbnail(ctx context.Context, repo MinioRepository, bucket, filename
 
 	emptyMetadata := make(map[string]string)
 
-	if err := repo.PutFile(ctx, bucket, fmt.Sprintf("thumbnail/%s", basename), int64(imgThumbnailBuffer.Len()), &imgThumbnailBuffer, emptyMetadata); err != nil {
+	if err := repo.Put(ctx, bucket, fmt.Sprintf("thumbnail/%s", basename), int64(imgThumbnailBuffer.Len()), &imgThumbnailBuffer, emptyMetadata); err != nil {
 		return fmt.Errorf("failed to copy thumbnail image to bucket '%s': %v", bucket, err)
 	}
 
@@ -1,13 +1,107 @@
 package media
 
-import "github.com/tupyy/gophoto/internal/entity"
+import (
+	"sort"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+type SortOrder int
+
+const (
+	NormalOrder SortOrder = iota
+	ReverseOrder
+)
+
+type SortType int
+
+const (
+	SortByCaptureDate SortType = iota
+	SortByUploadDate
+	SortByFilename
+	SortBySize
+)
 
 type mediaSorter struct {
-	medias []entity.Media
+	medias   []entity.Media
+	lessFunc func(m1, m2 entity.Media) bool
 }
 
+// newSorter returns a sorter by capture date, most recent first.
 func newSorter(m []entity.Media) *mediaSorter {
-	return &mediaSorter{m}
+	return &mediaSorter{medias: m, lessFunc: lessFunc(SortByCaptureDate, ReverseOrder)}
+}
+
+// Sort sorts the media. Media with the same key are sorted by filename so the order is stable between calls.
+func Sort(medias []entity.Media, sortType SortType, order SortOrder) {
+	sort.Sort(&mediaSorter{medias: medias, lessFunc: lessFunc(sortType, order)})
+}
+
+func lessFunc(sortType SortType, order SortOrder) func(m1, m2 entity.Media) bool {
+	var compare func(m1, m2 entity.Media) int
+
+	switch sortType {
+	case SortByUploadDate:
+		compare = func(m1, m2 entity.Media) int {
+			return compareTime(m1.UploadDate, m2.UploadDate)
+		}
+	case SortByFilename:
+		compare = func(m1, m2 entity.Media) int {
+			return 0
+		}
+	case SortBySize:
+		compare = func(m1, m2 entity.Media) int {
+			return compareInt(m1.Size, m2.Size)
+		}
+	default:
+		compare = func(m1, m2 entity.Media) int {
+			return compareTime(m1.CreateDate, m2.CreateDate)
+		}
+	}
+
+	return func(m1, m2 entity.Media) bool {
+		c := compare(m1, m2)
+		if c == 0 {
+			c = compareString(m1.Filename, m2.Filename)
+		}
+
+		if order == ReverseOrder {
+			return c > 0
+		}
+
+		return c < 0
+	}
+}
+
+func compareTime(t1, t2 time.Time) int {
+	switch {
+	case t1.Before(t2):
+		return -1
+	case t1.After(t2):
+		return 1
+	}
+	return 0
+}
+
+func compareInt(v1, v2 int64) int {
+	switch {
+	case v1 < v2:
+		return -1
+	case v1 > v2:
+		return 1
+	}
+	return 0
+}
+
+func compareString(s1, s2 string) int {
+	switch {
+	case s1 < s2:
+		return -1
+	case s1 > s2:
+		return 1
+	}
+	return 0
 }
 
 func (ms *mediaSorter) Len() int {
@@ -19,5 +113,5 @@ func (ms *mediaSorter) Swap(i, j int) {
 }
 
 func (ms *mediaSorter) Less(i, j int) bool {
-	return ms.medias[i].CreateDate.After(ms.medias[j].CreateDate)
+	return ms.lessFunc(ms.medias[i], ms.medias[j])
 }
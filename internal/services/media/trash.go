@@ -0,0 +1,202 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services"
+	"go.uber.org/zap"
+)
+
+// TrashPrefix is the prefix of the media moved to the trash. Each trashed media has its own folder named by an xid
+// holding the date it has been trashed, e.g. trash/<xid>/photos/a.jpg and trash/<xid>/thumbnail/a.jpg.
+// The trashed media are not listed.
+const TrashPrefix = "trash/"
+
+// TrashRetention is how long the media are kept in the trash. The media trashed before are purged by fsck.
+const TrashRetention = 30 * 24 * time.Hour
+
+// TrashedMedia is a media moved to the trash. Its metadata is kept until it is purged.
+type TrashedMedia struct {
+	// ID - id of the trash folder of the media
+	ID string
+	// Filename - name of the media before it has been trashed
+	Filename  string
+	TrashedAt time.Time
+	// Objects - names of the objects of the media in the trash
+	Objects []string
+}
+
+// ExpiresAt returns the date when the media can be purged.
+func (t TrashedMedia) ExpiresAt() time.Time {
+	return t.TrashedAt.Add(TrashRetention)
+}
+
+// Expired returns true if the media has been kept in the trash long enough to be purged.
+func (t TrashedMedia) Expired() bool {
+	return time.Now().After(t.ExpiresAt())
+}
+
+// Trash moves the media and its thumbnail to the trash of the bucket. The metadata of the media is kept so it is found
+// again when the media is restored. A failure leaves the media where it is.
+func (s *Service) Trash(ctx context.Context, bucket string, m entity.Media) (TrashedMedia, error) {
+	trashed := TrashedMedia{ID: xid.New().String(), Filename: m.Filename, Objects: []string{}}
+
+	for _, f := range objects(m) {
+		name := trashName(trashed.ID, f)
+		if err := s.repo.Copy(ctx, bucket, f, bucket, name); err != nil {
+			s.removeNames(ctx, bucket, trashed.Objects)
+			return TrashedMedia{}, fmt.Errorf("failed to move media '%s' to the trash: %v", f, err)
+		}
+
+		trashed.Objects = append(trashed.Objects, name)
+	}
+
+	// the media is in the trash. Leftovers are only logged.
+	s.removeNames(ctx, bucket, objects(m))
+	s.publish(ctx, entity.EventPhotoRemoved, bucket, m.Filename)
+
+	trashed.TrashedAt = time.Now()
+
+	return trashed, nil
+}
+
+// Trashed returns the media of the trash of the bucket, most recently trashed first.
+func (s *Service) Trashed(ctx context.Context, bucket string) ([]TrashedMedia, error) {
+	names, err := s.repo.Names(ctx, bucket)
+	if err != nil {
+		return []TrashedMedia{}, fmt.Errorf("failed to list bucket '%s': %v", bucket, err)
+	}
+
+	byID := make(map[string]*TrashedMedia)
+	for _, name := range names {
+		if !strings.HasPrefix(name, TrashPrefix) {
+			continue
+		}
+
+		parts := strings.SplitN(strings.TrimPrefix(name, TrashPrefix), "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		id, err := xid.FromString(parts[0])
+		if err != nil {
+			zap.S().Warnw("unexpected object in the trash", "bucket", bucket, "name", name)
+			continue
+		}
+
+		trashed, found := byID[parts[0]]
+		if !found {
+			trashed = &TrashedMedia{ID: parts[0], TrashedAt: id.Time(), Objects: []string{}}
+			byID[parts[0]] = trashed
+		}
+
+		trashed.Objects = append(trashed.Objects, name)
+		if !strings.HasPrefix(parts[1], "thumbnail/") {
+			trashed.Filename = parts[1]
+		}
+	}
+
+	trash := make([]TrashedMedia, 0, len(byID))
+	for _, trashed := range byID {
+		// a thumbnail without its media cannot be restored
+		if len(trashed.Filename) == 0 {
+			continue
+		}
+
+		sort.Strings(trashed.Objects)
+		trash = append(trash, *trashed)
+	}
+
+	sort.Slice(trash, func(i, j int) bool {
+		if !trash[i].TrashedAt.Equal(trash[j].TrashedAt) {
+			return trash[i].TrashedAt.After(trash[j].TrashedAt)
+		}
+		return trash[i].ID < trash[j].ID
+	})
+
+	return trash, nil
+}
+
+// Restore moves the trashed media back to the bucket. It fails if a media with the same name has been added since.
+func (s *Service) Restore(ctx context.Context, bucket, id string) (TrashedMedia, error) {
+	trashed, err := s.trashedMedia(ctx, bucket, id)
+	if err != nil {
+		return TrashedMedia{}, err
+	}
+
+	names, err := s.repo.Names(ctx, bucket)
+	if err != nil {
+		return TrashedMedia{}, fmt.Errorf("failed to list bucket '%s': %v", bucket, err)
+	}
+
+	for _, name := range names {
+		if name == trashed.Filename {
+			return TrashedMedia{}, fmt.Errorf("%w: media '%s' already exists in bucket '%s'", services.ErrMediaExists, trashed.Filename, bucket)
+		}
+	}
+
+	restored := make([]string, 0, len(trashed.Objects))
+	for _, name := range trashed.Objects {
+		f := strings.TrimPrefix(name, TrashPrefix+id+"/")
+		if err := s.repo.Copy(ctx, bucket, name, bucket, f); err != nil {
+			s.removeNames(ctx, bucket, restored)
+			return TrashedMedia{}, fmt.Errorf("failed to restore media '%s': %v", f, err)
+		}
+
+		restored = append(restored, f)
+	}
+
+	s.removeNames(ctx, bucket, trashed.Objects)
+	s.publish(ctx, entity.EventPhotoAdded, bucket, trashed.Filename)
+
+	return trashed, nil
+}
+
+// Purge removes the objects of the trashed media. Nothing is left to be restored.
+func (s *Service) Purge(ctx context.Context, bucket string, trashed TrashedMedia) error {
+	for _, name := range trashed.Objects {
+		if !strings.HasPrefix(name, TrashPrefix) {
+			return fmt.Errorf("object '%s' is not in the trash", name)
+		}
+
+		if err := s.repo.Delete(ctx, bucket, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) trashedMedia(ctx context.Context, bucket, id string) (TrashedMedia, error) {
+	trash, err := s.Trashed(ctx, bucket)
+	if err != nil {
+		return TrashedMedia{}, err
+	}
+
+	for _, trashed := range trash {
+		if trashed.ID == id {
+			return trashed, nil
+		}
+	}
+
+	return TrashedMedia{}, fmt.Errorf("%w: trashed media '%s'", services.ErrNotFound, id)
+}
+
+// removeNames removes the objects of the bucket. Failures are only logged.
+func (s *Service) removeNames(ctx context.Context, bucket string, names []string) {
+	for _, name := range names {
+		if err := s.repo.Delete(ctx, bucket, name); err != nil {
+			zap.S().Errorw("failed to remove object", "error", err, "bucket", bucket, "name", name)
+		}
+	}
+}
+
+func trashName(id, name string) string {
+	return TrashPrefix + id + "/" + name
+}
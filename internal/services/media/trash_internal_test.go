@@ -0,0 +1,132 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services"
+)
+
+func TestTrash(t *testing.T) {
+	ctx := context.Background()
+	a := entity.Media{Filename: "photos/a.jpg", Thumbnail: "thumbnail/a.jpg"}
+
+	// setup returns a service with the media a in the bucket.
+	setup := func(t *testing.T) (*Service, *memStorage, *memPublisher) {
+		storage := newMemStorage()
+		require.Nil(t, storage.CreateContainer(ctx, "bucket", map[string]string{}))
+		storage.objects["bucket"]["photos/a.jpg"] = []byte("content of a")
+		storage.objects["bucket"]["thumbnail/a.jpg"] = []byte("thumbnail of a")
+
+		publisher := &memPublisher{}
+
+		return New(&moveStorage{memStorage: storage}, newMemMediaRepo(), nil, publisher), storage, publisher
+	}
+
+	t.Run("trash and restore", func(t *testing.T) {
+		s, storage, publisher := setup(t)
+
+		trashed, err := s.Trash(ctx, "bucket", a)
+		require.Nil(t, err)
+		assert.Equal(t, "photos/a.jpg", trashed.Filename)
+		assert.Equal(t, []string{TrashPrefix + trashed.ID + "/photos/a.jpg", TrashPrefix + trashed.ID + "/thumbnail/a.jpg"}, trashed.Objects)
+		assert.Equal(t, trashed.Objects, names(storage, "bucket"), "only the trash must be left")
+		require.Equal(t, 1, len(publisher.events))
+		assert.Equal(t, entity.EventPhotoRemoved, publisher.events[0].Kind)
+
+		trash, err := s.Trashed(ctx, "bucket")
+		require.Nil(t, err)
+		require.Equal(t, 1, len(trash))
+		assert.Equal(t, trashed.ID, trash[0].ID)
+		assert.Equal(t, "photos/a.jpg", trash[0].Filename)
+		assert.WithinDuration(t, time.Now(), trash[0].TrashedAt, time.Minute)
+		assert.Equal(t, trash[0].TrashedAt.Add(TrashRetention), trash[0].ExpiresAt())
+
+		assert.False(t, trash[0].Expired())
+
+		restored, err := s.Restore(ctx, "bucket", trashed.ID)
+		require.Nil(t, err)
+		assert.Equal(t, "photos/a.jpg", restored.Filename)
+		assert.Equal(t, []string{"photos/a.jpg", "thumbnail/a.jpg"}, names(storage, "bucket"))
+		assert.Equal(t, "content of a", string(storage.objects["bucket"]["photos/a.jpg"]))
+		assert.Equal(t, entity.EventPhotoAdded, publisher.events[1].Kind)
+
+		_, err = s.Restore(ctx, "bucket", trashed.ID)
+		assert.True(t, errors.Is(err, services.ErrNotFound))
+	})
+
+	t.Run("restore over an existing media", func(t *testing.T) {
+		s, storage, _ := setup(t)
+
+		trashed, err := s.Trash(ctx, "bucket", a)
+		require.Nil(t, err)
+
+		storage.objects["bucket"]["photos/a.jpg"] = []byte("new a")
+
+		_, err = s.Restore(ctx, "bucket", trashed.ID)
+		assert.True(t, errors.Is(err, services.ErrMediaExists))
+		assert.Equal(t, "new a", string(storage.objects["bucket"]["photos/a.jpg"]))
+		assert.Equal(t, 3, len(storage.objects["bucket"]), "the trashed media must be kept")
+	})
+
+	t.Run("trash failing", func(t *testing.T) {
+		s, storage, publisher := setup(t)
+		// the names in the trash are not known in advance
+		s.repo = &trashFailingStorage{storage}
+
+		_, err := s.Trash(ctx, "bucket", a)
+		assert.NotNil(t, err)
+		assert.Equal(t, []string{"photos/a.jpg", "thumbnail/a.jpg"}, names(storage, "bucket"), "the media must be left where it is")
+		assert.Equal(t, 0, len(publisher.events))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		s, storage, _ := setup(t)
+
+		old := xid.NewWithTime(time.Now().Add(-TrashRetention - time.Hour)).String()
+		storage.objects["bucket"][TrashPrefix+old+"/photos/b.jpg"] = []byte("content of b")
+		storage.objects["bucket"][TrashPrefix+old+"/thumbnail/b.jpg"] = []byte("thumbnail of b")
+		// not named like the trash
+		storage.objects["bucket"][TrashPrefix+"unknown/photos/c.jpg"] = []byte("content of c")
+
+		_, err := s.Trash(ctx, "bucket", a)
+		require.Nil(t, err)
+
+		trash, err := s.Trashed(ctx, "bucket")
+		require.Nil(t, err)
+		require.Equal(t, 2, len(trash))
+		assert.Equal(t, "photos/a.jpg", trash[0].Filename)
+		assert.False(t, trash[0].Expired())
+		assert.Equal(t, "photos/b.jpg", trash[1].Filename)
+		assert.True(t, trash[1].Expired())
+
+		require.Nil(t, s.Purge(ctx, "bucket", trash[1]))
+
+		trash, err = s.Trashed(ctx, "bucket")
+		require.Nil(t, err)
+		require.Equal(t, 1, len(trash))
+		assert.Equal(t, "photos/a.jpg", trash[0].Filename)
+
+		assert.NotNil(t, s.Purge(ctx, "bucket", TrashedMedia{Objects: []string{"photos/a.jpg"}}), "only the trash can be purged")
+	})
+}
+
+// trashFailingStorage fails to copy the thumbnails to the trash.
+type trashFailingStorage struct {
+	*memStorage
+}
+
+func (f *trashFailingStorage) Copy(ctx context.Context, srcContainer, srcName, dstContainer, dstName string) error {
+	if strings.HasPrefix(dstName, TrashPrefix) && strings.Contains(dstName, "/thumbnail/") {
+		return errors.New("copy failed")
+	}
+
+	return f.memStorage.Copy(ctx, srcContainer, srcName, dstContainer, dstName)
+}
@@ -0,0 +1,84 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/album"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"go.uber.org/zap"
+)
+
+// Service moves the albums stored in their own bucket to the shared layout.
+type Service struct {
+	albumService *album.Service
+	mediaService *media.Service
+}
+
+func New(albumService *album.Service, mediaService *media.Service) *Service {
+	return &Service{albumService, mediaService}
+}
+
+// Pending returns the albums which are still stored in their own bucket, the oldest first.
+func (s *Service) Pending(ctx context.Context) ([]entity.Album, error) {
+	admin := entity.User{Role: entity.RoleAdmin}
+
+	albums, _, err := s.albumService.Query().SharedAlbums(true).All(ctx, admin)
+	if err != nil {
+		return []entity.Album{}, fmt.Errorf("failed to get albums: %w", err)
+	}
+
+	pending := make([]entity.Album, 0, len(albums))
+	for _, a := range albums {
+		if !media.IsAlbumContainer(a.Bucket) {
+			pending = append(pending, a)
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool { return pending[i].CreatedAt.Before(pending[j].CreatedAt) })
+
+	return pending, nil
+}
+
+// Migrate copies the objects of the album to albums/<album_id>/ in the shared bucket and points the album to it.
+// The album's bucket is then marked as deleted like the bucket of a deleted album, or removed if purge is true.
+// The album is left in its bucket if the copy fails, so the migration can be run again.
+func (s *Service) Migrate(ctx context.Context, a entity.Album, purge bool) (entity.Album, error) {
+	if media.IsAlbumContainer(a.Bucket) {
+		return a, nil
+	}
+
+	src, dst := a.Bucket, media.AlbumContainer(a.ID)
+
+	// leftovers of an interrupted migration. The album does not use them yet.
+	if err := s.mediaService.RemoveBucket(ctx, dst); err != nil {
+		return a, fmt.Errorf("failed to clean '%s': %w", dst, err)
+	}
+
+	if err := s.mediaService.CopyBucket(ctx, src, dst); err != nil {
+		return a, fmt.Errorf("failed to copy album '%s': %w", a.ID, err)
+	}
+
+	a.Bucket = dst
+	migrated, err := s.albumService.Update(ctx, a)
+	if err != nil {
+		if rerr := s.mediaService.RemoveBucket(ctx, dst); rerr != nil {
+			zap.S().Errorw("failed to remove copy of album", "error", rerr, "album_id", a.ID, "bucket", dst)
+		}
+		return entity.Album{}, fmt.Errorf("failed to update album '%s': %w", a.ID, err)
+	}
+
+	// the album is migrated. Failing to clean the old bucket is only logged.
+	if purge {
+		err = s.mediaService.RemoveBucket(ctx, src)
+	} else {
+		err = s.mediaService.DeleteBucket(ctx, src)
+	}
+	if err != nil {
+		zap.S().Errorw("failed to delete old bucket of album", "error", err, "album_id", a.ID, "bucket", src)
+	}
+
+	return migrated, nil
+}
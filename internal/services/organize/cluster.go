@@ -0,0 +1,149 @@
+package organize
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/album"
+)
+
+const (
+	// DefaultMaxGap - a gap longer than this between two photos starts a new album.
+	DefaultMaxGap = 6 * time.Hour
+	// DefaultMaxDistance - photos taken farther than this from the previous geolocated photo start a new album. In kilometers.
+	DefaultMaxDistance = 50.0
+)
+
+// Options tells when consecutive photos stop belonging to the same album.
+type Options struct {
+	MaxGap      time.Duration
+	MaxDistance float64
+}
+
+// DefaultOptions returns the options used when the user does not set them.
+func DefaultOptions() Options {
+	return Options{MaxGap: DefaultMaxGap, MaxDistance: DefaultMaxDistance}
+}
+
+// Proposal is an album proposed to the user.
+type Proposal struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+	// Place - place where most of the photos have been taken
+	Place entity.Place
+	// Location - location of the album
+	Location string
+	Photos   []entity.Media
+}
+
+// Cluster sorts the photos by capture date and splits them into albums.
+// A new album starts when the time since the previous photo is longer than MaxGap or
+// when the photo has been taken farther than MaxDistance from the previous geolocated photo.
+// Photos without location only split on time.
+func Cluster(medias []entity.Media, opts Options) []Proposal {
+	photos := make([]entity.Media, 0, len(medias))
+	for _, m := range medias {
+		if m.MediaType == entity.Photo {
+			photos = append(photos, m)
+		}
+	}
+
+	sort.SliceStable(photos, func(i, j int) bool {
+		d1, d2 := date(photos[i]), date(photos[j])
+		if !d1.Equal(d2) {
+			return d1.Before(d2)
+		}
+
+		return photos[i].Filename < photos[j].Filename
+	})
+
+	proposals := []Proposal{}
+
+	var (
+		current  []entity.Media
+		last     time.Time
+		location *entity.GeoPoint
+	)
+
+	for _, p := range photos {
+		split := len(current) > 0 && date(p).Sub(last) > opts.MaxGap
+		if !split && p.Location != nil && location != nil {
+			split = p.Location.Distance(*location) > opts.MaxDistance
+		}
+
+		if split {
+			proposals = append(proposals, newProposal(current))
+			current, location = nil, nil
+		}
+
+		current = append(current, p)
+		last = date(p)
+		if p.Location != nil {
+			location = p.Location
+		}
+	}
+
+	if len(current) > 0 {
+		proposals = append(proposals, newProposal(current))
+	}
+
+	return proposals
+}
+
+func newProposal(photos []entity.Media) Proposal {
+	p := Proposal{
+		Start:  date(photos[0]),
+		End:    date(photos[len(photos)-1]),
+		Place:  album.MainPlace(photos),
+		Photos: photos,
+	}
+
+	p.Name = Name(p.Place, p.Start, p.End)
+	p.Location = p.Place.String()
+
+	return p
+}
+
+// Name suggests the name of an album from the place and the dates of its photos.
+func Name(place entity.Place, start, end time.Time) string {
+	dates := dateRange(start, end)
+
+	var name string
+	switch {
+	case len(place.City) > 0:
+		name = place.City
+	case len(place.Region) > 0:
+		name = place.Region
+	case len(place.Country) > 0:
+		name = place.Country
+	default:
+		return dates
+	}
+
+	return fmt.Sprintf("%s, %s", name, dates)
+}
+
+func dateRange(start, end time.Time) string {
+	switch {
+	case start.Year() != end.Year():
+		return fmt.Sprintf("%s - %s", start.Format("2 Jan 2006"), end.Format("2 Jan 2006"))
+	case start.Month() != end.Month():
+		return fmt.Sprintf("%s - %s", start.Format("2 Jan"), end.Format("2 Jan 2006"))
+	case start.Day() != end.Day():
+		return fmt.Sprintf("%d-%s", start.Day(), end.Format("2 Jan 2006"))
+	default:
+		return start.Format("2 Jan 2006")
+	}
+}
+
+// date returns the capture date of the media or the upload date if the media has no capture date.
+func date(m entity.Media) time.Time {
+	if m.CreateDate.IsZero() {
+		return m.UploadDate
+	}
+
+	return m.CreateDate
+}
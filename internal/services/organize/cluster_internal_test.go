@@ -0,0 +1,101 @@
+package organize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+func TestCluster(t *testing.T) {
+	paris := &entity.GeoPoint{Latitude: 48.8566, Longitude: 2.3522}
+	versailles := &entity.GeoPoint{Latitude: 48.8049, Longitude: 2.1204}
+	lyon := &entity.GeoPoint{Latitude: 45.7640, Longitude: 4.8357}
+
+	medias := []entity.Media{
+		// unsorted on purpose
+		newPhoto("03.jpg", createDate(2022, 5, 1, 16), versailles, entity.Place{City: "Versailles"}),
+		newPhoto("01.jpg", createDate(2022, 5, 1, 10), paris, entity.Place{City: "Paris"}),
+		newPhoto("02.jpg", createDate(2022, 5, 1, 12), nil, entity.Place{}),
+		newPhoto("04.jpg", createDate(2022, 5, 2, 9), paris, entity.Place{City: "Paris"}),
+		// close in time but far away
+		newPhoto("05.jpg", createDate(2022, 5, 2, 11), lyon, entity.Place{City: "Lyon"}),
+		newPhoto("06.jpg", createDate(2022, 5, 2, 12), nil, entity.Place{}),
+		// long gap
+		newPhoto("07.jpg", createDate(2022, 6, 10, 12), nil, entity.Place{}),
+		{MediaType: entity.Video, Filename: "video.mp4", CreateDate: createDate(2022, 5, 1, 11)},
+	}
+
+	proposals := Cluster(medias, DefaultOptions())
+
+	assert.Equal(t, 4, len(proposals))
+
+	assert.Equal(t, []string{"photos/01.jpg", "photos/02.jpg", "photos/03.jpg"}, filenames(proposals[0]))
+	// tie between Paris and Versailles is broken by name
+	assert.Equal(t, "Paris, 1 May 2022", proposals[0].Name)
+	assert.Equal(t, "Paris", proposals[0].Location)
+
+	// the night is longer than the gap
+	assert.Equal(t, []string{"photos/04.jpg"}, filenames(proposals[1]))
+	assert.Equal(t, []string{"photos/05.jpg", "photos/06.jpg"}, filenames(proposals[2]))
+	assert.Equal(t, "Lyon, 2 May 2022", proposals[2].Name)
+
+	assert.Equal(t, []string{"photos/07.jpg"}, filenames(proposals[3]))
+	assert.Equal(t, "10 Jun 2022", proposals[3].Name)
+	assert.Equal(t, "", proposals[3].Location)
+}
+
+func TestName(t *testing.T) {
+	data := []struct {
+		place    entity.Place
+		start    time.Time
+		end      time.Time
+		expected string
+	}{
+		{
+			place:    entity.Place{City: "Paris", Country: "France"},
+			start:    createDate(2022, 5, 1, 10),
+			end:      createDate(2022, 5, 3, 10),
+			expected: "Paris, 1-3 May 2022",
+		},
+		{
+			place:    entity.Place{Region: "Bretagne", Country: "France"},
+			start:    createDate(2022, 4, 28, 10),
+			end:      createDate(2022, 5, 3, 10),
+			expected: "Bretagne, 28 Apr - 3 May 2022",
+		},
+		{
+			start:    createDate(2021, 12, 30, 10),
+			end:      createDate(2022, 1, 2, 10),
+			expected: "30 Dec 2021 - 2 Jan 2022",
+		},
+	}
+
+	for idx, d := range data {
+		assert.Equal(t, d.expected, Name(d.place, d.start, d.end), "test %d", idx)
+	}
+}
+
+func newPhoto(filename string, createDate time.Time, location *entity.GeoPoint, place entity.Place) entity.Media {
+	return entity.Media{
+		MediaType:  entity.Photo,
+		Filename:   "photos/" + filename,
+		CreateDate: createDate,
+		Location:   location,
+		Place:      place,
+	}
+}
+
+func createDate(year, month, day, hour int) time.Time {
+	return time.Date(year, time.Month(month), day, hour, 0, 0, 0, time.UTC)
+}
+
+func filenames(p Proposal) []string {
+	names := []string{}
+	for _, m := range p.Photos {
+		names = append(names, m.Filename)
+	}
+
+	return names
+}
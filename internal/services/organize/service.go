@@ -0,0 +1,99 @@
+package organize
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/album"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	albumService *album.Service
+	mediaService *media.Service
+}
+
+func New(albumService *album.Service, mediaService *media.Service) *Service {
+	return &Service{albumService, mediaService}
+}
+
+// Propose splits the photos of the album into new albums. The album's photos must be loaded.
+func (s *Service) Propose(ctx context.Context, source entity.Album, opts Options) ([]Proposal, error) {
+	photos, err := s.mediaService.WithMetadata(ctx, source.ID, source.Owner, source.Photos)
+	if err != nil {
+		return []Proposal{}, err
+	}
+
+	photos, err = s.mediaService.Geocode(ctx, source, photos)
+	if err != nil {
+		return []Proposal{}, err
+	}
+
+	return Cluster(photos, opts), nil
+}
+
+// Confirm creates an album owned by owner for each proposal and moves the proposal's photos from the source album to it.
+// It stops at the first failure. The albums created so far are returned with the error.
+func (s *Service) Confirm(ctx context.Context, owner string, source entity.Album, proposals []Proposal) ([]entity.Album, error) {
+	albums := make([]entity.Album, 0, len(proposals))
+	coverMoved := false
+
+	defer func() {
+		// the place and the cover of the source album change with its photos
+		s.refresh(ctx, source.ID, coverMoved)
+	}()
+
+	for _, p := range proposals {
+		a, err := s.albumService.Create(ctx, entity.Album{
+			Name:      p.Name,
+			CreatedAt: time.Now(),
+			Location:  p.Location,
+			Owner:     owner,
+		})
+		if err != nil {
+			return albums, err
+		}
+
+		if err := s.mediaService.Move(ctx, source, a, p.Photos); err != nil {
+			return append(albums, a), fmt.Errorf("failed to move photos to album '%s': %v", a.ID, err)
+		}
+
+		for _, photo := range p.Photos {
+			coverMoved = coverMoved || media.IsCover(photo, source.Thumbnail)
+		}
+
+		albums = append(albums, s.refresh(ctx, a.ID, true))
+	}
+
+	return albums, nil
+}
+
+// refresh reloads the album, updates its place and picks its cover if asked.
+// Failures are only logged since they are fixed with the next change of the album.
+func (s *Service) refresh(ctx context.Context, albumID string, pickThumbnail bool) entity.Album {
+	a, err := s.albumService.Query().First(ctx, albumID)
+	if err != nil {
+		zap.S().Warnw("failed to get album", "error", err, "album_id", albumID)
+		return entity.Album{ID: albumID}
+	}
+
+	a, err = s.albumService.Locate(ctx, a)
+	if err != nil {
+		zap.S().Warnw("failed to locate album", "error", err, "album_id", albumID)
+	}
+
+	if !pickThumbnail {
+		return a
+	}
+
+	updated, err := s.albumService.PickThumbnail(ctx, a)
+	if err != nil {
+		zap.S().Warnw("failed to pick thumbnail", "error", err, "album_id", albumID)
+		return a
+	}
+
+	return updated
+}
@@ -0,0 +1,64 @@
+package quota
+
+import (
+	"strings"
+
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+// Limits holds the storage quotas in bytes by username, group name and role. Names are case insensitive.
+// Negative quotas are unlimited.
+type Limits struct {
+	Users  map[string]int64
+	Groups map[string]int64
+	Roles  map[string]int64
+}
+
+// Limit returns the quota of the user and false if the user has no limit. The quota set for the user wins over
+// the quotas of the user's groups, the largest of which wins over the quota of the user's role.
+func (l Limits) Limit(user entity.User) (int64, bool) {
+	if q, found := lookup(l.Users, user.Username); found {
+		return q, q >= 0
+	}
+
+	limit, limited := int64(0), false
+	for _, g := range user.Groups {
+		q, found := lookup(l.Groups, g.Name)
+		if !found {
+			continue
+		}
+
+		// an unlimited group lifts the quotas of the other groups
+		if q < 0 {
+			return 0, false
+		}
+
+		if !limited || q > limit {
+			limit, limited = q, true
+		}
+	}
+
+	if limited {
+		return limit, true
+	}
+
+	if q, found := lookup(l.Roles, user.Role.String()); found {
+		return q, q >= 0
+	}
+
+	return 0, false
+}
+
+func lookup(quotas map[string]int64, name string) (int64, bool) {
+	if q, found := quotas[name]; found {
+		return q, true
+	}
+
+	for k, q := range quotas {
+		if strings.EqualFold(k, name) {
+			return q, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,61 @@
+package quota
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+func TestLimit(t *testing.T) {
+	limits := Limits{
+		Users:  map[string]int64{"alice": 10, "carol": -1},
+		Groups: map[string]int64{"Family": 100, "friends": 50, "staff": -1},
+		Roles:  map[string]int64{"user": 5},
+	}
+
+	data := []struct {
+		user    entity.User
+		limit   int64
+		limited bool
+	}{
+		{
+			// the user's quota wins over the group's quota
+			user:    entity.User{Username: "alice", Role: entity.RoleUser, Groups: []entity.Group{{Name: "family"}}},
+			limit:   10,
+			limited: true,
+		},
+		{
+			user:    entity.User{Username: "carol", Role: entity.RoleUser},
+			limited: false,
+		},
+		{
+			// the largest quota of the groups wins
+			user:    entity.User{Username: "bob", Role: entity.RoleUser, Groups: []entity.Group{{Name: "friends"}, {Name: "family"}}},
+			limit:   100,
+			limited: true,
+		},
+		{
+			user:    entity.User{Username: "bob", Role: entity.RoleUser, Groups: []entity.Group{{Name: "friends"}, {Name: "staff"}}},
+			limited: false,
+		},
+		{
+			// groups without quota are ignored
+			user:    entity.User{Username: "dave", Role: entity.RoleUser, Groups: []entity.Group{{Name: "other"}}},
+			limit:   5,
+			limited: true,
+		},
+		{
+			user:    entity.User{Username: "eve", Role: entity.RoleAdmin},
+			limited: false,
+		},
+	}
+
+	for idx, d := range data {
+		limit, limited := limits.Limit(d.user)
+		assert.Equal(t, d.limited, limited, "test %d", idx)
+		if d.limited {
+			assert.Equal(t, d.limit, limit, "test %d", idx)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services"
+	"github.com/tupyy/gophoto/internal/services/album"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"github.com/tupyy/gophoto/internal/services/users"
+)
+
+// Usage is the storage used by the albums of a user.
+type Usage struct {
+	// Used - bytes used by all the albums
+	Used int64
+	// Limit - quota of the user in bytes. Meaningless if Limited is false.
+	Limit   int64
+	Limited bool
+	// Albums - usage of each album, the largest first
+	Albums []AlbumUsage
+}
+
+// AlbumUsage is the storage used by an album.
+type AlbumUsage struct {
+	Album entity.Album
+	Bytes int64
+}
+
+// Service charges the storage used by an album to the album's owner and enforces the owner's quota.
+type Service struct {
+	albumService *album.Service
+	usersService *users.Service
+	// storage - storage without usage tracking, used to compute the usage
+	storage   media.Storage
+	usageRepo UsageRepository
+	limits    Limits
+}
+
+func New(albumService *album.Service, usersService *users.Service, storage media.Storage, usageRepo UsageRepository, limits Limits) *Service {
+	return &Service{albumService, usersService, storage, usageRepo, limits}
+}
+
+// Usage returns the storage used by the albums owned by the user and the user's quota.
+func (s *Service) Usage(ctx context.Context, user entity.User) (Usage, error) {
+	albums, _, err := s.albumService.Query().OwnAlbums(true).All(ctx, user)
+	if err != nil {
+		return Usage{}, fmt.Errorf("failed to get albums of user '%s': %w", user.Username, err)
+	}
+
+	buckets := make([]string, 0, len(albums))
+	for _, a := range albums {
+		buckets = append(buckets, a.Bucket)
+	}
+
+	bytes, err := s.usageRepo.Get(ctx, buckets)
+	if err != nil {
+		return Usage{}, fmt.Errorf("failed to get usage of user '%s': %w", user.Username, err)
+	}
+
+	usage := Usage{Albums: make([]AlbumUsage, 0, len(albums))}
+	usage.Limit, usage.Limited = s.limits.Limit(user)
+
+	for _, a := range albums {
+		usage.Albums = append(usage.Albums, AlbumUsage{Album: a, Bytes: bytes[a.Bucket]})
+		usage.Used += bytes[a.Bucket]
+	}
+
+	sort.SliceStable(usage.Albums, func(i, j int) bool { return usage.Albums[i].Bytes > usage.Albums[j].Bytes })
+
+	return usage, nil
+}
+
+// UsageOf returns the usage of the user with the username.
+func (s *Service) UsageOf(ctx context.Context, username string) (Usage, error) {
+	user, err := s.user(ctx, username)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	return s.Usage(ctx, user)
+}
+
+// Check returns services.ErrQuotaExceeded if adding size bytes to the album exceeds the quota of the album's owner.
+// The user is the one adding the media. The owner is looked up if someone else adds it.
+func (s *Service) Check(ctx context.Context, a entity.Album, size int64, user entity.User) error {
+	owner := user
+	if a.Owner != user.Username {
+		var err error
+
+		owner, err = s.user(ctx, a.Owner)
+		if err != nil {
+			return err
+		}
+	}
+
+	// no need to sum the usage if there is no limit
+	if _, limited := s.limits.Limit(owner); !limited {
+		return nil
+	}
+
+	usage, err := s.Usage(ctx, owner)
+	if err != nil {
+		return err
+	}
+
+	if usage.Used+size > usage.Limit {
+		return fmt.Errorf("%w: %d bytes used out of %d by the albums of '%s'", services.ErrQuotaExceeded, usage.Used, usage.Limit, owner.Username)
+	}
+
+	return nil
+}
+
+// Recompute computes the usage of every album from the objects of its bucket, the trashed media included.
+// It returns the usage mapped by album id.
+func (s *Service) Recompute(ctx context.Context) (map[string]int64, error) {
+	admin := entity.User{Role: entity.RoleAdmin}
+
+	albums, _, err := s.albumService.Query().SharedAlbums(true).All(ctx, admin)
+	if err != nil {
+		return map[string]int64{}, fmt.Errorf("failed to get albums: %w", err)
+	}
+
+	usage := make(map[string]int64, len(albums))
+	for _, a := range albums {
+		bytes, err := s.recompute(ctx, a.Bucket)
+		if err != nil {
+			return usage, fmt.Errorf("failed to compute usage of album '%s': %w", a.ID, err)
+		}
+
+		usage[a.ID] = bytes
+	}
+
+	return usage, nil
+}
+
+func (s *Service) recompute(ctx context.Context, bucket string) (int64, error) {
+	medias, err := s.storage.List(ctx, bucket)
+	if err != nil {
+		return 0, err
+	}
+
+	var bytes int64
+	for _, m := range medias {
+		bytes += m.Size
+
+		if len(m.Thumbnail) == 0 {
+			continue
+		}
+
+		if size, err := s.storage.Size(ctx, bucket, m.Thumbnail); err == nil {
+			bytes += size
+		}
+	}
+
+	// the trashed media are counted until they are purged
+	names, err := s.storage.Names(ctx, bucket)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, name := range names {
+		if !strings.HasPrefix(name, media.TrashPrefix) {
+			continue
+		}
+
+		if size, err := s.storage.Size(ctx, bucket, name); err == nil {
+			bytes += size
+		}
+	}
+
+	if err := s.usageRepo.Set(ctx, bucket, bytes); err != nil {
+		return 0, err
+	}
+
+	return bytes, nil
+}
+
+// user returns the user with the username. The users are matched by pattern so the exact username is looked for.
+func (s *Service) user(ctx context.Context, username string) (entity.User, error) {
+	found, err := s.usersService.Query().Where(users.Username(username)).All(ctx)
+	if err != nil {
+		return entity.User{}, fmt.Errorf("failed to get user '%s': %w", username, err)
+	}
+
+	for _, u := range found {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+
+	return entity.User{}, fmt.Errorf("%w: user '%s'", services.ErrNotFound, username)
+}
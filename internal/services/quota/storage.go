@@ -0,0 +1,146 @@
+package quota
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/tupyy/gophoto/internal/services/media"
+	"go.uber.org/zap"
+)
+
+// UsageRepository holds the size of the objects of each bucket.
+type UsageRepository interface {
+	// Add adds delta bytes to the usage of the bucket.
+	Add(ctx context.Context, bucket string, delta int64) error
+	// Set replaces the usage of the bucket.
+	Set(ctx context.Context, bucket string, bytes int64) error
+	// Delete removes the usage of the bucket.
+	Delete(ctx context.Context, bucket string) error
+	// Get returns the usage of the buckets mapped by bucket.
+	Get(ctx context.Context, buckets []string) (map[string]int64, error)
+}
+
+// Storage tracks the usage of each container while saving and removing the objects of the wrapped storage.
+// The objects uploaded with pre-signed urls are not counted until the upload is completed.
+// Failing to update the usage does not fail the operation. The usage can be computed again with Service.Recompute.
+type Storage struct {
+	media.Storage
+	usageRepo UsageRepository
+}
+
+// presigningStorage tracks the usage of a storage issuing pre-signed urls.
+type presigningStorage struct {
+	*Storage
+	media.Presigner
+}
+
+// NewStorage wraps the storage to track the usage of its containers. The returned storage issues pre-signed urls
+// if the wrapped storage does.
+func NewStorage(storage media.Storage, usageRepo UsageRepository) media.Storage {
+	s := &Storage{storage, usageRepo}
+
+	if presigner, ok := storage.(media.Presigner); ok {
+		return &presigningStorage{s, presigner}
+	}
+
+	return s
+}
+
+func (s *Storage) Put(ctx context.Context, container, name string, size int64, r io.Reader, metadata map[string]string) error {
+	if !tracked(name) {
+		return s.Storage.Put(ctx, container, name, size, r, metadata)
+	}
+
+	// the object may be replaced
+	previous, _ := s.Storage.Size(ctx, container, name)
+
+	if err := s.Storage.Put(ctx, container, name, size, r, metadata); err != nil {
+		return err
+	}
+
+	s.add(ctx, container, size-previous)
+
+	return nil
+}
+
+func (s *Storage) Copy(ctx context.Context, srcContainer, srcName, dstContainer, dstName string) error {
+	if !tracked(dstName) {
+		return s.Storage.Copy(ctx, srcContainer, srcName, dstContainer, dstName)
+	}
+
+	copied, err := s.Storage.Size(ctx, srcContainer, srcName)
+	if err != nil {
+		return s.Storage.Copy(ctx, srcContainer, srcName, dstContainer, dstName)
+	}
+
+	previous, _ := s.Storage.Size(ctx, dstContainer, dstName)
+
+	if err := s.Storage.Copy(ctx, srcContainer, srcName, dstContainer, dstName); err != nil {
+		return err
+	}
+
+	s.add(ctx, dstContainer, copied-previous)
+
+	return nil
+}
+
+func (s *Storage) Delete(ctx context.Context, container, name string) error {
+	if !tracked(name) {
+		return s.Storage.Delete(ctx, container, name)
+	}
+
+	deleted, err := s.Storage.Size(ctx, container, name)
+	if err != nil {
+		// missing objects are not counted
+		return s.Storage.Delete(ctx, container, name)
+	}
+
+	if err := s.Storage.Delete(ctx, container, name); err != nil {
+		return err
+	}
+
+	s.add(ctx, container, -deleted)
+
+	return nil
+}
+
+func (s *Storage) CreateContainer(ctx context.Context, container string, labels map[string]string) error {
+	if err := s.Storage.CreateContainer(ctx, container, labels); err != nil {
+		return err
+	}
+
+	s.reset(ctx, container)
+
+	return nil
+}
+
+func (s *Storage) DeleteContainer(ctx context.Context, container string) error {
+	if err := s.Storage.DeleteContainer(ctx, container); err != nil {
+		return err
+	}
+
+	s.reset(ctx, container)
+
+	return nil
+}
+
+func (s *Storage) add(ctx context.Context, container string, delta int64) {
+	if delta == 0 {
+		return
+	}
+
+	if err := s.usageRepo.Add(ctx, container, delta); err != nil {
+		zap.S().Errorw("failed to update usage", "error", err, "bucket", container, "delta", delta)
+	}
+}
+
+func (s *Storage) reset(ctx context.Context, container string) {
+	if err := s.usageRepo.Delete(ctx, container); err != nil {
+		zap.S().Errorw("failed to reset usage", "error", err, "bucket", container)
+	}
+}
+
+func tracked(name string) bool {
+	return !strings.HasPrefix(name, media.UploadPrefix)
+}
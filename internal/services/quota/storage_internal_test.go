@@ -0,0 +1,188 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/media"
+)
+
+// memStorage keeps the objects in memory. Only the methods used by the tracking and the recompute are implemented.
+type memStorage struct {
+	media.Storage
+	objects map[string][]byte
+}
+
+func (m *memStorage) Size(ctx context.Context, container, name string) (int64, error) {
+	content, found := m.objects[container+"/"+name]
+	if !found {
+		return 0, errors.New("not found")
+	}
+
+	return int64(len(content)), nil
+}
+
+func (m *memStorage) Put(ctx context.Context, container, name string, size int64, r io.Reader, metadata map[string]string) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	m.objects[container+"/"+name] = content
+
+	return nil
+}
+
+func (m *memStorage) Copy(ctx context.Context, srcContainer, srcName, dstContainer, dstName string) error {
+	m.objects[dstContainer+"/"+dstName] = m.objects[srcContainer+"/"+srcName]
+	return nil
+}
+
+func (m *memStorage) Delete(ctx context.Context, container, name string) error {
+	delete(m.objects, container+"/"+name)
+	return nil
+}
+
+func (m *memStorage) List(ctx context.Context, container string) ([]entity.Media, error) {
+	medias := []entity.Media{}
+	for _, name := range m.names(container) {
+		if strings.HasPrefix(name, "photos/") {
+			filename := strings.TrimPrefix(name, "photos/")
+			medias = append(medias, entity.Media{Filename: name, Thumbnail: "thumbnail/" + filename, Size: int64(len(m.objects[container+"/"+name]))})
+		}
+	}
+
+	return medias, nil
+}
+
+func (m *memStorage) Names(ctx context.Context, container string) ([]string, error) {
+	return m.names(container), nil
+}
+
+func (m *memStorage) names(container string) []string {
+	names := []string{}
+	for k := range m.objects {
+		if strings.HasPrefix(k, container+"/") {
+			names = append(names, strings.TrimPrefix(k, container+"/"))
+		}
+	}
+
+	return names
+}
+
+func (m *memStorage) DeleteContainer(ctx context.Context, container string) error {
+	for k := range m.objects {
+		if strings.HasPrefix(k, container+"/") {
+			delete(m.objects, k)
+		}
+	}
+
+	return nil
+}
+
+type presigningMemStorage struct {
+	memStorage
+}
+
+func (p *presigningMemStorage) PresignGet(ctx context.Context, container, name string, expiry time.Duration) (string, error) {
+	return "", nil
+}
+
+func (p *presigningMemStorage) PresignPost(ctx context.Context, container, name string, maxSize int64, expiry time.Duration) (string, map[string]string, error) {
+	return "", map[string]string{}, nil
+}
+
+func (p *presigningMemStorage) Uploads(ctx context.Context, container string) ([]string, error) {
+	return []string{}, nil
+}
+
+type memUsageRepo struct {
+	usage map[string]int64
+}
+
+func (m *memUsageRepo) Add(ctx context.Context, bucket string, delta int64) error {
+	m.usage[bucket] += delta
+	return nil
+}
+
+func (m *memUsageRepo) Set(ctx context.Context, bucket string, bytes int64) error {
+	m.usage[bucket] = bytes
+	return nil
+}
+
+func (m *memUsageRepo) Delete(ctx context.Context, bucket string) error {
+	delete(m.usage, bucket)
+	return nil
+}
+
+func (m *memUsageRepo) Get(ctx context.Context, buckets []string) (map[string]int64, error) {
+	return m.usage, nil
+}
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+	repo := &memUsageRepo{usage: make(map[string]int64)}
+	s := NewStorage(&memStorage{objects: make(map[string][]byte)}, repo)
+
+	put := func(container, name, content string) {
+		assert.Nil(t, s.Put(ctx, container, name, int64(len(content)), strings.NewReader(content), nil))
+	}
+
+	put("a", "photos/1.jpg", "12345")
+	put("a", "thumbnail/1.jpg", "12")
+	assert.Equal(t, int64(7), repo.usage["a"])
+
+	// replacing an object counts the difference
+	put("a", "photos/1.jpg", "123")
+	assert.Equal(t, int64(5), repo.usage["a"])
+
+	// uploads are not counted until completed
+	put("a", media.UploadPrefix+"x/2.jpg", "1234567890")
+	assert.Equal(t, int64(5), repo.usage["a"])
+
+	assert.Nil(t, s.Copy(ctx, "a", "photos/1.jpg", "b", "photos/1.jpg"))
+	assert.Equal(t, int64(5), repo.usage["a"])
+	assert.Equal(t, int64(3), repo.usage["b"])
+
+	assert.Nil(t, s.Delete(ctx, "a", "photos/1.jpg"))
+	assert.Equal(t, int64(2), repo.usage["a"])
+
+	// missing objects are not counted
+	assert.Nil(t, s.Delete(ctx, "a", "photos/1.jpg"))
+	assert.Equal(t, int64(2), repo.usage["a"])
+
+	assert.Nil(t, s.DeleteContainer(ctx, "b"))
+	_, found := repo.usage["b"]
+	assert.False(t, found)
+
+	// the wrapped storage's pre-signed urls are kept
+	_, ok := s.(media.Presigner)
+	assert.False(t, ok)
+
+	_, ok = NewStorage(&presigningMemStorage{memStorage{objects: make(map[string][]byte)}}, repo).(media.Presigner)
+	assert.True(t, ok)
+}
+
+func TestRecompute(t *testing.T) {
+	ctx := context.Background()
+	repo := &memUsageRepo{usage: make(map[string]int64)}
+	storage := &memStorage{objects: map[string][]byte{
+		"a/photos/1.jpg":                               []byte("12345"),
+		"a/thumbnail/1.jpg":                            []byte("12"),
+		"a/" + media.TrashPrefix + "x/photos/2.jpg":    []byte("123"),
+		"a/" + media.TrashPrefix + "x/thumbnail/2.jpg": []byte("1"),
+	}}
+
+	s := New(nil, nil, storage, repo, Limits{})
+
+	bytes, err := s.recompute(ctx, "a")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11), bytes, "the trashed media must be counted")
+	assert.Equal(t, int64(11), repo.usage["a"])
+}
@@ -0,0 +1,134 @@
+package similar
+
+import (
+	"sort"
+
+	"github.com/tupyy/gophoto/internal/services/image"
+	"github.com/tupyy/gophoto/internal/services/timeline"
+)
+
+const (
+	// DefaultThreshold - maximum number of different bits between the hashes of two similar photos.
+	DefaultThreshold = 10
+	// MaxThreshold - above this threshold unrelated photos start to be grouped together.
+	MaxThreshold = 24
+)
+
+// Group is a set of near-duplicate photos.
+type Group struct {
+	Items []timeline.Item
+}
+
+// Best returns the photo to keep: the one with the highest rating, then the biggest one.
+// Photos of the same size are compared by upload date, the oldest being the original.
+func (g Group) Best(rating func(item timeline.Item) int) timeline.Item {
+	best := g.Items[0]
+	for _, item := range g.Items[1:] {
+		if better(item, best, rating) {
+			best = item
+		}
+	}
+
+	return best
+}
+
+// Outliers returns the photos of the group whose hash differs from the hash of the kept photo by more than threshold bits.
+// Photos without hash are outliers since they cannot be compared.
+func (g Group) Outliers(kept timeline.Item, threshold int) []timeline.Item {
+	outliers := []timeline.Item{}
+	for _, item := range g.Items {
+		if kept.Photo.Hash == nil || item.Photo.Hash == nil || image.Distance(*kept.Photo.Hash, *item.Photo.Hash) > threshold {
+			outliers = append(outliers, item)
+		}
+	}
+
+	return outliers
+}
+
+func better(i1, i2 timeline.Item, rating func(item timeline.Item) int) bool {
+	if r1, r2 := rating(i1), rating(i2); r1 != r2 {
+		return r1 > r2
+	}
+
+	if i1.Photo.Size != i2.Photo.Size {
+		return i1.Photo.Size > i2.Photo.Size
+	}
+
+	if !i1.Photo.UploadDate.Equal(i2.Photo.UploadDate) {
+		return i1.Photo.UploadDate.Before(i2.Photo.UploadDate)
+	}
+
+	return i1.Photo.Filename < i2.Photo.Filename
+}
+
+// Groups groups the photos whose hashes differ by at most threshold bits. A photo similar to any photo of a group
+// joins the group. Photos without hash and photos without any similar photo are left out.
+// The order of the items is kept inside a group and groups are sorted by size, biggest first.
+func Groups(items []timeline.Item, threshold int) []Group {
+	hashed := make([]int, 0, len(items))
+	for i, item := range items {
+		if item.Photo.Hash != nil {
+			hashed = append(hashed, i)
+		}
+	}
+
+	parents := make([]int, len(items))
+	for i := range parents {
+		parents[i] = i
+	}
+
+	for a := 0; a < len(hashed); a++ {
+		for b := a + 1; b < len(hashed); b++ {
+			i, j := hashed[a], hashed[b]
+			if image.Distance(*items[i].Photo.Hash, *items[j].Photo.Hash) <= threshold {
+				union(parents, i, j)
+			}
+		}
+	}
+
+	byRoot := make(map[int]int)
+	groups := []Group{}
+	for _, i := range hashed {
+		root := find(parents, i)
+
+		idx, found := byRoot[root]
+		if !found {
+			idx = len(groups)
+			byRoot[root] = idx
+			groups = append(groups, Group{})
+		}
+
+		groups[idx].Items = append(groups[idx].Items, items[i])
+	}
+
+	similar := make([]Group, 0, len(groups))
+	for _, g := range groups {
+		if len(g.Items) > 1 {
+			similar = append(similar, g)
+		}
+	}
+
+	sort.SliceStable(similar, func(i, j int) bool {
+		return len(similar[i].Items) > len(similar[j].Items)
+	})
+
+	return similar
+}
+
+func find(parents []int, i int) int {
+	for parents[i] != i {
+		parents[i] = parents[parents[i]]
+		i = parents[i]
+	}
+
+	return i
+}
+
+func union(parents []int, i, j int) {
+	ri, rj := find(parents, i), find(parents, j)
+	if ri < rj {
+		parents[rj] = ri
+	} else {
+		parents[ri] = rj
+	}
+}
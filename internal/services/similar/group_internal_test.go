@@ -0,0 +1,92 @@
+package similar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/timeline"
+)
+
+func TestGroups(t *testing.T) {
+	items := []timeline.Item{
+		newItem("a.jpg", 0b0000_0000, 100),
+		newItem("b.jpg", 0xffff_ffff_0000_0000, 100),
+		newItem("c.jpg", 0b0000_0011, 100),
+		newItem("d.jpg", 0b0000_1111, 100),
+		newItem("e.jpg", 0xffff_ffff_0000_0001, 100),
+		{Album: entity.Album{ID: "album"}, Photo: entity.Media{Filename: "photos/nohash.jpg"}},
+		newItem("f.jpg", 0x00ff_00ff_00ff_00ff, 100),
+	}
+
+	groups := Groups(items, 2)
+
+	assert.Equal(t, 2, len(groups))
+	// d is 2 bits away from c which is 2 bits away from a
+	assert.Equal(t, []string{"photos/a.jpg", "photos/c.jpg", "photos/d.jpg"}, filenames(groups[0]))
+	assert.Equal(t, []string{"photos/b.jpg", "photos/e.jpg"}, filenames(groups[1]))
+
+	assert.Equal(t, 0, len(Groups(items, 0)))
+}
+
+func TestBest(t *testing.T) {
+	small := newItem("small.jpg", 0, 100)
+	big := newItem("big.jpg", 0, 200)
+	original := newItem("original.jpg", 0, 200)
+	original.Photo.UploadDate = big.Photo.UploadDate.Add(-time.Hour)
+
+	noRating := func(item timeline.Item) int { return 0 }
+
+	g := Group{Items: []timeline.Item{small, big, original}}
+	assert.Equal(t, "photos/original.jpg", g.Best(noRating).Photo.Filename)
+
+	g = Group{Items: []timeline.Item{small, big}}
+	assert.Equal(t, "photos/big.jpg", g.Best(noRating).Photo.Filename)
+
+	favorite := func(item timeline.Item) int {
+		if item.Photo.Filename == "photos/small.jpg" {
+			return 1
+		}
+		return 0
+	}
+	assert.Equal(t, "photos/small.jpg", g.Best(favorite).Photo.Filename)
+}
+
+func TestOutliers(t *testing.T) {
+	a := newItem("a.jpg", 0b0000_0000, 100)
+	c := newItem("c.jpg", 0b0000_0011, 100)
+	d := newItem("d.jpg", 0b0000_1111, 100)
+	noHash := timeline.Item{Album: entity.Album{ID: "album"}, Photo: entity.Media{Filename: "photos/nohash.jpg"}}
+
+	// d is in the group of a through c but too far from a to be deleted in favor of a
+	g := Group{Items: []timeline.Item{a, c, d}}
+	assert.Equal(t, []string{"photos/d.jpg"}, filenames(Group{Items: g.Outliers(a, 2)}))
+	assert.Equal(t, 0, len(g.Outliers(c, 2)))
+
+	g = Group{Items: []timeline.Item{a, noHash}}
+	assert.Equal(t, []string{"photos/nohash.jpg"}, filenames(Group{Items: g.Outliers(a, 2)}))
+	assert.Equal(t, 2, len(g.Outliers(noHash, 2)))
+}
+
+func newItem(filename string, hash uint64, size int64) timeline.Item {
+	return timeline.Item{
+		Album: entity.Album{ID: "album", Bucket: "bucket"},
+		Photo: entity.Media{
+			Bucket:     "bucket",
+			Filename:   "photos/" + filename,
+			Size:       size,
+			UploadDate: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			Hash:       &hash,
+		},
+	}
+}
+
+func filenames(g Group) []string {
+	names := []string{}
+	for _, item := range g.Items {
+		names = append(names, item.Photo.Filename)
+	}
+
+	return names
+}
@@ -0,0 +1,254 @@
+package takeout
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/tupyy/gophoto/internal/services/importer"
+)
+
+const albumMetadataName = "metadata.json"
+
+// roots are the folders holding the albums, in an archive and in an extracted archive.
+var roots = []string{"Takeout/Google Photos", "Google Photos"}
+
+// yearFolder matches the folders holding all the photos of a year. Photos found in an album too are not imported twice.
+var yearFolder = regexp.MustCompile(`^Photos from [0-9]{4}$`)
+
+// skippedFolders are not imported.
+var skippedFolders = map[string]struct{}{
+	"Trash": {},
+	"Bin":   {},
+}
+
+// Report lists the items of the export which are not imported as they are. The lists are sorted.
+type Report struct {
+	// Unsupported - files which cannot be imported like videos
+	Unsupported []string
+	// Duplicates - photos of the year folders already found in an album
+	Duplicates []string
+	// NoMetadata - photos without sidecar. They are imported with their EXIF data only.
+	NoMetadata []string
+	// Skipped - folders which are not imported like the trash
+	Skipped []string
+	// Invalid - sidecar and album metadata files which cannot be parsed
+	Invalid []string
+}
+
+type folder struct {
+	path  string
+	files []fs.DirEntry
+	json  map[string][]byte
+	// titles - sidecars mapped by title. It is filled the first time a sidecar is not found by name.
+	titles map[string]Sidecar
+}
+
+// Scan reads a Google Takeout export, either the archive or the extracted folder, and returns its albums sorted by path.
+// The metadata of the photos and the albums is read from the json files written by Google next to them.
+func Scan(fsys fs.FS) ([]importer.Folder, Report, error) {
+	report := Report{}
+
+	root := "."
+	for _, r := range roots {
+		if info, err := fs.Stat(fsys, r); err == nil && info.IsDir() {
+			root = r
+			break
+		}
+	}
+
+	folders := make(map[string]*folder)
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if p != root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if _, found := skippedFolders[d.Name()]; found && p != root {
+				report.Skipped = append(report.Skipped, p)
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		dir := path.Dir(p)
+
+		f, found := folders[dir]
+		if !found {
+			f = &folder{path: dir, json: make(map[string][]byte)}
+			folders[dir] = f
+		}
+
+		switch {
+		case strings.ToLower(path.Ext(p)) == ".json":
+			data, err := fs.ReadFile(fsys, p)
+			if err != nil {
+				return err
+			}
+			f.json[d.Name()] = data
+		case importer.Supported(p):
+			f.files = append(f.files, d)
+		default:
+			// files at the root of the export are not photos
+			if dir != root {
+				report.Unsupported = append(report.Unsupported, p)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []importer.Folder{}, report, fmt.Errorf("failed to read the export: %w", err)
+	}
+
+	// albums are read before the year folders to find the duplicated photos
+	sorted := make([]*folder, 0, len(folders))
+	for _, f := range folders {
+		sorted = append(sorted, f)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		yi, yj := yearFolder.MatchString(path.Base(sorted[i].path)), yearFolder.MatchString(path.Base(sorted[j].path))
+		if yi != yj {
+			return yj
+		}
+		return sorted[i].path < sorted[j].path
+	})
+
+	seen := make(map[string]struct{})
+
+	albums := make([]importer.Folder, 0, len(sorted))
+	for _, f := range sorted {
+		album := f.album(root, &report)
+		isYear := yearFolder.MatchString(path.Base(f.path))
+
+		for _, d := range f.files {
+			p := path.Join(f.path, d.Name())
+
+			key := d.Name()
+			if info, err := d.Info(); err == nil {
+				key = fmt.Sprintf("%s/%d", d.Name(), info.Size())
+			}
+
+			if _, found := seen[key]; found && isYear {
+				report.Duplicates = append(report.Duplicates, p)
+				continue
+			}
+			seen[key] = struct{}{}
+
+			album.Files = append(album.Files, p)
+
+			sidecar, found := f.sidecar(d.Name(), &report)
+			if !found {
+				report.NoMetadata = append(report.NoMetadata, p)
+				continue
+			}
+
+			album.Photos[p] = sidecar.Photo()
+		}
+
+		if len(album.Files) > 0 {
+			albums = append(albums, album)
+		}
+	}
+
+	sort.Slice(albums, func(i, j int) bool {
+		return albums[i].Path < albums[j].Path
+	})
+
+	for _, items := range [][]string{report.Unsupported, report.Duplicates, report.NoMetadata, report.Skipped, report.Invalid} {
+		sort.Strings(items)
+	}
+
+	return albums, report, nil
+}
+
+// album returns the album of the folder without its photos. The name of the album is read from its metadata.
+func (f *folder) album(root string, report *Report) importer.Folder {
+	name := path.Base(f.path)
+	if f.path == root {
+		name = "Google Photos"
+	}
+
+	album := importer.Folder{
+		Path:   f.path,
+		Name:   name,
+		Files:  []string{},
+		Photos: make(map[string]importer.Photo),
+	}
+
+	data, found := f.json[albumMetadataName]
+	if !found {
+		return album
+	}
+
+	title, description, err := parseAlbumMetadata(data)
+	if err != nil {
+		report.Invalid = append(report.Invalid, path.Join(f.path, albumMetadataName))
+		return album
+	}
+
+	if len(strings.TrimSpace(title)) > 0 {
+		album.Name = strings.TrimSpace(title)
+	}
+	album.Description = strings.TrimSpace(description)
+
+	return album
+}
+
+// sidecar returns the sidecar of the photo. If none is found by name, the sidecar whose title is the name of the photo is used.
+func (f *folder) sidecar(name string, report *Report) (Sidecar, bool) {
+	for _, n := range sidecarNames(name) {
+		data, found := f.json[n]
+		if !found {
+			continue
+		}
+
+		s, err := parseSidecar(data)
+		if err != nil {
+			report.Invalid = append(report.Invalid, path.Join(f.path, n))
+			return Sidecar{}, false
+		}
+
+		return s, true
+	}
+
+	if f.titles == nil {
+		f.titles = make(map[string]Sidecar)
+
+		jsonNames := make([]string, 0, len(f.json))
+		for n := range f.json {
+			jsonNames = append(jsonNames, n)
+		}
+		sort.Strings(jsonNames)
+
+		for _, n := range jsonNames {
+			if n == albumMetadataName {
+				continue
+			}
+
+			s, err := parseSidecar(f.json[n])
+			if err != nil || len(s.Title) == 0 {
+				continue
+			}
+
+			if _, found := f.titles[s.Title]; !found {
+				f.titles[s.Title] = s
+			}
+		}
+	}
+
+	s, found := f.titles[name]
+
+	return s, found
+}
@@ -0,0 +1,71 @@
+package takeout
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScan(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Takeout/archive_browser.html":                                                {Data: []byte("<html>")},
+		"Takeout/Google Photos/Trip to Paris/metadata.json":                           {Data: []byte(`{"title": "Paris 2019", "description": "first trip"}`)},
+		"Takeout/Google Photos/Trip to Paris/IMG_1.jpg":                               {Data: []byte("1")},
+		"Takeout/Google Photos/Trip to Paris/IMG_1.jpg.json":                          {Data: []byte(`{"title": "IMG_1.jpg", "description": "eiffel tower", "photoTakenTime": {"timestamp": "1556719200"}, "geoData": {"latitude": 48.858, "longitude": 2.294}}`)},
+		"Takeout/Google Photos/Trip to Paris/IMG_1-edited.jpg":                        {Data: []byte("1 edited")},
+		"Takeout/Google Photos/Trip to Paris/IMG_2.jpg":                               {Data: []byte("2")},
+		"Takeout/Google Photos/Trip to Paris/VID_1.mp4":                               {Data: []byte("video")},
+		"Takeout/Google Photos/Photos from 2019/IMG_1.jpg":                            {Data: []byte("1")},
+		"Takeout/Google Photos/Photos from 2019/IMG_1.jpg.json":                       {Data: []byte(`{"title": "IMG_1.jpg"}`)},
+		"Takeout/Google Photos/Photos from 2019/IMG_3.jpg":                            {Data: []byte("3")},
+		"Takeout/Google Photos/Photos from 2019/renamed.json":                         {Data: []byte(`{"title": "IMG_3.jpg", "geoData": {"latitude": 0, "longitude": 0}, "geoDataExif": {"latitude": 1.5, "longitude": 2.5}}`)},
+		"Takeout/Google Photos/Photos from 2019/IMG_4.jpg":                            {Data: []byte("4")},
+		"Takeout/Google Photos/Photos from 2019/IMG_4.jpg.supplemental-metadata.json": {Data: []byte("not json")},
+		"Takeout/Google Photos/Trash/IMG_5.jpg":                                       {Data: []byte("5")},
+	}
+
+	albums, report, err := Scan(fsys)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(albums))
+
+	year := albums[0]
+	assert.Equal(t, "Photos from 2019", year.Name)
+	assert.Equal(t, []string{"Takeout/Google Photos/Photos from 2019/IMG_3.jpg", "Takeout/Google Photos/Photos from 2019/IMG_4.jpg"}, year.Files)
+	assert.Equal(t, 1.5, year.Photos["Takeout/Google Photos/Photos from 2019/IMG_3.jpg"].Location.Latitude)
+
+	paris := albums[1]
+	assert.Equal(t, "Paris 2019", paris.Name)
+	assert.Equal(t, "first trip", paris.Description)
+	assert.Equal(t, 3, len(paris.Files))
+
+	photo := paris.Photos["Takeout/Google Photos/Trip to Paris/IMG_1.jpg"]
+	assert.Equal(t, "eiffel tower", photo.Caption)
+	assert.Equal(t, time.Date(2019, 5, 1, 14, 0, 0, 0, time.UTC), photo.CreateDate)
+	assert.Equal(t, 48.858, photo.Location.Latitude)
+
+	// the edited photo has the metadata of the original
+	assert.Equal(t, "eiffel tower", paris.Photos["Takeout/Google Photos/Trip to Paris/IMG_1-edited.jpg"].Caption)
+
+	assert.Equal(t, []string{"Takeout/Google Photos/Trip to Paris/VID_1.mp4"}, report.Unsupported)
+	assert.Equal(t, []string{"Takeout/Google Photos/Photos from 2019/IMG_1.jpg"}, report.Duplicates)
+	assert.Equal(t, []string{"Takeout/Google Photos/Trash"}, report.Skipped)
+	assert.Equal(t, []string{"Takeout/Google Photos/Photos from 2019/IMG_4.jpg.supplemental-metadata.json"}, report.Invalid)
+	assert.Equal(t, []string{"Takeout/Google Photos/Photos from 2019/IMG_4.jpg", "Takeout/Google Photos/Trip to Paris/IMG_2.jpg"}, report.NoMetadata)
+}
+
+func TestSidecarNames(t *testing.T) {
+	assert.Equal(t, []string{"IMG_1.jpg.json", "IMG_1.jpg.supplemental-metadata.json"}, sidecarNames("IMG_1.jpg"))
+	assert.Equal(t, []string{"IMG_1.jpg(1).json", "IMG_1.jpg.supplemental-metadata(1).json"}, sidecarNames("IMG_1(1).jpg"))
+	assert.Equal(t, []string{
+		"IMG_1-edited.jpg.json",
+		"IMG_1-edited.jpg.supplemental-metadata.json",
+		"IMG_1.jpg.json",
+		"IMG_1.jpg.supplemental-metadata.json",
+	}, sidecarNames("IMG_1-edited.jpg"))
+
+	names := sidecarNames("Screenshot_20200101-101010_Some_Very_Long_Application.jpg")
+	assert.Equal(t, "Screenshot_20200101-101010_Some_Very_Long_Appl.json", names[0])
+	assert.Equal(t, 1, len(names))
+}
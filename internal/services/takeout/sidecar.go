@@ -0,0 +1,130 @@
+package takeout
+
+import (
+	"encoding/json"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/importer"
+)
+
+// maxSidecarLength is the length Google truncates the names of the sidecar files to, not counting the counter of duplicates.
+const maxSidecarLength = 51
+
+var duplicateSuffix = regexp.MustCompile(`^(.*)(\([0-9]+\))$`)
+
+// Sidecar holds the metadata exported by Google Photos next to each photo.
+type Sidecar struct {
+	// Title - original name of the photo
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	PhotoTakenTime timestamp `json:"photoTakenTime"`
+	GeoData        geoData   `json:"geoData"`
+	GeoDataExif    geoData   `json:"geoDataExif"`
+}
+
+type timestamp struct {
+	// Timestamp - seconds since epoch
+	Timestamp string `json:"timestamp"`
+}
+
+type geoData struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// albumMetadata holds the metadata of an album. Older exports nest it under albumData.
+type albumMetadata struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	AlbumData   *struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	} `json:"albumData"`
+}
+
+func parseSidecar(data []byte) (Sidecar, error) {
+	var s Sidecar
+	err := json.Unmarshal(data, &s)
+	return s, err
+}
+
+func parseAlbumMetadata(data []byte) (title, description string, err error) {
+	var m albumMetadata
+	if err := json.Unmarshal(data, &m); err != nil {
+		return "", "", err
+	}
+
+	if m.AlbumData != nil {
+		return m.AlbumData.Title, m.AlbumData.Description, nil
+	}
+
+	return m.Title, m.Description, nil
+}
+
+// Photo returns the metadata of the photo. The location is taken from the EXIF data of the original photo
+// if the photo has not been located by Google.
+func (s Sidecar) Photo() importer.Photo {
+	p := importer.Photo{
+		Caption: strings.TrimSpace(s.Description),
+	}
+
+	if seconds, err := strconv.ParseInt(s.PhotoTakenTime.Timestamp, 10, 64); err == nil && seconds > 0 {
+		p.CreateDate = time.Unix(seconds, 0).UTC()
+	}
+
+	for _, g := range []geoData{s.GeoData, s.GeoDataExif} {
+		// Google writes 0,0 when the location is unknown
+		if g.Latitude != 0 || g.Longitude != 0 {
+			p.Location = &entity.GeoPoint{Latitude: g.Latitude, Longitude: g.Longitude}
+			break
+		}
+	}
+
+	return p
+}
+
+// sidecarNames returns the names the sidecar of the photo may have, the most likely first.
+// Google names the sidecar after the original photo: edited photos share the sidecar of the original,
+// the counter of duplicated names is moved after the extension and long names are truncated.
+func sidecarNames(name string) []string {
+	names := []string{}
+	add := func(n string) {
+		for _, nn := range names {
+			if nn == n {
+				return
+			}
+		}
+		names = append(names, n)
+	}
+
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	bases := []string{base}
+	if strings.HasSuffix(base, "-edited") {
+		bases = append(bases, strings.TrimSuffix(base, "-edited"))
+	}
+
+	for _, b := range bases {
+		counter := ""
+		if m := duplicateSuffix.FindStringSubmatch(b); m != nil {
+			b, counter = m[1], m[2]
+		}
+
+		// newer exports name the sidecar name.jpg.supplemental-metadata.json
+		for _, extra := range []string{"", ".supplemental-metadata"} {
+			stem := []rune(b + ext + extra)
+			if len(stem)+len(".json") > maxSidecarLength {
+				stem = stem[:maxSidecarLength-len(".json")]
+			}
+			add(string(stem) + counter + ".json")
+		}
+	}
+
+	return names
+}
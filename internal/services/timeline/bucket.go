@@ -0,0 +1,151 @@
+package timeline
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type Granularity int
+
+const (
+	ByYear Granularity = iota
+	ByMonth
+	ByDay
+)
+
+// Bucket holds the number of photos taken during the same year, month or day.
+type Bucket struct {
+	Granularity Granularity
+	// Start - first day of the bucket
+	Start time.Time
+	Count int
+	// Cursor - cursor of the page starting with the first photo of the bucket. Empty for the first bucket.
+	Cursor string
+}
+
+// Year returns the year of the bucket.
+func (b Bucket) Year() int {
+	return b.Start.Year()
+}
+
+// Month returns the month of the bucket or 0 if the buckets are years.
+func (b Bucket) Month() int {
+	if b.Granularity == ByYear {
+		return 0
+	}
+
+	return int(b.Start.Month())
+}
+
+// Day returns the day of the bucket or 0 if the buckets are years or months.
+func (b Bucket) Day() int {
+	if b.Granularity != ByDay {
+		return 0
+	}
+
+	return b.Start.Day()
+}
+
+// Group groups the sorted items by year, month or day.
+func Group(items []Item, granularity Granularity) []Bucket {
+	buckets := []Bucket{}
+
+	for i, item := range items {
+		start := truncate(item.Date(), granularity)
+
+		if len(buckets) > 0 && buckets[len(buckets)-1].Start.Equal(start) {
+			buckets[len(buckets)-1].Count++
+			continue
+		}
+
+		b := Bucket{Granularity: granularity, Start: start, Count: 1}
+		if i > 0 {
+			b.Cursor = encodeCursor(items[i-1])
+		}
+
+		buckets = append(buckets, b)
+	}
+
+	return buckets
+}
+
+func truncate(t time.Time, granularity Granularity) time.Time {
+	switch granularity {
+	case ByYear:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	case ByDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// cursor holds the sort key of the last item of a page.
+type cursor struct {
+	Date     time.Time `json:"d"`
+	Bucket   string    `json:"b"`
+	Filename string    `json:"f"`
+}
+
+// Paginate returns the page of sorted items which comes right after the cursor and the cursor of the next page.
+// An empty cursor returns the first page and an empty next cursor means there are no more pages.
+func Paginate(items []Item, after string, size int) ([]Item, string, error) {
+	start := 0
+
+	if len(after) > 0 {
+		last, err := decodeCursor(after)
+		if err != nil {
+			return []Item{}, "", err
+		}
+
+		start = len(items)
+		for i, item := range items {
+			if less(last, item) {
+				start = i
+				break
+			}
+		}
+	}
+
+	if size <= 0 || start+size >= len(items) {
+		return items[start:], "", nil
+	}
+
+	page := items[start : start+size]
+
+	return page, encodeCursor(page[len(page)-1]), nil
+}
+
+func encodeCursor(item Item) string {
+	data, _ := json.Marshal(cursor{
+		Date:     item.Date(),
+		Bucket:   item.Photo.Bucket,
+		Filename: item.Photo.Filename,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor returns an item holding the sort key of the cursor.
+func decodeCursor(s string) (Item, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Item{}, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Item{}, ErrInvalidCursor
+	}
+
+	item := Item{}
+	item.Photo.CreateDate = c.Date
+	item.Photo.Bucket = c.Bucket
+	item.Photo.Filename = c.Filename
+
+	return item, nil
+}
@@ -0,0 +1,107 @@
+package timeline
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tupyy/gophoto/internal/entity"
+)
+
+func TestGroup(t *testing.T) {
+	items := []Item{
+		newItem("b1", "a.jpg", time.Date(2022, 3, 2, 10, 0, 0, 0, time.UTC)),
+		newItem("b2", "b.jpg", time.Date(2022, 3, 2, 9, 0, 0, 0, time.UTC)),
+		newItem("b1", "c.jpg", time.Date(2022, 3, 1, 9, 0, 0, 0, time.UTC)),
+		newItem("b1", "d.jpg", time.Date(2022, 1, 5, 9, 0, 0, 0, time.UTC)),
+		newItem("b2", "e.jpg", time.Date(2021, 12, 31, 9, 0, 0, 0, time.UTC)),
+	}
+	sortItems(items)
+
+	data := []struct {
+		granularity Granularity
+		expected    []string
+	}{
+		{
+			granularity: ByYear,
+			expected:    []string{"2022-0-0:4", "2021-0-0:1"},
+		},
+		{
+			granularity: ByMonth,
+			expected:    []string{"2022-3-0:3", "2022-1-0:1", "2021-12-0:1"},
+		},
+		{
+			granularity: ByDay,
+			expected:    []string{"2022-3-2:2", "2022-3-1:1", "2022-1-5:1", "2021-12-31:1"},
+		},
+	}
+
+	for idx, d := range data {
+		buckets := Group(items, d.granularity)
+
+		counts := []string{}
+		for _, b := range buckets {
+			counts = append(counts, fmt.Sprintf("%d-%d-%d:%d", b.Year(), b.Month(), b.Day(), b.Count))
+		}
+		assert.Equal(t, d.expected, counts, "test %d", idx)
+
+		// the cursor of a bucket returns a page starting with the first photo of the bucket
+		offset := 0
+		for _, b := range buckets {
+			page, _, err := Paginate(items, b.Cursor, 1)
+			assert.Nil(t, err)
+			assert.Equal(t, items[offset].Photo.Filename, page[0].Photo.Filename, "test %d", idx)
+			offset += b.Count
+		}
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	date := time.Date(2022, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	// same filename and date in different albums
+	items := []Item{
+		newItem("b2", "a.jpg", date),
+		newItem("b1", "a.jpg", date),
+		newItem("b1", "b.jpg", date.Add(time.Hour)),
+		newItem("b1", "c.jpg", time.Time{}),
+	}
+	items[3].Photo.UploadDate = date.Add(-time.Hour)
+	sortItems(items)
+
+	expected := []string{"b1/b.jpg", "b1/a.jpg", "b2/a.jpg", "b1/c.jpg"}
+
+	names := []string{}
+	cursor := ""
+	for {
+		page, next, err := Paginate(items, cursor, 3)
+		assert.Nil(t, err)
+
+		for _, item := range page {
+			names = append(names, fmt.Sprintf("%s/%s", item.Photo.Bucket, item.Photo.Filename))
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	assert.Equal(t, expected, names)
+
+	_, _, err := Paginate(items, "not a cursor", 3)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func newItem(bucket, filename string, date time.Time) Item {
+	return Item{
+		Album: entity.Album{Bucket: bucket},
+		Photo: entity.Media{
+			MediaType:  entity.Photo,
+			Bucket:     bucket,
+			Filename:   filename,
+			CreateDate: date,
+		},
+	}
+}
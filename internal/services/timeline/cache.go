@@ -0,0 +1,94 @@
+package timeline
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/events"
+)
+
+// listingTTL is the time a listing is kept. The listings are invalidated by the events of their album
+// so the expiry only bounds the staleness when an event is lost.
+const listingTTL = 5 * time.Minute
+
+type listing struct {
+	medias  []entity.Media
+	expires time.Time
+}
+
+// listingCache keeps the listing of the albums' buckets. The timeline spans every readable album so
+// listing all of them on each request does not scale.
+type listingCache struct {
+	lock     sync.Mutex
+	listings map[string]listing
+	// generations is incremented on each invalidation so a listing loaded before an invalidation is not kept.
+	generations map[string]int
+	now         func() time.Time
+}
+
+func newListingCache() *listingCache {
+	return &listingCache{
+		listings:    make(map[string]listing),
+		generations: make(map[string]int),
+		now:         time.Now,
+	}
+}
+
+// get returns the cached listing of the bucket or loads it if it is missing or expired.
+func (c *listingCache) get(ctx context.Context, bucket string, load func(ctx context.Context, bucket string) ([]entity.Media, error)) ([]entity.Media, error) {
+	c.lock.Lock()
+	l, found := c.listings[bucket]
+	generation := c.generations[bucket]
+	c.lock.Unlock()
+
+	if found && c.now().Before(l.expires) {
+		return l.medias, nil
+	}
+
+	medias, err := load(ctx, bucket)
+	if err != nil {
+		return medias, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.generations[bucket] == generation {
+		c.listings[bucket] = listing{medias: medias, expires: c.now().Add(listingTTL)}
+	}
+
+	return medias, nil
+}
+
+// invalidate removes the listing of the bucket.
+func (c *listingCache) invalidate(bucket string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.listings, bucket)
+	c.generations[bucket]++
+}
+
+// Watch invalidates the cached listings with the events of the broker until the context is done.
+func (s *Service) Watch(ctx context.Context, broker *events.Broker) {
+	evts, cancel := broker.Subscribe(func(e entity.Event) bool { return e.Bucket != "" })
+
+	go func() {
+		defer cancel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, more := <-evts:
+				if !more {
+					return
+				}
+
+				s.listings.invalidate(e.Bucket)
+			}
+		}
+	}()
+}
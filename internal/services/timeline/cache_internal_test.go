@@ -0,0 +1,99 @@
+package timeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/events"
+)
+
+func TestListingCache(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Now()
+	c := newListingCache()
+	c.now = func() time.Time { return now }
+
+	loads := 0
+	load := func(ctx context.Context, bucket string) ([]entity.Media, error) {
+		loads++
+		return []entity.Media{{Bucket: bucket, Filename: "photos/a.jpg"}}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		medias, err := c.get(ctx, "a", load)
+		require.Nil(t, err)
+		assert.Equal(t, "a", medias[0].Bucket)
+	}
+	assert.Equal(t, 1, loads, "the listing must be cached")
+
+	_, err := c.get(ctx, "b", load)
+	require.Nil(t, err)
+	assert.Equal(t, 2, loads, "the listings are cached per bucket")
+
+	c.invalidate("a")
+	_, err = c.get(ctx, "a", load)
+	require.Nil(t, err)
+	assert.Equal(t, 3, loads, "an invalidated listing must be reloaded")
+
+	now = now.Add(listingTTL)
+	_, err = c.get(ctx, "b", load)
+	require.Nil(t, err)
+	assert.Equal(t, 4, loads, "an expired listing must be reloaded")
+
+	// errors are not cached
+	_, err = c.get(ctx, "c", func(ctx context.Context, bucket string) ([]entity.Media, error) {
+		return []entity.Media{}, errors.New("list failed")
+	})
+	assert.NotNil(t, err)
+	_, err = c.get(ctx, "c", load)
+	require.Nil(t, err)
+	assert.Equal(t, 5, loads)
+
+	// a listing loaded while the bucket is invalidated is not kept
+	_, err = c.get(ctx, "d", func(ctx context.Context, bucket string) ([]entity.Media, error) {
+		c.invalidate(bucket)
+		return load(ctx, bucket)
+	})
+	require.Nil(t, err)
+	_, err = c.get(ctx, "d", load)
+	require.Nil(t, err)
+	assert.Equal(t, 7, loads)
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := New(nil, nil)
+	broker := events.NewBroker(nil)
+	s.Watch(ctx, broker)
+
+	loads := 0
+	load := func(ctx context.Context, bucket string) ([]entity.Media, error) {
+		loads++
+		return []entity.Media{}, nil
+	}
+
+	_, err := s.listings.get(ctx, "a", load)
+	require.Nil(t, err)
+
+	broker.Publish(ctx, entity.Event{Kind: entity.EventPhotoAdded, Bucket: "a", Filename: "photos/b.jpg"})
+
+	assert.Eventually(t, func() bool {
+		s.listings.lock.Lock()
+		defer s.listings.lock.Unlock()
+
+		_, found := s.listings.listings["a"]
+		return !found
+	}, time.Second, 10*time.Millisecond, "the listing must be invalidated by the events of its bucket")
+
+	_, err = s.listings.get(ctx, "a", load)
+	require.Nil(t, err)
+	assert.Equal(t, 2, loads)
+}
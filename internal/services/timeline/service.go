@@ -0,0 +1,120 @@
+package timeline
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/tupyy/gophoto/internal/common"
+	"github.com/tupyy/gophoto/internal/entity"
+	"github.com/tupyy/gophoto/internal/services/album"
+	"github.com/tupyy/gophoto/internal/services/media"
+	"go.uber.org/zap"
+)
+
+// Item is a photo of the timeline together with the album it belongs to.
+type Item struct {
+	Album entity.Album
+	Photo entity.Media
+}
+
+// Date returns the capture date of the photo or the upload date if the photo has no capture date.
+func (i Item) Date() time.Time {
+	if i.Photo.CreateDate.IsZero() {
+		return i.Photo.UploadDate
+	}
+
+	return i.Photo.CreateDate
+}
+
+type Service struct {
+	albumService *album.Service
+	mediaService *media.Service
+	listings     *listingCache
+}
+
+func New(albumService *album.Service, mediaService *media.Service) *Service {
+	return &Service{albumService, mediaService, newListingCache()}
+}
+
+// Get returns the photos of all the albums readable by the user sorted by capture date, most recent first.
+// The listings of the albums are cached and read without side effect: the missing thumbnails are left to fsck.
+func (s *Service) Get(ctx context.Context, user entity.User, filter media.Filter) ([]Item, error) {
+	albums, _, err := s.albumService.Query().OwnAlbums(true).SharedAlbums(true).All(ctx, user)
+	if err != nil {
+		var serviceErr common.ServiceError
+		if !errors.As(err, &serviceErr) || serviceErr.Cause != common.EntityNotFound {
+			return []Item{}, err
+		}
+	}
+
+	photoType := entity.Photo
+	filter.MediaType = &photoType
+
+	items := []Item{}
+	for _, a := range albums {
+		medias, err := s.listings.get(ctx, a.Bucket, s.mediaService.List)
+		if err != nil {
+			// one bad bucket must not hide the photos of the other albums
+			zap.S().Errorw("failed to list album", "error", err, "album_id", a.ID, "bucket", a.Bucket, "user", user.Username)
+			continue
+		}
+
+		for _, m := range filter.Apply(medias) {
+			items = append(items, Item{Album: a, Photo: m})
+		}
+	}
+
+	sortItems(items)
+
+	return items, nil
+}
+
+// WithMetadata returns the items with the metadata of the photos as seen by the user.
+func (s *Service) WithMetadata(ctx context.Context, user entity.User, items []Item) ([]Item, error) {
+	byAlbum := make(map[string][]int)
+	for i, item := range items {
+		byAlbum[item.Album.ID] = append(byAlbum[item.Album.ID], i)
+	}
+
+	enriched := append([]Item{}, items...)
+	for albumID, indexes := range byAlbum {
+		photos := make([]entity.Media, 0, len(indexes))
+		for _, i := range indexes {
+			photos = append(photos, items[i].Photo)
+		}
+
+		photos, err := s.mediaService.WithMetadata(ctx, albumID, user.Username, photos)
+		if err != nil {
+			return items, err
+		}
+
+		for j, i := range indexes {
+			enriched[i].Photo = photos[j]
+		}
+	}
+
+	return enriched, nil
+}
+
+// sortItems sorts the items by date, most recent first.
+// Items with the same date are sorted by bucket and filename so the order is stable between calls.
+func sortItems(items []Item) {
+	sort.Slice(items, func(i, j int) bool {
+		return less(items[i], items[j])
+	})
+}
+
+func less(i1, i2 Item) bool {
+	d1, d2 := i1.Date(), i2.Date()
+	if !d1.Equal(d2) {
+		return d1.After(d2)
+	}
+
+	if i1.Photo.Bucket != i2.Photo.Bucket {
+		return i1.Photo.Bucket < i2.Photo.Bucket
+	}
+
+	return i1.Photo.Filename < i2.Photo.Filename
+}
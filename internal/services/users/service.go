@@ -8,7 +8,9 @@ import (
 	userFilters "github.com/tupyy/gophoto/internal/repos/filters/user"
 )
 
-type KeycloakRepository interface {
+// UserDirectory holds the users and their groups. It is backed by keycloak, by the users recorded from the claims of
+// an oidc provider or by the local users.
+type UserDirectory interface {
 	// Get returns all the users.
 	GetUsers(ctx context.Context, filters userFilters.Filters) ([]entity.User, error)
 	// GetByID return the user by id.
@@ -23,28 +25,28 @@ type UserRespository interface {
 }
 
 type Service struct {
-	keycloakRepo KeycloakRepository
-	userRepo     UserRespository
+	directory UserDirectory
+	userRepo  UserRespository
 }
 
 type Query struct {
-	predicates   []Predicate
-	keycloakRepo KeycloakRepository
-	userRepo     UserRespository
+	predicates []Predicate
+	directory  UserDirectory
+	userRepo   UserRespository
 }
 
-func New(keycloakRepo KeycloakRepository, userRepo UserRespository) *Service {
+func New(directory UserDirectory, userRepo UserRespository) *Service {
 	return &Service{
-		keycloakRepo: keycloakRepo,
-		userRepo:     userRepo,
+		directory: directory,
+		userRepo:  userRepo,
 	}
 }
 
 func (s *Service) Query() *Query {
 	return &Query{
-		predicates:   []Predicate{},
-		keycloakRepo: s.keycloakRepo,
-		userRepo:     s.userRepo,
+		predicates: []Predicate{},
+		directory:  s.directory,
+		userRepo:   s.userRepo,
 	}
 }
 
@@ -60,7 +62,7 @@ func (q *Query) All(ctx context.Context) ([]entity.User, error) {
 		filters = append(filters, p())
 	}
 
-	users, err := q.keycloakRepo.GetUsers(ctx, filters)
+	users, err := q.directory.GetUsers(ctx, filters)
 	if err != nil {
 		return []entity.User{}, err
 	}
@@ -81,7 +83,7 @@ func (q *Query) AllRelatedUsers(ctx context.Context, u entity.User) ([]entity.Us
 	}
 
 	// get all the users from keycloak
-	users, err := q.keycloakRepo.GetUsers(ctx, filters)
+	users, err := q.directory.GetUsers(ctx, filters)
 	if err != nil {
 		return []entity.User{}, err
 	}
@@ -105,7 +107,7 @@ func (q *Query) AllRelatedUsers(ctx context.Context, u entity.User) ([]entity.Us
 }
 
 func (q *Query) First(ctx context.Context, id string) (entity.User, error) {
-	user, err := q.keycloakRepo.GetUserByID(ctx, id)
+	user, err := q.directory.GetUserByID(ctx, id)
 	if err != nil {
 		return entity.User{}, err
 	}
@@ -114,7 +116,7 @@ func (q *Query) First(ctx context.Context, id string) (entity.User, error) {
 }
 
 func (q *Query) AllGroups(ctx context.Context) ([]entity.Group, error) {
-	groups, err := q.keycloakRepo.GetGroups(ctx)
+	groups, err := q.directory.GetGroups(ctx)
 	if err != nil {
 		return []entity.Group{}, err
 	}
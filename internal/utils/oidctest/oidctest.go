@@ -0,0 +1,305 @@
+// Package oidctest provides a mock OpenID Connect provider to test the login flow and the bearer tokens without a
+// running identity provider.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	// KeyID is the id of the key signing the tokens.
+	KeyID = "oidctest"
+	// TokenLifetime is the lifetime of the tokens issued by the server.
+	TokenLifetime = 5 * time.Minute
+)
+
+// Server is a mock OpenID Connect provider. The authorization endpoint approves every request and the tokens are issued
+// with the claims set by SetClaims.
+type Server struct {
+	*httptest.Server
+
+	ClientID     string
+	ClientSecret string
+
+	key *rsa.PrivateKey
+
+	lock    sync.Mutex
+	claims  map[string]interface{}
+	codes   map[string]grant
+	refresh map[string]grant
+	revoked []string
+}
+
+// grant is a code or a refresh token with the claims of the tokens it is exchanged for.
+type grant struct {
+	nonce  string
+	claims map[string]interface{}
+}
+
+// New starts a server accepting the client.
+func New(clientID, clientSecret string) (*Server, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		claims:       make(map[string]interface{}),
+		codes:        make(map[string]grant),
+		refresh:      make(map[string]grant),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/revoke", s.revoke)
+
+	s.Server = httptest.NewServer(mux)
+
+	return s, nil
+}
+
+// Issuer returns the issuer of the tokens.
+func (s *Server) Issuer() string {
+	return s.URL
+}
+
+// SetClaims sets the claims of the user of the next logins, like sub, preferred_username or groups.
+func (s *Server) SetClaims(claims map[string]interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.claims = copyClaims(claims)
+}
+
+// AccessToken returns an access token with the claims of the user.
+func (s *Server) AccessToken() (string, error) {
+	s.lock.Lock()
+	claims := copyClaims(s.claims)
+	s.lock.Unlock()
+
+	return s.Sign(s.tokenClaims(claims, ""))
+}
+
+// Sign signs the claims with the key of the server.
+func (s *Server) Sign(claims map[string]interface{}) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims(claims))
+	token.Header["kid"] = KeyID
+
+	return token.SignedString(s.key)
+}
+
+// Revoked returns the tokens revoked by the clients.
+func (s *Server) Revoked() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]string{}, s.revoked...)
+}
+
+func (s *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.URL,
+		"authorization_endpoint":                s.URL + "/authorize",
+		"token_endpoint":                        s.URL + "/token",
+		"jwks_uri":                              s.URL + "/jwks",
+		"revocation_endpoint":                   s.URL + "/revoke",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (s *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kid": KeyID,
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+			},
+		},
+	})
+}
+
+// authorize approves the request and redirects to the client with a code.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	if q.Get("client_id") != s.ClientID || q.Get("response_type") != "code" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || !redirect.IsAbs() {
+		http.Error(w, "invalid redirect uri", http.StatusBadRequest)
+		return
+	}
+
+	code := randString()
+
+	s.lock.Lock()
+	s.codes[code] = grant{nonce: q.Get("nonce"), claims: copyClaims(s.claims)}
+	s.lock.Unlock()
+
+	params := redirect.Query()
+	params.Set("code", code)
+	params.Set("state", q.Get("state"))
+	redirect.RawQuery = params.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// token exchanges a code or a refresh token for new tokens.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	if !s.authenticated(r) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	var (
+		g     grant
+		found bool
+	)
+
+	s.lock.Lock()
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		code := r.PostForm.Get("code")
+		g, found = s.codes[code]
+		delete(s.codes, code)
+	case "refresh_token":
+		token := r.PostForm.Get("refresh_token")
+		g, found = s.refresh[token]
+		delete(s.refresh, token)
+	}
+	s.lock.Unlock()
+
+	if !found {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	accessToken, err := s.Sign(s.tokenClaims(copyClaims(g.claims), ""))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	idToken, err := s.Sign(s.tokenClaims(copyClaims(g.claims), g.nonce))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	refreshToken := randString()
+
+	s.lock.Lock()
+	s.refresh[refreshToken] = grant{claims: g.claims}
+	s.lock.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":  accessToken,
+		"id_token":      idToken,
+		"refresh_token": refreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    int(TokenLifetime.Seconds()),
+	})
+}
+
+// revoke records the revoked token.
+func (s *Server) revoke(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || !s.authenticated(r) {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	token := r.PostForm.Get("token")
+
+	s.lock.Lock()
+	delete(s.refresh, token)
+	s.revoked = append(s.revoked, token)
+	s.lock.Unlock()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// authenticated checks the credentials of the client, sent with basic auth or in the form.
+func (s *Server) authenticated(r *http.Request) bool {
+	id, secret, ok := r.BasicAuth()
+	if ok {
+		id, _ = url.QueryUnescape(id)
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+
+	return id == s.ClientID && secret == s.ClientSecret
+}
+
+// tokenClaims adds the registered claims to the claims of the user.
+func (s *Server) tokenClaims(claims map[string]interface{}, nonce string) map[string]interface{} {
+	now := time.Now()
+
+	claims["iss"] = s.URL
+	claims["aud"] = s.ClientID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(TokenLifetime).Unix()
+	claims["jti"] = randString()
+
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+
+	return claims
+}
+
+func copyClaims(claims map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(claims))
+	for k, v := range claims {
+		c[k] = v
+	}
+
+	return c
+}
+
+func randString() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to read random bytes: %s", err))
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
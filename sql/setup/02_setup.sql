@@ -6,6 +6,12 @@ DROP TABLE IF EXISTS "album_user_permissions";
 DROP TABLE IF EXISTS "album_group_permissions";
 DROP TABLE IF EXISTS "tag";
 DROP TABLE IF EXISTS "albums_tags";
+DROP TABLE IF EXISTS "comment";
+DROP TABLE IF EXISTS "comment_history";
+DROP TABLE IF EXISTS "reaction";
+DROP TABLE IF EXISTS "media";
+DROP TABLE IF EXISTS "media_favorites";
+DROP TABLE IF EXISTS "media_tags";
 
 CREATE TYPE role as ENUM('admin','editor','user');
 
@@ -17,14 +23,19 @@ CREATE TABLE album (
     bucket TEXT NOT NULL,
     description TEXT,
     location TEXT,
-    thumbnail VARCHAR(200)
+    thumbnail VARCHAR(200),
+    city TEXT,
+    region TEXT,
+    country TEXT,
+    encrypted BOOLEAN DEFAULT false NOT NULL
 );
 
 CREATE TYPE permission_id as ENUM (
     'album.read',
     'album.write',
     'album.edit',
-    'album.delete'
+    'album.delete',
+    'album.comment'
 );
 
 CREATE TYPE owner_kind as ENUM (
@@ -61,4 +72,118 @@ CREATE TABLE albums_tags (
     ) 
 );
 
+CREATE TABLE comment (
+    id TEXT PRIMARY KEY,
+    album_id TEXT REFERENCES album(id) ON DELETE CASCADE,
+    filename TEXT NOT NULL,
+    author_id TEXT NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT (now() AT TIME ZONE 'UTC') NOT NULL,
+    updated_at TIMESTAMP
+);
+
+CREATE INDEX comment_album_filename_idx ON comment (album_id, filename);
+
+CREATE TABLE comment_history (
+    id SERIAL PRIMARY KEY,
+    comment_id TEXT REFERENCES comment(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT (now() AT TIME ZONE 'UTC') NOT NULL
+);
+
+CREATE INDEX comment_history_comment_id_idx ON comment_history (comment_id);
+
+CREATE TABLE reaction (
+    album_id TEXT REFERENCES album(id) ON DELETE CASCADE,
+    filename TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT (now() AT TIME ZONE 'UTC') NOT NULL,
+    CONSTRAINT reaction_pk PRIMARY KEY (
+        album_id,
+        filename,
+        user_id,
+        kind
+    )
+);
+
+CREATE TABLE media (
+    album_id TEXT REFERENCES album(id) ON DELETE CASCADE,
+    filename TEXT NOT NULL,
+    caption TEXT,
+    description TEXT,
+    city TEXT,
+    region TEXT,
+    country TEXT,
+    CONSTRAINT media_pk PRIMARY KEY (
+        album_id,
+        filename
+    )
+);
+
+CREATE TABLE media_favorites (
+    album_id TEXT REFERENCES album(id) ON DELETE CASCADE,
+    filename TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT (now() AT TIME ZONE 'UTC') NOT NULL,
+    CONSTRAINT media_favorites_pk PRIMARY KEY (
+        album_id,
+        filename,
+        user_id
+    )
+);
+
+CREATE TABLE media_tags (
+    album_id TEXT REFERENCES album(id) ON DELETE CASCADE,
+    filename TEXT NOT NULL,
+    tag_id TEXT REFERENCES tag(id) ON DELETE CASCADE,
+    CONSTRAINT media_tags_pk PRIMARY KEY (
+        album_id,
+        filename,
+        tag_id
+    )
+);
+
+-- size of the objects of each bucket. It is updated when objects are saved or removed.
+CREATE TABLE storage_usage (
+    bucket TEXT PRIMARY KEY,
+    bytes BIGINT DEFAULT 0 NOT NULL
+);
+
+-- personal access tokens. Only the sha256 of the tokens is stored.
+CREATE TABLE access_token (
+    id TEXT PRIMARY KEY,
+    owner TEXT NOT NULL,
+    name TEXT NOT NULL,
+    scope TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT (now() AT TIME ZONE 'UTC') NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP
+);
+
+CREATE INDEX access_token_owner_idx ON access_token (owner);
+
+-- users of the local directory. A user is the account identified by the issuer and the subject of its tokens.
+CREATE TABLE directory_user (
+    id TEXT PRIMARY KEY,
+    issuer TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    username TEXT NOT NULL UNIQUE,
+    first_name TEXT,
+    last_name TEXT,
+    role role DEFAULT 'user' NOT NULL,
+    can_share BOOLEAN DEFAULT false NOT NULL,
+    CONSTRAINT directory_user_account UNIQUE (issuer, subject)
+);
+
+CREATE TABLE directory_group (
+    user_id TEXT REFERENCES directory_user(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    CONSTRAINT directory_group_pk PRIMARY KEY (
+        user_id,
+        name
+    )
+);
+
 COMMIT;